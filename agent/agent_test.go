@@ -304,6 +304,7 @@ func stateServingInfo() params.StateServingInfo {
 		APIPort:        47,
 		SharedSecret:   "shared",
 		SystemIdentity: "identity",
+		SecretsKey:     "c2VjcmV0cw==",
 	}
 }
 
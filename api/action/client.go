@@ -30,6 +30,17 @@ func (c *Client) Actions(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// ActionsWithLog takes a list of ActionTags, and returns the full
+// Action for each ID, including the progress messages it has logged.
+func (c *Client) ActionsWithLog(arg params.Entities) (params.ActionResults, error) {
+	results := params.ActionResults{}
+	if c.facade.BestAPIVersion() < 3 {
+		return results, errors.NotImplementedf("ActionsWithLog() (need V3+)")
+	}
+	err := c.facade.FacadeCall("ActionsWithLog", arg, &results)
+	return results, err
+}
+
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
 func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
@@ -90,6 +101,22 @@ func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
 	return results, err
 }
 
+// Operations takes a list of operation ids, and returns the operation,
+// with its actions, for each id.
+func (c *Client) Operations(arg params.OperationIds) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	err := c.facade.FacadeCall("Operations", arg, &results)
+	return results, err
+}
+
+// ListOperations returns a page of the operations in the model, oldest
+// first, without their actions.
+func (c *Client) ListOperations(arg params.ListOperationsArgs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	err := c.facade.FacadeCall("ListOperations", arg, &results)
+	return results, err
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
@@ -109,6 +109,18 @@ func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionsWithLogNeedsV3(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected facade call %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.ActionsWithLog(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, `ActionsWithLog\(\) \(need V3\+\) not implemented`)
+}
+
 // replace sCharmActions" facade call with required results and error
 // if desired
 func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults []params.ApplicationCharmActionsResult, err string) func() {
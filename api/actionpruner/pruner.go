@@ -0,0 +1,38 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "ActionPruner"
+
+// Facade allows calls to "ActionPruner" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewFacade returns an "ActionPruner" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Facade{
+		facade:       facadeCaller,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+	}
+}
+
+// Prune calls "ActionPruner.Prune".
+func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryCount int) error {
+	p := params.ActionPruneArgs{
+		MaxHistoryTime:  maxHistoryTime,
+		MaxHistoryCount: maxHistoryCount,
+	}
+	return s.facade.FacadeCall("Prune", p, nil)
+}
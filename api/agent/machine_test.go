@@ -4,6 +4,7 @@
 package agent_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	stdtesting "testing"
 
@@ -52,6 +53,9 @@ func (s *servingInfoSuite) TestStateServingInfo(c *gc.C) {
 		SharedSecret: ssi.SharedSecret,
 		APIPort:      ssi.APIPort,
 		StatePort:    ssi.StatePort,
+		// The secrets key comes from the API server's agent, not
+		// from the database.
+		SecretsKey: base64.StdEncoding.EncodeToString(s.BackingState.SecretsKey()),
 	}
 	err := s.State.SetStateServingInfo(ssi)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ApplicationOffers API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ApplicationOffers
+// API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ApplicationOffers")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Offer offers the given endpoints of an application to other models,
+// under the given offer name; if it is empty, the application name is
+// used.
+func (c *Client) Offer(offer params.AddApplicationOffer) error {
+	args := params.AddApplicationOffers{
+		Offers: []params.AddApplicationOffer{offer},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// FindApplicationOffers returns the offers, made by the models on the
+// controller that the user can access, that match the filter.
+func (c *Client) FindApplicationOffers(filter params.OfferFilter) ([]params.ApplicationOffer, error) {
+	args := params.OfferFilters{
+		Filters: []params.OfferFilter{filter},
+	}
+	var results params.FindApplicationOffersResults
+	if err := c.facade.FacadeCall("FindApplicationOffers", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Offers, nil
+}
+
+// Consume adds a remote application to the model for the offer with
+// the given URL, named alias if it is not empty, and returns the
+// remote application's name.
+func (c *Client) Consume(offerURL, alias string) (string, error) {
+	args := params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			OfferURL:         offerURL,
+			ApplicationAlias: alias,
+		}},
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("Consume", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationoffers"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestOffer(c *gc.C) {
+	offer := params.AddApplicationOffer{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ApplicationOffers")
+		c.Check(request, gc.Equals, "Offer")
+		c.Check(arg, jc.DeepEquals, params.AddApplicationOffers{
+			Offers: []params.AddApplicationOffer{offer},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "offer already exists", Code: params.CodeAlreadyExists},
+			}},
+		}
+		return nil
+	})
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.Offer(offer)
+	c.Assert(err, gc.ErrorMatches, "offer already exists")
+}
+
+func (s *clientSuite) TestFindApplicationOffers(c *gc.C) {
+	expected := []params.ApplicationOffer{{
+		OfferURL:        "admin/prod.db",
+		OfferName:       "db",
+		ApplicationName: "mysql",
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ApplicationOffers")
+		c.Check(request, gc.Equals, "FindApplicationOffers")
+		c.Check(arg, jc.DeepEquals, params.OfferFilters{
+			Filters: []params.OfferFilter{{ModelName: "prod"}},
+		})
+		*(result.(*params.FindApplicationOffersResults)) = params.FindApplicationOffersResults{
+			Results: []params.FindApplicationOffersResult{{Offers: expected}},
+		}
+		return nil
+	})
+	client := applicationoffers.NewClient(apiCaller)
+	offers, err := client.FindApplicationOffers(params.OfferFilter{ModelName: "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestConsume(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ApplicationOffers")
+		c.Check(request, gc.Equals, "Consume")
+		c.Check(arg, jc.DeepEquals, params.ConsumeApplicationArgs{
+			Args: []params.ConsumeApplicationArg{{OfferURL: "prod.db", ApplicationAlias: "shared-db"}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "shared-db"}},
+		}
+		return nil
+	})
+	client := applicationoffers.NewClient(apiCaller)
+	name, err := client.Consume("prod.db", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "shared-db")
+}
+
+func (s *clientSuite) TestConsumeError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.Consume("prod.db", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
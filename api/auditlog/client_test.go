@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC)
+	expected := []params.AuditLogEntry{{
+		JujuServerVersion: "2.1.0",
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Client:v1 - FullStatus",
+	}}
+	filter := params.AuditLogFilter{
+		OriginName: "user-admin",
+		Limit:      5,
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Query")
+		c.Check(arg, jc.DeepEquals, filter)
+		c.Assert(result, gc.FitsTypeOf, &params.AuditLogResults{})
+		*(result.(*params.AuditLogResults)) = params.AuditLogResults{Entries: expected}
+		return nil
+	})
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Query(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -52,6 +52,7 @@ func (s *baseSuite) checkMetadataResult(c *gc.C, result *params.BackupsMetadataR
 	c.Check(result.Size, gc.Equals, meta.Size())
 	c.Check(result.Stored, gc.Equals, stored)
 	c.Check(result.Notes, gc.Equals, meta.Notes)
+	c.Check(result.Storage, gc.Equals, meta.Storage)
 
 	c.Check(result.Model, gc.Equals, meta.Origin.Model)
 	c.Check(result.Machine, gc.Equals, meta.Origin.Machine)
@@ -10,10 +10,29 @@ import (
 )
 
 // Create sends a request to create a backup of juju's state.  It
-// returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+// returns the metadata associated with the resulting backup. The
+// backup is kept in the named storage; if storage is empty, it is
+// kept in the controller.
+func (c *Client) Create(notes, storage string) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
+	args := params.BackupsCreateArgs{Notes: notes, Storage: storage}
+	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// CreateIncremental sends a request to create a backup holding only
+// the changes made since the identified parent backup or, if parent
+// is empty, since the latest backup that can be built on.
+func (c *Client) CreateIncremental(notes, storage, parent string) (*params.BackupsMetadataResult, error) {
+	var result params.BackupsMetadataResult
+	args := params.BackupsCreateArgs{
+		Notes:       notes,
+		Storage:     storage,
+		Incremental: true,
+		Parent:      parent,
+	}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -27,10 +27,12 @@ func (s *createSuite) TestCreate(c *gc.C) {
 			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
 			p := paramsIn.(params.BackupsCreateArgs)
 			c.Check(p.Notes, gc.Equals, "important")
+			c.Check(p.Storage, gc.Equals, "s3")
 
 			if result, ok := resp.(*params.BackupsMetadataResult); ok {
 				*result = apiserverbackups.ResultFromMetadata(s.Meta)
 				result.Notes = p.Notes
+				result.Storage = p.Storage
 			} else {
 				c.Fatalf("wrong output structure")
 			}
@@ -39,9 +41,30 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important")
+	result, err := s.client.Create("important", "s3")
 	c.Assert(err, jc.ErrorIsNil)
 
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
+	meta.Storage = "s3"
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateIncremental(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsCreateArgs{
+				Notes:       "important",
+				Incremental: true,
+				Parent:      "parent-id",
+			})
+			*resp.(*params.BackupsMetadataResult) = apiserverbackups.ResultFromMetadata(s.Meta)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateIncremental("important", "", "parent-id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkMetadataResult(c, result, s.Meta)
+}
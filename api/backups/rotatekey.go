@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RotateEncryptionKey makes key, a base64-encoded 256-bit key, the
+// controller's backup encryption key. The controller keeps the key it
+// replaces, so that backups encrypted with it can still be restored.
+func (c *Client) RotateEncryptionKey(key string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("RotateEncryptionKey() (need V2+)")
+	}
+	args := params.BackupsRotateKeyArgs{Key: key}
+	return errors.Trace(c.facade.FacadeCall("RotateEncryptionKey", args, nil))
+}
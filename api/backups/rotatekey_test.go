@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/controller"
+)
+
+type rotateKeySuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&rotateKeySuite{})
+
+const backupKey = "kjMMiFOZp8E3MHlyIS8HEwwtwXnK9xnFovI5W0GyPtg="
+
+func (s *rotateKeySuite) TestRotateEncryptionKey(c *gc.C) {
+	err := s.client.RotateEncryptionKey(backupKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets[controller.BackupEncryptionKey], gc.Equals, backupKey)
+}
+
+func (s *rotateKeySuite) TestRotateEncryptionKeyNeedsV2(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	err := s.client.RotateEncryptionKey(backupKey)
+	c.Assert(err, gc.ErrorMatches, `RotateEncryptionKey\(\) \(need V2\+\) not implemented`)
+}
@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify asks the controller to check the identified backup without
+// restoring it.
+func (c *Client) Verify(id string) (*params.BackupsVerifyResult, error) {
+	var result params.BackupsVerifyResult
+	args := params.BackupsVerifyArgs{ID: id}
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type verifySuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Verify")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsVerifyArgs{ID: "spam"})
+			*resp.(*params.BackupsVerifyResult) = params.BackupsVerifyResult{
+				ChecksumVerified: true,
+				Missing:          []string{"juju-backup/root.tar"},
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Verify("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, &params.BackupsVerifyResult{
+		ChecksumVerified: true,
+		Missing:          []string{"juju-backup/root.tar"},
+	})
+}
@@ -259,16 +259,72 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
-	if err := spec.Validate(); err != nil {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
 		return "", errors.Trace(err)
 	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRun checks whether the specified model could be
+// migrated, without migrating it, and returns every issue found that
+// would prevent the migration.
+func (c *Client) MigrationDryRun(spec MigrationSpec) ([]params.MigrationPrecheckIssue, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Issues, nil
+}
+
+// MigrationStatus returns the progress of the migrations with the
+// given ids, as returned by InitiateMigration. Each result holds an
+// error if the progress of that migration could not be found.
+func (c *Client) MigrationStatus(ids []string) ([]params.MigrationStatusResult, error) {
+	args := params.MigrationStatusArgs{MigrationIds: ids}
+	response := params.MigrationStatusResults{}
+	if err := c.facade.FacadeCall("MigrationStatus", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != len(ids) {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	return response.Results, nil
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
+	if err := spec.Validate(); err != nil {
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
+	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Trace(err)
+		return params.InitiateMigrationArgs{}, errors.Trace(err)
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -282,19 +338,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 			ExternalControl:      spec.ExternalControl,
 			SkipInitialPrechecks: spec.SkipInitialPrechecks,
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
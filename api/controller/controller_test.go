@@ -126,6 +126,83 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	issues := []params.MigrationPrecheckIssue{{
+		Stage:   "source",
+		Message: "cleanup needed",
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		out := result.(*params.MigrationDryRunResults)
+		*out = params.MigrationDryRunResults{
+			Results: []params.MigrationDryRunResult{{Issues: issues}},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	out, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, issues)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationDryRun", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		out := result.(*params.MigrationDryRunResults)
+		*out = params.MigrationDryRunResults{
+			Results: []params.MigrationDryRunResult{{
+				Error: common.ServerError(errors.New("boom")),
+			}},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	out, err := client.MigrationDryRun(makeSpec())
+	c.Check(out, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationStatus(c *gc.C) {
+	var stub jujutesting.Stub
+	results := []params.MigrationStatusResult{{
+		MigrationId:   "uuid:0",
+		ModelTag:      "model-uuid",
+		Phase:         "IMPORT",
+		StatusMessage: "importing model into target controller",
+	}, {
+		MigrationId: "other:0",
+		Error:       common.ServerError(errors.NotFoundf("migration")),
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		out := result.(*params.MigrationStatusResults)
+		*out = params.MigrationStatusResults{Results: results}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	out, err := client.MigrationStatus([]string{"uuid:0", "other:0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, results)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationStatus", []interface{}{params.MigrationStatusArgs{
+			MigrationIds: []string{"uuid:0", "other:0"},
+		}}},
+	})
+}
+
+func (s *Suite) TestMigrationStatusResultCount(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationStatus([]string{"uuid:0"})
+	c.Check(err, gc.ErrorMatches, "unexpected number of results returned")
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestHookHistory(c *gc.C) {
+	expected := []params.HookRecord{{
+		Hook:     "install",
+		Started:  time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC),
+		Duration: time.Minute,
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HookHistory")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "HookHistory")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.HookHistoryResults{})
+		*(result.(*params.HookHistoryResults)) = params.HookHistoryResults{
+			Results: []params.HookHistoryResult{{History: expected}},
+		}
+		return nil
+	})
+	client := hookhistory.NewClient(apiCaller)
+	history, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestHookHistoryResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HookHistoryResults)) = params.HookHistoryResults{
+			Results: []params.HookHistoryResult{{
+				Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+			}},
+		}
+		return nil
+	})
+	client := hookhistory.NewClient(apiCaller)
+	_, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+}
+
+func (s *clientSuite) TestHookHistoryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := hookhistory.NewClient(apiCaller)
+	_, err := client.HookHistory(names.NewUnitTag("mysql/0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -66,12 +66,27 @@ func Open(conn base.StreamConnector, cfg params.LogStreamConfig, controllerUUID
 // before being stored in the DB or if the DB on-disk storage for the
 // record becomes corrupted. Both scenarios are highly unlikely and
 // the respective systems are managed such that neither should happen.
-func (ls *LogStream) Next() ([]logfwd.Record, error) {
+//
+// If records were dropped by the log forwarding filters since the
+// previous batch, skipped identifies the last of them, so that the
+// caller can record that it has been dealt with. Only its ID,
+// Timestamp and Origin.ModelUUID are set.
+func (ls *LogStream) Next() (records []logfwd.Record, skipped *logfwd.Record, err error) {
 	apiRecords, err := ls.next()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
+	}
+	if apiRecords.Skipped != nil {
+		skipped = &logfwd.Record{
+			ID:        apiRecords.Skipped.ID,
+			Timestamp: apiRecords.Skipped.Timestamp,
+			Origin: logfwd.Origin{
+				ControllerUUID: ls.controllerUUID,
+				ModelUUID:      apiRecords.Skipped.ModelUUID,
+			},
+		}
 	}
-	records, err := recordsFromAPI(apiRecords, ls.controllerUUID)
+	records, err = recordsFromAPI(apiRecords, ls.controllerUUID)
 	if err != nil {
 		// This should only happen if the data got corrupted over the
 		// network. Any other cause should be addressed by fixing the
@@ -81,9 +96,9 @@ func (ls *LogStream) Next() ([]logfwd.Record, error) {
 		// block on a consistently invalid record or to throw away
 		// a record. The log stream needs to maintain a high level
 		// of reliable delivery.
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	return records, nil
+	return records, skipped, nil
 }
 
 func (ls *LogStream) next() (params.LogStreamRecords, error) {
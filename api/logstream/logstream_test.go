@@ -95,7 +95,7 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 	var records []logfwd.Record
 	done := make(chan struct{})
 	go func() {
-		records, err = stream.Next()
+		records, _, _, err = stream.Next()
 		c.Assert(err, jc.ErrorIsNil)
 		close(done)
 	}()
@@ -132,7 +132,7 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 	// Make sure we don't get extras.
 	done = make(chan struct{})
 	go func() {
-		records, err = stream.Next()
+		records, _, _, err = stream.Next()
 		c.Assert(err, jc.ErrorIsNil)
 		close(done)
 	}()
@@ -143,6 +143,50 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 	}
 }
 
+func (s *LogReaderSuite) TestNextSkipped(c *gc.C) {
+	ts := time.Now()
+	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	jsonReader := mockStream{stub: stub}
+	logsCh := make(chan params.LogStreamRecords, 1)
+	logsCh <- params.LogStreamRecords{
+		Skipped: &params.LogStreamRecord{
+			ID:        12,
+			ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Timestamp: ts,
+		},
+	}
+	jsonReader.ReturnReadJSON = logsCh
+	conn.ReturnConnectStream = jsonReader
+	var cfg params.LogStreamConfig
+	stream, err := logstream.Open(conn, cfg, cUUID)
+	c.Assert(err, gc.IsNil)
+
+	var records []logfwd.Record
+	var skipped *logfwd.Record
+	done := make(chan struct{})
+	go func() {
+		records, skipped, err = stream.Next()
+		c.Check(err, jc.ErrorIsNil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record")
+	}
+	c.Check(records, gc.HasLen, 0)
+	c.Check(skipped, jc.DeepEquals, &logfwd.Record{
+		ID:        12,
+		Timestamp: ts,
+		Origin: logfwd.Origin{
+			ControllerUUID: cUUID,
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		},
+	})
+}
+
 func (s *LogReaderSuite) TestNextError(c *gc.C) {
 	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
 	stub := &testing.Stub{}
@@ -158,7 +202,7 @@ func (s *LogReaderSuite) TestNextError(c *gc.C) {
 	var nextErr error
 	done := make(chan struct{})
 	go func() {
-		_, nextErr = stream.Next()
+		_, _, nextErr = stream.Next()
 		c.Check(errors.Cause(nextErr), gc.Equals, failure)
 		close(done)
 	}()
@@ -186,7 +230,7 @@ func (s *LogReaderSuite) TestClose(c *gc.C) {
 	err = stream.Close() // idempotent
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, err = stream.Next()
+	_, _, err = stream.Next()
 	c.Check(err, gc.ErrorMatches, `cannot read from closed stream`)
 	stub.CheckCallNames(c, "Close")
 }
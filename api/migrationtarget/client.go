@@ -33,6 +33,26 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
+// DryRun asks the target controller to check whether it could accept
+// the model in a migration, without importing it, and returns the
+// issues it found.
+func (c *Client) DryRun(model coremigration.ModelInfo, bytes []byte) ([]params.MigrationPrecheckIssue, error) {
+	args := params.MigrationTargetDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         model.UUID,
+			Name:         model.Name,
+			OwnerTag:     model.Owner.String(),
+			AgentVersion: model.AgentVersion,
+		},
+		Bytes: bytes,
+	}
+	var result params.MigrationPrecheckIssues
+	if err := c.caller.FacadeCall("DryRun", args, &result); err != nil {
+		return nil, err
+	}
+	return result.Issues, nil
+}
+
 // Import takes a serialized model and imports it into the target
 // controller.
 func (c *Client) Import(bytes []byte) error {
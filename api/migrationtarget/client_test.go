@@ -57,6 +57,49 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestDryRun(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+
+	_, err := client.DryRun(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.MigrationTargetDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         "uuid",
+			Name:         "name",
+			OwnerTag:     ownerTag.String(),
+			AgentVersion: vers,
+		},
+		Bytes: []byte("foo"),
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.DryRun", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestDryRunIssues(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.MigrationPrecheckIssues)) = params.MigrationPrecheckIssues{
+			Issues: []params.MigrationPrecheckIssue{{Stage: "target", Message: "upgrade in progress"}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	issues, err := client.DryRun(coremigration.ModelInfo{}, nil)
+	c.Assert(err, gc.IsNil)
+	c.Assert(issues, gc.DeepEquals, []params.MigrationPrecheckIssue{
+		{Stage: "target", Message: "upgrade in progress"},
+	})
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -80,6 +80,29 @@ func (c *Client) CreateModel(
 	return convertParamsModelInfo(modelInfo)
 }
 
+// CloneModel creates a new model, owned by the given user, holding a
+// copy of the source model's applications, charms, config and relations.
+// If addUnits is true, each application in the new model is given as many
+// units as it has in the source model.
+func (c *Client) CloneModel(source names.ModelTag, name, owner string, addUnits bool) (base.ModelInfo, error) {
+	var result base.ModelInfo
+	if !names.IsValidUser(owner) {
+		return result, errors.Errorf("invalid owner name %q", owner)
+	}
+	args := params.CloneModelArgs{
+		SourceModelTag: source.String(),
+		Name:           name,
+		OwnerTag:       names.NewUserTag(owner).String(),
+		AddUnits:       addUnits,
+	}
+	var modelInfo params.ModelInfo
+	err := c.facade.FacadeCall("CloneModel", args, &modelInfo)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return convertParamsModelInfo(modelInfo)
+}
+
 func convertParamsModelInfo(modelInfo params.ModelInfo) (base.ModelInfo, error) {
 	cloud, err := names.ParseCloudTag(modelInfo.CloudTag)
 	if err != nil {
@@ -221,6 +244,28 @@ func (c *Client) DumpModel(model names.ModelTag) (map[string]interface{}, error)
 	return result.Result, nil
 }
 
+// ExportModel returns the serialized form of the model, along with
+// the charms and tools it uses.
+func (c *Client) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	var results params.SerializedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+
+	err := c.facade.FacadeCall("ExportModels", entities, &results)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return params.SerializedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.SerializedModel{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // DumpModelDB returns all relevant mongo documents for the model.
 func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, error) {
 	var results params.MapResults
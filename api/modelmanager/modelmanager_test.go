@@ -69,6 +69,13 @@ func (s *modelmanagerSuite) testCreateModel(c *gc.C, cloud, region string) {
 	c.Assert(utils.IsValidUUIDString(newModel.UUID), jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestCloneModelBadUser(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	defer modelManager.Close()
+	_, err := modelManager.CloneModel(s.State.ModelTag(), "mymodel", "not a user", false)
+	c.Assert(err, gc.ErrorMatches, `invalid owner name "not a user"`)
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	modelManager := s.OpenAPI(c)
 	defer modelManager.Close()
@@ -287,6 +294,89 @@ func (s *dumpModelSuite) TestDumpModelError(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	expected := params.SerializedModel{
+		Bytes:  []byte("model-uuid: some-uuid\n"),
+		Charms: []string{"cs:xenial/mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.0.0-xenial-amd64",
+			URI:     "/tools/2.0.0-xenial-amd64",
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "ExportModels")
+			c.Assert(args, gc.DeepEquals, params.Entities{[]params.Entity{{testing.ModelTag.String()}}})
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			*res = params.SerializedModelResults{Results: []params.SerializedModelResult{{
+				Result: expected,
+			}}}
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.ExportModel(testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, expected)
+}
+
+func (s *dumpModelSuite) TestExportModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			*res = params.SerializedModelResults{Results: []params.SerializedModelResult{{
+				Error: &params.Error{Message: "fake error"},
+			}}}
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(testing.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "fake error")
+}
+
+func (s *dumpModelSuite) TestCloneModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CloneModel")
+			c.Assert(args, gc.DeepEquals, params.CloneModelArgs{
+				SourceModelTag: testing.ModelTag.String(),
+				Name:           "staging",
+				OwnerTag:       "user-bob",
+				AddUnits:       true,
+			})
+			res, ok := result.(*params.ModelInfo)
+			c.Assert(ok, jc.IsTrue)
+			*res = params.ModelInfo{
+				Name:     "staging",
+				UUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				CloudTag: "cloud-dummy",
+				OwnerTag: "user-bob",
+				Life:     params.Alive,
+			}
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.CloneModel(testing.ModelTag, "staging", "bob", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Name, gc.Equals, "staging")
+	c.Check(out.UUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Check(out.Cloud, gc.Equals, "dummy")
+	c.Check(out.Owner, gc.Equals, "bob")
+}
+
+func (s *dumpModelSuite) TestCloneModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			return &params.Error{Message: "fake error"}
+		})
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CloneModel(testing.ModelTag, "staging", "bob", false)
+	c.Assert(err, gc.ErrorMatches, "fake error")
+}
+
 func (s *dumpModelSuite) TestDumpModelDB(c *gc.C) {
 	expected := map[string]interface{}{
 		"models": []map[string]interface{}{{
@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+const apiName = "RemoteRelations"
+
+// Facade allows calls to "RemoteRelations" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a "RemoteRelations" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		facade: base.NewFacadeCaller(caller, apiName),
+	}
+}
+
+// Sync calls "RemoteRelations.Sync".
+func (f *Facade) Sync() error {
+	return f.facade.FacadeCall("Sync", nil, nil)
+}
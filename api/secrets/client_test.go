@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestListSecrets(c *gc.C) {
+	expected := []params.SecretDetails{{
+		Id:          "a1b2",
+		Owner:       "application-mysql",
+		Description: "root password",
+		Created:     time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{Results: expected}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	results, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *clientSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -64,6 +66,15 @@ func (s *actionSuite) TestAction(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", basicParams, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+}
+
 func (s *actionSuite) TestActionNotFound(c *gc.C) {
 	_, err := s.uniter.Action(names.NewActionTag("feedface-0123-4567-8901-2345deadbeef"))
 	c.Assert(err, gc.NotNil)
@@ -101,6 +112,26 @@ func (s *actionSuite) TestActionComplete(c *gc.C) {
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
 
+func (s *actionSuite) TestActionLog(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.ActionLog(action.ActionTag(), "too soon")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionLog(action.ActionTag(), "working")
+	c.Assert(err, jc.ErrorIsNil)
+
+	running, err := s.uniterSuite.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "working")
+}
+
 func (s *actionSuite) TestActionFail(c *gc.C) {
 	completed, err := s.uniterSuite.wordpressUnit.CompletedActions()
 	c.Assert(err, jc.ErrorIsNil)
@@ -8,6 +8,7 @@ import (
 
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
@@ -38,6 +39,21 @@ func PatchUnitFacadeCall(p testing.Patcher, u *Unit, respFunc func(request strin
 	testing.PatchFacadeCall(p, &u.st.facade, respFunc)
 }
 
+// PatchBestAPIVersion changes the internal FacadeCaller to one that
+// reports the given version as the best supported by the controller.
+func PatchBestAPIVersion(p testing.Patcher, st *State, version int) {
+	p.PatchValue(&st.facade, &versionedFacade{st.facade, version})
+}
+
+type versionedFacade struct {
+	base.FacadeCaller
+	version int
+}
+
+func (f *versionedFacade) BestAPIVersion() int {
+	return f.version
+}
+
 // CreateUnit creates uniter.Unit for tests.
 func CreateUnit(st *State, tag names.UnitTag) *Unit {
 	return &Unit{st, tag, params.Alive}
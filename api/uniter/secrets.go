@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddSecret stores a secret owned by the unit's application, and
+// returns its id.
+func (st *State) AddSecret(description string, value map[string]string) (string, error) {
+	if st.BestAPIVersion() < 5 {
+		// AddSecrets() was introduced in UniterAPIV5.
+		return "", errors.NotImplementedf("AddSecrets() (need V5+)")
+	}
+	var results params.StringResults
+	args := params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			UnitTag:     st.unitTag.String(),
+			Description: description,
+			Value:       value,
+		}},
+	}
+	err := st.facade.FacadeCall("AddSecrets", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// GetSecret returns the value of the secret with the given id, if the
+// unit may read it.
+func (st *State) GetSecret(id string) (map[string]string, error) {
+	if st.BestAPIVersion() < 5 {
+		// GetSecrets() was introduced in UniterAPIV5.
+		return nil, errors.NotImplementedf("GetSecrets() (need V5+)")
+	}
+	var results params.SecretValueResults
+	args := params.GetSecretArgs{
+		Args: []params.GetSecretArg{{
+			UnitTag: st.unitTag.String(),
+			Id:      id,
+		}},
+	}
+	err := st.facade.FacadeCall("GetSecrets", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Value, nil
+}
+
+// GrantSecret allows the given unit, application or relation to read
+// the secret with the given id.
+func (st *State) GrantSecret(id string, subject names.Tag) error {
+	if st.BestAPIVersion() < 5 {
+		// GrantSecrets() was introduced in UniterAPIV5.
+		return errors.NotImplementedf("GrantSecrets() (need V5+)")
+	}
+	var results params.ErrorResults
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			UnitTag:    st.unitTag.String(),
+			Id:         id,
+			SubjectTag: subject.String(),
+		}},
+	}
+	err := st.facade.FacadeCall("GrantSecrets", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
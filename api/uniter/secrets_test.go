@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/state"
+)
+
+type secretsSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestAddAndGetSecret(c *gc.C) {
+	id, err := s.uniter.AddSecret("admin", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Owner(), gc.Equals, s.wordpressService.ApplicationTag())
+	c.Check(secret.Description(), gc.Equals, "admin")
+
+	value, err := s.uniter.GetSecret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *secretsSuite) TestGetSecretNotFound(c *gc.C) {
+	_, err := s.uniter.GetSecret("42")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestSecretsNeedV5(c *gc.C) {
+	uniter.PatchBestAPIVersion(s, s.uniter, 4)
+	_, err := s.uniter.AddSecret("", map[string]string{"password": "sekrit"})
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = s.uniter.GetSecret("42")
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	err = s.uniter.GrantSecret("42", s.wordpressUnit.UnitTag())
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *secretsSuite) TestGrantSecret(c *gc.C) {
+	id, err := s.uniter.AddSecret("", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.GrantSecret(id, names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, `granting secret to "machine-0" not valid`)
+
+	err = s.uniter.GrantSecret(id, s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []string{s.wordpressUnit.Tag().String()})
+}
+
+func (s *secretsSuite) TestGetSecretNotGranted(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: mysql.ApplicationTag(),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.uniter.GetSecret(secret.Id())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -460,6 +460,23 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// AddHookRecord records an execution of a hook in the unit's hook
+// history.
+func (u *Unit) AddHookRecord(record params.HookRecord) error {
+	var result params.ErrorResults
+	args := params.AddHookRecordArgs{
+		Args: []params.AddHookRecordArg{{
+			Tag:    u.tag.String(),
+			Record: record,
+		}},
+	}
+	err := u.st.facade.FacadeCall("AddHookRecords", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 var ErrNoCharmURLSet = errors.New("unit has no charm url set")
 
 // CharmURL returns the charm URL this unit is currently using.
@@ -595,6 +612,34 @@ func (u *Unit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchAbortingActions returns a StringsWatcher for observing the ids
+// of the Unit's running Actions that have been asked to abort. The
+// initial event will contain the ids of any Actions already aborting
+// at the time the Watcher is made.
+func (u *Unit) WatchAbortingActions() (watcher.StringsWatcher, error) {
+	if u.st.BestAPIVersion() < 5 {
+		// WatchAbortingActions() was introduced in UniterAPIV5.
+		return nil, errors.NotImplementedf("WatchAbortingActions() (need V5+)")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchAbortingActions", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // RequestReboot sets the reboot flag for its machine agent
 func (u *Unit) RequestReboot() error {
 	machineId, err := u.AssignedMachine()
@@ -51,6 +51,24 @@ func (s *unitSuite) TestRequestReboot(c *gc.C) {
 	c.Assert(rFlag, jc.IsTrue)
 }
 
+func (s *unitSuite) TestAddHookRecord(c *gc.C) {
+	started := time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC)
+	err := s.apiUnit.AddHookRecord(params.HookRecord{
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(history, jc.DeepEquals, []state.HookRecord{{
+		Hook:     "install",
+		Started:  started,
+		Duration: time.Minute,
+	}})
+}
+
 func (s *unitSuite) TestUnitAndUnitTag(c *gc.C) {
 	apiUnitFoo, err := s.uniter.Unit(names.NewUnitTag("foo/42"))
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -512,6 +530,26 @@ func (s *unitSuite) TestWatchActionNotifications(c *gc.C) {
 	wc.AssertChange(action.Id())
 }
 
+func (s *unitSuite) TestWatchAbortingActions(c *gc.C) {
+	w, err := s.apiUnit.WatchAbortingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	_, err = action.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(action.Id())
+}
+
 func (s *unitSuite) TestWatchActionNotificationsError(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "WatchActionNotifications",
 		func(result interface{}) error {
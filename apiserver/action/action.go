@@ -5,6 +5,7 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -14,8 +15,12 @@ import (
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.action")
+
 func init() {
 	common.RegisterStandardFacade("Action", 2, NewActionAPI)
+	// Facade version 3 adds ActionsWithLog.
+	common.RegisterStandardFacade("Action", 3, NewActionAPI)
 }
 
 // ActionAPI implements the client API for interacting with Actions
@@ -76,6 +81,19 @@ func (a *ActionAPI) checkCanAdmin() error {
 // Actions takes a list of ActionTags, and returns the full Action for
 // each ID.
 func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
+	return a.actions(arg, common.MakeActionResult)
+}
+
+// ActionsWithLog takes a list of ActionTags, and returns the full
+// Action for each ID, including the progress messages it has logged.
+func (a *ActionAPI) ActionsWithLog(arg params.Entities) (params.ActionResults, error) {
+	return a.actions(arg, common.MakeActionResultWithLog)
+}
+
+func (a *ActionAPI) actions(
+	arg params.Entities,
+	makeResult func(names.Tag, state.Action) params.ActionResult,
+) (params.ActionResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -103,7 +121,7 @@ func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		response.Results[i] = common.MakeActionResult(receiverTag, action)
+		response.Results[i] = makeResult(receiverTag, action)
 	}
 	return response, nil
 }
@@ -158,7 +176,8 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
-// Action.
+// Action. The Actions are grouped into a single operation: a new one,
+// or the existing one named in the request.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -168,8 +187,23 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
-	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
+	if len(arg.Actions) == 0 {
+		return response, nil
+	}
+	var operation *state.Operation
+	var err error
+	if arg.Operation != "" {
+		operation, err = a.state.Operation(arg.Operation)
+	} else {
+		operation, err = a.state.EnqueueOperation(operationSummary(arg.Actions))
+	}
+	if err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
+	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
+	enqueuedCount := 0
 	for i, action := range arg.Actions {
 		currentResult := &response.Results[i]
 		receiver, err := tagToActionReceiver(action.Receiver)
@@ -177,14 +211,22 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddOperationAction(operation.Id(), action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		enqueuedCount++
 
 		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued)
 	}
+	if enqueuedCount == 0 && arg.Operation == "" {
+		// Don't leave an empty operation behind; the errors
+		// for the actions have been reported already.
+		if err := operation.Remove(); err != nil {
+			logger.Warningf("%v", err)
+		}
+	}
 	return response, nil
 }
 
@@ -232,7 +274,9 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel attempts to cancel enqueued Actions from running. Actions
+// that are already running are asked to abort instead, which the unit
+// running them will do.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -260,7 +304,13 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		var result state.Action
+		switch action.Status() {
+		case state.ActionRunning, state.ActionAborting:
+			result, err = action.Abort()
+		default:
+			result, err = action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		}
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
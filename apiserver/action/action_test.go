@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -161,6 +162,30 @@ func (s *actionSuite) TestActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestActionsWithLog(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+	entities := params.Entities{Entities: []params.Entity{{Tag: a.Tag().String()}}}
+
+	// The log is only included when asked for.
+	actions, err := s.action.Actions(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions.Results, gc.HasLen, 1)
+	c.Check(actions.Results[0].Error, gc.IsNil)
+	c.Check(actions.Results[0].Log, gc.HasLen, 0)
+
+	actions, err = s.action.ActionsWithLog(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions.Results, gc.HasLen, 1)
+	c.Check(actions.Results[0].Error, gc.IsNil)
+	c.Assert(actions.Results[0].Log, gc.HasLen, 1)
+	c.Check(actions.Results[0].Log[0].Message, gc.Equals, "halfway there")
+}
+
 func (s *actionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	// NOTE: full testing with multiple matches has been moved to state package.
 	arg := params.Actions{Actions: []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}}}}
@@ -273,6 +298,26 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Timeout:  10 * time.Minute,
+		}},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Check(res.Results[0].Action.Timeout, gc.Equals, 10*time.Minute)
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Timeout(), gc.Equals, 10*time.Minute)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -630,6 +675,25 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Entities{Entities: []params.Entity{{Tag: action.Tag().String()}}}
+	results, err := s.action.Cancel(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Status, gc.Equals, params.ActionAborting)
+
+	running, err := s.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	c.Check(running[0].Status(), gc.Equals, state.ActionAborting)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// defaultOperationsLimit is the number of operations returned by
+// ListOperations when the caller does not set a limit.
+const defaultOperationsLimit = 50
+
+// Operations returns the operations with the given ids, with their
+// actions.
+func (a *ActionAPI) Operations(arg params.OperationIds) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	response := params.OperationResults{Results: make([]params.OperationResult, len(arg.Ids))}
+	operations := make([]*state.Operation, len(arg.Ids))
+	var ids []string
+	for i, id := range arg.Ids {
+		operation, err := a.state.Operation(id)
+		if err != nil {
+			response.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		operations[i] = operation
+		ids = append(ids, operation.Id())
+	}
+	counts, err := a.state.OperationActionCounts(ids)
+	for i, operation := range operations {
+		if operation != nil {
+			response.Results[i] = makeOperationResult(operation, counts, err, true)
+		}
+	}
+	return response, nil
+}
+
+// ListOperations returns a page of the operations in the model,
+// oldest first, without their actions.
+func (a *ActionAPI) ListOperations(arg params.ListOperationsArgs) (params.OperationResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	limit := arg.Limit
+	if limit == 0 {
+		limit = defaultOperationsLimit
+	}
+	operations, more, err := a.state.ListOperations(arg.Offset, limit)
+	if err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+	ids := make([]string, len(operations))
+	for i, operation := range operations {
+		ids[i] = operation.Id()
+	}
+	counts, err := a.state.OperationActionCounts(ids)
+	response := params.OperationResults{
+		Results:   make([]params.OperationResult, len(operations)),
+		Truncated: more,
+	}
+	for i, operation := range operations {
+		response.Results[i] = makeOperationResult(operation, counts, err, false)
+	}
+	return response, nil
+}
+
+// makeOperationResult describes the operation, given the action counts
+// of a batch of operations and any error from getting them.
+func makeOperationResult(
+	operation *state.Operation,
+	allCounts map[string]map[state.ActionStatus]int,
+	countsErr error,
+	withActions bool,
+) params.OperationResult {
+	result := params.OperationResult{
+		Id:       operation.Id(),
+		Summary:  operation.Summary(),
+		Enqueued: operation.Enqueued(),
+	}
+	if countsErr != nil {
+		result.Error = common.ServerError(countsErr)
+		return result
+	}
+	counts := allCounts[operation.Id()]
+	result.Status = string(state.OperationStatus(counts))
+	result.Counts = make(map[string]int)
+	for status, count := range counts {
+		result.Counts[string(status)] = count
+	}
+	if !withActions {
+		return result
+	}
+
+	actions, err := operation.Actions()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	for _, action := range actions {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			result.Actions = append(result.Actions, params.ActionResult{Error: common.ServerError(err)})
+			continue
+		}
+		result.Actions = append(result.Actions, common.MakeActionResult(receiverTag, action))
+	}
+	return result
+}
+
+// operationSummary describes an operation enqueueing the given
+// actions, such as "backup on 3 units".
+func operationSummary(actions []params.Action) string {
+	actionNames := set.NewStrings()
+	kinds := set.NewStrings()
+	counts := make(map[string]int)
+	for _, action := range actions {
+		actionNames.Add(action.Name)
+		kind := "receiver"
+		if tag, err := names.ParseTag(action.Receiver); err == nil {
+			kind = tag.Kind()
+		}
+		kinds.Add(kind)
+		counts[kind]++
+	}
+	var receivers []string
+	for _, kind := range kinds.SortedValues() {
+		count := counts[kind]
+		if count != 1 {
+			kind += "s"
+		}
+		receivers = append(receivers, fmt.Sprintf("%d %s", count, kind))
+	}
+	return fmt.Sprintf("%s on %s",
+		strings.Join(actionNames.SortedValues(), ", "),
+		strings.Join(receivers, " and "),
+	)
+}
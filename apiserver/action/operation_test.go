@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *actionSuite) TestEnqueueGroupsActionsInOperation(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+		}}
+	r, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 2)
+	operationId := r.Results[0].Operation
+	c.Assert(operationId, gc.Not(gc.Equals), "")
+	c.Check(r.Results[1].Operation, gc.Equals, operationId)
+
+	operations, err := s.action.Operations(params.OperationIds{Ids: []string{operationId, "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 2)
+	result := operations.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Id, gc.Equals, operationId)
+	c.Check(result.Summary, gc.Equals, "fakeaction on 2 units")
+	c.Check(result.Status, gc.Equals, params.ActionPending)
+	c.Check(result.Counts, jc.DeepEquals, map[string]int{params.ActionPending: 2})
+	c.Assert(result.Actions, gc.HasLen, 2)
+	var tags []string
+	for _, action := range result.Actions {
+		tags = append(tags, action.Action.Tag)
+	}
+	c.Check(tags, jc.SameContents, []string{r.Results[0].Action.Tag, r.Results[1].Action.Tag})
+	c.Check(operations.Results[1].Error, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *actionSuite) TestEnqueueWithoutActionsLeavesNoOperation(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "nonsense"},
+		}}
+	r, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.NotNil)
+
+	operations, err := s.State.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(operations, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestListOperations(c *gc.C) {
+	first, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.machine0.Tag().String(), Name: "juju-run", Parameters: map[string]interface{}{"command": "ls"}},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, action := range actions {
+		if action.Operation() == first.Results[0].Operation {
+			_, err := action.Finish(state.ActionResults{Status: state.ActionCompleted})
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+
+	operations, err := s.action.ListOperations(params.ListOperationsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 2)
+	c.Check(operations.Results[0].Id, gc.Equals, first.Results[0].Operation)
+	c.Check(operations.Results[0].Status, gc.Equals, params.ActionCompleted)
+	c.Check(operations.Results[0].Actions, gc.HasLen, 0)
+	c.Check(operations.Results[1].Id, gc.Equals, second.Results[0].Operation)
+	c.Check(operations.Results[1].Summary, gc.Equals, "fakeaction, juju-run on 1 machine and 1 unit")
+	c.Check(operations.Results[1].Counts, jc.DeepEquals, map[string]int{params.ActionPending: 2})
+	c.Check(operations.Truncated, jc.IsFalse)
+
+	operations, err = s.action.ListOperations(params.ListOperationsArgs{Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 1)
+	c.Check(operations.Results[0].Id, gc.Equals, first.Results[0].Operation)
+	c.Check(operations.Truncated, jc.IsTrue)
+
+	operations, err = s.action.ListOperations(params.ListOperationsArgs{Offset: 1, Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 1)
+	c.Check(operations.Results[0].Id, gc.Equals, second.Results[0].Operation)
+	c.Check(operations.Truncated, jc.IsFalse)
+}
+
+func (s *actionSuite) TestEnqueueIntoExistingOperation(c *gc.C) {
+	first, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	operationId := first.Results[0].Operation
+
+	second, err := s.action.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+		},
+		Operation: operationId,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.Results, gc.HasLen, 1)
+	c.Check(second.Results[0].Operation, gc.Equals, operationId)
+
+	operations, err := s.action.ListOperations(params.ListOperationsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations.Results, gc.HasLen, 1)
+	c.Check(operations.Results[0].Counts, jc.DeepEquals, map[string]int{params.ActionPending: 2})
+
+	_, err = s.action.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+		},
+		Operation: "42",
+	})
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
@@ -65,6 +65,7 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 	}
 
 	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
+	actionParams.Operation = run.Operation
 
 	return queueActions(a, actionParams)
 }
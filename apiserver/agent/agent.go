@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -120,6 +122,9 @@ func (api *AgentAPIV2) StateServingInfo() (result params.StateServingInfo, err e
 		CAPrivateKey:   info.CAPrivateKey,
 		SharedSecret:   info.SharedSecret,
 		SystemIdentity: info.SystemIdentity,
+		// The secrets key is not stored in the database, so is
+		// passed on from this controller's own agent.
+		SecretsKey: base64.StdEncoding.EncodeToString(api.st.SecretsKey()),
 	}
 
 	return result, nil
@@ -11,11 +11,14 @@ package apiserver
 // place, not scattering it across packages and depending on magic import lists.
 import (
 	_ "github.com/juju/juju/apiserver/action" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/actionpruner"
 	_ "github.com/juju/juju/apiserver/agent"
 	_ "github.com/juju/juju/apiserver/agenttools"
-	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
-	_ "github.com/juju/juju/apiserver/application" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/annotations"       // ModelUser Write
+	_ "github.com/juju/juju/apiserver/application"       // ModelUser Write
+	_ "github.com/juju/juju/apiserver/applicationoffers" // ModelUser Write (offering requires Admin)
 	_ "github.com/juju/juju/apiserver/applicationscaler"
+	_ "github.com/juju/juju/apiserver/auditlog"
 	_ "github.com/juju/juju/apiserver/backups" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
 	_ "github.com/juju/juju/apiserver/bundle"
@@ -30,6 +33,7 @@ import (
 	_ "github.com/juju/juju/apiserver/diskmanager"
 	_ "github.com/juju/juju/apiserver/firewaller"
 	_ "github.com/juju/juju/apiserver/highavailability" // ModelUser Write
+	_ "github.com/juju/juju/apiserver/hookhistory"      // ModelUser Read
 	_ "github.com/juju/juju/apiserver/hostkeyreporter"
 	_ "github.com/juju/juju/apiserver/imagemanager" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/imagemetadata"
@@ -57,8 +61,10 @@ import (
 	_ "github.com/juju/juju/apiserver/provisioner"
 	_ "github.com/juju/juju/apiserver/proxyupdater"
 	_ "github.com/juju/juju/apiserver/reboot"
+	_ "github.com/juju/juju/apiserver/remoterelations"
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
+	_ "github.com/juju/juju/apiserver/secrets" // ModelUser Admin
 	_ "github.com/juju/juju/apiserver/singular"
 	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -0,0 +1,288 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationoffers defines an API end point for offering
+// application endpoints to other models, and for finding and consuming
+// the offers made by other models on the same controller.
+package applicationoffers
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("ApplicationOffers", 1, newAPIFromState)
+}
+
+// Offer describes an application offer made by a model.
+type Offer struct {
+	URL                    crossmodel.OfferURL
+	SourceModel            names.ModelTag
+	ApplicationName        string
+	ApplicationDescription string
+	Endpoints              []charm.Relation
+}
+
+// Backend defines the state functionality required by the
+// ApplicationOffers facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddApplicationOffer(state.AddApplicationOfferArgs) error
+	AddRemoteApplication(state.AddRemoteApplicationArgs) error
+
+	// OffersForUser returns the offers made by all the models on the
+	// controller that the user can access.
+	OffersForUser(user names.UserTag) ([]Offer, error)
+}
+
+// API implements the ApplicationOffers facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func newAPIFromState(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, authorizer)
+}
+
+// NewAPI returns a new ApplicationOffers API facade. Model admins may
+// offer the model's applications; users who can write to the model may
+// consume offers from the models they can also write to.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Offer offers the given application endpoints to other models.
+func (api *API) Offer(args params.AddApplicationOffers) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, err
+	}
+	owner := api.authorizer.GetAuthTag().(names.UserTag)
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	for i, offer := range args.Offers {
+		err := api.backend.AddApplicationOffer(state.AddApplicationOfferArgs{
+			OfferName:              offer.OfferName,
+			ApplicationName:        offer.ApplicationName,
+			ApplicationDescription: offer.ApplicationDescription,
+			Endpoints:              offer.Endpoints,
+			Owner:                  owner,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// FindApplicationOffers returns the offers, made by the models the
+// user can access, that match each of the given filters.
+func (api *API) FindApplicationOffers(args params.OfferFilters) (params.FindApplicationOffersResults, error) {
+	user := api.authorizer.GetAuthTag().(names.UserTag)
+	offers, err := api.backend.OffersForUser(user)
+	if err != nil {
+		return params.FindApplicationOffersResults{}, errors.Trace(err)
+	}
+	results := params.FindApplicationOffersResults{
+		Results: make([]params.FindApplicationOffersResult, len(args.Filters)),
+	}
+	for i, filter := range args.Filters {
+		for _, offer := range offers {
+			if offerMatches(offer, filter) {
+				results.Results[i].Offers = append(results.Results[i].Offers, offerParams(offer))
+			}
+		}
+	}
+	return results, nil
+}
+
+func offerMatches(offer Offer, filter params.OfferFilter) bool {
+	return (filter.OwnerName == "" || filter.OwnerName == offer.URL.Owner) &&
+		(filter.ModelName == "" || filter.ModelName == offer.URL.ModelName) &&
+		(filter.OfferName == "" || filter.OfferName == offer.URL.OfferName) &&
+		(filter.ApplicationName == "" || filter.ApplicationName == offer.ApplicationName)
+}
+
+func offerParams(offer Offer) params.ApplicationOffer {
+	result := params.ApplicationOffer{
+		OfferURL:               offer.URL.String(),
+		OfferName:              offer.URL.OfferName,
+		SourceModelTag:         offer.SourceModel.String(),
+		ApplicationName:        offer.ApplicationName,
+		ApplicationDescription: offer.ApplicationDescription,
+		Endpoints:              make([]params.CharmRelation, len(offer.Endpoints)),
+	}
+	for i, ep := range offer.Endpoints {
+		result.Endpoints[i] = params.CharmRelation{
+			Name:      ep.Name,
+			Role:      string(ep.Role),
+			Interface: ep.Interface,
+			Optional:  ep.Optional,
+			Limit:     ep.Limit,
+			Scope:     string(ep.Scope),
+		}
+	}
+	return result
+}
+
+// Consume adds remote applications to the model for the given offers,
+// so that the model's applications can relate to them. It returns the
+// names of the remote applications. The user must be able to write to
+// both this model and the model making each offer.
+func (api *API) Consume(args params.ConsumeApplicationArgs) (params.StringResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.StringResults{}, err
+	}
+	user := api.authorizer.GetAuthTag().(names.UserTag)
+	offers, err := api.backend.OffersForUser(user)
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		name, err := api.consume(user, offers, arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = name
+	}
+	return results, nil
+}
+
+func (api *API) consume(user names.UserTag, offers []Offer, arg params.ConsumeApplicationArg) (string, error) {
+	url, err := crossmodel.ParseOfferURL(arg.OfferURL)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	url = url.WithOwner(user.Id())
+	var offer *Offer
+	for i := range offers {
+		if offers[i].URL == url {
+			offer = &offers[i]
+			break
+		}
+	}
+	if offer == nil {
+		return "", errors.NotFoundf("application offer %q", url)
+	}
+	if offer.SourceModel == api.backend.ModelTag() {
+		return "", errors.Errorf("cannot consume an offer made by the same model")
+	}
+	// Relating to the offered application changes the offering model
+	// too, so seeing the offer is not enough to consume it.
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, offer.SourceModel)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !canWrite {
+		return "", common.ErrPerm
+	}
+	name := arg.ApplicationAlias
+	if name == "" {
+		name = url.OfferName
+	}
+	err = api.backend.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  name,
+		OfferURL:              url.String(),
+		SourceModel:           offer.SourceModel,
+		SourceApplicationName: offer.ApplicationName,
+		OfferName:             url.OfferName,
+		Endpoints:             offer.Endpoints,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) AddApplicationOffer(args state.AddApplicationOfferArgs) error {
+	_, err := s.State.AddApplicationOffer(args)
+	return err
+}
+
+func (s stateShim) AddRemoteApplication(args state.AddRemoteApplicationArgs) error {
+	_, err := s.State.AddRemoteApplication(args)
+	return err
+}
+
+func (s stateShim) OffersForUser(user names.UserTag) ([]Offer, error) {
+	models, err := s.ModelsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var offers []Offer
+	for _, model := range models {
+		modelOffers, err := s.modelOffers(model.Model)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting offers of model %q", model.Name())
+		}
+		offers = append(offers, modelOffers...)
+	}
+	return offers, nil
+}
+
+func (s stateShim) modelOffers(model *state.Model) ([]Offer, error) {
+	st, err := s.ForModel(model.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+
+	applicationOffers, err := st.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offers := make([]Offer, len(applicationOffers))
+	for i, applicationOffer := range applicationOffers {
+		eps, err := applicationOffer.Endpoints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		offers[i] = Offer{
+			URL: crossmodel.OfferURL{
+				Owner:     model.Owner().Id(),
+				ModelName: model.Name(),
+				OfferName: applicationOffer.OfferName(),
+			},
+			SourceModel:            model.ModelTag(),
+			ApplicationName:        applicationOffer.ApplicationName(),
+			ApplicationDescription: applicationOffer.ApplicationDescription(),
+		}
+		for _, ep := range eps {
+			offers[i].Endpoints = append(offers[i].Endpoints, ep.Relation)
+		}
+	}
+	return offers, nil
+}
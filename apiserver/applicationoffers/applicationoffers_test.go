@@ -0,0 +1,250 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/applicationoffers"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type applicationOffersSuite struct {
+	testing.IsolationSuite
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&applicationOffersSuite{})
+
+var (
+	offerModelTag = names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+
+	mysqlServerRelation = charm.Relation{
+		Name:      "server",
+		Role:      charm.RoleProvider,
+		Interface: "mysql",
+		Scope:     charm.ScopeGlobal,
+	}
+)
+
+func (s *applicationOffersSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		offers: []applicationoffers.Offer{{
+			URL:                    crossmodel.OfferURL{Owner: "admin", ModelName: "prod", OfferName: "db"},
+			SourceModel:            offerModelTag,
+			ApplicationName:        "mysql",
+			ApplicationDescription: "shared database",
+			Endpoints:              []charm.Relation{mysqlServerRelation},
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *applicationOffersSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationOffersSuite) TestOffer(c *gc.C) {
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.Offer(params.AddApplicationOffers{Offers: []params.AddApplicationOffer{{
+		OfferName:              "db",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "shared database",
+		Endpoints:              []string{"server"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{{"AddApplicationOffer", []interface{}{state.AddApplicationOfferArgs{
+		OfferName:              "db",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "shared database",
+		Endpoints:              []string{"server"},
+		Owner:                  names.NewUserTag("admin"),
+	}}}})
+}
+
+func (s *applicationOffersSuite) TestOfferRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.HasWriteTag = names.NewUserTag("bob")
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Offer(params.AddApplicationOffers{Offers: []params.AddApplicationOffer{{
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	}}})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *applicationOffersSuite) TestFindApplicationOffers(c *gc.C) {
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.FindApplicationOffers(params.OfferFilters{Filters: []params.OfferFilter{
+		{ModelName: "prod"},
+		{OfferName: "nope"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Offers, jc.DeepEquals, []params.ApplicationOffer{{
+		OfferURL:               "admin/prod.db",
+		OfferName:              "db",
+		SourceModelTag:         offerModelTag.String(),
+		ApplicationName:        "mysql",
+		ApplicationDescription: "shared database",
+		Endpoints: []params.CharmRelation{{
+			Name:      "server",
+			Role:      "provider",
+			Interface: "mysql",
+			Scope:     "global",
+		}},
+	}})
+	c.Check(results.Results[1].Offers, gc.HasLen, 0)
+	s.backend.CheckCalls(c, []testing.StubCall{{"OffersForUser", []interface{}{names.NewUserTag("admin")}}})
+}
+
+func (s *applicationOffersSuite) TestConsume(c *gc.C) {
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.Consume(params.ConsumeApplicationArgs{Args: []params.ConsumeApplicationArg{
+		{OfferURL: "prod.db"},
+		{OfferURL: "admin/prod.db", ApplicationAlias: "shared-db"},
+		{OfferURL: "prod.nope"},
+		{OfferURL: "nonsense"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], jc.DeepEquals, params.StringResult{Result: "db"})
+	c.Check(results.Results[1], jc.DeepEquals, params.StringResult{Result: "shared-db"})
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `application offer "admin/prod.nope" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `offer URL "nonsense", .* not valid`)
+
+	expectArgs := func(name string) state.AddRemoteApplicationArgs {
+		return state.AddRemoteApplicationArgs{
+			Name:                  name,
+			OfferURL:              "admin/prod.db",
+			SourceModel:           offerModelTag,
+			SourceApplicationName: "mysql",
+			OfferName:             "db",
+			Endpoints:             []charm.Relation{mysqlServerRelation},
+		}
+	}
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"OffersForUser", []interface{}{names.NewUserTag("admin")}},
+		{"AddRemoteApplication", []interface{}{expectArgs("db")}},
+		{"AddRemoteApplication", []interface{}{expectArgs("shared-db")}},
+	})
+}
+
+func (s *applicationOffersSuite) TestConsumeSameModel(c *gc.C) {
+	s.backend.offers[0].SourceModel = coretesting.ModelTag
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.Consume(params.ConsumeApplicationArgs{Args: []params.ConsumeApplicationArg{
+		{OfferURL: "prod.db"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "cannot consume an offer made by the same model")
+}
+
+func (s *applicationOffersSuite) TestConsumeRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := applicationoffers.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Consume(params.ConsumeApplicationArgs{Args: []params.ConsumeApplicationArg{
+		{OfferURL: "prod.db"},
+	}})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationOffersSuite) TestConsumeRequiresWriteOnOfferingModel(c *gc.C) {
+	authorizer := modelAuthorizer{
+		FakeAuthorizer: apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")},
+		writable:       []names.Tag{coretesting.ModelTag},
+	}
+	api, err := applicationoffers.NewAPI(s.backend, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.Consume(params.ConsumeApplicationArgs{Args: []params.ConsumeApplicationArg{
+		{OfferURL: "prod.db"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	s.backend.CheckCallNames(c, "OffersForUser")
+
+	authorizer.writable = append(authorizer.writable, offerModelTag)
+	api, err = applicationoffers.NewAPI(s.backend, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = api.Consume(params.ConsumeApplicationArgs{Args: []params.ConsumeApplicationArg{
+		{OfferURL: "prod.db"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0], jc.DeepEquals, params.StringResult{Result: "db"})
+}
+
+// modelAuthorizer is a FakeAuthorizer granting write access only to
+// the given models.
+type modelAuthorizer struct {
+	apiservertesting.FakeAuthorizer
+	writable []names.Tag
+}
+
+func (a modelAuthorizer) HasPermission(access permission.Access, target names.Tag) (bool, error) {
+	if access != permission.WriteAccess && access != permission.ReadAccess {
+		return false, nil
+	}
+	for _, tag := range a.writable {
+		if tag == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeBackend struct {
+	testing.Stub
+	offers []applicationoffers.Offer
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) AddApplicationOffer(args state.AddApplicationOfferArgs) error {
+	b.AddCall("AddApplicationOffer", args)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) AddRemoteApplication(args state.AddRemoteApplicationArgs) error {
+	b.AddCall("AddRemoteApplication", args)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) OffersForUser(user names.UserTag) ([]applicationoffers.Offer, error) {
+	b.AddCall("OffersForUser", user)
+	return b.offers, b.NextErr()
+}
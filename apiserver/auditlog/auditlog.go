@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for querying the audit
+// entries recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, newAPIFromState)
+}
+
+// maxEntries is the maximum number of entries returned by a single
+// query, regardless of the limit requested.
+const maxEntries = 10000
+
+// Backend defines the state functionality required by the AuditLog
+// facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(audit.Filter) ([]audit.AuditEntry, error)
+}
+
+// API implements the AuditLog facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func newAPIFromState(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(st, authorizer)
+}
+
+// NewAPI returns a new AuditLog API facade. Only controller
+// superusers may query the audit log.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Query returns the recorded audit entries satisfying the supplied
+// filter, oldest first.
+func (api *API) Query(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	filter := audit.Filter{
+		ModelUUID:     args.ModelUUID,
+		OriginType:    args.OriginType,
+		OriginName:    args.OriginName,
+		Operation:     args.Operation,
+		RemoteAddress: args.RemoteAddress,
+		Limit:         args.Limit,
+	}
+	if args.After != nil {
+		filter.After = args.After.UTC()
+	}
+	if args.Before != nil {
+		filter.Before = args.Before.UTC()
+	}
+	if filter.Limit <= 0 || filter.Limit > maxEntries {
+		filter.Limit = maxEntries
+	}
+	entries, err := api.backend.AuditEntries(filter)
+	if err != nil {
+		return params.AuditLogResults{}, errors.Trace(err)
+	}
+	results := params.AuditLogResults{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		results.Entries[i] = params.AuditLogEntry{
+			ID:                entry.ID,
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return results, nil
+}
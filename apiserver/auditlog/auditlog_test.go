@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.entries = []audit.AuditEntry{{
+		ID:                "5810a1f2c4d9e21a2b3c4d5e",
+		JujuServerVersion: version.MustParse("2.1.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Client:v1 - FullStatus",
+		Data:              map[string]interface{}{"request-body": "{}"},
+	}}
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	after := t0.Add(-time.Hour)
+	results, err := api.Query(params.AuditLogFilter{
+		ModelUUID:  coretesting.ModelTag.Id(),
+		OriginName: "user-admin",
+		Operation:  "FullStatus",
+		After:      &after,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AuditLogResults{
+		Entries: []params.AuditLogEntry{{
+			ID:                "5810a1f2c4d9e21a2b3c4d5e",
+			JujuServerVersion: "2.1.0",
+			ModelUUID:         coretesting.ModelTag.Id(),
+			Timestamp:         t0,
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-admin",
+			Operation:         "Client:v1 - FullStatus",
+			Data:              map[string]interface{}{"request-body": "{}"},
+		}},
+	})
+	c.Assert(s.backend.filter, jc.DeepEquals, audit.Filter{
+		ModelUUID:  coretesting.ModelTag.Id(),
+		OriginName: "user-admin",
+		Operation:  "FullStatus",
+		After:      after,
+		Limit:      10,
+	})
+}
+
+func (s *auditLogSuite) TestQueryDefaultLimit(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.Query(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Entries, gc.HasLen, 0)
+	c.Assert(s.backend.filter.Limit, gc.Equals, 10000)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeBackend struct {
+	entries []audit.AuditEntry
+	filter  audit.Filter
+	err     error
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	b.filter = filter
+	return b.entries, b.err
+}
@@ -21,7 +21,7 @@ import (
 
 var newBackups = func(st *state.State) (backups.Backups, io.Closer) {
 	stor := backups.NewStorage(st)
-	return backups.NewBackups(stor), stor
+	return backups.NewControllerBackups(stor, st), stor
 }
 
 // backupHandler handles backup requests.
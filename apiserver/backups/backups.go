@@ -36,6 +36,8 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelConfig() (*config.Config, error)
 	ControllerConfig() (controller.Config, error)
+	ControllerSecrets() (controller.Secrets, error)
+	RotateBackupEncryptionKey(key string) error
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
 }
@@ -107,7 +109,7 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 
 var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
 	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	return backups.NewControllerBackups(stor, backend), stor
 }
 
 // ResultFromMetadata updates the result with the information in the
@@ -129,6 +131,11 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Storage = meta.Storage
+	result.EncryptionScheme = meta.Encryption.Scheme
+	result.EncryptionKeyFingerprint = meta.Encryption.KeyFingerprint
+	result.Parent = meta.Parent
+	result.OplogPosition = int64(meta.OplogPosition)
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -148,6 +155,26 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 	return result
 }
 
+// VerifyResultFromVerification returns the API result describing the
+// verification of a backup.
+func VerifyResultFromVerification(v *backups.Verification) params.BackupsVerifyResult {
+	result := params.BackupsVerifyResult{
+		ChecksumVerified: v.ChecksumVerified,
+		ContentsVerified: v.ContentsVerified,
+		OplogEntries:     v.OplogEntries,
+		Missing:          v.Missing,
+		Problems:         v.Problems,
+	}
+	if len(v.Counts) > 0 {
+		result.Counts = v.Counts
+	}
+	if v.Metadata != nil {
+		meta := ResultFromMetadata(v.Metadata)
+		result.Metadata = &meta
+	}
+	return result
+}
+
 // MetadataFromResult returns a new Metadata based on the result. The ID
 // of the metadata is not set. Call meta.SetID() if that is desired.
 // Likewise with Stored and meta.SetStored().
@@ -163,6 +190,11 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Storage = result.Storage
+	meta.Encryption.Scheme = result.EncryptionScheme
+	meta.Encryption.KeyFingerprint = result.EncryptionKeyFingerprint
+	meta.Parent = result.Parent
+	meta.OplogPosition = backups.OplogPosition(result.OplogPosition)
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
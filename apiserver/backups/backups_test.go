@@ -64,6 +64,8 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 func (s *backupsSuite) TestRegistered(c *gc.C) {
 	_, err := common.Facades.GetType("Backups", 1)
 	c.Check(err, jc.ErrorIsNil)
+	_, err = common.Facades.GetType("Backups", 2)
+	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
@@ -84,3 +86,16 @@ func (s *backupsSuite) TestNewAPIHostedEnvironmentFails(c *gc.C) {
 	_, err := backupsAPI.NewAPI(&stateShim{otherState}, s.resources, s.authorizer)
 	c.Check(err, gc.ErrorMatches, "backups are not supported for hosted models")
 }
+
+func (s *backupsSuite) TestResultFromMetadataEncryption(c *gc.C) {
+	s.meta.Encryption = backups.EncryptionInfo{
+		Scheme:         backups.EncryptionSymmetric,
+		KeyFingerprint: "sha256:deadbeef",
+	}
+	result := backupsAPI.ResultFromMetadata(s.meta)
+	c.Check(result.EncryptionScheme, gc.Equals, backups.EncryptionSymmetric)
+	c.Check(result.EncryptionKeyFingerprint, gc.Equals, "sha256:deadbeef")
+
+	meta := backupsAPI.MetadataFromResult(result)
+	c.Check(meta.Encryption, jc.DeepEquals, s.meta.Encryption)
+}
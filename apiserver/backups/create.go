@@ -12,7 +12,10 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
-var waitUntilReady = replicaset.WaitUntilReady
+var (
+	waitUntilReady = replicaset.WaitUntilReady
+	readOplogRange = backups.ReadOplogRange
+)
 
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
@@ -42,6 +45,14 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	if err != nil {
 		return p, errors.Trace(err)
 	}
+	if dbInfo.Oplog, err = readOplogRange(session); err != nil {
+		if args.Incremental {
+			return p, errors.Trace(err)
+		}
+		// The backup is still worth having, even though no
+		// incremental backup can build on it.
+		logger.Warningf("cannot record oplog position of backup: %v", err)
+	}
 	mSeries, err := a.backend.MachineSeries(a.machineID)
 	if err != nil {
 		return p, errors.Trace(err)
@@ -52,6 +63,12 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 		return p, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	meta.Storage = args.Storage
+	if args.Incremental {
+		if meta.Parent, err = chooseParent(backupsMethods, args.Parent, meta.Origin.Model); err != nil {
+			return p, errors.Trace(err)
+		}
+	}
 
 	err = backupsMethods.Create(meta, a.paths, dbInfo)
 	if err != nil {
@@ -60,3 +77,21 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 
 	return ResultFromMetadata(meta), nil
 }
+
+// chooseParent returns the ID of the backup on which an incremental
+// backup builds: the one requested or, failing that, the latest
+// backup of the model that records its oplog position.
+func chooseParent(backupsMethods backups.Backups, requested, model string) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+	metaList, err := backupsMethods.List()
+	if err != nil {
+		return "", errors.Annotate(err, "finding backup to build on")
+	}
+	parent := backups.LatestParent(metaList, model)
+	if parent == nil {
+		return "", errors.New("no backup to build on; create a full backup first")
+	}
+	return parent.ID(), nil
+}
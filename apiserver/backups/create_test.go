@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -42,6 +43,23 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateStorage(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.meta.Storage = "s3"
+	s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Storage: "s3",
+	}
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := backups.ResultFromMetadata(s.meta)
+	expected.Storage = "s3"
+
+	c.Check(result, gc.DeepEquals, expected)
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
@@ -53,3 +71,50 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) patchOplogRange(oplog statebackups.OplogRange) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.PatchValue(backups.ReadOplogRange,
+		func(*mgo.Session) (statebackups.OplogRange, error) { return oplog, nil },
+	)
+}
+
+func (s *backupsSuite) TestCreateRecordsOplog(c *gc.C) {
+	oplog := statebackups.OplogRange{
+		First: statebackups.NewOplogPosition(100, 1),
+		Last:  statebackups.NewOplogPosition(200, 1),
+	}
+	s.patchOplogRange(oplog)
+	fake := s.setBackups(c, s.meta, "")
+	_, err := s.api.Create(params.BackupsCreateArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.DBInfoArg.Oplog, gc.Equals, oplog)
+}
+
+func (s *backupsSuite) TestCreateIncrementalLatestParent(c *gc.C) {
+	s.patchOplogRange(statebackups.OplogRange{
+		First: statebackups.NewOplogPosition(100, 1),
+		Last:  statebackups.NewOplogPosition(200, 1),
+	})
+	s.meta.SetID("parent")
+	s.meta.OplogPosition = statebackups.NewOplogPosition(150, 1)
+	s.meta.Origin.Model = s.State.ModelUUID()
+	fake := s.setBackups(c, s.meta, "")
+	// Keep the fake from overwriting the new backup's metadata.
+	fake.Meta = nil
+	_, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.MetaArg.Parent, gc.Equals, "parent")
+}
+
+func (s *backupsSuite) TestCreateIncrementalNoParent(c *gc.C) {
+	s.patchOplogRange(statebackups.OplogRange{})
+	s.setBackups(c, nil, "")
+	_, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+
+	c.Check(err, gc.ErrorMatches, "no backup to build on; create a full backup first")
+}
@@ -0,0 +1,15 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RotateEncryptionKey provides the implementation of the API method.
+func (a *API) RotateEncryptionKey(args params.BackupsRotateKeyArgs) error {
+	return errors.Trace(a.backend.RotateBackupEncryptionKey(args.Key))
+}
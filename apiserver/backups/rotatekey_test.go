@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+)
+
+const backupKey = "kjMMiFOZp8E3MHlyIS8HEwwtwXnK9xnFovI5W0GyPtg="
+
+func (s *backupsSuite) TestRotateEncryptionKey(c *gc.C) {
+	err := s.api.RotateEncryptionKey(params.BackupsRotateKeyArgs{Key: backupKey})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secrets, err := s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets[controller.BackupEncryptionKey], gc.Equals, backupKey)
+}
+
+func (s *backupsSuite) TestRotateEncryptionKeyInvalid(c *gc.C) {
+	err := s.api.RotateEncryptionKey(params.BackupsRotateKeyArgs{Key: "c2hvcnQ="})
+	c.Assert(err, gc.ErrorMatches, "cannot rotate backup encryption key: .*expected 32 bytes, got 5")
+}
@@ -17,6 +17,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Backups", 1, newAPI)
+
+	// Facade version 2 adds RotateEncryptionKey.
+	common.RegisterStandardFacade("Backups", 2, newAPI)
 }
 
 type stateShim struct {
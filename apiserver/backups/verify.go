@@ -0,0 +1,22 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify provides the implementation of the API method.
+func (a *API) Verify(args params.BackupsVerifyArgs) (params.BackupsVerifyResult, error) {
+	backups, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	verification, err := backups.Verify(args.ID)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	return VerifyResultFromVerification(verification), nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/backups"
+	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestVerifyOkay(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	impl.Verification = &statebackups.Verification{
+		Metadata:         s.meta,
+		ChecksumVerified: true,
+		ContentsVerified: true,
+		Counts:           map[string]int{"models": 2},
+	}
+	result, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backups.ResultFromMetadata(s.meta)
+	c.Check(result, jc.DeepEquals, params.BackupsVerifyResult{
+		Metadata:         &meta,
+		ChecksumVerified: true,
+		ContentsVerified: true,
+		Counts:           map[string]int{"models": 2},
+	})
+	c.Check(impl.IDArg, gc.Equals, "some-id")
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -27,6 +27,8 @@ func ParamsActionExecutionResultsToStateActionResults(arg params.ActionExecution
 		status = state.ActionFailed
 	case params.ActionPending:
 		status = state.ActionPending
+	case params.ActionAborted:
+		status = state.ActionAborted
 	default:
 		return state.ActionResults{}, errors.Errorf("unrecognized action status '%s'", arg.Status)
 	}
@@ -131,6 +133,28 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records progress messages for the running Actions
+// with the given Tags.
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+
+		err = action.Log(arg.Value)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -153,6 +177,7 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		}
 	}
 
@@ -229,8 +254,17 @@ func ConvertActions(ar state.ActionReceiver, fn GetActionsFn) ([]params.ActionRe
 	return items, nil
 }
 
+// MakeActionResultWithLog converts a state.Action to a
+// params.ActionResult, as MakeActionResult does, and includes the
+// progress messages logged by the action.
+func MakeActionResultWithLog(actionReceiverTag names.Tag, action state.Action) params.ActionResult {
+	result := MakeActionResult(actionReceiverTag, action)
+	result.Log = convertActionMessages(action.Messages())
+	return result
+}
+
 // MakeActionResult does the actual type conversion from state.Action
-// to params.ActionResult.
+// to params.ActionResult. The action's progress messages are left out.
 func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.ActionResult {
 	output, message := action.Results()
 	return params.ActionResult{
@@ -239,6 +273,7 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
@@ -246,5 +281,17 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
+		Operation: action.Operation(),
 	}
 }
+
+func convertActionMessages(messages []state.ActionMessage) []params.ActionMessage {
+	var result []params.ActionMessage
+	for _, m := range messages {
+		result = append(result, params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		})
+	}
+	return result
+}
@@ -28,6 +28,8 @@ func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult,
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	// Agents never need the controller's secrets, which should not be
+	// in its config, but make sure none are handed out.
+	result.Config = params.ControllerConfig(config.WithoutSecrets())
 	return result, nil
 }
@@ -26,18 +26,23 @@ var _ = gc.Suite(&controllerConfigSuite{})
 
 type fakeControllerAccessor struct {
 	controllerConfigError error
+	extra                 map[string]interface{}
 }
 
 func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 	if f.controllerConfigError != nil {
 		return nil, f.controllerConfigError
 	}
-	return map[string]interface{}{
+	cfg := map[string]interface{}{
 		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
 		controller.CACertKey:         testing.CACert,
 		controller.APIPort:           4321,
 		controller.StatePort:         1234,
-	}, nil
+	}
+	for k, v := range f.extra {
+		cfg[k] = v
+	}
+	return cfg, nil
 }
 
 func (s *controllerConfigSuite) TearDownTest(c *gc.C) {
@@ -59,6 +64,23 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (*controllerConfigSuite) TestControllerConfigWithoutSecrets(c *gc.C) {
+	cc := common.NewControllerConfig(
+		&fakeControllerAccessor{
+			extra: map[string]interface{}{
+				controller.AuditLogSyslogClientKey: "private",
+			},
+		},
+	)
+	result, err := cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, attr := range controller.SecretAttributes {
+		_, ok := result.Config[attr]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", attr))
+	}
+	c.Check(result.Config, gc.HasLen, 4)
+}
+
 func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	cc := common.NewControllerConfig(
 		&fakeControllerAccessor{
@@ -4,8 +4,10 @@
 package common
 
 import (
+	"sort"
 	"time"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/metricsender"
@@ -33,6 +35,7 @@ type ModelManagerBackend interface {
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdmin(user names.UserTag) (bool, error)
 	NewModel(state.ModelArgs) (Model, ModelManagerBackend, error)
+	CloneModel(state.CloneModelArgs) (Model, ModelManagerBackend, error)
 
 	ComposeNewModelConfig(modelAttr map[string]interface{}, regionSpec *environs.RegionSpec) (map[string]interface{}, error)
 	ControllerModel() (Model, error)
@@ -55,6 +58,9 @@ type ModelManagerBackend interface {
 	ControllerUUID() string
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
+	HasSecrets() (bool, error)
+	RemoteApplicationNames() ([]string, error)
+	ApplicationOfferNames() ([]string, error)
 	SetUserAccess(subject names.UserTag, target names.Tag, access permission.Access) (permission.UserAccess, error)
 	LastModelConnection(user names.UserTag) (time.Time, error)
 	DumpAll() (map[string]interface{}, error)
@@ -108,6 +114,43 @@ func (st modelManagerStateShim) NewModel(args state.ModelArgs) (Model, ModelMana
 	return modelShim{m}, modelManagerStateShim{otherState}, nil
 }
 
+// CloneModel implements ModelManagerBackend.
+func (st modelManagerStateShim) CloneModel(args state.CloneModelArgs) (Model, ModelManagerBackend, error) {
+	m, otherState, err := st.State.CloneModel(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return modelShim{m}, modelManagerStateShim{otherState}, nil
+}
+
+// RemoteApplicationNames implements ModelManagerBackend.
+func (st modelManagerStateShim) RemoteApplicationNames() ([]string, error) {
+	apps, err := st.State.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ApplicationOfferNames implements ModelManagerBackend.
+func (st modelManagerStateShim) ApplicationOfferNames() ([]string, error) {
+	offers, err := st.State.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(offers))
+	for i, offer := range offers {
+		names[i] = offer.OfferName()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // ForModel implements ModelManagerBackend.
 func (st modelManagerStateShim) ForModel(tag names.ModelTag) (ModelManagerBackend, error) {
 	otherState, err := st.State.ForModel(tag)
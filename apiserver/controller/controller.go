@@ -17,6 +17,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
@@ -33,6 +34,7 @@ var logger = loggo.GetLogger("juju.apiserver.controller")
 
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPIV4)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -48,6 +50,7 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	MigrationStatus(params.MigrationStatusArgs) (params.MigrationStatusResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
@@ -93,6 +96,35 @@ func NewControllerAPI(
 	}, nil
 }
 
+// ControllerV4 defines the methods on the version 4 controller API
+// end point, which adds MigrationDryRun.
+type ControllerV4 interface {
+	Controller
+	MigrationDryRun(params.InitiateMigrationArgs) (params.MigrationDryRunResults, error)
+}
+
+// ControllerAPIV4 implements version 4 of the controller API end
+// point.
+type ControllerAPIV4 struct {
+	*ControllerAPI
+}
+
+var _ ControllerV4 = (*ControllerAPIV4)(nil)
+
+// NewControllerAPIV4 creates a new api server endpoint for managing
+// environments, version 4.
+func NewControllerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*ControllerAPIV4, error) {
+	api, err := NewControllerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIV4{api}, nil
+}
+
 func (s *ControllerAPI) checkHasAdmin() error {
 	isAdmin, err := s.authorizer.HasPermission(permission.SuperuserAccess, s.state.ControllerTag())
 	if err != nil {
@@ -390,36 +422,135 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.openMigrationSpec(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Close()
 
-	// Ensure the model exists.
-	if _, err := c.state.GetModel(modelTag); err != nil {
-		return "", errors.Annotate(err, "unable to read model")
+	// Check if the migration is likely to succeed.
+	if !(spec.ExternalControl && spec.SkipInitialPrechecks) {
+		if err := runMigrationPrechecks(hostedState, targetInfo); err != nil {
+			return "", errors.Trace(err)
+		}
 	}
 
-	hostedState, err := c.state.ForModel(modelTag)
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy:     c.apiUser,
+		TargetInfo:      targetInfo,
+		ExternalControl: spec.ExternalControl,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationStatus reports the progress of one or more model
+// migrations, identified by the ids returned by InitiateMigration.
+// The progress remains available after a migrated model has been
+// removed from the controller.
+func (c *ControllerAPI) MigrationStatus(args params.MigrationStatusArgs) (
+	params.MigrationStatusResults, error,
+) {
+	out := params.MigrationStatusResults{
+		Results: make([]params.MigrationStatusResult, len(args.MigrationIds)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, id := range args.MigrationIds {
+		result := &out.Results[i]
+		result.MigrationId = id
+		if err := c.migrationStatus(id, result); err != nil {
+			result.Error = common.ServerError(err)
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) migrationStatus(id string, result *params.MigrationStatusResult) error {
+	mig, err := c.state.Migration(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result.ModelTag = names.NewModelTag(mig.ModelUUID()).String()
+	result.Phase = phase.String()
+	result.PhaseChangedTime = mig.PhaseChangedTime()
+	result.StatusMessage = mig.StatusMessage()
+	return nil
+}
+
+// MigrationDryRun checks whether one or more models could be migrated
+// to other controllers, without migrating them. Every issue found by
+// the prechecks of both controllers, and by a trial export of each
+// model and its import into the target controller, is reported.
+func (c *ControllerAPIV4) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		issues, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Issues = issues
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) ([]params.MigrationPrecheckIssue, error) {
+	hostedState, targetInfo, err := c.openMigrationSpec(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Close()
+	return runMigrationDryRun(hostedState, targetInfo)
+}
+
+// openMigrationSpec returns the state of the model to be migrated by
+// the spec, and the details of the target controller.
+func (c *ControllerAPI) openMigrationSpec(spec params.MigrationSpec) (*state.State, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, empty, errors.Annotate(err, "unable to read model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -431,23 +562,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if !(spec.ExternalControl && spec.SkipInitialPrechecks) {
-		if err := runMigrationPrechecks(hostedState, targetInfo); err != nil {
-			return "", errors.Trace(err)
-		}
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy:     c.apiUser,
-		TargetInfo:      targetInfo,
-		ExternalControl: spec.ExternalControl,
-	})
+	hostedState, err := c.state.ForModel(modelTag)
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -508,6 +627,69 @@ var runMigrationPrechecks = func(st *state.State, targetInfo coremigration.Targe
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+var runMigrationDryRun = func(st *state.State, targetInfo coremigration.TargetInfo) ([]params.MigrationPrecheckIssue, error) {
+	var issues []params.MigrationPrecheckIssue
+	addIssues := func(stage string, messages ...string) {
+		for _, message := range messages {
+			issues = append(issues, params.MigrationPrecheckIssue{
+				Stage:   stage,
+				Message: message,
+			})
+		}
+	}
+
+	// Check model and source controller.
+	addIssues("source", migration.SourcePrecheckIssues(migration.PrecheckShim(st)).Messages()...)
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		addIssues("export", err.Error())
+	}
+
+	// Check target controller, which also tries importing the model
+	// if it was exported. An unreachable target is reported alongside
+	// the issues already found.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		addIssues("target", errors.Annotate(err, "connect to target controller").Error())
+		return issues, nil
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetIssues, err := targetDryRun(conn, modelInfo, bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(issues, targetIssues...), nil
+}
+
+// targetDryRun asks the target controller for every issue that would
+// prevent it accepting the model. Targets too old to try importing
+// the model only run their prechecks, which stop at the first issue.
+func targetDryRun(caller base.APICaller, modelInfo coremigration.ModelInfo, bytes []byte) ([]params.MigrationPrecheckIssue, error) {
+	client := migrationtarget.NewClient(caller)
+	if caller.BestFacadeVersion("MigrationTarget") >= 2 {
+		issues, err := client.DryRun(modelInfo, bytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "target dry run failed")
+		}
+		return issues, nil
+	}
+	var issues []params.MigrationPrecheckIssue
+	if err := client.Prechecks(modelInfo); err != nil {
+		issues = append(issues, params.MigrationPrecheckIssue{
+			Stage:   "target",
+			Message: err.Error(),
+		})
+	}
+	return append(issues, params.MigrationPrecheckIssue{
+		Stage:   "import",
+		Message: "trial import not supported by target",
+	}), nil
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -10,12 +10,14 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/controller"
@@ -23,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -36,7 +39,7 @@ import (
 type controllerSuite struct {
 	statetesting.StateSuite
 
-	controller *controller.ControllerAPI
+	controller *controller.ControllerAPIV4
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 }
@@ -58,7 +61,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPI(s.State, s.resources, s.authorizer)
+	controller, err := controller.NewControllerAPIV4(s.State, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.controller = controller
 
@@ -547,6 +550,184 @@ func (s *controllerSuite) TestInitiateMigrationSkipPrechecks(c *gc.C) {
 	c.Check(out.Results[0].Error, gc.IsNil)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	issues := []params.MigrationPrecheckIssue{{
+		Stage:   "source",
+		Message: "unit foo/0 not idle (failed)",
+	}, {
+		Stage:   "target",
+		Message: "upgrade in progress",
+	}}
+	controller.SetDryRunResult(s, issues, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: st.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, st.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Issues, jc.DeepEquals, issues)
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetDryRunResult(s, nil, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestMigrationDryRunUnreachableTarget(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	issues, err := controller.RunMigrationDryRun(st, migration.TargetInfo{
+		ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+		Addrs:         []string{"127.0.0.1:0"},
+		CACert:        "cert",
+		AuthTag:       names.NewUserTag("admin"),
+		Password:      "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.Not(gc.HasLen), 0)
+	last := issues[len(issues)-1]
+	c.Check(last.Stage, gc.Equals, "target")
+	c.Check(last.Message, gc.Matches, "connect to target controller: .*")
+}
+
+// versionedCaller is an APICaller reporting the MigrationTarget facade
+// version given.
+type versionedCaller struct {
+	apitesting.APICallerFunc
+	version int
+}
+
+func (c versionedCaller) BestFacadeVersion(facade string) int {
+	return c.version
+}
+
+func (s *controllerSuite) TestTargetDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			stub.AddCall(objType+"."+request, version)
+			*(response.(*params.MigrationPrecheckIssues)) = params.MigrationPrecheckIssues{
+				Issues: []params.MigrationPrecheckIssue{{Stage: "import", Message: "boom"}},
+			}
+			return nil
+		},
+		version: 2,
+	}
+	issues, err := controller.TargetDryRun(caller, migration.ModelInfo{Owner: s.Owner}, []byte("model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, []params.MigrationPrecheckIssue{{Stage: "import", Message: "boom"}})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"MigrationTarget.DryRun", []interface{}{2}}})
+}
+
+func (s *controllerSuite) TestTargetDryRunFallsBackToPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	caller := versionedCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			stub.AddCall(objType+"."+request, version)
+			return errors.New("upgrade in progress")
+		},
+		version: 1,
+	}
+	issues, err := controller.TargetDryRun(caller, migration.ModelInfo{Owner: s.Owner}, []byte("model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, []params.MigrationPrecheckIssue{{
+		Stage:   "target",
+		Message: "upgrade in progress",
+	}, {
+		Stage:   "import",
+		Message: "trial import not supported by target",
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"MigrationTarget.Prechecks", []interface{}{1}}})
+}
+
+func (s *controllerSuite) TestMigrationStatus(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	initiated, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	id := initiated.Results[0].MigrationId
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("exporting model"), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationStatus(params.MigrationStatusArgs{
+		MigrationIds: []string{id, "deadbeef-0bad-400d-8000-4b1d0d06f00d:0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	result := out.Results[0]
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.MigrationId, gc.Equals, id)
+	c.Check(result.ModelTag, gc.Equals, st.ModelTag().String())
+	c.Check(result.Phase, gc.Equals, "QUIESCE")
+	c.Check(result.PhaseChangedTime.Equal(mig.PhaseChangedTime()), jc.IsTrue)
+	c.Check(result.StatusMessage, gc.Equals, "exporting model")
+
+	c.Check(out.Results[1].MigrationId, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d:0")
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "migration not found")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -4,10 +4,16 @@
 package controller
 
 import (
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
 
+var (
+	RunMigrationDryRun = runMigrationDryRun
+	TargetDryRun       = targetDryRun
+)
+
 type patcher interface {
 	PatchValue(destination, source interface{})
 }
@@ -17,3 +23,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, issues []params.MigrationPrecheckIssue, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, migration.TargetInfo) ([]params.MigrationPrecheckIssue, error) {
+		return issues, err
+	})
+}
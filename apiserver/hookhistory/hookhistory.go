@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookhistory defines an API end point for model users to see
+// which hooks units have run, and how long they took.
+package hookhistory
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("HookHistory", 1, newAPIFromState)
+}
+
+// Backend defines the state functionality required by the HookHistory
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	HookHistory(unitName string) ([]state.HookRecord, error)
+}
+
+// API implements the HookHistory facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func newAPIFromState(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, authorizer)
+}
+
+// NewAPI returns a new HookHistory API facade. Any user who can read
+// the model may see its units' hook histories.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	canRead, err := authorizer.HasPermission(permission.ReadAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canRead {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// HookHistory returns the most recent hook executions of each of the
+// given units, oldest first.
+func (api *API) HookHistory(args params.Entities) (params.HookHistoryResults, error) {
+	results := params.HookHistoryResults{
+		Results: make([]params.HookHistoryResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		history, err := api.hookHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].History = history
+	}
+	return results, nil
+}
+
+func (api *API) hookHistory(tag string) ([]params.HookRecord, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	records, err := api.backend.HookHistory(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history := make([]params.HookRecord, len(records))
+	for i, record := range records {
+		history[i] = params.HookRecord{
+			Hook:       record.Hook,
+			Relation:   record.Relation,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started,
+			Duration:   record.Duration,
+			Error:      record.Error,
+		}
+	}
+	return history, nil
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) HookHistory(unitName string) ([]state.HookRecord, error) {
+	unit, err := s.State.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.HookHistory()
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookhistory_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookHistorySuite struct {
+	testing.IsolationSuite
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+func (s *hookHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *hookHistorySuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *hookHistorySuite) TestNewAPIRequiresModelRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *hookHistorySuite) TestHookHistory(c *gc.C) {
+	started := time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC)
+	s.backend.history = []state.HookRecord{{
+		Hook:       "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Duration:   3 * time.Second,
+		Error:      "exit status 1",
+	}}
+	api, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.HookHistory(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0], jc.DeepEquals, params.HookHistoryResult{
+		History: []params.HookRecord{{
+			Hook:       "db-relation-changed",
+			Relation:   "db:2",
+			RemoteUnit: "mysql/0",
+			Started:    started,
+			Duration:   3 * time.Second,
+			Error:      "exit status 1",
+		}},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `"application-wordpress" is not a valid unit tag`)
+	s.backend.CheckCall(c, 0, "HookHistory", "wordpress/0")
+}
+
+func (s *hookHistorySuite) TestHookHistoryError(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf(`unit "wordpress/0"`))
+	api, err := hookhistory.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.HookHistory(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+type fakeBackend struct {
+	testing.Stub
+	history []state.HookRecord
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) HookHistory(unitName string) ([]state.HookRecord, error) {
+	b.AddCall("HookHistory", unitName)
+	return b.history, b.NextErr()
+}
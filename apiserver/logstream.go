@@ -14,12 +14,25 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/state"
 )
 
+const (
+	// logStreamFilterRefresh is how long a model's log forwarding
+	// filter is used before it is read again.
+	logStreamFilterRefresh = time.Minute
+
+	// logStreamSkippedDelay is how long the log stream waits, after
+	// dropping a record, for a record to send before telling the
+	// client which records were dropped.
+	logStreamSkippedDelay = 10 * time.Second
+)
+
 type logStreamSource interface {
 	getStart(sink string, allModels bool) (time.Time, error)
 	newTailer(*state.LogTailerParams) (state.LogTailer, error)
+	modelFilter(modelUUID string) (logfwd.Filter, error)
 }
 
 // logStreamEndpointHandler takes requests to stream logs from the DB.
@@ -34,7 +47,7 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return &logStreamState{st}, nil
+		return &logStreamState{st, ctxt.srv.statePool}, nil
 	}
 	return &logStreamEndpointHandler{
 		stopCh:    ctxt.stop(),
@@ -95,6 +108,8 @@ func (eph *logStreamEndpointHandler) newLogStreamRequestHandler(req *http.Reques
 		req:           req,
 		tailer:        tailer,
 		sendModelUUID: cfg.AllModels,
+		filters:       newLogStreamFilters(source, clock),
+		clock:         clock,
 	}
 	return reqHandler, nil
 }
@@ -130,6 +145,7 @@ func (eph logStreamEndpointHandler) newTailer(source logStreamSource, cfg params
 // logStreamState is an implementation of logStreamSource.
 type logStreamState struct {
 	state.LogTailerState
+	pool *state.StatePool
 }
 
 func (st logStreamState) getStart(sink string, allModels bool) (time.Time, error) {
@@ -170,16 +186,112 @@ func (st logStreamState) newTailer(args *state.LogTailerParams) (state.LogTailer
 	return tailer, nil
 }
 
+func (st logStreamState) modelFilter(modelUUID string) (logfwd.Filter, error) {
+	modelSt, err := st.pool.Get(modelUUID)
+	if err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
+	}
+	defer st.pool.Release(modelUUID)
+	cfg, err := modelSt.ModelConfig()
+	if err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
+	}
+	return cfg.LogFwdFilter(), nil
+}
+
+// logStreamFilters selects the log records to stream according to
+// the log forwarding filter of each record's own model.
+type logStreamFilters struct {
+	source   logStreamSource
+	clock    clock.Clock
+	matchers map[string]logStreamMatcher
+}
+
+// logStreamMatcher holds a model's compiled filter; a nil matcher
+// selects every record.
+type logStreamMatcher struct {
+	matcher *logfwd.Matcher
+	expires time.Time
+}
+
+func newLogStreamFilters(source logStreamSource, clock clock.Clock) *logStreamFilters {
+	return &logStreamFilters{
+		source:   source,
+		clock:    clock,
+		matchers: make(map[string]logStreamMatcher),
+	}
+}
+
+// wanted reports whether the record should be streamed. If the
+// record's filter cannot be read, the record is streamed rather than
+// lost.
+func (f *logStreamFilters) wanted(rec *state.LogRecord) bool {
+	matcher, err := f.matcher(rec.ModelUUID)
+	if err != nil {
+		logger.Warningf("cannot read log forwarding filter for model %q: %v", rec.ModelUUID, err)
+		return true
+	}
+	if matcher == nil {
+		return true
+	}
+	var entity string
+	if rec.Entity != nil {
+		entity = rec.Entity.String()
+	}
+	return matcher.MatchEntry(rec.Level, entity, rec.Module)
+}
+
+func (f *logStreamFilters) matcher(modelUUID string) (*logfwd.Matcher, error) {
+	now := f.clock.Now()
+	if cached, ok := f.matchers[modelUUID]; ok && now.Before(cached.expires) {
+		return cached.matcher, nil
+	}
+	filter, err := f.source.modelFilter(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var matcher *logfwd.Matcher
+	if !filter.IsZero() {
+		if matcher, err = filter.Compile(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	f.matchers[modelUUID] = logStreamMatcher{
+		matcher: matcher,
+		expires: now.Add(logStreamFilterRefresh),
+	}
+	return matcher, nil
+}
+
 type logStreamRequestHandler struct {
 	req           *http.Request
 	tailer        state.LogTailer
 	sendModelUUID bool
 
-	stream *apiLogStream
+	// filters, if set, selects the records to stream. The clock is
+	// used to decide when to report the records dropped.
+	filters *logStreamFilters
+	clock   clock.Clock
+}
+
+// logRecordSender sends log records to the client.
+type logRecordSender interface {
+	sendRecords(rec []*state.LogRecord, sendModelUUID bool) error
+	sendSkipped(rec *state.LogRecord, sendModelUUID bool) error
 }
 
 func (rh *logStreamRequestHandler) serveWebsocket(conn *websocket.Conn, stream *apiLogStream, stop <-chan struct{}) {
 	logger.Infof("log stream request handler starting")
+	rh.serve(stream, stop)
+}
+
+func (rh *logStreamRequestHandler) serve(stream logRecordSender, stop <-chan struct{}) {
+	// skipped is the last record dropped by the filters since a
+	// record was sent. If no record is sent for a while, the client
+	// is told about it, so that it can record the dropped records
+	// as dealt with instead of reading them again when it restarts.
+	var skipped *state.LogRecord
+	var reportSkipped <-chan time.Time
 
 	// TODO(wallyworld) - we currently only send one record at a time, but the API allows for
 	// sending batches of records, so we need to batch up the output from tailer.Logs().
@@ -187,22 +299,39 @@ func (rh *logStreamRequestHandler) serveWebsocket(conn *websocket.Conn, stream *
 		select {
 		case <-stop:
 			return
+		case <-reportSkipped:
+			reportSkipped = nil
+			logSendError(stream.sendSkipped(skipped, rh.sendModelUUID))
+			skipped = nil
 		case rec, ok := <-rh.tailer.Logs():
 			if !ok {
 				logger.Errorf("tailer stopped: %v", rh.tailer.Err())
 				return
 			}
-			if err := stream.sendRecords([]*state.LogRecord{rec}, rh.sendModelUUID); err != nil {
-				if isBrokenPipe(err) {
-					logger.Tracef("logstream handler stopped (client disconnected)")
-				} else {
-					logger.Errorf("logstream handler error: %v", err)
+			if rh.filters != nil && !rh.filters.wanted(rec) {
+				if skipped == nil {
+					reportSkipped = rh.clock.After(logStreamSkippedDelay)
 				}
+				skipped = rec
+				continue
 			}
+			skipped, reportSkipped = nil, nil
+			logSendError(stream.sendRecords([]*state.LogRecord{rec}, rh.sendModelUUID))
 		}
 	}
 }
 
+func logSendError(err error) {
+	if err == nil {
+		return
+	}
+	if isBrokenPipe(err) {
+		logger.Tracef("logstream handler stopped (client disconnected)")
+	} else {
+		logger.Errorf("logstream handler error: %v", err)
+	}
+}
+
 func initStream(conn *websocket.Conn, initial error) (*apiLogStream, error) {
 	stream := &apiLogStream{
 		conn:  conn,
@@ -246,6 +375,20 @@ func (als *apiLogStream) sendRecords(rec []*state.LogRecord, sendModelUUID bool)
 	return nil
 }
 
+func (als *apiLogStream) sendSkipped(rec *state.LogRecord, sendModelUUID bool) error {
+	skipped := params.LogStreamRecord{
+		ID:        rec.ID,
+		Timestamp: rec.Time,
+	}
+	if sendModelUUID {
+		skipped.ModelUUID = rec.ModelUUID
+	}
+	return errors.Trace(als.send(params.LogStreamRecords{
+		Records: []params.LogStreamRecord{},
+		Skipped: &skipped,
+	}))
+}
+
 func (als *apiLogStream) send(rec params.LogStreamRecords) error {
 	return als.codec.Send(als.conn, rec)
 }
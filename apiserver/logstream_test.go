@@ -4,6 +4,7 @@
 package apiserver
 
 import (
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -240,6 +242,119 @@ func (s *LogStreamIntSuite) TestFullRequest(c *gc.C) {
 	}
 }
 
+func (s *LogStreamIntSuite) TestServeFiltered(c *gc.C) {
+	modelUUID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	newRecord := func(id int64, level loggo.Level) state.LogRecord {
+		return state.LogRecord{
+			ID:        id,
+			ModelUUID: modelUUID,
+			Version:   version.Current,
+			Time:      time.Date(2015, 6, 19, 15, 34, int(id), 0, time.UTC),
+			Entity:    names.NewMachineTag("99"),
+			Module:    "some.where",
+			Location:  "code.go:42",
+			Level:     level,
+			Message:   "stuff happened",
+		}
+	}
+	logs := []state.LogRecord{
+		newRecord(10, loggo.INFO),
+		newRecord(11, loggo.ERROR),
+		newRecord(12, loggo.INFO),
+	}
+	tailer := &stubLogTailer{stub: &testing.Stub{}}
+	tailer.ReturnLogs = tailer.newChannel(logs)
+	source := &stubSource{
+		stub:              &testing.Stub{},
+		ReturnModelFilter: logfwd.Filter{MinLevel: loggo.ERROR},
+	}
+	clock := testing.NewClock(time.Time{})
+	handler := &logStreamRequestHandler{
+		tailer:        tailer,
+		sendModelUUID: true,
+		filters:       newLogStreamFilters(source, clock),
+		clock:         clock,
+	}
+	sender := &stubRecordSender{sent: make(chan string, 10)}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.serve(sender, stop)
+	}()
+	defer waitFor(c, done)
+	defer close(stop)
+
+	// The ERROR record is sent; the INFO record that follows it is
+	// reported as skipped after a delay. (The first INFO record also
+	// started a delay, but was followed by a record to send.)
+	c.Check(nextSent(c, sender), gc.Equals, "record 11")
+	c.Assert(clock.WaitAdvance(logStreamSkippedDelay, coretesting.LongWait, 2), jc.ErrorIsNil)
+	c.Check(nextSent(c, sender), gc.Equals, "skipped 12")
+	select {
+	case sent := <-sender.sent:
+		c.Errorf("unexpected %s", sent)
+	case <-time.After(coretesting.ShortWait):
+	}
+	// The model's filter was only read once.
+	source.stub.CheckCalls(c, []testing.StubCall{
+		{"modelFilter", []interface{}{modelUUID}},
+	})
+}
+
+func (s *LogStreamIntSuite) TestFiltersRefreshed(c *gc.C) {
+	source := &stubSource{
+		stub:              &testing.Stub{},
+		ReturnModelFilter: logfwd.Filter{MinLevel: loggo.ERROR},
+	}
+	clock := testing.NewClock(time.Time{})
+	filters := newLogStreamFilters(source, clock)
+	rec := &state.LogRecord{
+		ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Entity:    names.NewMachineTag("99"),
+		Module:    "some.where",
+		Level:     loggo.INFO,
+	}
+	c.Check(filters.wanted(rec), jc.IsFalse)
+
+	source.ReturnModelFilter = logfwd.Filter{}
+	c.Check(filters.wanted(rec), jc.IsFalse)
+	clock.Advance(logStreamFilterRefresh)
+	c.Check(filters.wanted(rec), jc.IsTrue)
+	source.stub.CheckCallNames(c, "modelFilter", "modelFilter")
+
+	// A record whose filter cannot be read is streamed.
+	clock.Advance(logStreamFilterRefresh)
+	source.stub.SetErrors(errors.New("boom"))
+	c.Check(filters.wanted(rec), jc.IsTrue)
+}
+
+func nextSent(c *gc.C, sender *stubRecordSender) string {
+	select {
+	case sent := <-sender.sent:
+		return sent
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record to be sent")
+	}
+	panic("unreachable")
+}
+
+type stubRecordSender struct {
+	sent chan string
+}
+
+func (s *stubRecordSender) sendRecords(recs []*state.LogRecord, sendModelUUID bool) error {
+	for _, rec := range recs {
+		s.sent <- fmt.Sprintf("record %d", rec.ID)
+	}
+	return nil
+}
+
+func (s *stubRecordSender) sendSkipped(rec *state.LogRecord, sendModelUUID bool) error {
+	s.sent <- fmt.Sprintf("skipped %d", rec.ID)
+	return nil
+}
+
 func (s *LogStreamIntSuite) newReq(c *gc.C, cfg params.LogStreamConfig) *http.Request {
 	attrs, err := query.Values(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -254,8 +369,9 @@ func (s *LogStreamIntSuite) newReq(c *gc.C, cfg params.LogStreamConfig) *http.Re
 type stubSource struct {
 	stub *testing.Stub
 
-	ReturnGetStart  int64
-	ReturnNewTailer state.LogTailer
+	ReturnGetStart    int64
+	ReturnNewTailer   state.LogTailer
+	ReturnModelFilter logfwd.Filter
 }
 
 func (s *stubSource) newSource(req *http.Request) (logStreamSource, error) {
@@ -285,6 +401,14 @@ func (s *stubSource) newTailer(args *state.LogTailerParams) (state.LogTailer, er
 	return s.ReturnNewTailer, nil
 }
 
+func (s *stubSource) modelFilter(modelUUID string) (logfwd.Filter, error) {
+	s.stub.AddCall("modelFilter", modelUUID)
+	if err := s.stub.NextErr(); err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
+	}
+	return s.ReturnModelFilter, nil
+}
+
 type stubLogTailer struct {
 	state.LogTailer
 	stub *testing.Stub
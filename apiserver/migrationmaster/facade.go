@@ -8,14 +8,11 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
-	"github.com/juju/utils/set"
-	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state/watcher"
@@ -174,21 +171,11 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
-	var serialized params.SerializedModel
-
 	model, err := api.backend.Export()
 	if err != nil {
-		return serialized, err
+		return params.SerializedModel{}, err
 	}
-
-	bytes, err := description.Serialize(model)
-	if err != nil {
-		return serialized, err
-	}
-	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
-	serialized.Tools = getUsedTools(model)
-	return serialized, nil
+	return common.SerializeModel(model)
 }
 
 // Reap removes all documents for the model associated with the API
@@ -267,45 +254,3 @@ func (api *API) MinionReports() (params.MinionReports, error) {
 
 	return out, nil
 }
-
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
-func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
-		out = append(out, params.SerializedModelTools{
-			Version: v.String(),
-			URI:     common.ToolsURL("", v),
-		})
-	}
-	return out
-}
-
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
@@ -18,6 +18,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MigrationTarget", 1, NewAPI)
+	common.RegisterStandardFacade("MigrationTarget", 2, NewAPIV2)
 }
 
 // API implements the API required for the model migration
@@ -44,6 +45,25 @@ func NewAPI(
 	}, nil
 }
 
+// APIV2 implements version 2 of the MigrationTarget API, which adds
+// DryRun.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 returns a new APIV2.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
 func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 	if !authorizer.AuthClient() {
 		return errors.Trace(common.ErrPerm)
@@ -76,6 +96,39 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	)
 }
 
+// DryRun reports every issue that would prevent the target controller
+// accepting the model in a migration. If the target prechecks pass and
+// a serialized model is given, it is imported and then removed again,
+// to find any problems with the import itself.
+func (api *APIV2) DryRun(args params.MigrationTargetDryRunArgs) (params.MigrationPrecheckIssues, error) {
+	var result params.MigrationPrecheckIssues
+	ownerTag, err := names.ParseUserTag(args.Model.OwnerTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	issues := migration.TargetPrecheckIssues(
+		migration.PrecheckShim(api.state),
+		coremigration.ModelInfo{
+			UUID:         args.Model.UUID,
+			Name:         args.Model.Name,
+			Owner:        ownerTag,
+			AgentVersion: args.Model.AgentVersion,
+		},
+	)
+	stage := "target"
+	if len(issues) == 0 && len(args.Bytes) > 0 {
+		issues = migration.ImportPrecheckIssues(api.state, args.Bytes)
+		stage = "import"
+	}
+	for _, message := range issues.Messages() {
+		result.Issues = append(result.Issues, params.MigrationPrecheckIssue{
+			Stage:   stage,
+			Message: message,
+		})
+	}
+	return result, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -4,6 +4,8 @@
 package migrationtarget_test
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -108,6 +110,72 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestDryRun(c *gc.C) {
+	api := s.mustNewAPIV2(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.DryRun(params.MigrationTargetDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         uuid,
+			Name:         "some-model",
+			OwnerTag:     s.Owner.String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 0)
+
+	// The trial import has been removed.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestDryRunTargetIssues(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPIV2(c)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.DryRun(params.MigrationTargetDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         uuid,
+			Name:         model.Name(),
+			OwnerTag:     model.Owner().String(),
+			AgentVersion: modelVersion,
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, jc.DeepEquals, []params.MigrationPrecheckIssue{{
+		Stage: "target",
+		Message: fmt.Sprintf("model has higher version than target controller (%s > %s)",
+			modelVersion, controllerVersion),
+	}, {
+		Stage:   "target",
+		Message: fmt.Sprintf("model named %q already exists", model.Name()),
+	}})
+}
+
+func (s *Suite) TestDryRunImportIssues(c *gc.C) {
+	api := s.mustNewAPIV2(c)
+	result, err := api.DryRun(params.MigrationTargetDryRunArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         utils.MustNewUUID().String(),
+			Name:         "some-model",
+			OwnerTag:     s.Owner.String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 1)
+	c.Assert(result.Issues[0].Stage, gc.Equals, "import")
+	c.Assert(result.Issues[0].Message, gc.Matches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -201,6 +269,12 @@ func (s *Suite) mustNewAPI(c *gc.C) *migrationtarget.API {
 	return api
 }
 
+func (s *Suite) mustNewAPIV2(c *gc.C) *migrationtarget.APIV2 {
+	api, err := migrationtarget.NewAPIV2(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
 func (s *Suite) makeExportedModel(c *gc.C) (string, []byte) {
 	model, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -320,6 +320,10 @@ type mockState struct {
 	cfgDefaults     config.ModelDefaultAttributes
 	blockMsg        string
 	block           state.BlockType
+
+	hasSecrets bool
+	remoteApps []string
+	offers     []string
 }
 
 type fakeModelDescription struct {
@@ -328,10 +332,33 @@ type fakeModelDescription struct {
 	UUID string `yaml:"model-uuid"`
 }
 
+func (*fakeModelDescription) Applications() []description.Application {
+	return nil
+}
+
+func (*fakeModelDescription) Machines() []description.Machine {
+	return nil
+}
+
 func (st *mockState) Export() (description.Model, error) {
 	return &fakeModelDescription{UUID: st.modelUUID}, nil
 }
 
+func (st *mockState) HasSecrets() (bool, error) {
+	st.MethodCall(st, "HasSecrets")
+	return st.hasSecrets, nil
+}
+
+func (st *mockState) RemoteApplicationNames() ([]string, error) {
+	st.MethodCall(st, "RemoteApplicationNames")
+	return st.remoteApps, nil
+}
+
+func (st *mockState) ApplicationOfferNames() ([]string, error) {
+	st.MethodCall(st, "ApplicationOfferNames")
+	return st.offers, nil
+}
+
 func (st *mockState) ModelUUID() string {
 	st.MethodCall(st, "ModelUUID")
 	return st.modelUUID
@@ -374,6 +401,12 @@ func (st *mockState) NewModel(args state.ModelArgs) (common.Model, common.ModelM
 	return st.model, st, st.NextErr()
 }
 
+func (st *mockState) CloneModel(args state.CloneModelArgs) (common.Model, common.ModelManagerBackend, error) {
+	st.MethodCall(st, "CloneModel", args)
+	st.model.tag = names.NewModelTag(args.Model.UUID)
+	return st.model, st, st.NextErr()
+}
+
 func (st *mockState) ControllerModel() (common.Model, error) {
 	st.MethodCall(st, "ControllerModel")
 	return st.controllerModel, st.NextErr()
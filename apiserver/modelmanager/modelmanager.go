@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/migration"
@@ -43,8 +44,10 @@ func init() {
 // ModelManager defines the methods on the modelmanager API endpoint.
 type ModelManager interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	CloneModel(args params.CloneModelArgs) (params.ModelInfo, error)
 	DumpModels(args params.Entities) params.MapResults
 	DumpModelsDB(args params.Entities) params.MapResults
+	ExportModels(args params.Entities) params.SerializedModelResults
 	ListModels(user params.Entity) (params.UserModelList, error)
 	DestroyModels(args params.Entities) (params.ErrorResults, error)
 }
@@ -326,6 +329,77 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	return m.getModelInfo(model.ModelTag())
 }
 
+// CloneModel creates a new model in the controller holding a copy of
+// the source model's config, constraints, applications and their
+// charms, settings, constraints and endpoint bindings, and the
+// relations between them. The source model's machines and units are
+// not copied, but the applications can be given as many fresh units
+// as they have in the source model. The user must be able to add
+// models, and be an admin of the source model or the controller.
+func (m *ModelManagerAPI) CloneModel(args params.CloneModelArgs) (params.ModelInfo, error) {
+	result := params.ModelInfo{}
+	canAddModel, err := m.authorizer.HasPermission(permission.AddModelAccess, m.state.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canAddModel {
+		return result, common.ErrPerm
+	}
+
+	sourceTag, err := names.ParseModelTag(args.SourceModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, sourceTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !m.isAdmin {
+		return result, common.ErrPerm
+	}
+	ownerTag, err := names.ParseUserTag(args.OwnerTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return result, errors.Annotate(err, "failed to generate model uuid")
+	}
+
+	st := m.state
+	if st.ModelTag() != sourceTag {
+		st, err = m.state.ForModel(sourceTag)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return result, errors.Trace(common.ErrBadId)
+			}
+			return result, errors.Trace(err)
+		}
+		defer st.Close()
+	}
+
+	// Secrets and cross-model relations cannot be carried by the
+	// exported model the clone is made from.
+	if err := migration.ExportPrecheck(st); err != nil {
+		return result, errors.Annotate(err, "failed to clone model")
+	}
+
+	model, newSt, err := st.CloneModel(state.CloneModelArgs{
+		Model: description.CloneArgs{
+			UUID:  uuid.String(),
+			Name:  args.Name,
+			Owner: ownerTag,
+		},
+		AddUnits: args.AddUnits,
+	})
+	if err != nil {
+		return result, errors.Annotate(err, "failed to clone model")
+	}
+	defer newSt.Close()
+
+	return m.getModelInfo(model.ModelTag())
+}
+
 func (m *ModelManagerAPI) dumpModel(args params.Entity) (map[string]interface{}, error) {
 	modelTag, err := names.ParseModelTag(args.Tag)
 	if err != nil {
@@ -437,6 +511,63 @@ func (m *ModelManagerAPI) DumpModelsDB(args params.Entities) params.MapResults {
 	return results
 }
 
+func (m *ModelManagerAPI) exportModel(args params.Entity) (params.SerializedModel, error) {
+	modelTag, err := names.ParseModelTag(args.Tag)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+
+	isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if !isModelAdmin && !m.isAdmin {
+		return params.SerializedModel{}, common.ErrPerm
+	}
+
+	st := m.state
+	if st.ModelTag() != modelTag {
+		st, err = m.state.ForModel(modelTag)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return params.SerializedModel{}, errors.Trace(common.ErrBadId)
+			}
+			return params.SerializedModel{}, errors.Trace(err)
+		}
+		defer st.Close()
+	}
+
+	if err := migration.ExportPrecheck(st); err != nil {
+		return params.SerializedModel{}, errors.Annotate(err, "cannot export model")
+	}
+	model, err := st.Export()
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	return common.SerializeModel(model)
+}
+
+// ExportModels serializes the specified models, along with the charms
+// and tools they use, so they can be written to an archive and
+// imported into another controller later. Models holding secrets or
+// cross-model relations, which an archive cannot carry, are refused.
+// The user needs to either be a controller admin, or have admin
+// privileges on the model itself.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		serialized, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = serialized
+	}
+	return results
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -532,6 +533,165 @@ func (s *modelManagerSuite) TestDumpModelUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModels(c *gc.C) {
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{
+		Tag: "bad-tag",
+	}, {
+		Tag: "application-foo",
+	}, {
+		Tag: s.st.ModelTag().String(),
+	}}})
+
+	c.Assert(results.Results, gc.HasLen, 3)
+	bad, notApp, good := results.Results[0], results.Results[1], results.Results[2]
+	c.Check(bad.Error.Message, gc.Equals, `"bad-tag" is not a valid tag`)
+	c.Check(notApp.Error.Message, gc.Equals, `"application-foo" is not a valid model tag`)
+
+	c.Check(good.Error, gc.IsNil)
+	c.Check(string(good.Result.Bytes), gc.Equals, "model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d\n")
+	c.Check(good.Result.Charms, gc.HasLen, 0)
+	c.Check(good.Result.Tools, gc.HasLen, 0)
+}
+
+func (s *modelManagerSuite) TestExportModelsMissingModel(c *gc.C) {
+	s.st.SetErrors(errors.NotFoundf("boom"))
+	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000")
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: tag.String()}}})
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, `not found`)
+	c.Check(result.Error.Message, gc.Equals, `id not found`)
+}
+
+func (s *modelManagerSuite) exportModelFails(c *gc.C, message string) {
+	results := s.api.ExportModels(params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, "cannot export model: "+message)
+	c.Check(result.Result.Bytes, gc.HasLen, 0)
+}
+
+func (s *modelManagerSuite) TestExportModelsWithSecrets(c *gc.C) {
+	s.st.hasSecrets = true
+	s.exportModelFails(c, "model has secrets, which cannot be migrated")
+}
+
+func (s *modelManagerSuite) TestExportModelsWithRemoteApplications(c *gc.C) {
+	s.st.remoteApps = []string{"mysql"}
+	s.exportModelFails(c, `model has remote applications \(mysql\), which cannot be migrated`)
+}
+
+func (s *modelManagerSuite) TestExportModelsWithOffers(c *gc.C) {
+	s.st.offers = []string{"hosted-mysql"}
+	s.exportModelFails(c, `model has application offers \(hosted-mysql\), which cannot be migrated`)
+}
+
+func (s *modelManagerSuite) TestExportModelsUsers(c *gc.C) {
+	models := params.Entities{[]params.Entity{{Tag: s.st.ModelTag().String()}}}
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		results := s.api.ExportModels(models)
+		c.Assert(results.Results, gc.HasLen, 1)
+		result := results.Results[0]
+		c.Assert(result.Error, gc.NotNil)
+		c.Check(result.Error.Message, gc.Equals, `permission denied`)
+	}
+}
+
+func (s *modelManagerSuite) getCloneModelArgs(c *gc.C) state.CloneModelArgs {
+	for _, v := range s.st.Calls() {
+		if v.FuncName == "CloneModel" {
+			return v.Args[0].(state.CloneModelArgs)
+		}
+	}
+	c.Fatal("failed to find state.CloneModelArgs")
+	panic("unreachable")
+}
+
+func (s *modelManagerSuite) TestCloneModel(c *gc.C) {
+	_, err := s.api.CloneModel(params.CloneModelArgs{
+		SourceModelTag: s.st.ModelTag().String(),
+		Name:           "staging",
+		OwnerTag:       "user-bob",
+		AddUnits:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := s.getCloneModelArgs(c)
+	c.Check(args.Model.Name, gc.Equals, "staging")
+	c.Check(args.Model.Owner, gc.Equals, names.NewUserTag("bob"))
+	c.Check(args.AddUnits, jc.IsTrue)
+	c.Check(utils.IsValidUUIDString(args.Model.UUID), jc.IsTrue)
+	c.Check(args.Model.UUID, gc.Not(gc.Equals), s.st.ModelUUID())
+}
+
+func (s *modelManagerSuite) cloneModelFails(c *gc.C, message string) {
+	_, err := s.api.CloneModel(params.CloneModelArgs{
+		SourceModelTag: s.st.ModelTag().String(),
+		Name:           "staging",
+		OwnerTag:       "user-admin",
+	})
+	c.Assert(err, gc.ErrorMatches, "failed to clone model: "+message)
+	for _, call := range s.st.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "CloneModel")
+	}
+}
+
+func (s *modelManagerSuite) TestCloneModelWithSecrets(c *gc.C) {
+	s.st.hasSecrets = true
+	s.cloneModelFails(c, "model has secrets, which cannot be migrated")
+}
+
+func (s *modelManagerSuite) TestCloneModelWithRemoteApplications(c *gc.C) {
+	s.st.remoteApps = []string{"mysql"}
+	s.cloneModelFails(c, `model has remote applications \(mysql\), which cannot be migrated`)
+}
+
+func (s *modelManagerSuite) TestCloneModelWithOffers(c *gc.C) {
+	s.st.offers = []string{"hosted-mysql"}
+	s.cloneModelFails(c, `model has application offers \(hosted-mysql\), which cannot be migrated`)
+}
+
+func (s *modelManagerSuite) TestCloneModelBadSourceTag(c *gc.C) {
+	_, err := s.api.CloneModel(params.CloneModelArgs{
+		SourceModelTag: "application-foo",
+		Name:           "staging",
+		OwnerTag:       "user-admin",
+	})
+	c.Assert(err, gc.ErrorMatches, `"application-foo" is not a valid model tag`)
+}
+
+func (s *modelManagerSuite) TestCloneModelMissingModel(c *gc.C) {
+	s.st.SetErrors(errors.NotFoundf("boom"))
+	_, err := s.api.CloneModel(params.CloneModelArgs{
+		SourceModelTag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000").String(),
+		Name:           "staging",
+		OwnerTag:       "user-admin",
+	})
+	c.Assert(err, gc.ErrorMatches, `id not found`)
+}
+
+func (s *modelManagerSuite) TestCloneModelUsers(c *gc.C) {
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		_, err := s.api.CloneModel(params.CloneModelArgs{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+			OwnerTag:       user.String(),
+		})
+		c.Check(err, gc.ErrorMatches, `permission denied`)
+	}
+}
+
 func (s *modelManagerSuite) TestDumpModelsDB(c *gc.C) {
 	results := s.api.DumpModelsDB(params.Entities{[]params.Entity{{
 		Tag: "bad-tag",
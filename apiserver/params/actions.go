@@ -25,11 +25,23 @@ const (
 	// ActionRunning is the status of an Action that has been started but
 	// not completed yet.
 	ActionRunning string = "running"
+
+	// ActionAborting is the status of a running Action that has been
+	// asked to stop, but has not yet done so.
+	ActionAborting string = "aborting"
+
+	// ActionAborted is the status of an Action that was stopped while
+	// running.
+	ActionAborted string = "aborted"
 )
 
 // Actions is a slice of Action for bulk requests.
 type Actions struct {
 	Actions []Action `json:"actions,omitempty"`
+	// Operation, if set, is the id of an existing operation to
+	// which the actions are added, rather than a new one; so that
+	// actions enqueued in batches may be followed together.
+	Operation string `json:"operation,omitempty"`
 }
 
 // Action describes an Action that will be or has been queued up.
@@ -38,6 +50,10 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Timeout is how long the action may run for before it is
+	// stopped and marked failed. If zero when enqueueing, the
+	// timeout declared for the action by the charm is used, if any.
+	Timeout time.Duration `json:"timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -54,9 +70,69 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
+	Operation string                 `json:"operation,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// OperationIds holds the ids of operations.
+type OperationIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ListOperationsArgs holds the arguments for listing a page of the
+// operations in a model.
+type ListOperationsArgs struct {
+	// Offset is the number of operations, oldest first, to skip.
+	Offset int `json:"offset,omitempty"`
+	// Limit is the maximum number of operations to return. If it
+	// is zero, the server chooses a limit.
+	Limit int `json:"limit,omitempty"`
+}
+
+// OperationResults holds the results of getting operations.
+type OperationResults struct {
+	Results []OperationResult `json:"results,omitempty"`
+	// Truncated is set when listing operations if there are more
+	// operations after those returned.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// OperationResult describes an operation, which groups the actions
+// enqueued by one request, and how far they have got.
+type OperationResult struct {
+	Id       string    `json:"id"`
+	Summary  string    `json:"summary"`
+	Enqueued time.Time `json:"enqueued"`
+	// Status is the overall status of the operation's actions.
+	Status string `json:"status"`
+	// Counts holds the number of the operation's actions with
+	// each status.
+	Counts map[string]int `json:"counts"`
+	// Actions holds the operation's actions, when asked for.
+	Actions []ActionResult `json:"actions,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionMessage is a timestamped progress message logged by a running
+// action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ActionMessageParams holds the progress messages to log for a
+// number of running actions.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
+// EntityString holds an entity tag and a string value.
+type EntityString struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -138,3 +214,10 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionPruneArgs holds arguments for the pruning of completed
+// actions.
+type ActionPruneArgs struct {
+	MaxHistoryTime  time.Duration `json:"max-history-time"`
+	MaxHistoryCount int           `json:"max-history-count"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AuditLogFilter holds the arguments for a call to the Query method
+// of the AuditLog facade. Empty fields match all entries.
+type AuditLogFilter struct {
+	// ModelUUID restricts entries to those recorded on the
+	// specified model.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// OriginType restricts entries to those with the specified
+	// origin type.
+	OriginType string `json:"origin-type,omitempty"`
+
+	// OriginName restricts entries to those triggered by the
+	// specified origin, e.g. "user-admin".
+	OriginName string `json:"origin-name,omitempty"`
+
+	// Operation restricts entries to those whose operation contains
+	// the specified string.
+	Operation string `json:"operation,omitempty"`
+
+	// RemoteAddress restricts entries to those triggered from the
+	// specified address.
+	RemoteAddress string `json:"remote-address,omitempty"`
+
+	// After restricts entries to those recorded at or after the
+	// specified time.
+	After *time.Time `json:"after,omitempty"`
+
+	// Before restricts entries to those recorded before the
+	// specified time.
+	Before *time.Time `json:"before,omitempty"`
+
+	// Limit, if non-zero, restricts the results to the most recent
+	// Limit matching entries.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single recorded audit entry.
+type AuditLogEntry struct {
+	ID                string                 `json:"id"`
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogResults holds the results of a call to the Query method of
+// the AuditLog facade, oldest first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
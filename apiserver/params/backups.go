@@ -12,6 +12,41 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// Storage names the place in which the backup is kept. If empty,
+	// the backup is kept in the controller.
+	Storage string `json:"storage,omitempty"`
+
+	// Incremental requests a backup holding only the changes made
+	// since the backup named by Parent or, if Parent is empty, since
+	// the latest backup that can be built on.
+	Incremental bool   `json:"incremental,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+}
+
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string `json:"id"`
+}
+
+// BackupsRotateKeyArgs holds the args for the API
+// RotateEncryptionKey method.
+type BackupsRotateKeyArgs struct {
+	Key string `json:"key"`
+}
+
+// BackupsVerifyResult holds the result of checking a backup archive
+// without restoring it.
+type BackupsVerifyResult struct {
+	// Metadata is the metadata found in the archive, if any.
+	Metadata *BackupsMetadataResult `json:"metadata,omitempty"`
+
+	ChecksumVerified bool           `json:"checksum-verified"`
+	ContentsVerified bool           `json:"contents-verified"`
+	Counts           map[string]int `json:"counts,omitempty"`
+	OplogEntries     int            `json:"oplog-entries,omitempty"`
+	Missing          []string       `json:"missing,omitempty"`
+	Problems         []string       `json:"problems,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -67,6 +102,13 @@ type BackupsMetadataResult struct {
 	Hostname string         `json:"hostname"`
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
+	Storage  string         `json:"storage,omitempty"`
+
+	EncryptionScheme         string `json:"encryption-scheme,omitempty"`
+	EncryptionKeyFingerprint string `json:"encryption-key-fingerprint,omitempty"`
+
+	Parent        string `json:"parent,omitempty"`
+	OplogPosition int64  `json:"oplog-position,omitempty"`
 
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AddApplicationOffers holds the application endpoints to offer to
+// other models.
+type AddApplicationOffers struct {
+	Offers []AddApplicationOffer `json:"offers"`
+}
+
+// AddApplicationOffer holds an application's endpoints to offer to
+// other models, and the name to offer them by.
+type AddApplicationOffer struct {
+	OfferName              string   `json:"offer-name,omitempty"`
+	ApplicationName        string   `json:"application-name"`
+	ApplicationDescription string   `json:"application-description,omitempty"`
+	Endpoints              []string `json:"endpoints"`
+}
+
+// OfferFilters holds the filters used to find application offers.
+type OfferFilters struct {
+	Filters []OfferFilter `json:"filters"`
+}
+
+// OfferFilter matches the application offers with the given values;
+// empty values match any offer.
+type OfferFilter struct {
+	OwnerName       string `json:"owner-name,omitempty"`
+	ModelName       string `json:"model-name,omitempty"`
+	OfferName       string `json:"offer-name,omitempty"`
+	ApplicationName string `json:"application-name,omitempty"`
+}
+
+// ApplicationOffer describes an application offer.
+type ApplicationOffer struct {
+	OfferURL               string          `json:"offer-url"`
+	OfferName              string          `json:"offer-name"`
+	SourceModelTag         string          `json:"source-model-tag"`
+	ApplicationName        string          `json:"application-name"`
+	ApplicationDescription string          `json:"application-description,omitempty"`
+	Endpoints              []CharmRelation `json:"endpoints"`
+}
+
+// FindApplicationOffersResults holds the offers matching each of a
+// set of filters.
+type FindApplicationOffersResults struct {
+	Results []FindApplicationOffersResult `json:"results"`
+}
+
+// FindApplicationOffersResult holds the offers matching a filter, or
+// an error.
+type FindApplicationOffersResult struct {
+	Offers []ApplicationOffer `json:"offers,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ConsumeApplicationArgs holds the offers to consume.
+type ConsumeApplicationArgs struct {
+	Args []ConsumeApplicationArg `json:"args"`
+}
+
+// ConsumeApplicationArg holds the URL of an offer to consume, and the
+// name by which the consumed application is to be known, if not the
+// offer name.
+type ConsumeApplicationArg struct {
+	OfferURL         string `json:"offer-url"`
+	ApplicationAlias string `json:"application-alias,omitempty"`
+}
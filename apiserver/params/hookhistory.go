@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// HookRecord describes one execution of a hook by a unit.
+type HookRecord struct {
+	Hook       string        `json:"hook"`
+	Relation   string        `json:"relation,omitempty"`
+	RemoteUnit string        `json:"remote-unit,omitempty"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// AddHookRecordArgs holds the hook executions to record for units.
+type AddHookRecordArgs struct {
+	Args []AddHookRecordArg `json:"args"`
+}
+
+// AddHookRecordArg holds a hook execution to record for a unit.
+type AddHookRecordArg struct {
+	Tag    string     `json:"tag"`
+	Record HookRecord `json:"record"`
+}
+
+// HookHistoryResults holds the hook histories of units.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
+// HookHistoryResult holds a unit's most recent hook executions, oldest
+// first, or an error.
+type HookHistoryResult struct {
+	History []HookRecord `json:"history,omitempty"`
+	Error   *Error       `json:"error,omitempty"`
+}
@@ -130,6 +130,25 @@ type ModelCreateArgs struct {
 	CloudCredentialTag string `json:"credential,omitempty"`
 }
 
+// CloneModelArgs holds the arguments that are necessary to clone a
+// model into a new model in the same controller.
+type CloneModelArgs struct {
+	// SourceModelTag is the tag of the model to clone.
+	SourceModelTag string `json:"source-model-tag"`
+
+	// Name is the name for the new model.
+	Name string `json:"name"`
+
+	// OwnerTag represents the user that will own the new model.
+	// The OwnerTag must be a valid user tag.  If the user tag represents
+	// a local user, that user must exist.
+	OwnerTag string `json:"owner-tag"`
+
+	// AddUnits is true if each application in the new model is to
+	// be given as many units as it has in the source model.
+	AddUnits bool `json:"add-units,omitempty"`
+}
+
 // Model holds the result of an API call returning a name and UUID
 // for a model and the tag of the server in which it is running.
 type Model struct {
@@ -578,6 +597,9 @@ type RunParams struct {
 	Machines     []string      `json:"machines,omitempty"`
 	Applications []string      `json:"applications,omitempty"`
 	Units        []string      `json:"units,omitempty"`
+	// Operation, if set, is the id of an existing operation to
+	// which the actions running the commands are added.
+	Operation string `json:"operation,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -10,6 +10,12 @@ import (
 // LogStreamRecord contains a slice of LogStreamRecords.
 type LogStreamRecords struct {
 	Records []LogStreamRecord `json:"records"`
+
+	// Skipped, if set, is the last record dropped by the log
+	// forwarding filters of its model since the previous message
+	// was sent. It is earlier than any of Records, and only its ID,
+	// ModelUUID and Timestamp are set.
+	Skipped *LogStreamRecord `json:"skipped,omitempty"`
 }
 
 // LogStreamRecord describes a single log record being streamed from
@@ -53,6 +53,65 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationStatusArgs holds the ids of the model migrations to report
+// the progress of.
+type MigrationStatusArgs struct {
+	MigrationIds []string `json:"migration-ids"`
+}
+
+// MigrationStatusResults holds the progress of one or more model
+// migrations.
+type MigrationStatusResults struct {
+	Results []MigrationStatusResult `json:"results"`
+}
+
+// MigrationStatusResult holds the progress of a model migration.
+type MigrationStatusResult struct {
+	MigrationId      string    `json:"migration-id"`
+	ModelTag         string    `json:"model-tag"`
+	Phase            string    `json:"phase"`
+	PhaseChangedTime time.Time `json:"phase-changed-time"`
+	StatusMessage    string    `json:"status-message"`
+	Error            *Error    `json:"error,omitempty"`
+}
+
+// MigrationDryRunResults holds the issues found by dry runs of one or
+// more model migrations.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult holds the issues that would prevent a model
+// from being migrated, or the error that stopped them being found.
+type MigrationDryRunResult struct {
+	ModelTag string                   `json:"model-tag"`
+	Issues   []MigrationPrecheckIssue `json:"issues,omitempty"`
+	Error    *Error                   `json:"error,omitempty"`
+}
+
+// MigrationPrecheckIssues holds the issues that would prevent a model
+// from being migrated.
+type MigrationPrecheckIssues struct {
+	Issues []MigrationPrecheckIssue `json:"issues,omitempty"`
+}
+
+// MigrationPrecheckIssue describes an issue that would prevent a model
+// from being migrated. Stage records where it was found: "source" or
+// "target" for the prechecks of either controller, "export" for the
+// serialization of the model, and "import" for the trial import into
+// the target controller.
+type MigrationPrecheckIssue struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
+
+// MigrationTargetDryRunArgs holds the model for the target controller
+// to check that it could accept in a migration.
+type MigrationTargetDryRunArgs struct {
+	Model MigrationModelInfo `json:"model"`
+	Bytes []byte             `json:"bytes"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -85,6 +144,18 @@ type SerializedModelTools struct {
 	URI string `json:"uri"`
 }
 
+// SerializedModelResult holds a serialized model or an error.
+type SerializedModelResult struct {
+	Result SerializedModel `json:"result"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// SerializedModelResults holds the results of exporting a number of
+// models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
@@ -509,6 +509,10 @@ type StateServingInfo struct {
 	// this will be passed as the KeyFile argument to MongoDB
 	SharedSecret   string `json:"shared-secret"`
 	SystemIdentity string `json:"system-identity"`
+	// SecretsKey is the base64-encoded key with which the values of
+	// charm secrets are encrypted. It is held only by the controller
+	// agents, and not stored in the database.
+	SecretsKey string `json:"secrets-key,omitempty"`
 }
 
 // IsMasterResult holds the result of an IsMaster API call.
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AddSecretArgs holds the arguments for adding secrets on behalf of
+// units.
+type AddSecretArgs struct {
+	Args []AddSecretArg `json:"args"`
+}
+
+// AddSecretArg holds a secret to add; it is owned by the unit's
+// application.
+type AddSecretArg struct {
+	UnitTag     string            `json:"unit-tag"`
+	Description string            `json:"description,omitempty"`
+	Value       map[string]string `json:"value"`
+}
+
+// GetSecretArgs holds the arguments for getting the values of secrets
+// on behalf of units.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a secret to get for a unit.
+type GetSecretArg struct {
+	UnitTag string `json:"unit-tag"`
+	Id      string `json:"id"`
+}
+
+// SecretValueResults holds the values of secrets.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the value of a secret, or an error.
+type SecretValueResult struct {
+	Value map[string]string `json:"value,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantSecretArgs holds the arguments for granting secrets on behalf
+// of units.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GrantSecretArg identifies a secret, and the unit, application or
+// relation the unit grants it to.
+type GrantSecretArg struct {
+	UnitTag    string `json:"unit-tag"`
+	Id         string `json:"id"`
+	SubjectTag string `json:"subject-tag"`
+}
+
+// ListSecretResults holds the details of the secrets in a model.
+type ListSecretResults struct {
+	Results []SecretDetails `json:"results"`
+}
+
+// SecretDetails describes a secret, without its value.
+type SecretDetails struct {
+	Id          string    `json:"id"`
+	Owner       string    `json:"owner"`
+	Description string    `json:"description,omitempty"`
+	Grants      []string  `json:"grants,omitempty"`
+	Created     time.Time `json:"created"`
+}
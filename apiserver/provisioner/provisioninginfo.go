@@ -100,7 +100,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		SubnetsToZones:   subnetsToZones,
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
-		ControllerConfig: controllerCfg,
+		ControllerConfig: controllerCfg.WithoutSecrets(),
 	}, nil
 }
 
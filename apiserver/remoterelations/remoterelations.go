@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations defines an API end point for the model
+// worker that keeps relations to applications in other models in step
+// with their counterparts there.
+package remoterelations
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("RemoteRelations", 1, NewAPI)
+}
+
+// API is the concrete implementation of the RemoteRelations endpoint.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, resources facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: auth,
+	}, nil
+}
+
+// Sync brings the model's relations to remote applications into line
+// with their counterparts in the other models.
+func (api *API) Sync() error {
+	return api.st.SyncRemoteRelations()
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets defines an API end point for model administrators to
+// see the secrets stored by the charms in a model.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Secrets", 1, newAPIFromState)
+}
+
+// Secret defines the secret details used by the Secrets facade.
+type Secret interface {
+	Id() string
+	Owner() names.ApplicationTag
+	Description() string
+	Grants() []string
+	Created() time.Time
+}
+
+// Backend defines the state functionality required by the Secrets
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllSecrets() ([]Secret, error)
+}
+
+// API implements the Secrets facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func newAPIFromState(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return NewAPI(stateShim{st}, authorizer)
+}
+
+// NewAPI returns a new Secrets API facade. Only model administrators
+// may list secrets; their values are never returned.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// ListSecrets returns the details of all the secrets in the model,
+// oldest first.
+func (api *API) ListSecrets() (params.ListSecretResults, error) {
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return params.ListSecretResults{}, errors.Trace(err)
+	}
+	results := params.ListSecretResults{
+		Results: make([]params.SecretDetails, len(secrets)),
+	}
+	for i, secret := range secrets {
+		results.Results[i] = params.SecretDetails{
+			Id:          secret.Id(),
+			Owner:       secret.Owner().String(),
+			Description: secret.Description(),
+			Grants:      secret.Grants(),
+			Created:     secret.Created(),
+		}
+	}
+	return results, nil
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) AllSecrets() ([]Secret, error) {
+	secrets, err := s.State.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		results[i] = secret
+	}
+	return results, nil
+}
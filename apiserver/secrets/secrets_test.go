@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	testing.IsolationSuite
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *secretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := secrets.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *secretsSuite) TestNewAPIRequiresModelAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := secrets.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	created := time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.secrets = []secrets.Secret{&fakeSecret{
+		id:          "a1b2",
+		owner:       names.NewApplicationTag("mysql"),
+		description: "root password",
+		grants:      []string{"application-wordpress"},
+		created:     created,
+	}}
+	api, err := secrets.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ListSecretResults{
+		Results: []params.SecretDetails{{
+			Id:          "a1b2",
+			Owner:       "application-mysql",
+			Description: "root password",
+			Grants:      []string{"application-wordpress"},
+			Created:     created,
+		}},
+	})
+}
+
+func (s *secretsSuite) TestListSecretsError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := secrets.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeBackend struct {
+	secrets []secrets.Secret
+	err     error
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) AllSecrets() ([]secrets.Secret, error) {
+	return b.secrets, b.err
+}
+
+type fakeSecret struct {
+	id          string
+	owner       names.ApplicationTag
+	description string
+	grants      []string
+	created     time.Time
+}
+
+func (s *fakeSecret) Id() string                  { return s.id }
+func (s *fakeSecret) Owner() names.ApplicationTag { return s.owner }
+func (s *fakeSecret) Description() string         { return s.description }
+func (s *fakeSecret) Grants() []string            { return s.grants }
+func (s *fakeSecret) Created() time.Time          { return s.created }
@@ -0,0 +1,49 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddHookRecords records hook executions in the hook history of the
+// given units.
+func (u *UniterAPIV3) AddHookRecords(args params.AddHookRecordArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := u.addHookRecord(canAccess, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) addHookRecord(canAccess common.AuthFunc, arg params.AddHookRecordArg) error {
+	unitTag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil || !canAccess(unitTag) {
+		return common.ErrPerm
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return unit.AddHookRecord(state.HookRecord{
+		Hook:       arg.Record.Hook,
+		Relation:   arg.Record.Relation,
+		RemoteUnit: arg.Record.RemoteUnit,
+		Started:    arg.Record.Started,
+		Duration:   arg.Record.Duration,
+		Error:      arg.Record.Error,
+	})
+}
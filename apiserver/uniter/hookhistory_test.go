@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestAddHookRecords(c *gc.C) {
+	record := params.HookRecord{
+		Hook:       "db-relation-changed",
+		Relation:   "db:0",
+		RemoteUnit: "mysql/0",
+		Started:    time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC),
+		Duration:   3 * time.Second,
+		Error:      "exit status 1",
+	}
+	result, err := s.uniter.AddHookRecords(params.AddHookRecordArgs{Args: []params.AddHookRecordArg{
+		{Tag: "unit-wordpress-0", Record: record},
+		{Tag: "unit-mysql-0", Record: record},
+		{Tag: "application-wordpress", Record: record},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{nil},
+		{apiservertesting.ErrUnauthorized},
+		{apiservertesting.ErrUnauthorized},
+	}})
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(history, jc.DeepEquals, []state.HookRecord{{
+		Hook:       "db-relation-changed",
+		Relation:   "db:0",
+		RemoteUnit: "mysql/0",
+		Started:    time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC),
+		Duration:   3 * time.Second,
+		Error:      "exit status 1",
+	}})
+}
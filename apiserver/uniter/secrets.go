@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddSecrets stores secrets on behalf of the given units, each owned
+// by the unit's application, and returns their ids.
+func (u *UniterAPIV5) AddSecrets(args params.AddSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		unitTag, err := u.secretUnitTag(canAccess, arg.UnitTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		secret, err := u.st.AddSecret(state.AddSecretArgs{
+			Owner:       names.NewApplicationTag(appName),
+			Description: arg.Description,
+			Value:       arg.Value,
+		})
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secret.Id()
+	}
+	return result, nil
+}
+
+// GetSecrets returns the values of the given secrets, for the units
+// that may read them.
+func (u *UniterAPIV5) GetSecrets(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretValueResults{}, err
+	}
+	for i, arg := range args.Args {
+		value, err := u.getSecret(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Value = value
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) getSecret(canAccess common.AuthFunc, arg params.GetSecretArg) (map[string]string, error) {
+	unitTag, err := u.secretUnitTag(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// A secret the unit cannot read is reported in the same way as
+	// one that does not exist, so that ids cannot be probed.
+	secret, err := u.st.Secret(arg.Id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	canRead, err := secret.CanRead(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if !canRead {
+		return nil, common.ErrPerm
+	}
+	return secret.Value()
+}
+
+// GrantSecrets allows the given units, applications or relations to
+// read secrets. Only the units of the application owning a secret may
+// grant it.
+func (u *UniterAPIV5) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := u.grantSecret(canAccess, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV5) grantSecret(canAccess common.AuthFunc, arg params.GrantSecretArg) error {
+	unitTag, err := u.secretUnitTag(canAccess, arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	secret, err := u.st.Secret(arg.Id)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Owner().Id() != appName {
+		return common.ErrPerm
+	}
+	subject, err := names.ParseTag(arg.SubjectTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return secret.Grant(subject)
+}
+
+// secretUnitTag parses the tag of a unit acting on secrets, and checks
+// that it may be accessed.
+func (u *UniterAPIV5) secretUnitTag(canAccess common.AuthFunc, tag string) (names.UnitTag, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return names.UnitTag{}, common.ErrPerm
+	}
+	if !canAccess(unitTag) {
+		return names.UnitTag{}, common.ErrPerm
+	}
+	return unitTag, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestAddAndGetSecrets(c *gc.C) {
+	uniterAPI := s.newUniterAPIV5(c)
+	added, err := uniterAPI.AddSecrets(params.AddSecretArgs{Args: []params.AddSecretArg{
+		{UnitTag: "unit-wordpress-0", Description: "admin", Value: map[string]string{"password": "sekrit"}},
+		{UnitTag: "unit-mysql-0", Value: map[string]string{"password": "sekrit"}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added.Results, gc.HasLen, 2)
+	c.Assert(added.Results[0].Error, gc.IsNil)
+	c.Check(added.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	id := added.Results[0].Result
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Owner(), gc.Equals, s.wordpress.ApplicationTag())
+	c.Check(secret.Description(), gc.Equals, "admin")
+
+	got, err := uniterAPI.GetSecrets(params.GetSecretArgs{Args: []params.GetSecretArg{
+		{UnitTag: "unit-wordpress-0", Id: id},
+		{UnitTag: "unit-mysql-0", Id: id},
+		{UnitTag: "unit-wordpress-0", Id: "42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Results, gc.HasLen, 3)
+	c.Check(got.Results[0], jc.DeepEquals, params.SecretValueResult{
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Check(got.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(got.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestGetSecretNotGranted(c *gc.C) {
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.ApplicationTag(),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{
+		{UnitTag: "unit-wordpress-0", Id: secret.Id()},
+	}}
+	uniterAPI := s.newUniterAPIV5(c)
+
+	got, err := uniterAPI.GetSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	err = secret.Grant(s.wordpress.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = uniterAPI.GetSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Results[0].Error, gc.IsNil)
+	c.Check(got.Results[0].Value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *uniterSuite) TestGrantSecrets(c *gc.C) {
+	own, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.wordpress.ApplicationTag(),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.ApplicationTag(),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uniterAPI := s.newUniterAPIV5(c)
+	result, err := uniterAPI.GrantSecrets(params.GrantSecretArgs{Args: []params.GrantSecretArg{
+		{UnitTag: "unit-wordpress-0", Id: own.Id(), SubjectTag: "application-mysql"},
+		{UnitTag: "unit-wordpress-0", Id: other.Id(), SubjectTag: "application-wordpress"},
+		{UnitTag: "unit-mysql-0", Id: own.Id(), SubjectTag: "application-mysql"},
+		{UnitTag: "unit-wordpress-0", Id: own.Id(), SubjectTag: "machine-0"},
+		{UnitTag: "unit-wordpress-0", Id: "42", SubjectTag: "application-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 5)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, `granting secret to "machine-0" not valid`)
+	c.Check(result.Results[4].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	own, err = s.State.Secret(own.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(own.Grants(), jc.DeepEquals, []string{"application-mysql"})
+}
@@ -29,6 +29,7 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -140,6 +141,21 @@ func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer faca
 	}, nil
 }
 
+// UniterAPIV5 implements the API version 5, used by the uniter worker.
+// It adds WatchAbortingActions and the secrets methods to version 4.
+type UniterAPIV5 struct {
+	UniterAPIV3
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	uniterAPI, err := NewUniterAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{*uniterAPI}, nil
+}
+
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
 func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
@@ -814,6 +830,31 @@ func (u *UniterAPIV3) WatchActionNotifications(args params.Entities) (params.Str
 	return common.WatchActionNotifications(args, canAccess, watchOne), nil
 }
 
+// WatchAbortingActions returns a StringsWatcher for observing the
+// running actions of each given unit that have been asked to abort.
+func (u *UniterAPIV5) WatchAbortingActions(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringsWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			result.Results[i], err = u.watchOneUnitAbortingActions(tag)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // ConfigSettings returns the complete set of service charm config
 // settings available to each given unit.
 func (u *UniterAPIV3) ConfigSettings(args params.Entities) (params.ConfigSettingsResults, error) {
@@ -952,6 +993,18 @@ func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records progress messages for the running Actions
+// represented by the passed in Tags.
+func (u *UniterAPIV3) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1367,6 +1420,23 @@ func (u *UniterAPIV3) watchOneServiceRelations(tag names.ApplicationTag) (params
 	return nothing, watcher.EnsureErr(watch)
 }
 
+func (u *UniterAPIV3) watchOneUnitAbortingActions(tag names.UnitTag) (params.StringsWatchResult, error) {
+	nothing := params.StringsWatchResult{}
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nothing, err
+	}
+	watch := unit.WatchAbortingActions()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: u.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return nothing, watcher.EnsureErr(watch)
+}
+
 func (u *UniterAPIV3) watchOneUnitConfigSettings(tag names.UnitTag) (string, error) {
 	unit, err := u.getUnit(tag)
 	if err != nil {
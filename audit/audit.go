@@ -18,6 +18,10 @@ type AuditEntrySinkFn func(AuditEntry) error
 
 // AuditEntry represents an auditted event.
 type AuditEntry struct {
+	// ID uniquely identifies an entry read back from the
+	// controller's audit log. It is empty for entries that have not
+	// been recorded there.
+	ID string
 	// JujuServerVersion is the version of the jujud that recorded
 	// this AuditEntry.
 	JujuServerVersion version.Number
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// Filter holds the criteria used to select recorded audit entries.
+// Empty fields match every entry.
+type Filter struct {
+	// ModelUUID restricts entries to those recorded on the
+	// specified model.
+	ModelUUID string
+
+	// OriginType restricts entries to those with the specified
+	// origin type.
+	OriginType string
+
+	// OriginName restricts entries to those triggered by the
+	// specified origin (e.g. "user-admin").
+	OriginName string
+
+	// Operation restricts entries to those whose operation
+	// contains the specified string.
+	Operation string
+
+	// RemoteAddress restricts entries to those triggered from the
+	// specified address.
+	RemoteAddress string
+
+	// After restricts entries to those recorded at or after the
+	// specified time.
+	After time.Time
+
+	// Before restricts entries to those recorded strictly before
+	// the specified time.
+	Before time.Time
+
+	// Limit, if non-zero, restricts the result to the most recent
+	// Limit matching entries.
+	Limit int
+}
+
+// Match reports whether the supplied entry satisfies the filter.
+// Limit is not considered.
+func (f Filter) Match(entry AuditEntry) bool {
+	if f.ModelUUID != "" && entry.ModelUUID != f.ModelUUID {
+		return false
+	}
+	if f.OriginType != "" && entry.OriginType != f.OriginType {
+		return false
+	}
+	if f.OriginName != "" && entry.OriginName != f.OriginName {
+		return false
+	}
+	if f.Operation != "" && !strings.Contains(entry.Operation, f.Operation) {
+		return false
+	}
+	if f.RemoteAddress != "" && entry.RemoteAddress != f.RemoteAddress {
+		return false
+	}
+	if !f.After.IsZero() && entry.Timestamp.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && !entry.Timestamp.Before(f.Before) {
+		return false
+	}
+	return true
+}
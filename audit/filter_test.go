@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type filterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&filterSuite{})
+
+func (s *filterSuite) TestMatch(c *gc.C) {
+	entry := validEntry()
+	entry.OriginName = "user-admin"
+	entry.OriginType = "API request"
+	entry.Operation = "Client:v1 - FullStatus"
+
+	for i, test := range []struct {
+		about  string
+		filter audit.Filter
+		match  bool
+	}{{
+		about: "empty filter",
+		match: true,
+	}, {
+		about:  "model matches",
+		filter: audit.Filter{ModelUUID: entry.ModelUUID},
+		match:  true,
+	}, {
+		about:  "model differs",
+		filter: audit.Filter{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	}, {
+		about:  "origin name matches",
+		filter: audit.Filter{OriginName: "user-admin", OriginType: "API request"},
+		match:  true,
+	}, {
+		about:  "origin name differs",
+		filter: audit.Filter{OriginName: "user-bob"},
+	}, {
+		about:  "operation substring",
+		filter: audit.Filter{Operation: "FullStatus"},
+		match:  true,
+	}, {
+		about:  "operation differs",
+		filter: audit.Filter{Operation: "Deploy"},
+	}, {
+		about:  "remote address differs",
+		filter: audit.Filter{RemoteAddress: "10.0.0.1"},
+	}, {
+		about: "within time range",
+		filter: audit.Filter{
+			After:  entry.Timestamp.Add(-time.Second),
+			Before: entry.Timestamp.Add(time.Second),
+		},
+		match: true,
+	}, {
+		about:  "after is inclusive",
+		filter: audit.Filter{After: entry.Timestamp},
+		match:  true,
+	}, {
+		about:  "after is later",
+		filter: audit.Filter{After: entry.Timestamp.Add(time.Nanosecond)},
+	}, {
+		about:  "before is exclusive",
+		filter: audit.Filter{Before: entry.Timestamp},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(test.filter.Match(entry), gc.Equals, test.match)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v1"
+)
+
+// DefaultQueueSize is the number of audit entries a QueuedSink holds
+// before it starts dropping them.
+const DefaultQueueSize = 1000
+
+// QueuedSink passes audit entries to another sink from its own
+// goroutine, so that a slow or unreachable sink does not delay the
+// API requests being audited. At most a fixed number of entries is
+// held; entries arriving when the queue is full are dropped and
+// counted, unless the QueuedSink was created to block instead.
+type QueuedSink struct {
+	tomb         tomb.Tomb
+	sink         AuditEntrySinkFn
+	errorHandler func(error)
+	entries      chan AuditEntry
+	block        bool
+	dropped      int64
+}
+
+// NewQueuedSink returns a QueuedSink holding up to size entries for
+// the supplied sink. Errors returned by the sink are passed to
+// errorHandler. The QueuedSink must be stopped with Kill and Wait
+// when no longer needed.
+func NewQueuedSink(sink AuditEntrySinkFn, size int, errorHandler func(error)) *QueuedSink {
+	q := &QueuedSink{
+		sink:         sink,
+		errorHandler: errorHandler,
+		entries:      make(chan AuditEntry, size),
+	}
+	go func() {
+		defer q.tomb.Done()
+		q.tomb.Kill(q.loop())
+	}()
+	return q
+}
+
+// NewBlockingQueuedSink returns a QueuedSink like NewQueuedSink, except
+// that when its queue is full Handle waits for room rather than
+// dropping the entry. It is for sinks whose entries must not be lost,
+// such as the database, at the cost of slowing the requests audited
+// when the sink cannot keep up.
+func NewBlockingQueuedSink(sink AuditEntrySinkFn, size int, errorHandler func(error)) *QueuedSink {
+	q := NewQueuedSink(sink, size, errorHandler)
+	q.block = true
+	return q
+}
+
+// Handle is an AuditEntrySinkFn which queues the entry for the
+// underlying sink. If the queue is full, the entry is dropped and an
+// error is returned, unless the QueuedSink blocks, in which case
+// Handle waits until the entry can be queued or the QueuedSink is
+// stopped.
+func (q *QueuedSink) Handle(entry AuditEntry) error {
+	if q.block {
+		select {
+		case q.entries <- entry:
+			return nil
+		case <-q.tomb.Dying():
+			return errors.New("audit queue stopped")
+		}
+	}
+	select {
+	case q.entries <- entry:
+		return nil
+	default:
+	}
+	dropped := atomic.AddInt64(&q.dropped, 1)
+	return errors.Errorf("audit queue full: %d entries dropped", dropped)
+}
+
+// Dropped returns the number of entries dropped because the queue
+// was full.
+func (q *QueuedSink) Dropped() int64 {
+	return atomic.LoadInt64(&q.dropped)
+}
+
+// Kill is part of the worker.Worker interface. Entries still queued
+// when the QueuedSink is killed are discarded.
+func (q *QueuedSink) Kill() {
+	q.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (q *QueuedSink) Wait() error {
+	return q.tomb.Wait()
+}
+
+func (q *QueuedSink) loop() error {
+	for {
+		select {
+		case <-q.tomb.Dying():
+			return tomb.ErrDying
+		case entry := <-q.entries:
+			if err := q.sink(entry); err != nil {
+				q.errorHandler(errors.Trace(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type queuedSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&queuedSinkSuite{})
+
+func (s *queuedSinkSuite) TestPassesEntriesOn(c *gc.C) {
+	entries := make(chan audit.AuditEntry, 1)
+	sink := func(entry audit.AuditEntry) error {
+		entries <- entry
+		return nil
+	}
+	queue := audit.NewQueuedSink(sink, 10, func(err error) {
+		c.Errorf("unexpected error: %v", err)
+	})
+	defer workertest.CleanKill(c, queue)
+
+	entry := validEntry()
+	err := queue.Handle(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case received := <-entries:
+		c.Assert(received, jc.DeepEquals, entry)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for entry")
+	}
+}
+
+func (s *queuedSinkSuite) TestDoesNotWaitForSink(c *gc.C) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	started := make(chan struct{}, 1)
+	sink := func(entry audit.AuditEntry) error {
+		started <- struct{}{}
+		<-unblock
+		return nil
+	}
+	queue := audit.NewQueuedSink(sink, 1, func(error) {})
+	defer workertest.CleanKill(c, queue)
+
+	// The first entry is taken by the blocked sink, and the second
+	// fills the queue.
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sink")
+	}
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+
+	err := queue.Handle(validEntry())
+	c.Assert(err, gc.ErrorMatches, "audit queue full: 1 entries dropped")
+	err = queue.Handle(validEntry())
+	c.Assert(err, gc.ErrorMatches, "audit queue full: 2 entries dropped")
+	c.Assert(queue.Dropped(), gc.Equals, int64(2))
+}
+
+func (s *queuedSinkSuite) TestReportsSinkErrors(c *gc.C) {
+	sink := func(entry audit.AuditEntry) error {
+		return errors.New("boom")
+	}
+	errs := make(chan error, 1)
+	queue := audit.NewQueuedSink(sink, 10, func(err error) {
+		errs <- err
+	})
+	defer workertest.CleanKill(c, queue)
+
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, "boom")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for error")
+	}
+}
+
+func (s *queuedSinkSuite) TestBlockingWaitsForRoom(c *gc.C) {
+	unblock := make(chan struct{})
+	started := make(chan struct{}, 3)
+	sink := func(entry audit.AuditEntry) error {
+		started <- struct{}{}
+		<-unblock
+		return nil
+	}
+	queue := audit.NewBlockingQueuedSink(sink, 1, func(error) {})
+	defer workertest.CleanKill(c, queue)
+
+	// The first entry is taken by the blocked sink, and the second
+	// fills the queue.
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sink")
+	}
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+
+	handled := make(chan error, 1)
+	go func() {
+		handled <- queue.Handle(validEntry())
+	}()
+	select {
+	case err := <-handled:
+		c.Fatalf("entry handled while queue full: %v", err)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	close(unblock)
+	select {
+	case err := <-handled:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for entry to be queued")
+	}
+	c.Assert(queue.Dropped(), gc.Equals, int64(0))
+}
+
+func (s *queuedSinkSuite) TestBlockingStopsWaitingWhenKilled(c *gc.C) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	started := make(chan struct{}, 1)
+	sink := func(entry audit.AuditEntry) error {
+		started <- struct{}{}
+		<-unblock
+		return nil
+	}
+	queue := audit.NewBlockingQueuedSink(sink, 1, func(error) {})
+
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for sink")
+	}
+	c.Assert(queue.Handle(validEntry()), jc.ErrorIsNil)
+
+	handled := make(chan error, 1)
+	go func() {
+		handled <- queue.Handle(validEntry())
+	}()
+	queue.Kill()
+	select {
+	case err := <-handled:
+		c.Assert(err, gc.ErrorMatches, "audit queue stopped")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Handle to return")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// These are the names of the audit sinks known to juju. The
+// database sink is provided by the controller agent, since it
+// requires access to state; the remainder are registered by
+// NewSinkRegistry.
+const (
+	LogFileSinkName  = "logfile"
+	DatabaseSinkName = "mongo"
+	SyslogSinkName   = "syslog"
+	WebhookSinkName  = "webhook"
+)
+
+// SinkConfig holds the configuration used when creating audit sinks.
+type SinkConfig struct {
+	// ControllerUUID is the UUID of the controller recording the
+	// audit entries.
+	ControllerUUID string
+
+	// LogDir is the directory in which the log file sink will
+	// write its audit.log file.
+	LogDir string
+
+	// WebhookURL is the URL to which the webhook sink will POST
+	// audit entries.
+	WebhookURL string
+
+	// Syslog holds the configuration of the syslog host to which
+	// the syslog sink will forward audit entries.
+	Syslog *syslog.RawConfig
+}
+
+// SinkFactory returns a new AuditEntrySinkFn configured according
+// to the supplied SinkConfig.
+type SinkFactory func(SinkConfig) (AuditEntrySinkFn, error)
+
+// SinkRegistry holds a set of named audit sink factories, from which
+// combined audit sinks may be created.
+type SinkRegistry struct {
+	mu        sync.Mutex
+	factories map[string]SinkFactory
+}
+
+// NewSinkRegistry returns a new SinkRegistry with the log file,
+// syslog and webhook sinks registered.
+func NewSinkRegistry() *SinkRegistry {
+	return &SinkRegistry{
+		factories: map[string]SinkFactory{
+			LogFileSinkName: newLogFileSinkFromConfig,
+			SyslogSinkName:  newSyslogSinkFromConfig,
+			WebhookSinkName: newWebhookSinkFromConfig,
+		},
+	}
+}
+
+// Register adds the named sink factory to the registry. It is an
+// error to register the same name twice.
+func (r *SinkRegistry) Register(name string, factory SinkFactory) error {
+	if name == "" {
+		return errors.NotValidf("empty sink name")
+	}
+	if factory == nil {
+		return errors.NotValidf("nil factory for sink %q", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.factories[name]; ok {
+		return errors.AlreadyExistsf("audit sink %q", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// Names returns the sorted names of all registered sinks.
+func (r *SinkRegistry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSink returns an AuditEntrySinkFn which sends each audit entry
+// to every one of the named sinks. An error satisfying
+// errors.IsNotFound is returned if any of the names is not
+// registered.
+func (r *SinkRegistry) NewSink(names []string, config SinkConfig) (AuditEntrySinkFn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sinks := make([]namedSink, 0, len(names))
+	for _, name := range names {
+		factory, ok := r.factories[name]
+		if !ok {
+			return nil, errors.NotFoundf("audit sink %q", name)
+		}
+		sink, err := factory(config)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating audit sink %q", name)
+		}
+		sinks = append(sinks, namedSink{name, sink})
+	}
+	return newMultiSink(sinks), nil
+}
+
+type namedSink struct {
+	name string
+	sink AuditEntrySinkFn
+}
+
+// newMultiSink returns an AuditEntrySinkFn which sends each entry to
+// all of the supplied sinks, regardless of whether earlier sinks
+// fail. The returned error names all of the sinks that failed.
+func newMultiSink(sinks []namedSink) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		var failed []string
+		var firstErr error
+		for _, s := range sinks {
+			if err := s.sink(entry); err != nil {
+				logger.Errorf("cannot save audit record to %s: %v", s.name, err)
+				failed = append(failed, s.name)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		if firstErr == nil {
+			return nil
+		}
+		return errors.Annotatef(firstErr, "cannot save audit record to %s", strings.Join(failed, ", "))
+	}
+}
+
+func newLogFileSinkFromConfig(config SinkConfig) (AuditEntrySinkFn, error) {
+	if config.LogDir == "" {
+		return nil, errors.NotValidf("empty log directory")
+	}
+	return NewLogFileSink(config.LogDir), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type sinkRegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sinkRegistrySuite{})
+
+func (s *sinkRegistrySuite) TestNames(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	c.Assert(registry.Names(), jc.DeepEquals, []string{"logfile", "syslog", "webhook"})
+
+	err := registry.Register(audit.DatabaseSinkName, recordingSinkFactory(nil, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(registry.Names(), jc.DeepEquals, []string{"logfile", "mongo", "syslog", "webhook"})
+}
+
+func (s *sinkRegistrySuite) TestRegisterDuplicate(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	err := registry.Register(audit.LogFileSinkName, recordingSinkFactory(nil, nil))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `audit sink "logfile" already exists`)
+}
+
+func (s *sinkRegistrySuite) TestRegisterInvalid(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	err := registry.Register("", recordingSinkFactory(nil, nil))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = registry.Register("foo", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *sinkRegistrySuite) TestNewSinkUnknown(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	_, err := registry.NewSink([]string{"logfile", "bogus"}, audit.SinkConfig{LogDir: c.MkDir()})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `audit sink "bogus" not found`)
+}
+
+func (s *sinkRegistrySuite) TestNewSinkFactoryError(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	_, err := registry.NewSink([]string{"webhook"}, audit.SinkConfig{})
+	c.Assert(err, gc.ErrorMatches, `creating audit sink "webhook": empty webhook URL not valid`)
+}
+
+func (s *sinkRegistrySuite) TestNewSinkWritesToAll(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	var recorded []audit.AuditEntry
+	err := registry.Register("recorder", recordingSinkFactory(&recorded, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	dir := c.MkDir()
+	sink, err := registry.NewSink([]string{"logfile", "recorder"}, audit.SinkConfig{LogDir: dir})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entry := validEntry()
+	err = sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, []audit.AuditEntry{entry})
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(contents), gc.Not(gc.Equals), "")
+}
+
+func (s *sinkRegistrySuite) TestNewSinkContinuesAfterFailure(c *gc.C) {
+	registry := audit.NewSinkRegistry()
+	var recorded []audit.AuditEntry
+	err := registry.Register("broken", recordingSinkFactory(nil, errors.New("boom")))
+	c.Assert(err, jc.ErrorIsNil)
+	err = registry.Register("recorder", recordingSinkFactory(&recorded, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	sink, err := registry.NewSink([]string{"broken", "recorder"}, audit.SinkConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entry := validEntry()
+	err = sink(entry)
+	c.Assert(err, gc.ErrorMatches, "cannot save audit record to broken: boom")
+	c.Assert(recorded, jc.DeepEquals, []audit.AuditEntry{entry})
+}
+
+func recordingSinkFactory(recorded *[]audit.AuditEntry, sinkErr error) audit.SinkFactory {
+	return func(audit.SinkConfig) (audit.AuditEntrySinkFn, error) {
+		return func(entry audit.AuditEntry) error {
+			if sinkErr != nil {
+				return sinkErr
+			}
+			*recorded = append(*recorded, entry)
+			return nil
+		}, nil
+	}
+}
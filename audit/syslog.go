@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// auditModule is the module recorded against audit entries forwarded
+// as log records.
+const auditModule = "juju.audit"
+
+// RecordSender is the subset of the logfwd/syslog client used by
+// the syslog sink.
+type RecordSender interface {
+	Send([]logfwd.Record) error
+}
+
+// NewSyslogSink returns an audit entry sink which forwards each entry
+// as a log record via the supplied sender.
+func NewSyslogSink(controllerUUID string, sender RecordSender) AuditEntrySinkFn {
+	sink := &syslogSink{
+		controllerUUID: controllerUUID,
+		sender:         sender,
+	}
+	return sink.handle
+}
+
+func newSyslogSinkFromConfig(config SinkConfig) (AuditEntrySinkFn, error) {
+	if config.Syslog == nil || config.Syslog.Host == "" {
+		return nil, errors.NotValidf("missing syslog host")
+	}
+	cfg := *config.Syslog
+	cfg.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sender := &lazySyslogSender{config: cfg}
+	return NewSyslogSink(config.ControllerUUID, sender), nil
+}
+
+type syslogSink struct {
+	controllerUUID string
+	sender         RecordSender
+
+	mu     sync.Mutex
+	lastID int64
+}
+
+func (s *syslogSink) handle(entry AuditEntry) error {
+	s.mu.Lock()
+	s.lastID++
+	id := s.lastID
+	s.mu.Unlock()
+
+	rec := logfwd.Record{
+		ID:        id,
+		Origin:    s.origin(entry),
+		Timestamp: entry.Timestamp,
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   auditModule,
+			Filename: "audit.go",
+			Line:     1,
+		},
+		Message: fmt.Sprintf("%s %s (%s) %s %v",
+			entry.RemoteAddress,
+			entry.OriginName,
+			entry.OriginType,
+			entry.Operation,
+			entry.Data,
+		),
+	}
+	return errors.Trace(s.sender.Send([]logfwd.Record{rec}))
+}
+
+func (s *syslogSink) origin(entry AuditEntry) logfwd.Origin {
+	origin := logfwd.Origin{
+		ControllerUUID: s.controllerUUID,
+		ModelUUID:      entry.ModelUUID,
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: logfwd.CanonicalPEN,
+			Name:                    "jujud-audit",
+			Version:                 entry.JujuServerVersion,
+		},
+	}
+	if tag, err := names.ParseTag(entry.OriginName); err == nil {
+		if originType, err := logfwd.ParseOriginType(tag.Kind()); err == nil {
+			origin.Type = originType
+			origin.Name = tag.Id()
+		}
+	}
+	return origin
+}
+
+// lazySyslogSender opens its connection to the syslog host on first
+// use, and reopens it after a failed send. The connection is never
+// held locked while it is being opened or used, so that a slow syslog
+// host cannot hold up other senders.
+type lazySyslogSender struct {
+	config syslog.RawConfig
+
+	mu     sync.Mutex
+	client *syslog.Client
+}
+
+// Send implements RecordSender.
+func (l *lazySyslogSender) Send(records []logfwd.Record) error {
+	client, err := l.takeClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.Send(records); err != nil {
+		client.Close()
+		return errors.Trace(err)
+	}
+	l.returnClient(client)
+	return nil
+}
+
+// takeClient returns the idle client, if there is one, or opens a
+// new one.
+func (l *lazySyslogSender) takeClient() (*syslog.Client, error) {
+	l.mu.Lock()
+	client := l.client
+	l.client = nil
+	l.mu.Unlock()
+	if client != nil {
+		return client, nil
+	}
+	client, err := syslog.Open(l.config)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to syslog host")
+	}
+	return client, nil
+}
+
+// returnClient makes the client available for reuse, closing it if
+// another client is already available.
+func (l *lazySyslogSender) returnClient(client *syslog.Client) {
+	l.mu.Lock()
+	if l.client == nil {
+		l.client = client
+		client = nil
+	}
+	l.mu.Unlock()
+	if client != nil {
+		client.Close()
+	}
+}
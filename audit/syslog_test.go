@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type syslogSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&syslogSinkSuite{})
+
+func (s *syslogSinkSuite) TestSendsRecord(c *gc.C) {
+	sender := &fakeRecordSender{}
+	controllerUUID := coretesting.ControllerTag.Id()
+	sink := audit.NewSyslogSink(controllerUUID, sender)
+
+	entry := validEntry()
+	entry.OriginName = "user-admin"
+	entry.OriginType = "API request"
+	entry.Operation = "Client:v1 - FullStatus"
+	err := sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(sender.records, gc.HasLen, 1)
+	rec := sender.records[0]
+	c.Check(rec.ID, gc.Equals, int64(1))
+	c.Check(rec.Timestamp, gc.Equals, entry.Timestamp)
+	c.Check(rec.Level, gc.Equals, loggo.INFO)
+	c.Check(rec.Location.Module, gc.Equals, "juju.audit")
+	c.Check(rec.Origin.ControllerUUID, gc.Equals, controllerUUID)
+	c.Check(rec.Origin.ModelUUID, gc.Equals, entry.ModelUUID)
+	c.Check(rec.Origin.Type, gc.Equals, logfwd.OriginTypeUser)
+	c.Check(rec.Origin.Name, gc.Equals, "admin")
+	c.Check(rec.Origin.Software.PrivateEnterpriseNumber, gc.Equals, logfwd.CanonicalPEN)
+	c.Check(rec.Message, gc.Equals, "8.8.8.8 user-admin (API request) Client:v1 - FullStatus map[]")
+	c.Check(rec.Validate(), jc.ErrorIsNil)
+
+	err = sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender.records, gc.HasLen, 2)
+	c.Check(sender.records[1].ID, gc.Equals, int64(2))
+}
+
+func (s *syslogSinkSuite) TestSendError(c *gc.C) {
+	sender := &fakeRecordSender{err: errors.New("connection refused")}
+	sink := audit.NewSyslogSink(coretesting.ControllerTag.Id(), sender)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, "connection refused")
+}
+
+type fakeRecordSender struct {
+	records []logfwd.Record
+	err     error
+}
+
+func (f *fakeRecordSender) Send(records []logfwd.Record) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.records = append(f.records, records...)
+	return nil
+}
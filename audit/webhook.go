@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// webhookTimeout is the maximum time the default webhook client
+// will wait for a webhook request to complete.
+const webhookTimeout = 30 * time.Second
+
+// HTTPDoer is the subset of *http.Client used by the webhook sink.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewWebhookSink returns an audit entry sink which POSTs each entry,
+// encoded as JSON, to the specified URL.
+func NewWebhookSink(webhookURL string, client HTTPDoer) AuditEntrySinkFn {
+	sink := &webhookSink{
+		url:    webhookURL,
+		client: client,
+	}
+	return sink.handle
+}
+
+func newWebhookSinkFromConfig(config SinkConfig) (AuditEntrySinkFn, error) {
+	if err := ValidateWebhookURL(config.WebhookURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &http.Client{Timeout: webhookTimeout}
+	return NewWebhookSink(config.WebhookURL, client), nil
+}
+
+// ValidateWebhookURL ensures that the supplied URL is usable as an
+// audit webhook target.
+func ValidateWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return errors.NotValidf("empty webhook URL")
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return errors.NewNotValid(err, "webhook URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("webhook URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("webhook URL without host")
+	}
+	return nil
+}
+
+type webhookSink struct {
+	url    string
+	client HTTPDoer
+}
+
+// webhookEntry is the JSON representation of an AuditEntry sent
+// to the webhook.
+type webhookEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+func (w *webhookSink) handle(entry AuditEntry) error {
+	body, err := json.Marshal(webhookEntry{
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		ModelUUID:         entry.ModelUUID,
+		Timestamp:         entry.Timestamp.UTC(),
+		RemoteAddress:     entry.RemoteAddress,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		Operation:         entry.Operation,
+		Data:              entry.Data,
+	})
+	if err != nil {
+		return errors.Annotate(err, "marshalling audit entry")
+	}
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "posting audit entry")
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection may be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("posting audit entry: unexpected response %q", resp.Status)
+	}
+	return nil
+}
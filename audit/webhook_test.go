@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type webhookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&webhookSuite{})
+
+func (s *webhookSuite) TestPostsEntry(c *gc.C) {
+	var received map[string]interface{}
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		contentType = req.Header.Get("Content-Type")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(json.Unmarshal(body, &received), jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	entry := validEntry()
+	entry.Data = map[string]interface{}{"foo": "bar"}
+	sink := audit.NewWebhookSink(server.URL, http.DefaultClient)
+	err := sink(entry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(contentType, gc.Equals, "application/json")
+	c.Assert(received["model-uuid"], gc.Equals, entry.ModelUUID)
+	c.Assert(received["juju-server-version"], gc.Equals, "1.0.0")
+	c.Assert(received["remote-address"], gc.Equals, "8.8.8.8")
+	c.Assert(received["operation"], gc.Equals, ".")
+	c.Assert(received["data"], jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *webhookSuite) TestErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := audit.NewWebhookSink(server.URL, http.DefaultClient)
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, `posting audit entry: unexpected response "503 Service Unavailable"`)
+}
+
+func (s *webhookSuite) TestValidateWebhookURL(c *gc.C) {
+	for i, test := range []struct {
+		url    string
+		expect string
+	}{{
+		url: "https://audit.example.com/hook",
+	}, {
+		url: "http://10.0.0.1:8080",
+	}, {
+		url:    "",
+		expect: "empty webhook URL not valid",
+	}, {
+		url:    "ftp://audit.example.com",
+		expect: `webhook URL scheme "ftp" not valid`,
+	}, {
+		url:    "https://",
+		expect: "webhook URL without host not valid",
+	}} {
+		c.Logf("test %d: %q", i, test.url)
+		err := audit.ValidateWebhookURL(test.url)
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
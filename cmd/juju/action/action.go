@@ -49,6 +49,10 @@ type APIClient interface {
 	// the ActionReceiver if necessary.
 	Actions(params.Entities) (params.ActionResults, error)
 
+	// ActionsWithLog fetches actions by tag, along with the progress
+	// messages they have logged.
+	ActionsWithLog(params.Entities) (params.ActionResults, error)
+
 	// FindActionTagsByPrefix takes a list of string prefixes and finds
 	// corresponding ActionTags that match that prefix.
 	FindActionTagsByPrefix(params.FindTags) (params.FindTagsResults, error)
@@ -56,6 +60,14 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// Operations takes a list of operation ids, and returns the
+	// operation, with its actions, for each id.
+	Operations(params.OperationIds) (params.OperationResults, error)
+
+	// ListOperations returns a page of the operations in the model,
+	// oldest first, without their actions.
+	ListOperations(params.ListOperationsArgs) (params.OperationResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
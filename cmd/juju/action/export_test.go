@@ -15,6 +15,8 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	WatchInterval      = &watchInterval
+	NewMessages        = newMessages
 )
 
 type ShowOutputCommand struct {
@@ -29,8 +31,8 @@ type RunCommand struct {
 	*runCommand
 }
 
-func (c *RunCommand) UnitTag() names.UnitTag {
-	return c.unitTag
+func (c *RunCommand) UnitTags() []names.UnitTag {
+	return c.unitTags
 }
 
 func (c *RunCommand) ActionName() string {
@@ -85,6 +87,18 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewShowOperationCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showOperationCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
+func NewListOperationsCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listOperationsCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -0,0 +1,266 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewShowOperationCommand() cmd.Command {
+	return modelcmd.Wrap(&showOperationCommand{})
+}
+
+// showOperationCommand shows an operation, and the actions it groups,
+// by ID.
+type showOperationCommand struct {
+	ActionCommandBase
+	out         cmd.Output
+	operationId string
+}
+
+const showOperationDoc = `
+Show the status of an operation, and of each of the Actions it groups.
+
+An operation is created each time Actions are queued together, such as by
+'juju run-action' with more than one unit, or 'juju run --application'.
+It is pending until any of its Actions start, and running until all of them
+have finished; it has then failed if any of them failed, and otherwise
+completed.
+
+Examples:
+
+$ juju show-operation 3
+id: "3"
+summary: backup on 3 units
+status: running
+enqueued: 2016-10-18 08:13:00 +0000 UTC
+counts:
+  completed: 2
+  running: 1
+actions:
+- id: <ID>
+  status: completed
+  unit: mysql/0
+...
+
+See also:
+    list-operations
+    show-action-output
+`
+
+// Set up the output.
+func (c *showOperationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+func (c *showOperationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-operation",
+		Args:    "<operation ID>",
+		Purpose: "Show the progress of an operation and its actions.",
+		Doc:     showOperationDoc,
+	}
+}
+
+func (c *showOperationCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no operation ID specified")
+	case 1:
+		c.operationId = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+func (c *showOperationCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Operations(params.OperationIds{Ids: []string{c.operationId}})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected one result got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return c.out.Write(ctx, operationToMap(result))
+}
+
+func NewListOperationsCommand() cmd.Command {
+	return modelcmd.Wrap(&listOperationsCommand{})
+}
+
+// listOperationsCommand lists the operations in the model.
+type listOperationsCommand struct {
+	ActionCommandBase
+	out    cmd.Output
+	offset int
+	limit  int
+}
+
+const listOperationsDoc = `
+List the operations in the model, oldest first, with the number of their
+Actions with each status. To see the Actions themselves, use
+'juju show-operation <ID>'.
+
+Only a page of operations is listed at a time; use --offset to skip the
+operations already seen, and --limit to change the size of the page.
+
+Examples:
+    juju list-operations
+    juju list-operations --offset 50 --limit 100
+
+See also:
+    show-operation
+`
+
+// Set up the output.
+func (c *listOperationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printOperationsTabular,
+	})
+	f.IntVar(&c.offset, "offset", 0, "Number of operations to skip")
+	f.IntVar(&c.limit, "limit", 0, "Maximum number of operations to list")
+}
+
+func (c *listOperationsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-operations",
+		Purpose: "List the operations in the model.",
+		Doc:     listOperationsDoc,
+		Aliases: []string{"operations"},
+	}
+}
+
+func (c *listOperationsCommand) Init(args []string) error {
+	if c.offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	if c.limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listOperationsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListOperations(params.ListOperationsArgs{
+		Offset: c.offset,
+		Limit:  c.limit,
+	})
+	if err != nil {
+		return err
+	}
+	operations := make([]operationOutput, len(results.Results))
+	for i, result := range results.Results {
+		operations[i] = newOperationOutput(result)
+	}
+	if err := c.out.Write(ctx, operations); err != nil {
+		return err
+	}
+	if results.Truncated {
+		ctx.Infof("more operations exist; use --offset %d to list them", c.offset+len(operations))
+	}
+	return nil
+}
+
+// operationOutput holds an operation, without its actions, as listed.
+type operationOutput struct {
+	Id       string         `yaml:"id" json:"id"`
+	Summary  string         `yaml:"summary" json:"summary"`
+	Status   string         `yaml:"status" json:"status"`
+	Enqueued time.Time      `yaml:"enqueued" json:"enqueued"`
+	Counts   map[string]int `yaml:"counts" json:"counts"`
+}
+
+func newOperationOutput(result params.OperationResult) operationOutput {
+	return operationOutput{
+		Id:       result.Id,
+		Summary:  result.Summary,
+		Status:   result.Status,
+		Enqueued: result.Enqueued,
+		Counts:   result.Counts,
+	}
+}
+
+// operationToMap returns the operation, with its actions, ready to be
+// served to the formatter for printing.
+func operationToMap(result params.OperationResult) map[string]interface{} {
+	actions := []map[string]interface{}{}
+	for _, action := range result.Actions {
+		actions = append(actions, resultToMap(action))
+	}
+	return map[string]interface{}{
+		"id":       result.Id,
+		"summary":  result.Summary,
+		"status":   result.Status,
+		"enqueued": result.Enqueued,
+		"counts":   result.Counts,
+		"actions":  actions,
+	}
+}
+
+// printOperationsTabular prints the list of operations in tabular
+// format.
+func printOperationsTabular(writer io.Writer, value interface{}) error {
+	operations, ok := value.([]operationOutput)
+	if !ok {
+		return errors.New("unexpected value")
+	}
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", "Id", "Status", "Enqueued", "Actions", "Summary")
+	for _, op := range operations {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			op.Id,
+			op.Status,
+			op.Enqueued.Format("2006-01-02 15:04:05"),
+			formatCounts(op.Counts),
+			op.Summary,
+		)
+	}
+	tw.Flush()
+	return nil
+}
+
+// formatCounts returns the counts of actions with each status, such
+// as "completed:2 running:1".
+func formatCounts(counts map[string]int) string {
+	var parts []string
+	for status, count := range counts {
+		parts = append(parts, fmt.Sprintf("%s:%d", status, count))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type OperationSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+var testOperation = params.OperationResult{
+	Id:       "3",
+	Summary:  "backup on 2 units",
+	Enqueued: time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC),
+	Status:   params.ActionRunning,
+	Counts:   map[string]int{params.ActionCompleted: 1, params.ActionRunning: 1},
+	Actions: []params.ActionResult{{
+		Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"},
+		Status: params.ActionCompleted,
+	}},
+}
+
+func (s *OperationSuite) TestShowOperationInit(c *gc.C) {
+	_, err := testing.RunCommand(c, action.NewShowOperationCommandForTest(s.store), "-m", "admin")
+	c.Check(err, gc.ErrorMatches, "no operation ID specified")
+	_, err = testing.RunCommand(c, action.NewShowOperationCommandForTest(s.store), "-m", "admin", "3", "4")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["4"\]`)
+}
+
+func (s *OperationSuite) TestShowOperation(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{
+		operationResults: []params.OperationResult{testOperation},
+	})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewShowOperationCommandForTest(s.store), "-m", "admin", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+actions:
+- id: f47ac10b-58cc-4372-a567-0e02b2c3d479
+  status: completed
+  unit: mysql/0
+counts:
+  completed: 1
+  running: 1
+enqueued: 2016-10-18 08:13:00 +0000 UTC
+id: "3"
+status: running
+summary: backup on 2 units
+`[1:])
+}
+
+func (s *OperationSuite) TestShowOperationError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{
+		operationResults: []params.OperationResult{{
+			Error: &params.Error{Message: `operation "42" not found`},
+		}},
+	})
+	defer restore()
+
+	_, err := testing.RunCommand(c, action.NewShowOperationCommandForTest(s.store), "-m", "admin", "42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *OperationSuite) TestListOperationsTabular(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{
+		operationResults: []params.OperationResult{testOperation},
+	})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListOperationsCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+Id  Status   Enqueued             Actions                Summary
+3   running  2016-10-18 08:13:00  completed:1 running:1  backup on 2 units
+`[1:])
+}
+
+func (s *OperationSuite) TestListOperationsYAML(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{
+		operationResults: []params.OperationResult{testOperation},
+	})
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListOperationsCommandForTest(s.store), "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+- id: "3"
+  summary: backup on 2 units
+  status: running
+  enqueued: 2016-10-18 08:13:00 +0000 UTC
+  counts:
+    completed: 1
+    running: 1
+`[1:])
+}
+
+func (s *OperationSuite) TestListOperationsAPIError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{
+		apiErr: errors.New("boom"),
+	})
+	defer restore()
+
+	_, err := testing.RunCommand(c, action.NewListOperationsCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *OperationSuite) TestListOperationsPage(c *gc.C) {
+	client := &fakeAPIClient{
+		operationResults: []params.OperationResult{testOperation},
+		operationsTrunc:  true,
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	ctx, err := testing.RunCommand(c, action.NewListOperationsCommandForTest(s.store), "-m", "admin", "--offset", "2", "--limit", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.listOperationsArgs, jc.DeepEquals, params.ListOperationsArgs{Offset: 2, Limit: 1})
+	c.Check(testing.Stderr(ctx), gc.Equals, "more operations exist; use --offset 3 to list them\n")
+}
+
+func (s *OperationSuite) TestListOperationsNegativeLimit(c *gc.C) {
+	_, err := testing.RunCommand(c, action.NewListOperationsCommandForTest(s.store), "-m", "admin", "--limit", "-1")
+	c.Assert(err, gc.ErrorMatches, "limit must not be negative")
+}
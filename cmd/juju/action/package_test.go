@@ -127,6 +127,9 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	operationResults   []params.OperationResult
+	operationsTrunc    bool
+	listOperationsArgs params.ListOperationsArgs
 	apiErr             error
 }
 
@@ -176,6 +179,15 @@ func (c *fakeAPIClient) ApplicationCharmActions(params.Entity) (map[string]param
 }
 
 func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results, err := c.ActionsWithLog(args)
+	for i := range results.Results {
+		// Only ActionsWithLog returns the progress messages.
+		results.Results[i].Log = nil
+	}
+	return results, err
+}
+
+func (c *fakeAPIClient) ActionsWithLog(args params.Entities) (params.ActionResults, error) {
 	// If the test supplies a delay time too long, we'll return an error
 	// to prevent the test hanging.  If the given wait is up, then return
 	// the results; otherwise, return a pending status.
@@ -186,7 +198,9 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 	select {
 	case _ = <-c.delay.C:
 		// The API delay timer is up.  Pass pre-canned results back.
-		return params.ActionResults{Results: c.actionResults}, c.apiErr
+		results := make([]params.ActionResult, len(c.actionResults))
+		copy(results, c.actionResults)
+		return params.ActionResults{Results: results}, c.apiErr
 	case _ = <-c.timeout.C:
 		// Timeout to prevent tests from hanging.
 		return params.ActionResults{}, errors.New("test timed out before wait time")
@@ -209,3 +223,15 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) Operations(args params.OperationIds) (params.OperationResults, error) {
+	return params.OperationResults{Results: c.operationResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListOperations(args params.ListOperationsArgs) (params.OperationResults, error) {
+	c.listOperationsArgs = args
+	return params.OperationResults{
+		Results:   c.operationResults,
+		Truncated: c.operationsTrunc,
+	}, c.apiErr
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// Rolling holds the options for running on many targets in batches,
+// rather than on all of them at once, so that a change can be rolled
+// out without taking every target down together.
+type Rolling struct {
+	// BatchSize is the number of targets to run on at once; zero
+	// means all of them.
+	BatchSize int
+
+	// BatchWait is how long to wait after each batch finishes before
+	// starting the next.
+	BatchWait time.Duration
+
+	// MaxFailures is the number of targets that may fail before no
+	// more batches are started.
+	MaxFailures int
+}
+
+// SetFlags adds the rolling flags to the flag set.
+func (r *Rolling) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&r.BatchSize, "batch-size", 0, "Run on this many targets at a time, waiting for each batch to finish before starting the next")
+	f.DurationVar(&r.BatchWait, "batch-wait", 0, "How long to wait between batches")
+	f.IntVar(&r.MaxFailures, "max-failures", 0, "Start no more batches once more than this many targets have failed")
+}
+
+// Validate checks that the rolling options make sense.
+func (r *Rolling) Validate() error {
+	if r.BatchSize < 0 {
+		return errors.Errorf("invalid batch size %d", r.BatchSize)
+	}
+	if r.BatchWait < 0 {
+		return errors.Errorf("invalid batch wait %v", r.BatchWait)
+	}
+	if r.MaxFailures < 0 {
+		return errors.Errorf("invalid max failures %d", r.MaxFailures)
+	}
+	if r.BatchSize == 0 && (r.BatchWait != 0 || r.MaxFailures != 0) {
+		return errors.New("--batch-wait and --max-failures require --batch-size")
+	}
+	return nil
+}
+
+// Enabled reports whether targets should be run on in batches.
+func (r *Rolling) Enabled() bool {
+	return r.BatchSize > 0
+}
+
+// Run calls runBatch with successive batches of the targets, waiting
+// for BatchWait between them, and returns an error if more than
+// MaxFailures targets fail. No more batches are started once that
+// many have failed. runBatch
+// must wait for the batch to finish, and return the number of targets
+// that failed; after returns a channel that sends after the given
+// duration.
+func (r *Rolling) Run(targets []string, runBatch func([]string) (int, error), after func(time.Duration) <-chan time.Time) error {
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(targets)
+	}
+	failed := 0
+	for start := 0; start < len(targets); start += batchSize {
+		if start > 0 && r.BatchWait > 0 {
+			<-after(r.BatchWait)
+		}
+		end := start + batchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batchFailed, err := runBatch(targets[start:end])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		failed += batchFailed
+		if failed <= r.MaxFailures {
+			continue
+		}
+		if end < len(targets) {
+			return errors.Errorf(
+				"%d of %d targets failed, more than the %d allowed; not running on the remaining %d",
+				failed, end, r.MaxFailures, len(targets)-end,
+			)
+		}
+		return errors.Errorf(
+			"%d of %d targets failed, more than the %d allowed",
+			failed, end, r.MaxFailures,
+		)
+	}
+	return nil
+}
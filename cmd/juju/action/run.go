@@ -6,6 +6,7 @@ package action
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -25,22 +26,33 @@ func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
 
-// runCommand enqueues an Action for running on the given unit with given
+// runCommand enqueues an Action for running on the given units with given
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTag      names.UnitTag
+	unitTags     []names.UnitTag
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
+	rolling      Rolling
 	out          cmd.Output
 	args         [][]string
 }
 
 const runDoc = `
-Queue an Action for execution on a given unit, with a given set of params.
-The Action ID is returned for use with 'juju show-action-output <ID>' or
-'juju show-action-status <ID>'.
+Queue an Action for execution on the given units, with a given set of
+params. The Action ID is returned for use with 'juju show-action-output <ID>'
+or 'juju show-action-status <ID>'.
+
+When more than one unit is given, an Action is queued on each of them, and
+they are grouped together in an operation. The operation ID is returned, with
+the Action ID for each unit; the progress of the operation as a whole can be
+followed with 'juju show-operation <ID>'.
+
+With --batch-size, the Actions are queued on that many units at a time, each
+batch waiting for the last to finish, and for --batch-wait after it. If more
+than --max-failures of the Actions fail, no more batches are queued.
  
 Params are validated according to the charm for the unit's application.  The 
 valid params can be seen using "juju actions <application> --schema".
@@ -104,6 +116,24 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/0 mysql/1 mysql/2 backup
+Operation queued with id: <ID>
+Actions queued:
+  mysql/0: <ID>
+  mysql/1: <ID>
+  mysql/2: <ID>
+
+$ juju run-action mysql/0 mysql/1 mysql/2 restart --batch-size 1 --batch-wait 1m
+...
+The Action is run on one unit at a time, a minute apart, and stopped at the
+first failure.
+
+$ juju run-action mysql/3 backup --timeout 30m
+...
+The action will be stopped, and marked failed, if it is still running
+after 30 minutes. Without --timeout, the action may run for as long as
+it needs, unless the charm declares a timeout for it in actions.yaml.
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -115,42 +145,61 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "Stop the action and mark it failed if it runs for longer than this")
+	c.rolling.SetFlags(f)
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tags, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.Errorf("invalid timeout %v", c.timeout)
+	}
+	if err := c.rolling.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the unit names; the first argument is
+		// always a unit, and any following it that contain a slash,
+		// which an action name cannot, are units too.
+		c.unitTags = nil
+		for i, unitName := range args {
+			if i > 0 && !strings.Contains(unitName, "/") {
+				break
+			}
+			if !names.IsValidUnit(unitName) {
+				return errors.Errorf("invalid unit name %q", unitName)
+			}
+			c.unitTags = append(c.unitTags, names.NewUnitTag(unitName))
+		}
+		args = args[len(c.unitTags):]
+		if len(args) == 0 {
+			return errors.New("no action specified")
 		}
-		ActionName := args[1]
+		ActionName := args[0]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return errors.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
-		if len(args) == 2 {
+		if len(args) == 1 {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
 		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
+		for _, arg := range args[1:] {
 			thisArg := strings.SplitN(arg, "=", 2)
 			if len(thisArg) != 2 {
 				return errors.Errorf("argument %q must be of the form key...=value", arg)
@@ -229,37 +278,152 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
-	actionParam := params.Actions{
-		Actions: []params.Action{{
-			Receiver:   c.unitTag.String(),
+	actionParam := params.Actions{}
+	for _, unitTag := range c.unitTags {
+		actionParam.Actions = append(actionParam.Actions, params.Action{
+			Receiver:   unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
-		}},
+			Timeout:    c.timeout,
+		})
+	}
+
+	if c.rolling.Enabled() {
+		return c.runRolling(ctx, api, actionParam.Actions)
 	}
 
 	results, err := api.Enqueue(actionParam)
 	if err != nil {
 		return err
 	}
-	if len(results.Results) != 1 {
+	if len(results.Results) != len(c.unitTags) {
 		return errors.New("illegal number of results returned")
 	}
 
-	result := results.Results[0]
+	if len(c.unitTags) == 1 {
+		id, err := queuedActionId(results.Results[0])
+		if err != nil {
+			return err
+		}
+		output := map[string]string{"Action queued with id": id}
+		return c.out.Write(ctx, output)
+	}
 
-	if result.Error != nil {
-		return result.Error
+	queued := queuedActions{ids: make(map[string]string)}
+	_, failed := queued.add(actionParam.Actions, results.Results)
+	if err := c.out.Write(ctx, queued.output()); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d actions could not be queued", failed, len(c.unitTags))
 	}
+	return nil
+}
 
-	if result.Action == nil {
-		return errors.New("action failed to enqueue")
+// runRolling queues the actions in batches, as the rolling options
+// specify, waiting for each batch to finish before queueing the next.
+// All the batches are added to the operation created for the first.
+func (c *runCommand) runRolling(ctx *cmd.Context, api APIClient, actions []params.Action) error {
+	queued := queuedActions{ids: make(map[string]string)}
+	byUnit := make(map[string]params.Action)
+	var unitNames []string
+	for i, action := range actions {
+		unitName := c.unitTags[i].Id()
+		byUnit[unitName] = action
+		unitNames = append(unitNames, unitName)
+	}
+	runBatch := func(batch []string) (int, error) {
+		args := params.Actions{Operation: queued.operationId}
+		for _, unitName := range batch {
+			args.Actions = append(args.Actions, byUnit[unitName])
+		}
+		ctx.Infof("queueing %s on %s", c.actionName, strings.Join(batch, ", "))
+		results, err := api.Enqueue(args)
+		if err != nil {
+			return 0, err
+		}
+		if len(results.Results) != len(batch) {
+			return 0, errors.New("illegal number of results returned")
+		}
+		tags, failed := queued.add(args.Actions, results.Results)
+		finished, err := WaitForActions(api, tags, time.After)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		for _, result := range finished {
+			if result.Error != nil {
+				failed++
+				continue
+			}
+			switch result.Status {
+			case params.ActionFailed, params.ActionAborted, params.ActionCancelled:
+				failed++
+			}
+		}
+		return failed, nil
+	}
+	rollingErr := c.rolling.Run(unitNames, runBatch, time.After)
+	if err := c.out.Write(ctx, queued.output()); err != nil {
+		return err
+	}
+	return rollingErr
+}
+
+// queuedActions records the actions queued on each of many units, and
+// the operation grouping them.
+type queuedActions struct {
+	operationId string
+	ids         map[string]string
+}
+
+// add records the results of queueing the actions; a unit whose action
+// could not be queued is recorded with the error instead. It returns
+// the tags of the actions queued, and the number that could not be.
+func (q *queuedActions) add(actions []params.Action, results []params.ActionResult) ([]params.Entity, int) {
+	var tags []params.Entity
+	failed := 0
+	for i, result := range results {
+		unitName := actions[i].Receiver
+		if tag, err := names.ParseUnitTag(unitName); err == nil {
+			unitName = tag.Id()
+		}
+		id, err := queuedActionId(result)
+		if err != nil {
+			q.ids[unitName] = "error: " + err.Error()
+			failed++
+			continue
+		}
+		q.ids[unitName] = id
+		if q.operationId == "" {
+			q.operationId = result.Operation
+		}
+		tags = append(tags, params.Entity{Tag: result.Action.Tag})
 	}
+	return tags, failed
+}
+
+// output returns the queued actions ready to be served to the
+// formatter for printing.
+func (q *queuedActions) output() map[string]interface{} {
+	output := map[string]interface{}{"Actions queued": q.ids}
+	if q.operationId != "" {
+		output["Operation queued with id"] = q.operationId
+	}
+	return output
+}
 
+// queuedActionId returns the id of the action enqueued with the given
+// result.
+func queuedActionId(result params.ActionResult) (string, error) {
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.Action == nil {
+		return "", errors.New("action failed to enqueue")
+	}
 	tag, err := names.ParseActionTag(result.Action.Tag)
 	if err != nil {
-		return err
+		return "", err
 	}
-
-	output := map[string]string{"Action queued with id": tag.Id()}
-	return c.out.Write(ctx, output)
+	return tag.Id(), nil
 }
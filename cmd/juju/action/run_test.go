@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	jc "github.com/juju/testing/checkers"
@@ -59,7 +60,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	tests := []struct {
 		should               string
 		args                 []string
-		expectUnit           names.UnitTag
+		expectUnits          []names.UnitTag
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
@@ -78,10 +79,30 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
 		expectError: "invalid unit name \"something-strange-\"",
+	}, {
+		should:      "fail with invalid second unit tag",
+		args:        []string{validUnitId, "mysql/x", "valid-action-name"},
+		expectError: "invalid unit name \"mysql/x\"",
+	}, {
+		should:      "fail with units but no action specified",
+		args:        []string{validUnitId, "mysql/1"},
+		expectError: "no action specified",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
 		expectError: "invalid action name \"BadName\"",
+	}, {
+		should:      "fail with negative timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout", "-1m"},
+		expectError: "invalid timeout -1m0s",
+	}, {
+		should:      "fail with negative batch size",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "-1"},
+		expectError: "invalid batch size -1",
+	}, {
+		should:      "fail with max failures but no batch size",
+		args:        []string{validUnitId, "valid-action-name", "--max-failures", "2"},
+		expectError: "--batch-wait and --max-failures require --batch-size",
 	}, {
 		should:      "fail with wrong formatting of k-v args",
 		args:        []string{validUnitId, "valid-action-name", "uh"},
@@ -97,31 +118,37 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:       "work with empty values",
 		args:         []string{validUnitId, "valid-action-name", "ok="},
-		expectUnit:   names.NewUnitTag(validUnitId),
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{{"ok", ""}},
 	}, {
 		should:             "handle --parse-strings",
 		args:               []string{validUnitId, "valid-action-name", "--string-args"},
-		expectUnit:         names.NewUnitTag(validUnitId),
+		expectUnits:        []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
 		should:       "work with multiple '=' signs",
 		args:         []string{validUnitId, "valid-action-name", "ok=this=is=weird="},
-		expectUnit:   names.NewUnitTag(validUnitId),
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{{"ok", "this=is=weird="}},
 	}, {
 		should:       "init properly with no params",
 		args:         []string{validUnitId, "valid-action-name"},
-		expectUnit:   names.NewUnitTag(validUnitId),
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction: "valid-action-name",
+	}, {
+		should:       "init properly with multiple units",
+		args:         []string{validUnitId, "mysql/1", "mysql/2", "valid-action-name", "foo=bar"},
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId), names.NewUnitTag("mysql/1"), names.NewUnitTag("mysql/2")},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{{"foo", "bar"}},
 	}, {
 		should:               "handle --params properly",
 		args:                 []string{validUnitId, "valid-action-name", "--params=foo.yml"},
-		expectUnit:           names.NewUnitTag(validUnitId),
+		expectUnits:          []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction:         "valid-action-name",
 		expectParamsYamlPath: "foo.yml",
 	}, {
@@ -134,7 +161,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			"foo.baz.bo=3",
 			"bar.foo=hello",
 		},
-		expectUnit:           names.NewUnitTag(validUnitId),
+		expectUnits:          []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction:         "valid-action-name",
 		expectParamsYamlPath: "foo.yml",
 		expectKVArgs: [][]string{
@@ -151,7 +178,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			"foo.baz.bo=y",
 			"bar.foo=hello",
 		},
-		expectUnit:   names.NewUnitTag(validUnitId),
+		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId)},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{
 			{"foo", "bar", "2"},
@@ -168,7 +195,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			args := append([]string{modelFlag, "admin"}, t.args...)
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
-				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.UnitTags(), jc.DeepEquals, t.expectUnits)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
@@ -298,6 +325,18 @@ func (s *RunSuite) TestRun(c *gc.C) {
 				},
 			},
 		},
+	}, {
+		should:   "enqueue an action with a timeout",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "5m"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Parameters: map[string]interface{}{},
+			Timeout:    5 * time.Minute,
+		},
 	}, {
 		should: "enqueue an action with file params plus CLI args",
 		withArgs: []string{validUnitId, "some-action",
@@ -399,3 +438,130 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunMultipleUnits(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action:    &params.Action{Tag: validActionTagString},
+			Operation: "1",
+		}, {
+			Error: &params.Error{Message: "unit mysql/1 not found"},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", validUnitId, "mysql/1", "some-action")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 actions could not be queued")
+
+	enqueued := fakeClient.EnqueuedActions()
+	c.Assert(enqueued.Actions, gc.HasLen, 2)
+	c.Check(enqueued.Actions[0].Receiver, gc.Equals, names.NewUnitTag(validUnitId).String())
+	c.Check(enqueued.Actions[1].Receiver, gc.Equals, "unit-mysql-1")
+	c.Check(enqueued.Actions[1].Name, gc.Equals, "some-action")
+
+	var output map[string]interface{}
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, gc.IsNil)
+	c.Check(output, jc.DeepEquals, map[string]interface{}{
+		"Operation queued with id": "1",
+		"Actions queued": map[interface{}]interface{}{
+			validUnitId: validActionId,
+			"mysql/1":   "error: unit mysql/1 not found",
+		},
+	})
+}
+
+// rollingAPIClient enqueues an action with a known id on each unit, and
+// reports each as finished with the status given for its unit.
+type rollingAPIClient struct {
+	*fakeAPIClient
+	statuses map[string]string
+	enqueued []params.Actions
+}
+
+func rollingActionTag(unitName string) string {
+	return names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d47" + unitName[len(unitName)-1:]).String()
+}
+
+func (c *rollingAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	c.enqueued = append(c.enqueued, args)
+	var results params.ActionResults
+	for _, a := range args.Actions {
+		unitTag, err := names.ParseUnitTag(a.Receiver)
+		if err != nil {
+			return params.ActionResults{}, err
+		}
+		results.Results = append(results.Results, params.ActionResult{
+			Action:    &params.Action{Tag: rollingActionTag(unitTag.Id()), Receiver: a.Receiver},
+			Operation: "7",
+		})
+	}
+	return results, nil
+}
+
+func (c *rollingAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	var results params.ActionResults
+	for _, entity := range args.Entities {
+		status := params.ActionCompleted
+		for unitName, unitStatus := range c.statuses {
+			if rollingActionTag(unitName) == entity.Tag {
+				status = unitStatus
+			}
+		}
+		results.Results = append(results.Results, params.ActionResult{Status: status})
+	}
+	return results, nil
+}
+
+func (s *RunSuite) TestRunRolling(c *gc.C) {
+	s.PatchValue(action.WatchInterval, time.Duration(0))
+	fakeClient := &rollingAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		statuses:      map[string]string{"mysql/1": params.ActionFailed},
+	}
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return fakeClient, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, "-m", "admin",
+		"mysql/0", "mysql/1", "mysql/2", "restart", "--batch-size", "1")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 targets failed, more than the 0 allowed; not running on the remaining 1")
+
+	c.Assert(fakeClient.enqueued, gc.HasLen, 2)
+	c.Check(fakeClient.enqueued[0].Operation, gc.Equals, "")
+	c.Check(fakeClient.enqueued[0].Actions, gc.HasLen, 1)
+	c.Check(fakeClient.enqueued[0].Actions[0].Receiver, gc.Equals, "unit-mysql-0")
+	c.Check(fakeClient.enqueued[1].Operation, gc.Equals, "7")
+	c.Check(fakeClient.enqueued[1].Actions[0].Receiver, gc.Equals, "unit-mysql-1")
+
+	var output map[string]interface{}
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, gc.IsNil)
+	c.Check(output, jc.DeepEquals, map[string]interface{}{
+		"Operation queued with id": "7",
+		"Actions queued": map[interface{}]interface{}{
+			"mysql/0": "f47ac10b-58cc-4372-a567-0e02b2c3d470",
+			"mysql/1": "f47ac10b-58cc-4372-a567-0e02b2c3d471",
+		},
+	})
+}
+
+func (s *RunSuite) TestRunRollingFailsInLastBatch(c *gc.C) {
+	s.PatchValue(action.WatchInterval, time.Duration(0))
+	fakeClient := &rollingAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		statuses:      map[string]string{"mysql/1": params.ActionFailed},
+	}
+	s.PatchValue(action.NewActionAPIClient, func(*action.ActionCommandBase) (action.APIClient, error) {
+		return fakeClient, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "-m", "admin",
+		"mysql/0", "mysql/1", "restart", "--batch-size", "1")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 targets failed, more than the 0 allowed")
+	c.Assert(fakeClient.enqueued, gc.HasLen, 2)
+}
@@ -4,6 +4,7 @@
 package action
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,8 +28,13 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
+// watchInterval is how often a watched action is checked for new
+// progress messages.
+var watchInterval = 2 * time.Second
+
 const showOutputDoc = `
 Show the results returned by an action with the given ID.  A partial ID may
 also be used.  To block until the result is known completed or failed, use
@@ -38,6 +44,16 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress of a running action, use the --watch flag.  The
+progress messages logged by the action with action-log are written to stderr
+as they arrive, and the results are shown once the action has finished.  With
+--watch, the wait is indefinite unless a positive --wait is also given.
+
+Examples:
+    juju show-action-output 7fa0e6ce
+    juju show-action-output --wait 1m 7fa0e6ce
+    juju show-action-output --watch 7fa0e6ce
 `
 
 // Set up the output.
@@ -45,6 +61,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages until the action finishes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -93,9 +110,9 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
-	case waitDur.Nanoseconds() < 0:
+	case waitDur.Nanoseconds() < 0 && !c.watch:
 		// Negative duration signals immediate return.  All is well.
-	case waitDur.Nanoseconds() == 0:
+	case waitDur.Nanoseconds() <= 0:
 		// Zero duration signals indefinite wait.  Discard the tick.
 		wait = time.NewTimer(0 * time.Second)
 		_ = <-wait.C
@@ -104,7 +121,13 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	var result params.ActionResult
+	if c.watch {
+		result, err = watchActionResult(ctx, api, c.requestedId, wait)
+	} else {
+		tick := time.NewTimer(2 * time.Second)
+		result, err = timerLoop(api, c.requestedId, wait, tick, true)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -112,6 +135,53 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// watchActionResult repeatedly fetches an action until it is in a
+// completed state, writing each new progress message to stderr, and
+// then returns it. It waits for a maximum of "wait" before returning
+// with the latest action status.
+func watchActionResult(ctx *cmd.Context, api APIClient, requestedId string, wait *time.Timer) (params.ActionResult, error) {
+	var last *params.ActionMessage
+	for {
+		result, err := fetchResult(api, requestedId, true)
+		if err != nil {
+			return result, err
+		}
+		for _, message := range newMessages(result.Log, last) {
+			fmt.Fprintf(ctx.Stderr, "%s %s\n", message.Timestamp, message.Message)
+		}
+		if n := len(result.Log); n > 0 {
+			last = &result.Log[n-1]
+		}
+
+		switch result.Status {
+		case params.ActionRunning, params.ActionAborting, params.ActionPending:
+		default:
+			return result, nil
+		}
+
+		select {
+		case <-wait.C:
+			return result, nil
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// newMessages returns the messages in log that follow last. Messages
+// are only ever appended to the log, but the oldest are discarded once
+// there are many, so last is looked for rather than counted.
+func newMessages(log []params.ActionMessage, last *params.ActionMessage) []params.ActionMessage {
+	if last == nil {
+		return log
+	}
+	for i := len(log) - 1; i >= 0; i-- {
+		if log[i].Timestamp.Equal(last.Timestamp) && log[i].Message == last.Message {
+			return log[i+1:]
+		}
+	}
+	return log
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -121,13 +191,57 @@ func GetActionResult(api APIClient, requestedId string, wait *time.Timer) (param
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	tick := time.NewTimer(2 * time.Second)
 
-	return timerLoop(api, requestedId, wait, tick)
+	return timerLoop(api, requestedId, wait, tick, false)
+}
+
+// WaitForActions polls the actions with the given tags every
+// watchInterval until they have all finished, and returns their
+// results in the same order as the tags. An action whose result is an
+// error counts as finished. after returns a channel that sends after
+// the given duration.
+func WaitForActions(api APIClient, tags []params.Entity, after func(time.Duration) <-chan time.Time) ([]params.ActionResult, error) {
+	results := make([]params.ActionResult, len(tags))
+	unfinished := make([]int, len(tags))
+	for i := range tags {
+		unfinished[i] = i
+	}
+	for len(unfinished) > 0 {
+		args := params.Entities{Entities: make([]params.Entity, len(unfinished))}
+		for i, index := range unfinished {
+			args.Entities[i] = tags[index]
+		}
+		actions, err := api.Actions(args)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(actions.Results) != len(unfinished) {
+			return nil, errors.New("illegal number of results returned")
+		}
+		var stillUnfinished []int
+		for i, result := range actions.Results {
+			index := unfinished[i]
+			results[index] = result
+			if result.Error != nil {
+				continue
+			}
+			switch result.Status {
+			case params.ActionPending, params.ActionRunning, params.ActionAborting:
+				stillUnfinished = append(stillUnfinished, index)
+			}
+		}
+		unfinished = stillUnfinished
+		if len(unfinished) > 0 {
+			<-after(watchInterval)
+		}
+	}
+	return results, nil
 }
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
 // out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// result to the given output. The action's log is only fetched if
+// withLog is true.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, withLog bool) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
@@ -136,7 +250,7 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 	// Loop over results until we get "failed" or "completed".  Wait for
 	// timer, and reset it each time.
 	for {
-		result, err = fetchResult(api, requestedId)
+		result, err = fetchResult(api, requestedId, withLog)
 		if err != nil {
 			return result, err
 		}
@@ -144,7 +258,7 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
 		switch result.Status {
-		case params.ActionRunning, params.ActionPending:
+		case params.ActionRunning, params.ActionAborting, params.ActionPending:
 		default:
 			return result, nil
 		}
@@ -162,7 +276,9 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 
 // fetchResult queries the given API for the given Action ID prefix, and
 // makes sure the results are acceptable, returning an error if they are not.
-func fetchResult(api APIClient, requestedId string) (params.ActionResult, error) {
+// If withLog is true the action's progress messages are fetched as well,
+// where the controller supports it.
+func fetchResult(api APIClient, requestedId string, withLog bool) (params.ActionResult, error) {
 	none := params.ActionResult{}
 
 	actionTag, err := getActionTagByPrefix(api, requestedId)
@@ -170,9 +286,16 @@ func fetchResult(api APIClient, requestedId string) (params.ActionResult, error)
 		return none, err
 	}
 
-	actions, err := api.Actions(params.Entities{
+	args := params.Entities{
 		Entities: []params.Entity{{actionTag.String()}},
-	})
+	}
+	var actions params.ActionResults
+	if withLog {
+		actions, err = api.ActionsWithLog(args)
+	}
+	if !withLog || errors.IsNotImplemented(err) {
+		actions, err = api.Actions(args)
+	}
 	if err != nil {
 		return none, err
 	}
@@ -204,6 +327,16 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]map[string]string, len(result.Log))
+		for i, message := range result.Log {
+			log[i] = map[string]string{
+				"timestamp": message.Timestamp.String(),
+				"message":   message.Message,
+			}
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
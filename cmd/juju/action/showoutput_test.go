@@ -290,6 +290,86 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunWithLog(c *gc.C) {
+	client := makeFakeClient(0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "halfway there",
+			}},
+			Started: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		params.ActionsByNames{}, "")
+	testRunHelper(c, s, client, "", `
+log:
+- message: halfway there
+  timestamp: 2015-02-14 08:15:10 +0000 UTC
+status: completed
+timing:
+  started: 2015-02-14 08:15:00 +0000 UTC
+`[1:], "", validActionId, "-m")
+}
+
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	s.PatchValue(action.WatchInterval, 10*time.Millisecond)
+	log := []params.ActionMessage{{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "copied 3 of 10 tables",
+	}, {
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "copied 10 of 10 tables",
+	}}
+	// The action is reported as pending until the delay is up.
+	client := makeFakeClient(50*time.Millisecond, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Output:    map[string]interface{}{"tables": 10},
+			Log:       log,
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{}, "")
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", "--watch", validActionId)
+	c.Assert(err, gc.IsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+2015-02-14 08:15:10 +0000 UTC copied 3 of 10 tables
+2015-02-14 08:15:20 +0000 UTC copied 10 of 10 tables
+`[1:])
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+log:
+- message: copied 3 of 10 tables
+  timestamp: 2015-02-14 08:15:10 +0000 UTC
+- message: copied 10 of 10 tables
+  timestamp: 2015-02-14 08:15:20 +0000 UTC
+results:
+  tables: 10
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestNewMessages(c *gc.C) {
+	t0 := time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC)
+	log := []params.ActionMessage{
+		{Timestamp: t0, Message: "one"},
+		{Timestamp: t0, Message: "two"},
+		{Timestamp: t0.Add(time.Second), Message: "three"},
+	}
+	c.Check(action.NewMessages(log, nil), gc.DeepEquals, log)
+	c.Check(action.NewMessages(log, &log[1]), gc.DeepEquals, log[2:])
+	c.Check(action.NewMessages(log, &log[2]), gc.HasLen, 0)
+	// If the last message seen has been discarded, all are new.
+	gone := params.ActionMessage{Timestamp: t0.Add(-time.Second), Message: "zero"}
+	c.Check(action.NewMessages(log, &gone), gc.DeepEquals, log)
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
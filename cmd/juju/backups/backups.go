@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 
 	"github.com/juju/cmd"
@@ -24,7 +25,10 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(notes, storage string) (*params.BackupsMetadataResult, error)
+	// CreateIncremental sends an RPC request to create a new backup
+	// holding the changes made since the parent backup.
+	CreateIncremental(notes, storage, parent string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -35,6 +39,11 @@ type APIClient interface {
 	Upload(ar io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
 	// Remove removes the stored backup.
 	Remove(id string) error
+	// Verify checks the stored backup without restoring it.
+	Verify(id string) (*params.BackupsVerifyResult, error)
+	// RotateEncryptionKey sets the key with which new backups are
+	// encrypted.
+	RotateEncryptionKey(key string) error
 	// Restore will restore a backup with the given id into the controller.
 	Restore(string, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
@@ -76,6 +85,14 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "checksum format: %q\n", result.ChecksumFormat)
 	fmt.Fprintf(ctx.Stdout, "size (B):        %d\n", result.Size)
 	fmt.Fprintf(ctx.Stdout, "stored:          %v\n", result.Stored)
+	fmt.Fprintf(ctx.Stdout, "storage:         %s\n", storageName(result.Storage))
+	if result.EncryptionScheme != "" {
+		fmt.Fprintf(ctx.Stdout, "encryption:      %s\n", result.EncryptionScheme)
+		fmt.Fprintf(ctx.Stdout, "key fingerprint: %s\n", result.EncryptionKeyFingerprint)
+	}
+	if result.Parent != "" {
+		fmt.Fprintf(ctx.Stdout, "parent backup:   %q\n", result.Parent)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
@@ -87,6 +104,15 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
 }
 
+// storageName returns the name of the storage in which a backup is
+// kept, as reported to the user.
+func storageName(storage string) string {
+	if storage == "" {
+		return statebackups.ControllerStorage
+	}
+	return storage
+}
+
 // ArchiveReader can read a backup archive.
 type ArchiveReader interface {
 	io.ReadSeeker
@@ -105,6 +131,16 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 		return nil, nil, errors.Trace(err)
 	}
 
+	// An encrypted archive's metadata cannot be read.
+	header := make([]byte, 16)
+	if n, _ := io.ReadFull(archive, header); statebackups.IsEncryptedArchive(header[:n]) {
+		return nil, nil, errors.Errorf("%s is an encrypted backup archive; its decryption key is needed to read it", filename)
+	}
+	_, err = archive.Seek(0, os.SEEK_SET)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -156,3 +192,47 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 
 	return archive, metaResult, nil
 }
+
+// readDecryptionKey reads the key with which encrypted backup archives
+// are decrypted from the named file.
+func readDecryptionKey(ctx *cmd.Context, filename string) (*statebackups.DecryptionKey, error) {
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading decryption key")
+	}
+	key, err := statebackups.ParseDecryptionKey(data)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading decryption key")
+	}
+	return key, nil
+}
+
+// decryptArchive decrypts the named backup archive into a temporary
+// file and returns the temporary file's name. The caller is
+// responsible for removing it.
+func decryptArchive(filename string, key *statebackups.DecryptionKey) (_ string, err error) {
+	in, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer in.Close()
+	decrypted, err := statebackups.NewDecryptingReader(in, key)
+	if err != nil {
+		return "", errors.Annotatef(err, "decrypting %s", filename)
+	}
+
+	out, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		out.Close()
+		if err != nil {
+			os.Remove(out.Name())
+		}
+	}()
+	if _, err := io.Copy(out, decrypted); err != nil {
+		return "", errors.Annotatef(err, "decrypting %s", filename)
+	}
+	return out.Name(), nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -28,6 +29,25 @@ create-backup requests that juju create a backup of its state and print the
 backup's unique ID.  You may provide a note to associate with the backup.
 
 The backup archive and associated metadata are stored remotely by juju.
+By default they are kept in the controller's database. If the controller
+has been configured with other backup storage, the --storage option may
+be used to keep the backup there instead:
+
+    dir  the directory named by the controller's backup-storage-dir
+         setting, which may be on a mounted network filesystem
+    s3   the S3-compatible bucket named by the controller's
+         backup-s3-bucket setting
+
+Backups kept in other storage are listed by "juju backups" alongside
+those kept in the controller, and survive the loss of the controller.
+
+With --incremental, the backup holds only the database changes made
+since the latest earlier backup, or since the backup named by --parent,
+which makes it much smaller than a full backup. Restoring it restores
+the full backup it builds on and then each incremental backup in turn,
+so all of them must still be available. An incremental backup cannot
+be made once the changes since its parent have left the database's
+operation log; create a full backup instead.
 
 The --download option may be used without the --filename option.  In
 that case, the backup archive will be stored in the current working
@@ -60,6 +80,13 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// Storage names the place in which the backup should be kept.
+	Storage string
+	// Incremental means the backup should hold only the changes made
+	// since an earlier backup.
+	Incremental bool
+	// Parent is the ID of the backup an incremental backup builds on.
+	Parent string
 }
 
 // Info implements Command.Info.
@@ -77,6 +104,9 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.Storage, "storage", "", "Keep the backup in this storage (controller, dir or s3)")
+	f.BoolVar(&c.Incremental, "incremental", false, "Back up only the changes made since an earlier backup")
+	f.StringVar(&c.Parent, "parent", "", "Build the incremental backup on the backup with this ID")
 }
 
 // Init implements Command.Init.
@@ -93,6 +123,14 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.Parent != "" && !c.Incremental {
+		return errors.Errorf("--parent can only be used with --incremental")
+	}
+	switch c.Storage {
+	case "", backups.ControllerStorage, backups.DirStorage, backups.S3Storage:
+	default:
+		return errors.Errorf("unknown backup storage %q", c.Storage)
+	}
 
 	return nil
 }
@@ -110,7 +148,12 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	var result *params.BackupsMetadataResult
+	if c.Incremental {
+		result, err = client.CreateIncremental(c.Notes, c.Storage, c.Parent)
+	} else {
+		result, err = client.Create(c.Notes, c.Storage)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
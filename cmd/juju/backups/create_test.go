@@ -105,6 +105,38 @@ func (s *createSuite) TestNotes(c *gc.C) {
 	client.Check(c, s.metaresult.ID, "spam", "Create", "Download")
 }
 
+func (s *createSuite) TestStorage(c *gc.C) {
+	client := s.BaseBackupsSuite.setDownload()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--storage", "s3", "--quiet")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "Create", "Download")
+	client.CheckStorage(c, "s3")
+}
+
+func (s *createSuite) TestUnknownStorage(c *gc.C) {
+	s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--storage", "nfs")
+
+	c.Check(err, gc.ErrorMatches, `unknown backup storage "nfs"`)
+}
+
+func (s *createSuite) TestIncremental(c *gc.C) {
+	client := s.BaseBackupsSuite.setDownload()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--incremental", "--parent", "parent-id", "--quiet")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "CreateIncremental", "Download")
+	c.Check(client.parent, gc.Equals, "parent-id")
+}
+
+func (s *createSuite) TestParentNotIncremental(c *gc.C) {
+	s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--parent", "parent-id")
+
+	c.Check(err, gc.ErrorMatches, "--parent can only be used with --incremental")
+}
+
 func (s *createSuite) TestFilename(c *gc.C) {
 	client := s.setDownload()
 	ctx, err := testing.RunCommand(c, s.wrappedCommand, "--filename", "backup.tgz", "--quiet")
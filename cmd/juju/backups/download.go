@@ -21,6 +21,14 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+If the backup is encrypted, it is downloaded as it is stored unless
+--decryption-key-file is used, in which case it is decrypted as it is
+downloaded. The key file holds either the base64-encoded key set as the
+controller's backup-encryption-key, or the PEM-encoded private key
+matching its backup-encryption-public-key. The key is checked against
+the fingerprint recorded when the backup was created before anything
+is downloaded.
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -35,6 +43,9 @@ type downloadCommand struct {
 	Filename string
 	// ID is the backup ID to download.
 	ID string
+	// DecryptionKeyFile is the file holding the key with which to
+	// decrypt the downloaded archive.
+	DecryptionKeyFile string
 }
 
 // Info implements Command.Info.
@@ -51,6 +62,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	f.StringVar(&c.DecryptionKeyFile, "decryption-key-file", "", "Decrypt the archive with the key in this file")
 }
 
 // Init implements Command.Init.
@@ -73,18 +85,51 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	var key *backups.DecryptionKey
+	if c.DecryptionKeyFile != "" {
+		var err error
+		if key, err = readDecryptionKey(ctx, c.DecryptionKeyFile); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
+	if key != nil {
+		// Check the key before downloading anything, so that the
+		// wrong key is reported as such.
+		meta, err := client.Info(c.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if meta.EncryptionScheme == "" {
+			return errors.Errorf("backup %s is not encrypted", c.ID)
+		}
+		err = key.CheckDecrypts(backups.EncryptionInfo{
+			Scheme:         meta.EncryptionScheme,
+			KeyFingerprint: meta.EncryptionKeyFingerprint,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot decrypt backup %s", c.ID)
+		}
+	}
+
 	// Download the archive.
 	resultArchive, err := client.Download(c.ID)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer resultArchive.Close()
+	var source io.Reader = resultArchive
+	if key != nil {
+		source, err = backups.NewDecryptingReader(resultArchive, key)
+		if err != nil {
+			return errors.Annotate(err, "decrypting backup archive")
+		}
+	}
 
 	// Prepare the local archive.
 	filename := c.ResolveFilename()
@@ -95,8 +140,10 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	defer archive.Close()
 
 	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
+	_, err = io.Copy(archive, source)
 	if err != nil {
+		archive.Close()
+		os.Remove(filename)
 		return errors.Annotate(err, "while creating local archive file")
 	}
 
@@ -4,12 +4,17 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -71,3 +76,45 @@ func (s *downloadSuite) TestError(c *gc.C) {
 	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *downloadSuite) TestDecrypt(c *gc.C) {
+	client := s.setSuccess()
+	encrypted, keyFile := s.encrypt(c, s.data)
+	client.archive = ioutil.NopCloser(bytes.NewReader(encrypted))
+	s.metaresult.EncryptionScheme = statebackups.EncryptionSymmetric
+	s.metaresult.EncryptionKeyFingerprint = s.keyFingerprint(c, keyFile)
+	ctx, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decryption-key-file", keyFile)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(client.calls, jc.DeepEquals, []string{"Info", "Download"})
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	s.checkStd(c, ctx, s.filename+"\n", "")
+	s.checkArchive(c)
+}
+
+func (s *downloadSuite) TestDecryptNotEncrypted(c *gc.C) {
+	client := s.setDownload()
+	_, keyFile := s.encrypt(c, s.data)
+	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decryption-key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, "backup "+s.metaresult.ID+" is not encrypted")
+	c.Check(client.calls, jc.DeepEquals, []string{"Info"})
+}
+
+func (s *downloadSuite) TestDecryptWrongKey(c *gc.C) {
+	client := s.setDownload()
+	_, keyFile := s.encrypt(c, s.data)
+	s.metaresult.EncryptionScheme = statebackups.EncryptionSymmetric
+	s.metaresult.EncryptionKeyFingerprint = "sha256:other"
+	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decryption-key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup "+s.metaresult.ID+": backup is encrypted with key sha256:other, not sha256:.*")
+	c.Check(client.calls, jc.DeepEquals, []string{"Info"})
+}
+
+func (s *downloadSuite) TestBadDecryptionKey(c *gc.C) {
+	s.setDownload()
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("not a key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decryption-key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, `reading decryption key: symmetric key \(.*\) not valid`)
+}
@@ -67,6 +67,18 @@ func NewRemoveCommandForTest() cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest() cmd.Command {
+	c := &verifyCommand{}
+	c.Log = &cmd.Log{}
+	return modelcmd.Wrap(c)
+}
+
+func NewRotateKeyCommandForTest() cmd.Command {
+	c := &rotateKeyCommand{}
+	c.Log = &cmd.Log{}
+	return modelcmd.Wrap(c)
+}
+
 func NewRestoreCommandForTest(
 	store jujuclient.ClientStore,
 	api RestoreAPI,
@@ -5,9 +5,11 @@ package backups_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/juju/cmd"
@@ -18,6 +20,7 @@ import (
 	apibackups "github.com/juju/juju/api/backups"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 	jujutesting "github.com/juju/juju/testing"
 )
 
@@ -29,6 +32,7 @@ checksum:        ""
 checksum format: ""
 size (B):        0
 stored:          0001-01-01 00:00:00 +0000 UTC
+storage:         controller
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
@@ -108,6 +112,34 @@ func (s *BaseBackupsSuite) checkArchive(c *gc.C) {
 	c.Check(string(data), gc.Equals, s.data)
 }
 
+// encrypt encrypts data as a backup archive, returning the encrypted
+// data and the name of a file holding the key to decrypt it.
+func (s *BaseBackupsSuite) encrypt(c *gc.C, data string) ([]byte, string) {
+	key := bytes.Repeat([]byte{1}, statebackups.SymmetricKeySize)
+	encrypter, err := statebackups.NewSymmetricEncrypter(key)
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := encrypter.Encrypt(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = io.WriteString(w, data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err = ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes(), keyFile
+}
+
+// keyFingerprint returns the fingerprint of the key in the file.
+func (s *BaseBackupsSuite) keyFingerprint(c *gc.C, keyFile string) string {
+	data, err := ioutil.ReadFile(keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	key, err := statebackups.ParseDecryptionKey(data)
+	c.Assert(err, jc.ErrorIsNil)
+	return key.Fingerprint()
+}
+
 func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string) {
 	c.Check(ctx.Stdin.(*bytes.Buffer).Len(), gc.Equals, 0)
 	jujutesting.CheckString(c, ctx.Stdout.(*bytes.Buffer).String(), out)
@@ -115,14 +147,22 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 }
 
 type fakeAPIClient struct {
-	metaresult *params.BackupsMetadataResult
-	archive    io.ReadCloser
-	err        error
+	metaresult   *params.BackupsMetadataResult
+	verifyresult *params.BackupsVerifyResult
+	archive      io.ReadCloser
+	err          error
+
+	calls   []string
+	args    []string
+	idArg   string
+	notes   string
+	storage string
+	parent  string
+	key     string
+}
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+func (f *fakeAPIClient) CheckStorage(c *gc.C, storage string) {
+	c.Check(f.storage, gc.Equals, storage)
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,16 +171,39 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes, storage string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
-	c.args = append(c.args, "notes")
+	c.args = append(c.args, "notes", "storage")
 	c.notes = notes
+	c.storage = storage
 	if c.err != nil {
 		return nil, c.err
 	}
 	return c.metaresult, nil
 }
 
+func (c *fakeAPIClient) CreateIncremental(notes, storage, parent string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateIncremental")
+	c.args = append(c.args, "notes", "storage", "parent")
+	c.notes = notes
+	c.storage = storage
+	c.parent = parent
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
+func (c *fakeAPIClient) Verify(id string) (*params.BackupsVerifyResult, error) {
+	c.calls = append(c.calls, "Verify")
+	c.args = append(c.args, "id")
+	c.idArg = id
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.verifyresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, "id")
@@ -189,6 +252,13 @@ func (c *fakeAPIClient) Remove(id string) error {
 	return nil
 }
 
+func (c *fakeAPIClient) RotateEncryptionKey(key string) error {
+	c.calls = append(c.calls, "RotateEncryptionKey")
+	c.args = append(c.args, "key")
+	c.key = key
+	return c.err
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
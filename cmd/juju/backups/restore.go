@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/api/backups"
 	"github.com/juju/juju/apiserver/params"
@@ -28,6 +30,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/jujuclient"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/version"
 )
 
@@ -51,6 +54,7 @@ type restoreCommand struct {
 	constraints    constraints.Value
 	constraintsStr string
 	filename       string
+	keyFilename    string
 	backupId       string
 	bootstrap      bool
 	buildAgent     bool
@@ -72,6 +76,9 @@ type RestoreAPI interface {
 
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+
+	// Upload is taken from backups.Client.
+	Upload(r io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
 }
 
 var restoreDoc = `
@@ -89,6 +96,22 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+An encrypted backup file is decrypted before it is restored, using the
+key in the file given with --decryption-key-file. A backup restored by
+id is decrypted by the controller, which can only do so if it was
+encrypted with the controller's backup-encryption-key or a key that
+rotate-backup-key has since replaced; one encrypted with a public key
+must be downloaded and restored from the file.
+
+An incremental backup is restored together with the backups it builds
+on, which must still be held by the controller. When -b is used to
+bootstrap a new controller from an incremental backup file, the files
+of the backups it builds on must be in the same directory, named as
+download-backup names them (juju-backup-<ID>.tar.gz), and encrypted
+with the same key if it is. The new controller is bootstrapped from
+the full backup at the root of the chain, and every backup in the
+chain is uploaded to it before the restore.
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
@@ -109,6 +132,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.constraintsStr, "constraints", "", "set model constraints")
 	f.BoolVar(&c.bootstrap, "b", false, "Bootstrap a new state machine")
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
+	f.StringVar(&c.keyFilename, "decryption-key-file", "", "Decrypt the backup file with the key in this file")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
 	f.BoolVar(&c.buildAgent, "build-agent", false, "Build binary agent if bootstraping a new machine")
 }
@@ -124,6 +148,9 @@ func (c *restoreCommand) Init(args []string) error {
 	if c.backupId != "" && c.bootstrap {
 		return errors.Errorf("it is not possible to rebootstrap and restore from an id.")
 	}
+	if c.keyFilename != "" && c.filename == "" {
+		return errors.Errorf("--decryption-key-file can only be used with --file.")
+	}
 
 	var err error
 	if c.filename != "" {
@@ -380,21 +407,43 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
+	var parents []*backupFile
 	target := c.backupId
 	if c.filename != "" {
 		// Read archive specified by the filename;
 		// we'll need the info later regardless if
 		// we need it now to rebootstrap.
 		target = c.filename
-		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+		var key *statebackups.DecryptionKey
+		if c.keyFilename != "" {
+			if key, err = readDecryptionKey(ctx, c.keyFilename); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		backup, err := c.openArchive(c.filename, key)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		defer archive.Close()
+		defer backup.Close()
+		archive, meta = backup.archive, backup.meta
 
 		if c.bootstrap {
-			if err := c.rebootstrap(ctx, meta); err != nil {
+			// A new controller holds none of the backups that an
+			// incremental backup builds on, so it is bootstrapped
+			// from the root of the chain and given all of them.
+			bootstrapMeta := meta
+			if meta.Parent != "" {
+				if parents, err = c.openParents(meta, key); err != nil {
+					return errors.Trace(err)
+				}
+				defer func() {
+					for _, parent := range parents {
+						parent.Close()
+					}
+				}()
+				bootstrapMeta = parents[0].meta
+			}
+			if err := c.rebootstrap(ctx, bootstrapMeta); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -409,6 +458,11 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
 	if c.filename != "" {
+		for _, parent := range parents {
+			if _, err := client.Upload(parent.archive, *parent.meta); err != nil {
+				return errors.Annotatef(err, "uploading backup %q", parent.id)
+			}
+		}
 		err = client.RestoreReader(archive, meta, c.newClient)
 	} else {
 		err = client.Restore(c.backupId, c.newClient)
@@ -419,3 +473,81 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// backupFile is a backup archive file opened for restoring.
+type backupFile struct {
+	// id is the ID of the backup, if known.
+	id      string
+	archive ArchiveReader
+	meta    *params.BackupsMetadataResult
+	// decrypted, if set, is the temporary file holding the decrypted
+	// archive.
+	decrypted string
+}
+
+// Close closes the archive and removes any decrypted copy of it.
+func (f *backupFile) Close() error {
+	err := f.archive.Close()
+	if f.decrypted != "" {
+		os.Remove(f.decrypted)
+	}
+	return err
+}
+
+// openArchive opens the named backup archive file, decrypting it with
+// the key if one is given.
+func (c *restoreCommand) openArchive(filename string, key *statebackups.DecryptionKey) (*backupFile, error) {
+	var backup backupFile
+	archiveFilename := filename
+	if key != nil {
+		var err error
+		if archiveFilename, err = decryptArchive(filename, key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		backup.decrypted = archiveFilename
+	}
+	var err error
+	backup.archive, backup.meta, err = c.getArchiveFunc(archiveFilename)
+	if err != nil {
+		if backup.decrypted != "" {
+			os.Remove(backup.decrypted)
+		}
+		return nil, errors.Trace(err)
+	}
+	return &backup, nil
+}
+
+// openParents opens the files of the backups on which the incremental
+// backup with the given metadata builds, starting with the full backup
+// at the root of the chain. They are looked for next to the backup
+// file, under the names given by download-backup.
+func (c *restoreCommand) openParents(meta *params.BackupsMetadataResult, key *statebackups.DecryptionKey) (_ []*backupFile, err error) {
+	var parents []*backupFile
+	defer func() {
+		if err != nil {
+			for _, parent := range parents {
+				parent.Close()
+			}
+		}
+	}()
+	seen := set.NewStrings()
+	for meta.Parent != "" {
+		id := meta.Parent
+		if seen.Contains(id) {
+			return nil, errors.Errorf("backup %q is its own ancestor", id)
+		}
+		seen.Add(id)
+		filename := filepath.Join(filepath.Dir(c.filename), statebackups.FilenamePrefix+id+".tar.gz")
+		if _, err := os.Stat(filename); os.IsNotExist(err) {
+			return nil, errors.Errorf("cannot bootstrap from incremental backup: it builds on backup %q, which is not in %s", id, filename)
+		}
+		parent, err := c.openArchive(filename, key)
+		if err != nil {
+			return nil, errors.Annotatef(err, "opening backup %q", id)
+		}
+		parent.id = id
+		parents = append([]*backupFile{parent}, parents...)
+		meta = parent.meta
+	}
+	return parents, nil
+}
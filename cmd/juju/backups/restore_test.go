@@ -5,6 +5,9 @@ package backups_test
 
 import (
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/juju/errors"
@@ -104,21 +107,31 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 
 	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "-b")
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--decryption-key-file", "akey")
+	c.Assert(err, gc.ErrorMatches, "--decryption-key-file can only be used with --file.")
 }
 
 // TODO(wallyworld) - add more api related unit tests
 type mockRestoreAPI struct {
 	backups.RestoreAPI
+	calls []string
 }
 
 func (*mockRestoreAPI) Close() error {
 	return nil
 }
 
-func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (m *mockRestoreAPI) RestoreReader(_ io.ReadSeeker, meta *params.BackupsMetadataResult, _ apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "RestoreReader "+meta.Notes)
 	return nil
 }
 
+func (m *mockRestoreAPI) Upload(_ io.ReadSeeker, meta params.BackupsMetadataResult) (string, error) {
+	m.calls = append(m.calls, "Upload "+meta.Notes)
+	return "", nil
+}
+
 type mockArchiveReader struct {
 	backups.ArchiveReader
 }
@@ -127,6 +140,33 @@ func (*mockArchiveReader) Close() error {
 	return nil
 }
 
+func (s *restoreSuite) TestRestoreDecryptsFile(c *gc.C) {
+	encrypted, keyFile := s.encrypt(c, s.data)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, encrypted, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var archiveFilename string
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, &mockRestoreAPI{},
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			archiveFilename = filename
+			data, err := ioutil.ReadFile(filename)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(string(data), gc.Equals, s.data)
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
+		},
+		nil, nil,
+	)
+	_, err = testing.RunCommand(c, s.command, "restore", "--file", filename, "--decryption-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The decrypted archive is removed once it has been restored.
+	c.Check(archiveFilename, gc.Not(gc.Equals), filename)
+	_, err = os.Stat(archiveFilename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
 func (s *restoreSuite) TestRestoreReboostrapControllerExists(c *gc.C) {
 	fakeEnv := fakeEnviron{controllerInstances: []instance.Id{"1"}}
 	s.command = backups.NewRestoreCommandForTest(
@@ -141,6 +181,58 @@ func (s *restoreSuite) TestRestoreReboostrapControllerExists(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*still seems to exist.*")
 }
 
+func (s *restoreSuite) TestRestoreReboostrapIncremental(c *gc.C) {
+	dir := c.MkDir()
+	metadata := map[string]*params.BackupsMetadataResult{
+		"juju-backup-root.tar.gz": {
+			Notes:        "root",
+			CACert:       testing.CACert,
+			CAPrivateKey: testing.CAKey,
+		},
+		"juju-backup-middle.tar.gz": {Notes: "middle", Parent: "root"},
+		"incremental.tar.gz":        {Notes: "incremental", Parent: "middle"},
+	}
+	for name := range metadata {
+		err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0600)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, api,
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return &mockArchiveReader{}, metadata[filepath.Base(filename)], nil
+		},
+		backups.GetEnvironFunc(fakeEnviron{}),
+		backups.GetRebootstrapParamsFunc("mycloud"),
+	)
+	s.PatchValue(&backups.BootstrapFunc, func(ctx environs.BootstrapContext, environ environs.Environ, args bootstrap.BootstrapParams) error {
+		// The new controller is bootstrapped from the full backup.
+		c.Check(args.CAPrivateKey, gc.Equals, testing.CAKey)
+		return nil
+	})
+
+	_, err := testing.RunCommand(c, s.command, "restore", "-m", "testing:test1", "--file", filepath.Join(dir, "incremental.tar.gz"), "-b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.calls, jc.DeepEquals, []string{
+		"Upload root",
+		"Upload middle",
+		"RestoreReader incremental",
+	})
+}
+
+func (s *restoreSuite) TestRestoreReboostrapIncrementalMissingParent(c *gc.C) {
+	dir := c.MkDir()
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, &mockRestoreAPI{},
+		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{Parent: "parent-id"}, nil
+		},
+		nil, nil,
+	)
+	_, err := testing.RunCommand(c, s.command, "restore", "--file", filepath.Join(dir, "afile"), "-b")
+	c.Assert(err, gc.ErrorMatches, `cannot bootstrap from incremental backup: it builds on backup "parent-id", which is not in .*/juju-backup-parent-id.tar.gz`)
+}
+
 func (s *restoreSuite) TestRestoreReboostrapNoControllers(c *gc.C) {
 	fakeEnv := fakeEnviron{}
 	s.command = backups.NewRestoreCommandForTest(
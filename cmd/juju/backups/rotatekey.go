@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const rotateKeyDoc = `
+rotate-backup-key sets the key with which the controller encrypts new
+backups to the base64-encoded 256-bit key held in the given file.
+
+The controller keeps the key it replaces, so that backups already
+encrypted with it can still be verified and restored from the
+controller. Keep a copy of every key: a downloaded backup can only be
+decrypted with the key it was encrypted with, which is identified by
+the key fingerprint shown by show-backup.
+
+A controller that encrypts backups with a public key cannot be given
+a symmetric key.
+
+Examples:
+    head -c 32 /dev/urandom | base64 > backup.key
+    juju rotate-backup-key backup.key
+`
+
+// NewRotateKeyCommand returns a command used to rotate the backup
+// encryption key.
+func NewRotateKeyCommand() cmd.Command {
+	return modelcmd.Wrap(&rotateKeyCommand{})
+}
+
+// rotateKeyCommand is the sub-command for rotating the backup
+// encryption key.
+type rotateKeyCommand struct {
+	CommandBase
+	// KeyFile names the file holding the new key.
+	KeyFile string
+}
+
+// Info implements Command.Info.
+func (c *rotateKeyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-backup-key",
+		Args:    "<key file>",
+		Purpose: "Set the key with which backups are encrypted.",
+		Doc:     rotateKeyDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *rotateKeyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing key file")
+	}
+	keyFile, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.KeyFile = keyFile
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *rotateKeyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.KeyFile))
+	if err != nil {
+		return errors.Annotate(err, "reading backup key")
+	}
+	key, err := statebackups.ParseSymmetricKey(string(data))
+	if err != nil {
+		return errors.Annotate(err, "reading backup key")
+	}
+	decryptionKey, err := statebackups.NewSymmetricDecryptionKey(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.RotateEncryptionKey(base64.StdEncoding.EncodeToString(key)); err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "key fingerprint: %s\n", decryptionKey.Fingerprint())
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/testing"
+)
+
+type rotateKeySuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&rotateKeySuite{})
+
+func (s *rotateKeySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewRotateKeyCommandForTest()
+}
+
+func (s *rotateKeySuite) TestOkay(c *gc.C) {
+	client := s.setSuccess()
+	_, keyFile := s.encrypt(c, s.data)
+	ctx, err := testing.RunCommand(c, s.command, keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.calls, jc.DeepEquals, []string{"RotateEncryptionKey"})
+	c.Check(client.key, gc.Equals, string(data))
+	s.checkStd(c, ctx, "key fingerprint: "+s.keyFingerprint(c, keyFile)+"\n", "")
+}
+
+func (s *rotateKeySuite) TestMissingKeyFile(c *gc.C) {
+	_, err := testing.RunCommand(c, s.command)
+	c.Check(err, gc.ErrorMatches, "missing key file")
+}
+
+func (s *rotateKeySuite) TestBadKey(c *gc.C) {
+	client := s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("c2hvcnQ="), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = testing.RunCommand(c, s.command, keyFile)
+	c.Check(err, gc.ErrorMatches, `reading backup key: symmetric key of 5 bytes \(want 32\) not valid`)
+	c.Check(client.calls, gc.HasLen, 0)
+}
+
+func (s *rotateKeySuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, keyFile := s.encrypt(c, s.data)
+	_, err := testing.RunCommand(c, s.command, keyFile)
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -6,6 +6,7 @@ package backups_test
 import (
 	"archive/tar"
 	"compress/gzip"
+	"io/ioutil"
 	"os"
 
 	"github.com/juju/cmd"
@@ -91,3 +92,12 @@ func (s *uploadSuite) TestError(c *gc.C) {
 	_, err := testing.RunCommand(c, s.command, s.filename)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *uploadSuite) TestEncrypted(c *gc.C) {
+	encrypted, _ := s.encrypt(c, s.data)
+	err := ioutil.WriteFile(s.filename, encrypted, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setSuccess()
+	_, err = testing.RunCommand(c, s.command, s.filename)
+	c.Check(err, gc.ErrorMatches, ".* is an encrypted backup archive; its decryption key is needed to read it")
+}
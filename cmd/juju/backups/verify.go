@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiserverbackups "github.com/juju/juju/apiserver/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a backup could be restored, without restoring
+it. The backup may be given by its ID, in which case the controller
+reads it from wherever it is kept, or as a local archive file.
+
+The whole archive is read. Its size and checksum are compared with
+those recorded when it was created, the database dump is parsed, and
+the files needed to restore the controller are looked for. The models,
+machines, applications and units in the dump are counted, and any
+missing files or other problems are reported. The command fails if
+the backup could not be restored, so it may be run regularly to find
+broken backups before they are needed.
+
+The command also fails if the checksum or the contents could not be
+checked, unless --allow-unverified is given. An archive file carries
+no record of its checksum, so only its contents are checked. An
+encrypted archive file is checked using the key in the file given with
+--decryption-key-file. A backup given by ID that is encrypted with a
+public key can only have its checksum checked.
+
+Examples:
+    juju verify-backup 20161018-103000.7c5c7b84-1b23-4c1b-8b2a-6a5b8a7c1d2e
+    juju verify-backup --allow-unverified juju-backup-20161018-103000.tar.gz
+`
+
+// NewVerifyCommand returns a command used to verify backups.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup.
+type verifyCommand struct {
+	CommandBase
+	// Backup is the ID or filename of the backup to verify.
+	Backup string
+	// DecryptionKeyFile names the file holding the key with which to
+	// decrypt an encrypted archive file.
+	DecryptionKeyFile string
+	// AllowUnverified is true if the command should succeed even
+	// though the checksum or the contents could not be checked.
+	AllowUnverified bool
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-backup",
+		Args:    "<ID>|<filename>",
+		Purpose: "Check that a backup could be restored.",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.DecryptionKeyFile, "decryption-key-file", "", "Decrypt the archive file with the key in this file")
+	f.BoolVar(&c.AllowUnverified, "allow-unverified", false, "Succeed even if the checksum or the contents could not be checked")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing ID or filename")
+	}
+	backup, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Backup = backup
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	var result *params.BackupsVerifyResult
+	filename := ctx.AbsPath(c.Backup)
+	if _, err := os.Stat(filename); err == nil {
+		if result, err = c.verifyFile(ctx, filename); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		if c.DecryptionKeyFile != "" {
+			return errors.Errorf("--decryption-key-file can only be used with an archive file")
+		}
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer client.Close()
+		if result, err = client.Verify(c.Backup); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	dumpVerifyResult(ctx, c.Backup, result)
+	if len(result.Missing) > 0 || len(result.Problems) > 0 {
+		return errors.Errorf("backup %s cannot be restored", c.Backup)
+	}
+	var unverified []string
+	if !result.ChecksumVerified {
+		unverified = append(unverified, "checksum")
+	}
+	if !result.ContentsVerified {
+		unverified = append(unverified, "contents")
+	}
+	if len(unverified) > 0 && !c.AllowUnverified {
+		return errors.Errorf("backup %s %s not verified (use --allow-unverified to accept this)",
+			c.Backup, strings.Join(unverified, " and "))
+	}
+	return nil
+}
+
+func (c *verifyCommand) verifyFile(ctx *cmd.Context, filename string) (*params.BackupsVerifyResult, error) {
+	var key *statebackups.DecryptionKey
+	if c.DecryptionKeyFile != "" {
+		var err error
+		if key, err = readDecryptionKey(ctx, c.DecryptionKeyFile); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	archive, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+	result := apiserverbackups.VerifyResultFromVerification(statebackups.VerifyArchive(archive, nil, key))
+	return &result, nil
+}
+
+func dumpVerifyResult(ctx *cmd.Context, backup string, result *params.BackupsVerifyResult) {
+	fmt.Fprintf(ctx.Stdout, "backup:          %s\n", backup)
+	fmt.Fprintf(ctx.Stdout, "checksum:        %s\n", verifiedString(result.ChecksumVerified))
+	contents := "checked"
+	if !result.ContentsVerified {
+		contents = "not checked"
+	}
+	fmt.Fprintf(ctx.Stdout, "contents:        %s\n", contents)
+	if meta := result.Metadata; meta != nil {
+		fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", meta.Model)
+		fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", meta.Machine)
+		fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", meta.Version)
+		fmt.Fprintf(ctx.Stdout, "series:          %s\n", meta.Series)
+		if meta.Parent != "" {
+			fmt.Fprintf(ctx.Stdout, "parent backup:   %q\n", meta.Parent)
+		}
+	}
+	if len(result.Counts) > 0 {
+		for _, collection := range []string{"models", "machines", "applications", "units"} {
+			fmt.Fprintf(ctx.Stdout, "%-17s%d\n", collection+":", result.Counts[collection])
+		}
+	}
+	if result.OplogEntries > 0 {
+		fmt.Fprintf(ctx.Stdout, "oplog entries:   %d\n", result.OplogEntries)
+	}
+	dumpList(ctx, "missing:", result.Missing)
+	dumpList(ctx, "problems:", result.Problems)
+}
+
+func dumpList(ctx *cmd.Context, label string, items []string) {
+	for i, item := range items {
+		if i > 0 {
+			label = ""
+		}
+		fmt.Fprintf(ctx.Stdout, "%-17s%s\n", label, item)
+	}
+}
+
+func verifiedString(verified bool) string {
+	if verified {
+		return "verified"
+	}
+	return "not verified"
+}
@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	command cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.command = backups.NewVerifyCommandForTest()
+}
+
+func (s *verifySuite) setVerify(result *params.BackupsVerifyResult) *fakeAPIClient {
+	client := s.setSuccess()
+	client.verifyresult = result
+	return client
+}
+
+func (s *verifySuite) TestOkay(c *gc.C) {
+	s.metaresult.Series = "xenial"
+	client := s.setVerify(&params.BackupsVerifyResult{
+		Metadata:         s.metaresult,
+		ChecksumVerified: true,
+		ContentsVerified: true,
+		Counts: map[string]int{
+			"models":       2,
+			"machines":     3,
+			"applications": 4,
+			"units":        5,
+		},
+	})
+	ctx, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(err, jc.ErrorIsNil)
+	client.Check(c, "spam", "", "Verify")
+
+	out := `
+backup:          spam
+checksum:        verified
+contents:        checked
+model ID:        ""
+machine ID:      ""
+juju version:    0.0.0
+series:          xenial
+models:          2
+machines:        3
+applications:    4
+units:           5
+`[1:]
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *verifySuite) TestProblems(c *gc.C) {
+	s.setVerify(&params.BackupsVerifyResult{
+		ChecksumVerified: false,
+		ContentsVerified: true,
+		Missing:          []string{"juju-backup/metadata.json", "juju-backup/root.tar"},
+		Problems:         []string{"archive is 10 bytes, but 12 were recorded"},
+	})
+	ctx, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(err, gc.ErrorMatches, "backup spam cannot be restored")
+
+	out := `
+backup:          spam
+checksum:        not verified
+contents:        checked
+missing:         juju-backup/metadata.json
+                 juju-backup/root.tar
+problems:        archive is 10 bytes, but 12 were recorded
+`[1:]
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *verifySuite) TestChecksumNotVerified(c *gc.C) {
+	s.setVerify(&params.BackupsVerifyResult{
+		ChecksumVerified: false,
+		ContentsVerified: true,
+	})
+	_, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(err, gc.ErrorMatches, `backup spam checksum not verified \(use --allow-unverified to accept this\)`)
+}
+
+func (s *verifySuite) TestContentsNotVerified(c *gc.C) {
+	s.setVerify(&params.BackupsVerifyResult{
+		ChecksumVerified: true,
+		ContentsVerified: false,
+	})
+	_, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(err, gc.ErrorMatches, `backup spam contents not verified \(use --allow-unverified to accept this\)`)
+}
+
+func (s *verifySuite) TestAllowUnverified(c *gc.C) {
+	s.setVerify(&params.BackupsVerifyResult{
+		ChecksumVerified: false,
+		ContentsVerified: false,
+	})
+	ctx, err := testing.RunCommand(c, s.command, "spam", "--allow-unverified")
+	c.Check(err, jc.ErrorIsNil)
+
+	out := `
+backup:          spam
+checksum:        not verified
+contents:        not checked
+`[1:]
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *verifySuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := testing.RunCommand(c, s.command, "spam")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
+
+func (s *verifySuite) TestMissingArg(c *gc.C) {
+	_, err := testing.RunCommand(c, s.command)
+	c.Check(err, gc.ErrorMatches, "missing ID or filename")
+}
+
+func (s *verifySuite) TestKeyFileWithID(c *gc.C) {
+	client := s.setSuccess()
+	_, err := testing.RunCommand(c, s.command, "spam", "--decryption-key-file", "backup.key")
+	c.Check(err, gc.ErrorMatches, "--decryption-key-file can only be used with an archive file")
+	c.Check(client.calls, gc.HasLen, 0)
+}
+
+func (s *verifySuite) TestFile(c *gc.C) {
+	client := s.setSuccess()
+	filename := filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte(s.data), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, s.command, filename)
+	c.Check(err, gc.ErrorMatches, "backup .* cannot be restored")
+	c.Check(client.calls, gc.HasLen, 0)
+	c.Check(testing.Stdout(ctx), gc.Matches, `(?s).*problems:        archive is not gzip compressed: .*`)
+}
+
+func (s *verifySuite) TestEncryptedFile(c *gc.C) {
+	s.setSuccess()
+	encrypted, keyFile := s.encrypt(c, s.data)
+	filename := filepath.Join(c.MkDir(), "juju-backup.tar.gz.enc")
+	err := ioutil.WriteFile(filename, encrypted, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, s.command, filename)
+	c.Check(err, gc.ErrorMatches, "backup .* checksum and contents not verified .*")
+	c.Check(testing.Stdout(ctx), gc.Matches, `(?s).*contents:        not checked\n`)
+
+	s.command = backups.NewVerifyCommandForTest()
+	_, err = testing.RunCommand(c, s.command, filename, "--allow-unverified")
+	c.Check(err, jc.ErrorIsNil)
+
+	s.command = backups.NewVerifyCommandForTest()
+
+	ctx, err = testing.RunCommand(c, s.command, filename, "--decryption-key-file", keyFile)
+	c.Check(err, gc.ErrorMatches, "backup .* cannot be restored")
+	c.Check(testing.Stdout(ctx), gc.Matches, `(?s).*problems:        archive is not gzip compressed: .*`)
+}
@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+func newDebugCodeCommand() cmd.Command {
+	return modelcmd.Wrap(&debugCodeCommand{})
+}
+
+// debugCodeCommand pauses hooks and actions on a unit as they start,
+// so that they can be resumed, skipped, or run by hand in a shell.
+type debugCodeCommand struct {
+	debugHooksCommand
+	debugAt string
+}
+
+const debugCodeDoc = `
+Pauses hooks and actions on an application unit as they start, without
+using tmux. When a matching hook or action is about to run, you are
+asked whether to:
+
+ - resume it, running it as usual;
+ - skip it, as if it had succeeded; or
+ - open a debug shell, in the charm directory with the full hook
+   environment already set, in which to run it by hand. The exit status
+   of the shell becomes the result of the hook.
+
+Hooks and actions run with JUJU_DEBUG_AT set to the value of --at, so
+that charm code can decide where to stop at breakpoints of its own.
+
+If no hook or action names are given, or "*" is given, all hooks and
+actions are paused. Stop debugging with CTRL-C; any paused hook then
+resumes.
+
+See the "juju help ssh" for information about SSH related options
+accepted by the debug-code command.
+
+Examples:
+
+    juju debug-code mysql/0
+    juju debug-code mysql/0 install config-changed
+    juju debug-code --at=config mysql/0 backup
+
+See also:
+    debug-hooks
+`
+
+func (c *debugCodeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "debug-code",
+		Args:    "<unit name> [hook or action names]",
+		Purpose: "Pause hooks and actions as they start, to resume, skip or run them by hand.",
+		Doc:     debugCodeDoc,
+	}
+}
+
+func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.debugHooksCommand.SetFlags(f)
+	f.StringVar(&c.debugAt, "at", "all", "The value of JUJU_DEBUG_AT for the hooks and actions debugged")
+}
+
+func (c *debugCodeCommand) Init(args []string) error {
+	if err := c.debugHooksCommand.Init(args); err != nil {
+		return err
+	}
+	if c.debugAt == "" {
+		return errors.New("--at cannot be empty")
+	}
+	return nil
+}
+
+type charmActionsAPI interface {
+	ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error)
+}
+
+func (c *debugCodeCommand) getActionAPI() (charmActionsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return action.NewClient(root), nil
+}
+
+// validateHooks checks that the hooks requested are hooks or actions
+// of the target unit's charm.
+func (c *debugCodeCommand) validateHooks() error {
+	if len(c.hooks) == 0 {
+		return nil
+	}
+	validHooks, err := c.validHooks()
+	if err != nil {
+		return err
+	}
+	service, err := names.UnitApplication(c.Target)
+	if err != nil {
+		return err
+	}
+	actionAPI, err := c.getActionAPI()
+	if err != nil {
+		return err
+	}
+	actions, err := actionAPI.ApplicationCharmActions(params.Entity{
+		Tag: names.NewApplicationTag(service).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for name := range actions {
+		validHooks[name] = true
+	}
+	return c.checkHooks(validHooks)
+}
+
+// Run ensures c.Target is a unit, and resolves its address,
+// and connects to it via SSH to execute the debug-code
+// script.
+func (c *debugCodeCommand) Run(ctx *cmd.Context) error {
+	err := c.initRun()
+	if err != nil {
+		return err
+	}
+	defer c.cleanupRun()
+	err = c.validateHooks()
+	if err != nil {
+		return err
+	}
+	debugctx := unitdebug.NewHooksContext(c.Target)
+	c.Args = sudoScriptArgs(unitdebug.CodeClientScript(debugctx, c.hooks, c.debugAt))
+	return c.sshCommand.Run(ctx)
+}
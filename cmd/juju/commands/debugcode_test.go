@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&DebugCodeSuite{})
+
+type DebugCodeSuite struct {
+	SSHCommonSuite
+}
+
+var debugCodeTests = []struct {
+	info  string
+	args  []string
+	error string
+}{{
+	info: "unit name without hook",
+	args: []string{"mysql/0"},
+}, {
+	info: `"*" is a valid hook name: it means pause everything`,
+	args: []string{"mysql/0", "*"},
+}, {
+	info: `hooks and actions may be specified together`,
+	args: []string{"mysql/0", "start", "fakeaction"},
+}, {
+	info: `--at is passed through`,
+	args: []string{"--at=config", "mysql/0", "config-changed"},
+}, {
+	info:  `--at may not be empty`,
+	args:  []string{"--at=", "mysql/0"},
+	error: `--at cannot be empty`,
+}, {
+	info:  `invalid unit syntax`,
+	args:  []string{"mysql"},
+	error: `"mysql" is not a valid unit name`,
+}, {
+	info:  `invalid unit`,
+	args:  []string{"nonexistent/123"},
+	error: `unit "nonexistent/123" not found`,
+}, {
+	info:  `invalid hook or action`,
+	args:  []string{"mysql/0", "invalid-hook"},
+	error: `unit "mysql/0" does not contain hook "invalid-hook"`,
+}}
+
+func (s *DebugCodeSuite) TestDebugCodeCommand(c *gc.C) {
+	//TODO(bogdanteleaga): Fix once debughooks are supported on windows
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+
+	s.setupModel(c)
+
+	for i, t := range debugCodeTests {
+		c.Logf("test %d: %s\n\t%s\n", i, t.info, t.args)
+
+		_, err := coretesting.RunCommand(c, newDebugCodeCommand(), t.args...)
+		if t.error != "" {
+			c.Check(err, gc.ErrorMatches, t.error)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}
+}
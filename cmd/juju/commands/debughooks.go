@@ -80,17 +80,26 @@ func (c *debugHooksCommand) validateHooks() error {
 	if len(c.hooks) == 0 {
 		return nil
 	}
-	service, err := names.UnitApplication(c.Target)
+	validHooks, err := c.validHooks()
 	if err != nil {
 		return err
 	}
+	return c.checkHooks(validHooks)
+}
+
+// validHooks returns the names of the hooks of the target unit's charm.
+func (c *debugHooksCommand) validHooks() (map[string]bool, error) {
+	service, err := names.UnitApplication(c.Target)
+	if err != nil {
+		return nil, err
+	}
 	serviceAPI, err := c.getServiceAPI()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	relations, err := serviceAPI.CharmRelations(service)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	validHooks := make(map[string]bool)
@@ -103,6 +112,12 @@ func (c *debugHooksCommand) validateHooks() error {
 			validHooks[hook] = true
 		}
 	}
+	return validHooks, nil
+}
+
+// checkHooks returns an error if any of the requested hooks is not
+// one of validHooks.
+func (c *debugHooksCommand) checkHooks(validHooks map[string]bool) error {
 	for _, hook := range c.hooks {
 		if !validHooks[hook] {
 			names := make([]string, 0, len(validHooks))
@@ -131,9 +146,14 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
-	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
-	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
-	c.Args = args
+	c.Args = sudoScriptArgs(unitdebug.ClientScript(debugctx, c.hooks))
 	return c.sshCommand.Run(ctx)
 }
+
+// sudoScriptArgs returns the ssh arguments for running the supplied
+// bash script as root on the target.
+func sudoScriptArgs(script string) []string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(script))
+	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, encoded)
+	return []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/cmd/juju/charmcmd"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/juju/gui"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
@@ -233,6 +234,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Creation commands.
 	r.Register(newBootstrapCommand())
 	r.Register(application.NewAddRelationCommand())
+	r.Register(crossmodel.NewOfferCommand())
+	r.Register(crossmodel.NewConsumeCommand())
+	r.Register(crossmodel.NewFindOffersCommand())
 
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
@@ -243,6 +247,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewHookHistoryCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -251,6 +256,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand())
+	r.Register(newDebugCodeCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -270,7 +276,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
+	r.Register(backups.NewRotateKeyCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -307,10 +315,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
+	r.Register(model.NewCloneCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewSecretsCommand())
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
+		r.Register(model.NewExportCommand())
+		r.Register(model.NewImportCommand())
 	}
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -322,6 +334,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewRunCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
+	r.Register(action.NewShowOperationCommand())
+	r.Register(action.NewListOperationsCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -375,6 +389,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -400,6 +400,7 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -407,15 +408,18 @@ var commandNames = []string{
 	"cached-images",
 	"change-user-password",
 	"charm",
+	"clone-model",
 	"clouds",
 	"config",
 	"collect-metrics",
+	"consume",
 	"controllers",
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
 	"credentials",
 	"controller-config",
+	"debug-code",
 	"debug-hooks",
 	"debug-log",
 	"remove-user",
@@ -431,6 +435,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-user",
 	"expose",
+	"find-offers",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -450,8 +455,10 @@ var commandNames = []string{
 	"list-disabled-commands",
 	"list-machines",
 	"list-models",
+	"list-operations",
 	"list-plans",
 	"list-regions",
+	"list-secrets",
 	"list-ssh-keys",
 	"list-spaces",
 	"list-storage",
@@ -465,6 +472,8 @@ var commandNames = []string{
 	"model-config",
 	"model-defaults",
 	"models",
+	"offer",
+	"operations",
 	"plans",
 	"regions",
 	"register",
@@ -482,9 +491,11 @@ var commandNames = []string{
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"rotate-backup-key",
 	"run",
 	"run-action",
 	"scp",
+	"secrets",
 	"set-budget",
 	"set-constraints",
 	"set-default-credential",
@@ -498,8 +509,10 @@ var commandNames = []string{
 	"show-budget",
 	"show-cloud",
 	"show-controller",
+	"show-hook-history",
 	"show-machine",
 	"show-model",
+	"show-operation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -523,6 +536,7 @@ var commandNames = []string{
 	"upgrade-gui",
 	"upgrade-juju",
 	"users",
+	"verify-backup",
 	"version",
 	"whoami",
 }
@@ -532,6 +546,8 @@ var devFeatures = []string{feature.Migration}
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
+	"export-model",
+	"import-model",
 	"migrate",
 )
 
@@ -4,20 +4,43 @@
 package commands
 
 import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
+// migrationPollInterval is how often the progress of the migrations
+// started by a bulk migration is checked.
+const migrationPollInterval = 5 * time.Second
+
+// maxMigrationStatusErrors is how many times in a row the progress of
+// the migrations may fail to be checked before the bulk migration stops
+// waiting for them.
+const maxMigrationStatusErrors = 3
+
 func newMigrateCommand() cmd.Command {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.JujuCommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
 	return modelcmd.WrapController(&cmd)
 }
 
@@ -26,12 +49,53 @@ type migrateCommand struct {
 	modelcmd.ControllerCommandBase
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
-	model            string
+	clock            clock.Clock
+	out              cmd.Output
+	models           []string
 	targetController string
+	all              bool
+	maxConcurrent    int
+	dryRun           bool
 }
 
 type migrateAPI interface {
+	AllModels() ([]base.UserModel, error)
+	ModelConfig() (map[string]interface{}, error)
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) ([]params.MigrationPrecheckIssue, error)
+	MigrationStatus(ids []string) ([]params.MigrationStatusResult, error)
+}
+
+// migrationIssue is an issue preventing migration, written by the
+// migrate command's dry run. The model is only recorded when more
+// than one model is checked.
+type migrationIssue struct {
+	Model   string `yaml:"model,omitempty" json:"model,omitempty"`
+	Stage   string `yaml:"stage" json:"stage"`
+	Message string `yaml:"message" json:"message"`
+}
+
+// modelMigrationResult records how far the migration of a model got,
+// written by the migrate command when migrating more than one model.
+type modelMigrationResult struct {
+	Model       string `yaml:"model" json:"model"`
+	MigrationId string `yaml:"migration-id,omitempty" json:"migration-id,omitempty"`
+	Phase       string `yaml:"phase,omitempty" json:"phase,omitempty"`
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// migrated reports whether the model is now managed by the target
+// controller. A failure to remove the model from the source
+// controller afterwards is not a failed migration.
+func (r modelMigrationResult) migrated() bool {
+	return r.Phase == coremigration.DONE.String() || r.Phase == coremigration.REAPFAILED.String()
+}
+
+// migrationModel is a model to be migrated, and the details of its
+// migration.
+type migrationModel struct {
+	name string
+	spec controller.MigrationSpec
 }
 
 const migrateDoc = `
@@ -45,6 +109,19 @@ available at the source controller.
 Note that only hosted models can be migrated. Controller models can
 not be migrated.
 
+More than one model can be migrated to the same controller at once,
+by naming each of them, or with --all to migrate every hosted model of
+the controller. The migrations are started no more than
+--max-concurrent at a time, and the command waits for all of them to
+finish, reporting each model's migration phase as it changes: QUIESCE,
+IMPORT, VALIDATION, SUCCESS and so on, ending with DONE once the model
+has been migrated, or ABORTDONE if its migration failed. A summary of
+every model's migration is written when they have all finished, and
+the command fails if any of them did not complete. If their progress
+repeatedly cannot be checked, the command stops waiting and starts no
+more migrations, but still writes the summary. Interrupting the
+command does not stop the migrations already started.
+
 If the migration fails for some reason, the model be returned to its
 original state with the model being managed by the original
 controller.
@@ -53,9 +130,27 @@ In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
 
-This command only starts a model migration - it does not wait for its
-completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+When a single model is migrated, this command only starts the
+migration - it does not wait for its completion. The progress of a
+migration can be tracked using the "status" command and by consulting
+the logs.
+
+With --dry-run, the model is not migrated. Instead, the checks that
+are made before a migration starts are run on both controllers, and
+the model is exported and then imported into the target controller,
+which removes it again. Every issue found that would prevent the
+migration is listed, with the stage at which it was found: "source" or
+"target" for the checks of either controller, and "export" or "import"
+for the trial migration of the model itself. The command fails if any
+issues are found. --dry-run can check more than one model too.
+
+Examples:
+
+    juju migrate mymodel target
+    juju migrate mymodel othermodel target
+    juju migrate --all --max-concurrent 10 target
+    juju migrate --dry-run mymodel target
+    juju migrate --dry-run --format yaml mymodel target
 
 See also:
     login
@@ -67,37 +162,115 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
-		Purpose: "Migrate a hosted model to another controller.",
+		Args:    "<model-name> [<model-name>...] <target-controller-name>",
+		Purpose: "Migrate hosted models to another controller.",
 		Doc:     migrateDoc,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the models could be migrated, without migrating them")
+	f.BoolVar(&c.all, "all", false, "Migrate all the hosted models of the controller")
+	f.IntVar(&c.maxConcurrent, "max-concurrent", 5, "The maximum number of models migrated at the same time")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrateTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
+	if c.maxConcurrent < 1 {
+		return errors.New("--max-concurrent must be at least 1")
+	}
+	if c.all {
+		if len(args) < 1 {
+			return errors.New("target controller not specified")
+		}
+		if len(args) > 1 {
+			return errors.New("models cannot be specified with --all")
+		}
+		c.targetController = args[0]
+		return nil
+	}
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
-	if len(args) > 2 {
-		return errors.New("too many arguments specified")
-	}
 
-	c.model = args[0]
-	c.targetController = args[1]
+	c.models = args[:len(args)-1]
+	c.targetController = args[len(args)-1]
 	return nil
 }
 
-func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
-	store := c.ClientStore()
+// bulk reports whether more than one model may be migrated.
+func (c *migrateCommand) bulk() bool {
+	return c.all || len(c.models) > 1
+}
+
+// getMigrationModels returns the models to migrate, with the details
+// of their migrations.
+func (c *migrateCommand) getMigrationModels(api migrateAPI) ([]migrationModel, error) {
+	spec, err := c.getMigrationSpec()
+	if err != nil {
+		return nil, err
+	}
 
-	modelUUIDs, err := c.ModelUUIDs([]string{c.model})
+	var modelNames, modelUUIDs []string
+	if c.all {
+		modelNames, modelUUIDs, err = hostedModels(api)
+	} else {
+		modelNames = c.models
+		modelUUIDs, err = c.ModelUUIDs(c.models)
+	}
 	if err != nil {
 		return nil, err
 	}
-	modelUUID := modelUUIDs[0]
+
+	models := make([]migrationModel, len(modelUUIDs))
+	for i, modelUUID := range modelUUIDs {
+		models[i] = migrationModel{name: modelNames[i], spec: *spec}
+		models[i].spec.ModelUUID = modelUUID
+	}
+	return models, nil
+}
+
+// hostedModels returns the names and UUIDs of all the models of the
+// controller but the controller model itself.
+func hostedModels(api migrateAPI) (modelNames, modelUUIDs []string, _ error) {
+	models, err := api.AllModels()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot list models")
+	}
+	// Hosted models may share the controller model's name, so it is
+	// told apart by its UUID.
+	controllerConfig, err := api.ModelConfig()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot get controller model config")
+	}
+	controllerModelUUID, _ := controllerConfig["uuid"].(string)
+	for _, model := range models {
+		if model.UUID == controllerModelUUID {
+			continue
+		}
+		modelNames = append(modelNames, jujuclient.JoinOwnerModelName(names.NewUserTag(model.Owner), model.Name))
+		modelUUIDs = append(modelUUIDs, model.UUID)
+	}
+	if len(modelUUIDs) == 0 {
+		return nil, nil, errors.New("no hosted models to migrate")
+	}
+	return modelNames, modelUUIDs, nil
+}
+
+// getMigrationSpec returns the details of a migration to the target
+// controller, without a model.
+func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
+	store := c.ClientStore()
 
 	controllerInfo, err := store.ControllerByName(c.targetController)
 	if err != nil {
@@ -119,7 +292,6 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	}
 
 	return &controller.MigrationSpec{
-		ModelUUID:            modelUUID,
 		TargetControllerUUID: controllerInfo.ControllerUUID,
 		TargetAddrs:          controllerInfo.APIEndpoints,
 		TargetCACert:         controllerInfo.CACert,
@@ -131,15 +303,21 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
-	spec, err := c.getMigrationSpec()
+	api, err := c.getAPI()
 	if err != nil {
 		return err
 	}
-	api, err := c.getAPI()
+	models, err := c.getMigrationModels(api)
 	if err != nil {
 		return err
 	}
-	id, err := api.InitiateMigration(*spec)
+	if c.dryRun {
+		return c.runDryRun(ctx, api, models)
+	}
+	if c.bulk() {
+		return c.runBulk(ctx, api, models)
+	}
+	id, err := api.InitiateMigration(models[0].spec)
 	if err != nil {
 		return err
 	}
@@ -147,6 +325,216 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, models []migrationModel) error {
+	var result []migrationIssue
+	for _, model := range models {
+		issues, err := api.MigrationDryRun(model.spec)
+		if err != nil {
+			if !c.bulk() {
+				return err
+			}
+			issues = []params.MigrationPrecheckIssue{{Message: err.Error()}}
+		}
+		if len(issues) == 0 {
+			ctx.Infof("Model %q can be migrated to controller %q", model.name, c.targetController)
+		}
+		for _, issue := range issues {
+			result = append(result, migrationIssue{
+				Stage:   issue.Stage,
+				Message: issue.Message,
+			})
+			if c.bulk() {
+				result[len(result)-1].Model = model.name
+			}
+		}
+	}
+	if len(result) == 0 && c.out.Name() == "tabular" {
+		return nil
+	}
+	if result == nil {
+		result = []migrationIssue{}
+	}
+	if err := c.out.Write(ctx, result); err != nil {
+		return err
+	}
+	if len(result) > 0 {
+		if c.bulk() {
+			return errors.Errorf("%d issue(s) would prevent migration of the models", len(result))
+		}
+		return errors.Errorf("%d issue(s) would prevent migration of model %q", len(result), models[0].name)
+	}
+	return nil
+}
+
+// runBulk migrates the models, starting no more than maxConcurrent
+// migrations at a time, and waits for the migrations to finish. Each
+// model's migration phase is reported as it changes.
+func (c *migrateCommand) runBulk(ctx *cmd.Context, api migrateAPI, models []migrationModel) error {
+	progress := newMigrationProgress(ctx.Stderr, models)
+	results := make([]modelMigrationResult, len(models))
+	active := make(map[string]int)
+	next := 0
+	statusErrors := 0
+	for next < len(models) || len(active) > 0 {
+		for ; next < len(models) && len(active) < c.maxConcurrent; next++ {
+			result := &results[next]
+			result.Model = models[next].name
+			id, err := api.InitiateMigration(models[next].spec)
+			if err != nil {
+				result.Message = err.Error()
+				progress.report(*result)
+				continue
+			}
+			result.MigrationId = id
+			active[id] = next
+		}
+		if len(active) == 0 {
+			continue
+		}
+
+		<-c.clock.After(migrationPollInterval)
+		ids := make([]string, 0, len(active))
+		for id := range active {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		statuses, err := api.MigrationStatus(ids)
+		if err != nil {
+			statusErrors++
+			if statusErrors < maxMigrationStatusErrors {
+				logger.Warningf("cannot get migration status (will retry): %v", err)
+				continue
+			}
+			// The migrations carry on without us; report them as
+			// unknown rather than losing the results gathered so far,
+			// and start no more.
+			for _, id := range ids {
+				result := &results[active[id]]
+				result.Message = fmt.Sprintf("cannot get migration status: %v", err)
+				progress.report(*result)
+				delete(active, id)
+			}
+			for ; next < len(models); next++ {
+				results[next].Model = models[next].name
+				results[next].Message = "migration not started"
+				progress.report(results[next])
+			}
+			continue
+		}
+		statusErrors = 0
+		for i, status := range statuses {
+			result := &results[active[ids[i]]]
+			if status.Error != nil {
+				result.Message = status.Error.Error()
+				progress.report(*result)
+				delete(active, ids[i])
+				continue
+			}
+			if status.Phase != result.Phase || status.StatusMessage != result.Message {
+				result.Phase = status.Phase
+				result.Message = status.StatusMessage
+				progress.report(*result)
+			}
+			if phase, ok := coremigration.ParsePhase(status.Phase); ok && phase.IsTerminal() {
+				delete(active, ids[i])
+			}
+		}
+	}
+
+	if err := c.out.Write(ctx, results); err != nil {
+		return err
+	}
+	var failed int
+	for _, result := range results {
+		if !result.migrated() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d model migration(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// migrationProgress writes a line for each change of phase of the
+// models being migrated, in columns wide enough for their names.
+type migrationProgress struct {
+	writer io.Writer
+	format string
+}
+
+func newMigrationProgress(writer io.Writer, models []migrationModel) *migrationProgress {
+	width := len("MODEL")
+	for _, model := range models {
+		if len(model.name) > width {
+			width = len(model.name)
+		}
+	}
+	p := &migrationProgress{
+		writer: writer,
+		format: fmt.Sprintf("%%-%ds  %%-11s  %%s", width),
+	}
+	p.writeLine("MODEL", "PHASE", "MESSAGE")
+	return p
+}
+
+func (p *migrationProgress) report(result modelMigrationResult) {
+	p.writeLine(result.Model, migrationPhase(result), result.Message)
+}
+
+func (p *migrationProgress) writeLine(model, phase, message string) {
+	line := fmt.Sprintf(p.format, model, phase, message)
+	fmt.Fprintln(p.writer, strings.TrimRight(line, " "))
+}
+
+// migrationPhase returns the phase to show for a model migration; a
+// migration that could not be started has none.
+func migrationPhase(result modelMigrationResult) string {
+	if result.Phase == "" {
+		return "-"
+	}
+	return result.Phase
+}
+
+// formatMigrateTabular writes the issues preventing model migrations,
+// or the results of migrating more than one model, as a table.
+func formatMigrateTabular(writer io.Writer, value interface{}) error {
+	switch value := value.(type) {
+	case []migrationIssue:
+		formatMigrationIssuesTabular(writer, value)
+	case []modelMigrationResult:
+		formatMigrationResultsTabular(writer, value)
+	default:
+		return errors.Errorf("unexpected value of type %T", value)
+	}
+	return nil
+}
+
+func formatMigrationIssuesTabular(writer io.Writer, issues []migrationIssue) {
+	withModel := len(issues) > 0 && issues[0].Model != ""
+	tw := output.TabWriter(writer)
+	if withModel {
+		fmt.Fprint(tw, "MODEL\t")
+	}
+	fmt.Fprintln(tw, "STAGE\tISSUE")
+	for _, issue := range issues {
+		if withModel {
+			fmt.Fprintf(tw, "%s\t", issue.Model)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", issue.Stage, issue.Message)
+	}
+	tw.Flush()
+}
+
+func formatMigrationResultsTabular(writer io.Writer, results []modelMigrationResult) {
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "MODEL\tPHASE\tMESSAGE")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Model, migrationPhase(result), result.Message)
+	}
+	tw.Flush()
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -4,13 +4,16 @@
 package commands
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	cookiejar "github.com/juju/persistent-cookiejar"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
@@ -18,6 +21,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/jujuclient"
@@ -128,9 +132,38 @@ func (s *MigrateSuite) TestMissingTargetController(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "target controller not specified")
 }
 
-func (s *MigrateSuite) TestTooManyArgs(c *gc.C) {
-	_, err := s.makeAndRun(c, "one", "too", "many")
-	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+func (s *MigrateSuite) TestInitMultipleModels(c *gc.C) {
+	cmd := s.makeCommand()
+	err := testing.InitCommand(modelcmd.WrapController(cmd), []string{"one", "two", "target"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmd.models, jc.DeepEquals, []string{"one", "two"})
+	c.Check(cmd.targetController, gc.Equals, "target")
+	c.Check(cmd.maxConcurrent, gc.Equals, 5)
+}
+
+func (s *MigrateSuite) TestInitAll(c *gc.C) {
+	cmd := s.makeCommand()
+	err := testing.InitCommand(modelcmd.WrapController(cmd), []string{"--all", "--max-concurrent", "10", "target"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmd.all, jc.IsTrue)
+	c.Check(cmd.models, gc.HasLen, 0)
+	c.Check(cmd.targetController, gc.Equals, "target")
+	c.Check(cmd.maxConcurrent, gc.Equals, 10)
+}
+
+func (s *MigrateSuite) TestInitAllMissingTargetController(c *gc.C) {
+	_, err := s.makeAndRun(c, "--all")
+	c.Assert(err, gc.ErrorMatches, "target controller not specified")
+}
+
+func (s *MigrateSuite) TestInitAllWithModels(c *gc.C) {
+	_, err := s.makeAndRun(c, "--all", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "models cannot be specified with --all")
+}
+
+func (s *MigrateSuite) TestInitBadMaxConcurrent(c *gc.C) {
+	_, err := s.makeAndRun(c, "--max-concurrent", "0", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--max-concurrent must be at least 1")
 }
 
 func (s *MigrateSuite) TestSuccess(c *gc.C) {
@@ -191,13 +224,279 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // No migration was started.
+	c.Check(s.api.dryRunSpecSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "target",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunIssues(c *gc.C) {
+	s.api.issues = []params.MigrationPrecheckIssue{{
+		Stage:   "source",
+		Message: "unit mysql/0 not idle (executing)",
+	}, {
+		Stage:   "source",
+		Message: "machine 1 tools don't match model (2.0.0 != 2.0.1)",
+	}, {
+		Stage:   "target",
+		Message: "upgrade in progress",
+	}}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `3 issue\(s\) would prevent migration of model "model"`)
+
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+STAGE   ISSUE
+source  unit mysql/0 not idle (executing)
+source  machine 1 tools don't match model (2.0.0 != 2.0.1)
+target  upgrade in progress
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunIssuesJSON(c *gc.C) {
+	s.api.issues = []params.MigrationPrecheckIssue{{
+		Stage:   "import",
+		Message: `cloud credential "aws/bob/default" not found`,
+	}}
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "json", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `1 issue\(s\) would prevent migration of model "model"`)
+	c.Check(testing.Stdout(ctx), gc.Equals,
+		`[{"stage":"import","message":"cloud credential \"aws/bob/default\" not found"}]`+"\n")
+}
+
+func (s *MigrateSuite) addModels(c *gc.C, names ...string) {
+	for i, name := range names {
+		err := s.store.UpdateModel("source", "source/"+name, jujuclient.ModelDetails{
+			ModelUUID: fmt.Sprintf("deadbeef-0bad-400d-8000-4b1d0d06f0%02d", i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *MigrateSuite) TestDryRunMultipleModels(c *gc.C) {
+	s.addModels(c, "model2")
+	s.api.dryRunIssues = map[string][]params.MigrationPrecheckIssue{
+		"deadbeef-0bad-400d-8000-4b1d0d06f000": {{
+			Stage:   "target",
+			Message: "model with same UUID already exists",
+		}},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "model2", "target")
+	c.Assert(err, gc.ErrorMatches, `1 issue\(s\) would prevent migration of the models`)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+MODEL   STAGE   ISSUE
+model2  target  model with same UUID already exists
+`[1:])
+	c.Check(s.api.specsSeen, gc.HasLen, 0)
+}
+
+func (s *MigrateSuite) TestBulkMigration(c *gc.C) {
+	s.addModels(c, "model2", "model3")
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {
+			{Phase: "QUIESCE"},
+			{Phase: "IMPORT", StatusMessage: "importing model into target controller"},
+			{Phase: "DONE", StatusMessage: "successful"},
+		},
+		"uuid:1": {
+			{Phase: "QUIESCE"},
+			{Phase: "ABORTDONE", StatusMessage: "aborted: model data transfer failed"},
+		},
+		"uuid:2": {
+			{Phase: "SUCCESS"},
+			{Phase: "REAPFAILED", StatusMessage: "reap failed"},
+		},
+	}
+	ctx, err := s.makeAndRun(c, "--max-concurrent", "2", "model", "model2", "model3", "target")
+	c.Assert(err, gc.ErrorMatches, `1 of 3 model migration\(s\) failed`)
+
+	c.Assert(s.api.specsSeen, gc.HasLen, 3)
+	c.Check(s.api.specsSeen[0].ModelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.specsSeen[1].ModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f000")
+	c.Check(s.api.specsSeen[2].ModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f001")
+	for _, spec := range s.api.specsSeen {
+		c.Check(spec.TargetControllerUUID, gc.Equals, targetControllerUUID)
+	}
+	// No more than two migrations are in progress at once.
+	c.Check(s.api.statusCalls, jc.DeepEquals, [][]string{
+		{"uuid:0", "uuid:1"},
+		{"uuid:0", "uuid:1"},
+		{"uuid:0", "uuid:2"},
+		{"uuid:2"},
+	})
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+MODEL   PHASE        MESSAGE
+model   QUIESCE
+model2  QUIESCE
+model   IMPORT       importing model into target controller
+model2  ABORTDONE    aborted: model data transfer failed
+model   DONE         successful
+model3  SUCCESS
+model3  REAPFAILED   reap failed
+`[1:])
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+MODEL   PHASE       MESSAGE
+model   DONE        successful
+model2  ABORTDONE   aborted: model data transfer failed
+model3  REAPFAILED  reap failed
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkMigrationStartFailure(c *gc.C) {
+	s.addModels(c, "model2")
+	s.api.initiateErrs = map[string]error{
+		modelUUID: errors.New("model is busy"),
+	}
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:1": {{Phase: "DONE"}},
+	}
+	ctx, err := s.makeAndRun(c, "--format", "yaml", "model", "model2", "target")
+	c.Assert(err, gc.ErrorMatches, `1 of 2 model migration\(s\) failed`)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+- model: model
+  message: model is busy
+- model: model2
+  migration-id: uuid:1
+  phase: DONE
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkMigrationStatusError(c *gc.C) {
+	s.addModels(c, "model2")
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {{Error: &params.Error{Message: "migration not found"}}},
+		"uuid:1": {{Phase: "DONE"}},
+	}
+	_, err := s.makeAndRun(c, "model", "model2", "target")
+	c.Assert(err, gc.ErrorMatches, `1 of 2 model migration\(s\) failed`)
+}
+
+func (s *MigrateSuite) TestBulkMigrationStatusCallRetried(c *gc.C) {
+	s.addModels(c, "model2")
+	s.api.statusErrs = []error{errors.New("connection reset")}
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {{Phase: "DONE"}},
+		"uuid:1": {{Phase: "DONE"}},
+	}
+	ctx, err := s.makeAndRun(c, "model", "model2", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.statusCalls, gc.HasLen, 2)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+MODEL   PHASE  MESSAGE
+model   DONE   
+model2  DONE   
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkMigrationStatusCallFails(c *gc.C) {
+	s.addModels(c, "model2", "model3")
+	s.api.statusErrs = []error{
+		nil,
+		nil,
+		errors.New("connection reset"),
+		errors.New("connection reset"),
+		errors.New("connection reset"),
+	}
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {{Phase: "DONE"}},
+		"uuid:1": {{Phase: "QUIESCE"}},
+	}
+	ctx, err := s.makeAndRun(c, "--max-concurrent", "1", "model", "model2", "model3", "target")
+	c.Assert(err, gc.ErrorMatches, `2 of 3 model migration\(s\) failed`)
+	c.Check(s.api.specsSeen, gc.HasLen, 2)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+MODEL   PHASE    MESSAGE
+model   DONE     
+model2  QUIESCE  cannot get migration status: connection reset
+model3  -        migration not started
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateAll(c *gc.C) {
+	s.api.controllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+	s.api.models = []base.UserModel{{
+		Name:  "controller",
+		UUID:  "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		Owner: "admin",
+	}, {
+		Name:  "model",
+		UUID:  modelUUID,
+		Owner: "source",
+	}, {
+		Name:  "other",
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f000",
+		Owner: "bob",
+	}}
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {{Phase: "DONE"}},
+		"uuid:1": {{Phase: "DONE"}},
+	}
+	ctx, err := s.makeAndRun(c, "--all", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.specsSeen, gc.HasLen, 2)
+	c.Check(s.api.specsSeen[0].ModelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.specsSeen[1].ModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f000")
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+MODEL         PHASE  MESSAGE
+source/model  DONE   
+bob/other     DONE   
+`[1:])
+}
+
+func (s *MigrateSuite) TestMigrateAllHostedModelNamedController(c *gc.C) {
+	s.api.controllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+	s.api.models = []base.UserModel{{
+		Name:  "controller",
+		UUID:  "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		Owner: "admin",
+	}, {
+		Name:  "controller",
+		UUID:  modelUUID,
+		Owner: "bob",
+	}}
+	s.api.statuses = map[string][]params.MigrationStatusResult{
+		"uuid:0": {{Phase: "DONE"}},
+	}
+	_, err := s.makeAndRun(c, "--all", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.specsSeen, gc.HasLen, 1)
+	c.Check(s.api.specsSeen[0].ModelUUID, gc.Equals, modelUUID)
+}
+
+func (s *MigrateSuite) TestMigrateAllNoHostedModels(c *gc.C) {
+	s.api.controllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+	s.api.models = []base.UserModel{{
+		Name:  "controller",
+		UUID:  "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		Owner: "admin",
+	}}
+	_, err := s.makeAndRun(c, "--all", "target")
+	c.Assert(err, gc.ErrorMatches, "no hosted models to migrate")
+	c.Check(s.api.specsSeen, gc.HasLen, 0)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return s.run(c, s.makeCommand(), args...)
 }
 
 func (s *MigrateSuite) makeCommand() *migrateCommand {
 	cmd := &migrateCommand{
-		api: s.api,
+		api:   s.api,
+		clock: immediateClock{},
 		newAPIRoot: func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 			return s.targetControllerAPI, nil
 		},
@@ -211,12 +510,81 @@ func (s *MigrateSuite) run(c *gc.C, cmd *migrateCommand, args ...string) (*cmd.C
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen       *controller.MigrationSpec
+	specsSeen      []controller.MigrationSpec
+	initiateErrs   map[string]error
+	dryRunSpecSeen *controller.MigrationSpec
+	issues         []params.MigrationPrecheckIssue
+	dryRunIssues   map[string][]params.MigrationPrecheckIssue
+	models         []base.UserModel
+	controllerUUID string
+	statuses       map[string][]params.MigrationStatusResult
+	statusErrs     []error
+	statusCalls    [][]string
 }
 
+func (a *fakeMigrateAPI) AllModels() ([]base.UserModel, error) {
+	return a.models, nil
+}
+
+// ModelConfig returns the config of the controller model, which is the
+// model with UUID controllerUUID.
+func (a *fakeMigrateAPI) ModelConfig() (map[string]interface{}, error) {
+	return map[string]interface{}{"uuid": a.controllerUUID}, nil
+}
+
+// InitiateMigration returns ids numbered in the order the migrations
+// were started.
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
-	return "uuid:0", nil
+	a.specsSeen = append(a.specsSeen, spec)
+	if err := a.initiateErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("uuid:%d", len(a.specsSeen)-1), nil
+}
+
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) ([]params.MigrationPrecheckIssue, error) {
+	a.dryRunSpecSeen = &spec
+	if a.dryRunIssues != nil {
+		return a.dryRunIssues[spec.ModelUUID], nil
+	}
+	return a.issues, nil
+}
+
+// MigrationStatus fails with the next of statusErrs, if any is left and
+// not nil, and otherwise reports the next of the statuses of each
+// migration, and then the last one again.
+func (a *fakeMigrateAPI) MigrationStatus(ids []string) ([]params.MigrationStatusResult, error) {
+	a.statusCalls = append(a.statusCalls, ids)
+	if len(a.statusErrs) > 0 {
+		err := a.statusErrs[0]
+		a.statusErrs = a.statusErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	results := make([]params.MigrationStatusResult, len(ids))
+	for i, id := range ids {
+		statuses := a.statuses[id]
+		results[i] = statuses[0]
+		results[i].MigrationId = id
+		if len(statuses) > 1 {
+			a.statuses[id] = statuses[1:]
+		}
+	}
+	return results, nil
+}
+
+// immediateClock is a clock whose timers expire at once.
+type immediateClock struct {
+	clock.Clock
+}
+
+func (immediateClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
 }
 
 type fakeModelAPI struct {
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	actionapi "github.com/juju/juju/api/action"
@@ -36,6 +38,7 @@ type runCommand struct {
 	services []string
 	units    []string
 	commands string
+	rolling  action.Rolling
 }
 
 const runDoc = `
@@ -67,6 +70,16 @@ targets.
 
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
+
+With --batch-size, the commands are run on that many targets at a time,
+rather than on all of them at once; each batch must finish before the next
+is started, after waiting for --batch-wait. If more than --max-failures
+targets fail, no more batches are started. A target fails if its command
+cannot be run, times out, or exits with a non-zero code. For example, to
+restart a service on the units of an application two at a time, stopping
+if any of them fails:
+
+    juju run --application mysql --batch-size 2 --batch-wait 30s 'service mysql restart'
 `
 
 func (c *runCommand) Info() *cmd.Info {
@@ -91,6 +104,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	c.rolling.SetFlags(f)
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -135,6 +149,9 @@ func (c *runCommand) Init(args []string) error {
 		return errors.Errorf("The following run targets are not valid:\n%s",
 			strings.Join(nameErrors, "\n"))
 	}
+	if err := c.rolling.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 
 	return cmd.CheckEmpty(args)
 }
@@ -194,6 +211,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.rolling.Enabled() {
+		return c.runRolling(ctx, client)
+	}
+
 	var runResults []params.ActionResult
 	if c.all {
 		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
@@ -212,6 +233,150 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	actionsToQuery := queryActions(ctx, runResults)
+	if len(actionsToQuery) == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
+	values, _, err := waitForActions(client, actionsToQuery)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.writeResults(ctx, values)
+}
+
+// runRolling runs the commands on the targets in batches, as the
+// rolling options specify, and writes the results of those it ran on.
+func (c *runCommand) runRolling(ctx *cmd.Context, client RunClient) error {
+	targets, err := c.rollingTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(targets) == 0 {
+		return errors.New("no targets to run on")
+	}
+
+	// All the batches are added to the operation created for the
+	// first, so that the run may be followed as a whole.
+	operationId := ""
+	values := []interface{}{}
+	runBatch := func(batch []string) (int, error) {
+		runParams := params.RunParams{
+			Commands:  c.commands,
+			Timeout:   c.timeout,
+			Operation: operationId,
+		}
+		for _, target := range batch {
+			tag, err := names.ParseTag(target)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			switch tag.(type) {
+			case names.UnitTag:
+				runParams.Units = append(runParams.Units, tag.Id())
+			case names.MachineTag:
+				runParams.Machines = append(runParams.Machines, tag.Id())
+			}
+		}
+		ctx.Infof("running on %s", strings.Join(append(runParams.Units, runParams.Machines...), ", "))
+		runResults, err := client.Run(runParams)
+		if err != nil {
+			return 0, block.ProcessBlockedError(err, block.BlockChange)
+		}
+		for _, result := range runResults {
+			if operationId == "" && result.Operation != "" {
+				operationId = result.Operation
+				ctx.Infof("operation %s", operationId)
+			}
+		}
+		actionsToQuery := queryActions(ctx, runResults)
+		batchValues, failed, err := waitForActions(client, actionsToQuery)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		values = append(values, batchValues...)
+		// Targets whose actions could not be enqueued have failed
+		// too.
+		return failed + len(batch) - len(actionsToQuery), nil
+	}
+	rollingErr := c.rolling.Run(targets, runBatch, afterFunc)
+	if len(values) > 0 {
+		if err := c.writeResults(ctx, values); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return rollingErr
+}
+
+// rollingTargets returns the tags of the machines and units on which
+// the commands are run, in the order they are run on. Applications are
+// expanded to their units, which needs the model's status.
+func (c *runCommand) rollingTargets() ([]string, error) {
+	unitNames := set.NewStrings(c.units...)
+	var machineIds []string
+	if c.all || len(c.services) > 0 {
+		client, err := getRunStatusClient(c)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer client.Close()
+		status, err := client.Status(nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if c.all {
+			machineIds = statusMachineIds(status.Machines)
+		}
+		applications := set.NewStrings(c.services...)
+		for _, applicationName := range c.services {
+			if _, ok := status.Applications[applicationName]; !ok {
+				return nil, errors.NotFoundf("application %q", applicationName)
+			}
+		}
+		for _, app := range status.Applications {
+			addStatusUnitNames(unitNames, applications, app.Units)
+		}
+	}
+	if !c.all {
+		machineIds = c.machines
+	}
+
+	var targets []string
+	for _, unitName := range unitNames.SortedValues() {
+		targets = append(targets, names.NewUnitTag(unitName).String())
+	}
+	for _, machineId := range machineIds {
+		targets = append(targets, names.NewMachineTag(machineId).String())
+	}
+	return targets, nil
+}
+
+// statusMachineIds returns the ids of the machines and containers in
+// the status, sorted.
+func statusMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for _, machine := range machines {
+		ids = append(ids, machine.Id)
+		ids = append(ids, statusMachineIds(machine.Containers)...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// addStatusUnitNames adds the names of the units in the status, and of
+// their subordinates, that belong to the given applications.
+func addStatusUnitNames(unitNames, applications set.Strings, units map[string]params.UnitStatus) {
+	for unitName, unit := range units {
+		if applicationName, err := names.UnitApplication(unitName); err == nil && applications.Contains(applicationName) {
+			unitNames.Add(unitName)
+		}
+		addStatusUnitNames(unitNames, applications, unit.Subordinates)
+	}
+}
+
+// queryActions returns the actions to query for the results of
+// enqueueing them, reporting any that could not be enqueued.
+func queryActions(ctx *cmd.Context, runResults []params.ActionResult) []actionQuery {
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
@@ -245,38 +410,47 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				tag:          receiverTag,
 			}})
 	}
+	return actionsToQuery
+}
 
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
+// waitForActions waits for the actions to finish, and returns their
+// results ready for formatting, with the number that failed.
+func waitForActions(client RunClient, actionsToQuery []actionQuery) ([]interface{}, int, error) {
+	results, err := action.WaitForActions(client, entities(actionsToQuery).Entities, afterFunc)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
 	}
-
 	values := []interface{}{}
-	for len(actionsToQuery) > 0 {
-		actionResults, err := client.Actions(entities(actionsToQuery))
-		if err != nil {
-			return errors.Trace(err)
+	failed := 0
+	for i, result := range results {
+		if actionFailed(result) {
+			failed++
 		}
+		values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+	}
+	return values, failed, nil
+}
 
-		newActionsToQuery := []actionQuery{}
-		for i, result := range actionResults.Results {
-			if result.Error == nil {
-				switch result.Status {
-				case params.ActionRunning, params.ActionPending:
-					newActionsToQuery = append(newActionsToQuery, actionsToQuery[i])
-					continue
-				}
-			}
-
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+// actionFailed reports whether the finished action failed to run the
+// commands, or they exited with a non-zero code.
+func actionFailed(result params.ActionResult) bool {
+	if result.Error != nil {
+		return true
+	}
+	switch result.Status {
+	case params.ActionFailed, params.ActionAborted, params.ActionCancelled:
+		return true
+	}
+	if res, ok := result.Output["Code"].(string); ok {
+		if code, err := strconv.Atoi(res); err == nil && code != 0 {
+			return true
 		}
-
-		actionsToQuery = newActionsToQuery
-
-		// TODO: use a watcher instead of sleeping
-		// this should be easier once we implement action grouping
-		<-afterFunc(1 * time.Second)
 	}
+	return false
+}
 
+// writeResults writes the results of the actions.
+func (c *runCommand) writeResults(ctx *cmd.Context, values []interface{}) error {
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
 	if len(values) == 1 && c.out.Name() == "default" {
@@ -330,6 +504,21 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// runStatusClient is the part of the client API used to find the
+// targets of a rolling run.
+type runStatusClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+var getRunStatusClient = func(c *runCommand) (runStatusClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return root.Client(), nil
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
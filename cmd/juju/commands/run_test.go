@@ -227,6 +227,141 @@ func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestRollingArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message  string
+		args     []string
+		errMatch string
+		rolling  action.Rolling
+	}{{
+		message: "not rolling",
+		args:    []string{"--all", "sudo reboot"},
+	}, {
+		message: "rolling",
+		args:    []string{"--batch-size=2", "--batch-wait=30s", "--max-failures=1", "--all", "sudo reboot"},
+		rolling: action.Rolling{BatchSize: 2, BatchWait: 30 * time.Second, MaxFailures: 1},
+	}, {
+		message:  "negative batch size",
+		args:     []string{"--batch-size=-1", "--all", "sudo reboot"},
+		errMatch: "invalid batch size -1",
+	}, {
+		message:  "negative max failures",
+		args:     []string{"--batch-size=1", "--max-failures=-1", "--all", "sudo reboot"},
+		errMatch: "invalid max failures -1",
+	}, {
+		message:  "batch wait without batch size",
+		args:     []string{"--batch-wait=30s", "--all", "sudo reboot"},
+		errMatch: "--batch-wait and --max-failures require --batch-size",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		testing.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.rolling, gc.Equals, test.rolling)
+		}
+	}
+}
+
+func (s *RunSuite) TestRunRollingStopsAfterFailures(c *gc.C) {
+	mock := s.setupMockAPI()
+	var waits []time.Duration
+	s.PatchValue(&afterFunc, func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	mock.setResponse("unit/0", mockResponse{stdout: "ok", code: "0", unitTag: "unit-unit-0"})
+	mock.setResponse("unit/1", mockResponse{stdout: "oops", code: "1", unitTag: "unit-unit-1"})
+	mock.setResponse("unit/2", mockResponse{stdout: "ok", code: "0", unitTag: "unit-unit-2"})
+	mock.actionResponses = map[string]params.ActionResult{}
+	for _, id := range []string{"unit/0", "unit/1", "unit/2"} {
+		mock.actionResponses[mock.receiverIdMap[id]] = mock.runResponses[id]
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--batch-wait=30s",
+		"--unit=unit/2,unit/1,unit/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "1 of 2 targets failed, more than the 0 allowed; not running on the remaining 1")
+
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"unit/0"})
+	c.Check(mock.runCalls[1].Units, jc.DeepEquals, []string{"unit/1"})
+	// The actions have finished when first polled, so the only
+	// wait is the one between the batches.
+	c.Check(waits, jc.DeepEquals, []time.Duration{30 * time.Second})
+
+	unformatted := []interface{}{
+		ConvertActionResults(mock.runResponses["unit/0"], makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))),
+		ConvertActionResults(mock.runResponses["unit/1"], makeActionQuery(mock.receiverIdMap["unit/1"], "UnitId", names.NewUnitTag("unit/1"))),
+	}
+	buff := &bytes.Buffer{}
+	err = cmd.FormatJson(buff, unformatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, buff.String())
+}
+
+func (s *RunSuite) TestRunRollingApplication(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.PatchValue(&afterFunc, func(d time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	s.PatchValue(&getRunStatusClient, func(_ *runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"wordpress": {Units: map[string]params.UnitStatus{
+					"wordpress/0": {Subordinates: map[string]params.UnitStatus{
+						"logging/0": {},
+					}},
+					"wordpress/1": {Subordinates: map[string]params.UnitStatus{
+						"logging/1": {},
+					}},
+				}},
+				"logging": {},
+			},
+		}}, nil
+	})
+	for _, id := range []string{"logging/0", "logging/1"} {
+		mock.setResponse(id, mockResponse{stdout: "ok", unitTag: names.NewUnitTag(id).String()})
+	}
+	mock.actionResponses = map[string]params.ActionResult{}
+	for _, id := range []string{"logging/0", "logging/1"} {
+		mock.actionResponses[mock.receiverIdMap[id]] = mock.runResponses[id]
+	}
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--batch-size=1", "--application=logging", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"logging/0"})
+	c.Check(mock.runCalls[0].Applications, gc.HasLen, 0)
+	c.Check(mock.runCalls[1].Units, jc.DeepEquals, []string{"logging/1"})
+}
+
+func (s *RunSuite) TestRunRollingUnknownApplication(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.PatchValue(&getRunStatusClient, func(_ *runCommand) (runStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"wordpress": {Units: map[string]params.UnitStatus{
+					"wordpress/0": {},
+				}},
+			},
+		}}, nil
+	})
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--batch-size=1", "--application=wordpress,wordpres", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `application "wordpres" not found`)
+	c.Assert(mock.runCalls, gc.HasLen, 0)
+}
+
 func (s *RunSuite) TestRunForMachineAndUnit(c *gc.C) {
 	mock := s.setupMockAPI()
 	machineResponse := mockResponse{
@@ -418,6 +553,7 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runCalls        []params.RunParams
 }
 
 type mockResponse struct {
@@ -523,6 +659,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
+	m.runCalls = append(m.runCalls, runParams)
 
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
@@ -564,5 +701,17 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+type mockRunStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (m *mockRunStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return m.status, nil
+}
+
+func (*mockRunStatusAPI) Close() error {
+	return nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
@@ -0,0 +1,278 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// defaultAuditLogPollInterval is how often new audit entries are
+// requested when following the audit log.
+const defaultAuditLogPollInterval = 2 * time.Second
+
+// NewAuditLogCommand returns a command that displays the audit
+// entries recorded by a controller.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// auditLogCommand displays the audit entries recorded by a
+// controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	api   AuditLogAPI
+	clock clock.Clock
+
+	filter  params.AuditLogFilter
+	after   string
+	before  string
+	follow  bool
+	isoTime bool
+
+	// omitHeader is set once the tabular header has been written
+	// when following the audit log.
+	omitHeader bool
+}
+
+const auditLogHelpDoc = `
+Displays the audit entries recorded by the controller, oldest first.
+Auditing must be enabled on the controller, with the "mongo" audit
+sink selected, for entries to be available.
+
+Entries may be filtered by model UUID, origin, operation, remote
+address and time. Times may be given as YYYY-MM-DD or in RFC3339
+format. Operations match if they contain the specified text.
+
+With --follow, the command continues to display new entries as they
+are recorded, until interrupted.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --origin user-admin --operation Deploy
+    juju audit-log --model-uuid <uuid> --after 2016-11-01 --format json
+    juju audit-log --follow
+
+See also:
+    controller-config
+    debug-log
+`
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays audit entries recorded by a controller.",
+		Doc:     strings.TrimSpace(auditLogHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.filter.ModelUUID, "model-uuid", "", "Only show entries for the model with this UUID")
+	f.StringVar(&c.filter.OriginName, "origin", "", "Only show entries triggered by this origin (e.g. user-admin)")
+	f.StringVar(&c.filter.OriginType, "origin-type", "", "Only show entries with this origin type")
+	f.StringVar(&c.filter.Operation, "operation", "", "Only show entries whose operation contains this text")
+	f.StringVar(&c.filter.RemoteAddress, "remote-address", "", "Only show entries triggered from this address")
+	f.StringVar(&c.after, "after", "", "Only show entries recorded at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show entries recorded before this time")
+	f.IntVar(&c.filter.Limit, "n", 100, "Show at most this many of the most recent entries")
+	f.BoolVar(&c.follow, "follow", false, "Wait for and display new entries")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.filter.Limit < 0 {
+		return errors.NotValidf("negative -n")
+	}
+	if c.after != "" {
+		after, err := parseAuditLogTime(c.after)
+		if err != nil {
+			return errors.Annotate(err, "invalid --after")
+		}
+		c.filter.After = &after
+	}
+	if c.before != "" {
+		if c.follow {
+			return errors.New("--before cannot be used with --follow")
+		}
+		before, err := parseAuditLogTime(c.before)
+		if err != nil {
+			return errors.Annotate(err, "invalid --before")
+		}
+		c.filter.Before = &before
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		if value := os.Getenv(osenv.JujuStatusIsoTimeEnvKey); value != "" {
+			isoTime, err := strconv.ParseBool(value)
+			if err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+			c.isoTime = isoTime
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func parseAuditLogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is not a YYYY-MM-DD or RFC3339 time", value)
+	}
+	return t.UTC(), nil
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(c.filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) > 0 || c.out.Name() != "tabular" {
+		if err := c.out.Write(ctx, entries); err != nil {
+			return errors.Trace(err)
+		}
+		c.omitHeader = c.follow
+	}
+	if !c.follow {
+		if len(entries) == 0 && c.out.Name() == "tabular" {
+			fmt.Fprintln(ctx.Stderr, "No audit entries to display.")
+		}
+		return nil
+	}
+	return c.followEntries(ctx, client, entries)
+}
+
+// followEntries polls for and writes new entries until the command
+// is interrupted.
+func (c *auditLogCommand) followEntries(ctx *cmd.Context, client AuditLogAPI, entries []params.AuditLogEntry) error {
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	// The lower time bound is inclusive, so each poll requests again
+	// the entries recorded at the time of the last one shown, in case
+	// more were recorded at that time; those already shown are
+	// recognised by ID and skipped.
+	filter := c.filter
+	filter.Limit = 0
+	shown := make(map[string]bool)
+	for {
+		if n := len(entries); n > 0 {
+			last := entries[n-1].Timestamp
+			if filter.After == nil || !last.Equal(*filter.After) {
+				shown = make(map[string]bool)
+			}
+			filter.After = &last
+			for _, entry := range entries {
+				if entry.Timestamp.Equal(last) {
+					shown[entry.ID] = true
+				}
+			}
+		}
+		select {
+		case <-interrupted:
+			return nil
+		case <-c.clock.After(defaultAuditLogPollInterval):
+		}
+		results, err := client.Query(filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entries = nil
+		for _, entry := range results {
+			if !shown[entry.ID] {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if err := c.out.Write(ctx, entries); err != nil {
+			return errors.Trace(err)
+		}
+		c.omitHeader = true
+	}
+}
+
+// formatTabular writes a tabular summary of audit entries.
+func (c *auditLogCommand) formatTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]params.AuditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	if !c.omitHeader {
+		w.Println("TIME", "MODEL", "ORIGIN", "ADDRESS", "OPERATION")
+	}
+	for _, entry := range entries {
+		timestamp := entry.Timestamp
+		w.Println(
+			common.FormatTime(&timestamp, c.isoTime),
+			entry.ModelUUID,
+			entry.OriginName,
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			ID:                "5810a1f2c4d9e21a2b3c4d5e",
+			JujuServerVersion: "2.1.0",
+			ModelUUID:         testing.ModelTag.Id(),
+			Timestamp:         time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1:43210",
+			OriginType:        "API request",
+			OriginName:        "user-admin",
+			Operation:         "Client:v1 - FullStatus",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, jujutesting.NewClock(time.Time{}))
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"foo"},
+		expect: `unrecognized args: \["foo"\]`,
+	}, {
+		args:   []string{"--after", "yesterday"},
+		expect: `invalid --after: "yesterday" is not a YYYY-MM-DD or RFC3339 time`,
+	}, {
+		args:   []string{"--follow", "--before", "2016-11-01"},
+		expect: `--before cannot be used with --follow`,
+	}, {
+		args:   []string{"-n", "-1"},
+		expect: `negative -n not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.store, nil)
+		err := testing.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--model-uuid", testing.ModelTag.Id(),
+		"--origin", "user-admin",
+		"--operation", "FullStatus",
+		"--remote-address", "10.0.0.1",
+		"--after", "2016-10-01",
+		"--before", "2016-11-02T00:00:00Z",
+		"-n", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2016, time.October, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2016, time.November, 2, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Query", []interface{}{params.AuditLogFilter{
+			ModelUUID:     testing.ModelTag.Id(),
+			OriginName:    "user-admin",
+			Operation:     "FullStatus",
+			RemoteAddress: "10.0.0.1",
+			After:         &after,
+			Before:        &before,
+			Limit:         5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  MODEL                                 ORIGIN      ADDRESS         OPERATION\n"+
+		"2016-11-01 10:00:00Z  deadbeef-0bad-400d-8000-4b1d0d06f00d  user-admin  10.0.0.1:43210  Client:v1 - FullStatus\n")
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"id":"5810a1f2c4d9e21a2b3c4d5e","juju-server-version":"2.1.0",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","timestamp":"2016-11-01T10:00:00Z",`+
+		`"remote-address":"10.0.0.1:43210","origin-type":"API request","origin-name":"user-admin",`+
+		`"operation":"Client:v1 - FullStatus"}]`+"\n")
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No audit entries to display.\n")
+}
+
+func (s *AuditLogSuite) TestFollowSkipsEntriesAlreadyShown(c *gc.C) {
+	t0 := time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC)
+	first := s.api.entries[0]
+	second := first
+	second.ID = "5810a1f2c4d9e21a2b3c4d5f"
+	second.Operation = "Client:v1 - AddMachines"
+	third := first
+	third.ID = "5810a1f2c4d9e21a2b3c4d60"
+	third.Timestamp = t0.Add(time.Second)
+	third.Operation = "Client:v1 - Destroy"
+	// The second entry was recorded at the same time as the first,
+	// so the first is returned again by the following query.
+	s.api.results = [][]params.AuditLogEntry{
+		{first},
+		{first, second},
+		{second, third},
+	}
+	s.api.SetErrors(nil, nil, nil, errors.New("done"))
+
+	clock := jujutesting.NewClock(time.Time{})
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, clock)
+	type result struct {
+		ctx *cmd.Context
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ctx, err := testing.RunCommand(c, command, "--follow", "--utc")
+		done <- result{ctx, err}
+	}()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-clock.Alarms():
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for poll %d", i)
+		}
+		clock.Advance(2 * time.Second)
+	}
+	var r result
+	select {
+	case r = <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+	c.Assert(r.err, gc.ErrorMatches, "done")
+	c.Assert(testing.Stdout(r.ctx), gc.Equals, ""+
+		"TIME                  MODEL                                 ORIGIN      ADDRESS         OPERATION\n"+
+		"2016-11-01 10:00:00Z  deadbeef-0bad-400d-8000-4b1d0d06f00d  user-admin  10.0.0.1:43210  Client:v1 - FullStatus\n"+
+		"2016-11-01 10:00:00Z  deadbeef-0bad-400d-8000-4b1d0d06f00d  user-admin  10.0.0.1:43210  Client:v1 - AddMachines\n"+
+		"2016-11-01 10:00:01Z  deadbeef-0bad-400d-8000-4b1d0d06f00d  user-admin  10.0.0.1:43210  Client:v1 - Destroy\n")
+	t1 := t0.Add(time.Second)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Query", []interface{}{params.AuditLogFilter{}}},
+		{"Query", []interface{}{params.AuditLogFilter{After: &t0}}},
+		{"Query", []interface{}{params.AuditLogFilter{After: &t0}}},
+		{"Query", []interface{}{params.AuditLogFilter{After: &t1}}},
+		{"Close", nil},
+	})
+}
+
+type fakeAuditLogAPI struct {
+	jujutesting.Stub
+	entries []params.AuditLogEntry
+	results [][]params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Query(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "Query", filter)
+	if len(f.results) > 0 {
+		entries := f.results[0]
+		f.results = f.results[1:]
+		return entries, f.NextErr()
+	}
+	return f.entries, f.NextErr()
+}
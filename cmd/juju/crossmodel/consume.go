@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// NewConsumeCommand returns a command that adds an application offered
+// by another model to the current model.
+func NewConsumeCommand() cmd.Command {
+	return modelcmd.Wrap(&consumeCommand{})
+}
+
+type consumeCommand struct {
+	modelcmd.ModelCommandBase
+	api       ConsumeAPI
+	offerURL  string
+	localName string
+}
+
+var consumeDoc = `
+Adds a remote application to the model, for an application offered by
+another model on the controller. The remote application can then be
+related to the model's own applications with add-relation, and its
+units appear to them as the units of any other related application.
+
+The offer is given as [<owner>/]<model>.<offer>; the owner defaults to
+the current user. The remote application is named after the offer,
+unless a local name is given.
+
+Examples:
+
+    juju consume prod.mysql
+    juju consume bob/prod.mysql prod-db
+
+See also:
+    add-relation
+    find-offers
+    offer
+`
+
+// ConsumeAPI defines the API methods used by the consume command.
+type ConsumeAPI interface {
+	Close() error
+	Consume(offerURL, alias string) (string, error)
+}
+
+func (c *consumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "consume",
+		Args:    "[<owner>/]<model>.<offer> [<local name>]",
+		Purpose: "Adds a remote application offered by another model.",
+		Doc:     consumeDoc,
+	}
+}
+
+func (c *consumeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+func (c *consumeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	if _, err := crossmodel.ParseOfferURL(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.offerURL = args[0]
+	if len(args) > 1 {
+		c.localName = args[1]
+		if !names.IsValidApplication(c.localName) {
+			return errors.NotValidf("application name %q", c.localName)
+		}
+	}
+	if len(args) > 2 {
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+func (c *consumeCommand) getAPI() (ConsumeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	name, err := client.Consume(c.offerURL, c.localName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added %s as %s", c.offerURL, name)
+	return nil
+}
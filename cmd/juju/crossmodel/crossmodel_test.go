@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type crossModelSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake  fakeOffersClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&crossModelSuite{})
+
+type fakeOffersClient struct {
+	gitjujutesting.Stub
+	offers []params.ApplicationOffer
+}
+
+func (f *fakeOffersClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeOffersClient) Offer(offer params.AddApplicationOffer) error {
+	f.MethodCall(f, "Offer", offer)
+	return f.NextErr()
+}
+
+func (f *fakeOffersClient) FindApplicationOffers(filter params.OfferFilter) ([]params.ApplicationOffer, error) {
+	f.MethodCall(f, "FindApplicationOffers", filter)
+	return f.offers, f.NextErr()
+}
+
+func (f *fakeOffersClient) Consume(offerURL, alias string) (string, error) {
+	f.MethodCall(f, "Consume", offerURL, alias)
+	if alias == "" {
+		alias = "mysql"
+	}
+	return alias, f.NextErr()
+}
+
+func (s *crossModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeOffersClient{
+		offers: []params.ApplicationOffer{{
+			OfferURL:        "bob/prod.mysql",
+			OfferName:       "mysql",
+			ApplicationName: "mysql",
+			Endpoints: []params.CharmRelation{{
+				Name: "db", Role: "provider", Interface: "mysql",
+			}, {
+				Name: "admin", Role: "provider", Interface: "mysql-root",
+			}},
+		}, {
+			OfferURL:               "admin/staging.cache",
+			OfferName:              "cache",
+			ApplicationName:        "memcached",
+			ApplicationDescription: "Staging cache",
+			Endpoints: []params.CharmRelation{{
+				Name: "cache", Role: "provider", Interface: "memcache",
+			}},
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *crossModelSuite) newOfferCommand() cmd.Command {
+	command := &offerCommand{api: &s.fake}
+	command.SetClientStore(s.store)
+	return modelcmd.Wrap(command)
+}
+
+func (s *crossModelSuite) newConsumeCommand() cmd.Command {
+	command := &consumeCommand{api: &s.fake}
+	command.SetClientStore(s.store)
+	return modelcmd.Wrap(command)
+}
+
+func (s *crossModelSuite) newFindOffersCommand() cmd.Command {
+	command := &findOffersCommand{api: &s.fake}
+	command.SetClientStore(s.store)
+	return modelcmd.Wrap(command)
+}
+
+func (s *crossModelSuite) TestOfferInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application endpoints specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `endpoints "mysql" not valid, expected <application>:<endpoint>\[,<endpoint>...\]`,
+	}, {
+		args: []string{"mysql:db,"},
+		err:  `endpoints "mysql:db," not valid, expected <application>:<endpoint>\[,<endpoint>...\]`,
+	}, {
+		args: []string{"-:db"},
+		err:  `application name "-" not valid`,
+	}, {
+		args: []string{"mysql:db", "shared.db"},
+		err:  `offer name "shared.db" not valid`,
+	}, {
+		args: []string{"mysql:db", "shared-db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := coretesting.RunCommand(c, s.newOfferCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *crossModelSuite) TestOffer(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newOfferCommand(),
+		"--description", "Production database", "mysql:db,admin", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Offer", []interface{}{params.AddApplicationOffer{
+			OfferName:              "shared-db",
+			ApplicationName:        "mysql",
+			ApplicationDescription: "Production database",
+			Endpoints:              []string{"db", "admin"},
+		}}},
+		{"Close", nil},
+	})
+	c.Assert(coretesting.Stderr(ctx), gc.Equals,
+		`Application "mysql" endpoints [db admin] available at "admin/mymodel.shared-db"`+"\n")
+}
+
+func (s *crossModelSuite) TestOfferError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := coretesting.RunCommand(c, s.newOfferCommand(), "mysql:db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *crossModelSuite) TestConsumeInit(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newConsumeCommand())
+	c.Check(err, gc.ErrorMatches, "no offer specified")
+	_, err = coretesting.RunCommand(c, s.newConsumeCommand(), "mysql")
+	c.Check(err, gc.ErrorMatches, `offer URL "mysql", expected .* not valid`)
+	_, err = coretesting.RunCommand(c, s.newConsumeCommand(), "prod.mysql", "-")
+	c.Check(err, gc.ErrorMatches, `application name "-" not valid`)
+	_, err = coretesting.RunCommand(c, s.newConsumeCommand(), "prod.mysql", "db", "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *crossModelSuite) TestConsume(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newConsumeCommand(), "bob/prod.mysql", "prod-db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Consume", []interface{}{"bob/prod.mysql", "prod-db"}},
+		{"Close", nil},
+	})
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "Added bob/prod.mysql as prod-db\n")
+}
+
+func (s *crossModelSuite) TestFindOffersInit(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newFindOffersCommand(), "-bob/prod")
+	c.Check(err, gc.ErrorMatches, `owner "-bob" not valid`)
+	_, err = coretesting.RunCommand(c, s.newFindOffersCommand(), "prod.-")
+	c.Check(err, gc.ErrorMatches, `offer name "-" not valid`)
+	_, err = coretesting.RunCommand(c, s.newFindOffersCommand(), "prod", "staging")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["staging"\]`)
+}
+
+func (s *crossModelSuite) TestFindOffersFilter(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newFindOffersCommand(), "--application", "mysql", "bob/prod.db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"FindApplicationOffers", []interface{}{params.OfferFilter{
+			OwnerName:       "bob",
+			ModelName:       "prod",
+			OfferName:       "db",
+			ApplicationName: "mysql",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *crossModelSuite) TestFindOffersTabular(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newFindOffersCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+URL                  APPLICATION  ENDPOINTS
+admin/staging.cache  memcached    cache:memcache
+bob/prod.mysql       mysql        admin:mysql-root,db:mysql
+`[1:])
+}
+
+func (s *crossModelSuite) TestFindOffersYAML(c *gc.C) {
+	s.fake.offers = s.fake.offers[1:]
+	ctx, err := coretesting.RunCommand(c, s.newFindOffersCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+admin/staging.cache:
+  application: memcached
+  description: Staging cache
+  endpoints:
+    cache:
+      interface: memcache
+      role: provider
+`[1:])
+}
+
+func (s *crossModelSuite) TestFindOffersNone(c *gc.C) {
+	s.fake.offers = nil
+	ctx, err := coretesting.RunCommand(c, s.newFindOffersCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No application offers found.\n")
+}
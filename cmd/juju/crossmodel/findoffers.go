@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// NewFindOffersCommand returns a command that lists the application
+// offers the user can consume.
+func NewFindOffersCommand() cmd.Command {
+	return modelcmd.Wrap(&findOffersCommand{})
+}
+
+type findOffersCommand struct {
+	modelcmd.ModelCommandBase
+	out    cmd.Output
+	api    FindOffersAPI
+	filter params.OfferFilter
+}
+
+var findOffersDoc = `
+Lists the application offers, made by the models on the controller
+that the user can see, which may be consumed into the current model.
+
+The offers may be narrowed down by giving a model, optionally with its
+owner and an offer name, as [<owner>/]<model>[.<offer>], and by the
+name of the offered application.
+
+Examples:
+
+    juju find-offers
+    juju find-offers prod
+    juju find-offers bob/prod.mysql
+    juju find-offers --application mysql
+
+See also:
+    consume
+    offer
+`
+
+// FindOffersAPI defines the API methods used by the find-offers
+// command.
+type FindOffersAPI interface {
+	Close() error
+	FindApplicationOffers(params.OfferFilter) ([]params.ApplicationOffer, error)
+}
+
+// offerEndpoint is an offered endpoint written by the find-offers
+// command.
+type offerEndpoint struct {
+	Interface string `yaml:"interface" json:"interface"`
+	Role      string `yaml:"role" json:"role"`
+}
+
+// offerDetails is the application offer information written by the
+// find-offers command.
+type offerDetails struct {
+	Application string                   `yaml:"application" json:"application"`
+	Description string                   `yaml:"description,omitempty" json:"description,omitempty"`
+	Endpoints   map[string]offerEndpoint `yaml:"endpoints" json:"endpoints"`
+}
+
+func (c *findOffersCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "find-offers",
+		Args:    "[[<owner>/]<model>[.<offer>]]",
+		Purpose: "Lists the application offers that can be consumed.",
+		Doc:     findOffersDoc,
+	}
+}
+
+func (c *findOffersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filter.ApplicationName, "application", "", "Only list the offers of the named application")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFindOffersTabular,
+	})
+}
+
+func (c *findOffersCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	rest := args[0]
+	if i := strings.Index(rest, "/"); i != -1 {
+		c.filter.OwnerName, rest = rest[:i], rest[i+1:]
+		if !names.IsValidUser(c.filter.OwnerName) {
+			return errors.NotValidf("owner %q", c.filter.OwnerName)
+		}
+	}
+	if i := strings.Index(rest, "."); i != -1 {
+		c.filter.OfferName, rest = rest[i+1:], rest[:i]
+		if !crossmodel.IsValidOfferName(c.filter.OfferName) {
+			return errors.NotValidf("offer name %q", c.filter.OfferName)
+		}
+	}
+	c.filter.ModelName = rest
+	if !names.IsValidModelName(c.filter.ModelName) {
+		return errors.NotValidf("model name %q", c.filter.ModelName)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *findOffersCommand) getAPI() (FindOffersAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+func (c *findOffersCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	offers, err := client.FindApplicationOffers(c.filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(offers) == 0 && c.out.Name() == "tabular" {
+		fmt.Fprintln(ctx.Stderr, "No application offers found.")
+		return nil
+	}
+	results := make(map[string]offerDetails)
+	for _, offer := range offers {
+		details := offerDetails{
+			Application: offer.ApplicationName,
+			Description: offer.ApplicationDescription,
+			Endpoints:   make(map[string]offerEndpoint),
+		}
+		for _, ep := range offer.Endpoints {
+			details.Endpoints[ep.Name] = offerEndpoint{
+				Interface: ep.Interface,
+				Role:      ep.Role,
+			}
+		}
+		results[offer.OfferURL] = details
+	}
+	return c.out.Write(ctx, results)
+}
+
+// formatFindOffersTabular writes a tabular summary of application
+// offers.
+func formatFindOffersTabular(writer io.Writer, value interface{}) error {
+	offers, ok := value.(map[string]offerDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", offers, value)
+	}
+	urls := make([]string, 0, len(offers))
+	for url := range offers {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("URL", "APPLICATION", "ENDPOINTS")
+	for _, url := range urls {
+		offer := offers[url]
+		endpointNames := make([]string, 0, len(offer.Endpoints))
+		for name := range offer.Endpoints {
+			endpointNames = append(endpointNames, name)
+		}
+		sort.Strings(endpointNames)
+		endpoints := make([]string, len(endpointNames))
+		for i, name := range endpointNames {
+			endpoints[i] = name + ":" + offer.Endpoints[name].Interface
+		}
+		w.Println(url, offer.Application, strings.Join(endpoints, ","))
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel provides the commands for relating applications
+// across models: offering an application's endpoints, finding the
+// offers made by other models, and consuming them.
+package crossmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// NewOfferCommand returns a command that offers an application's
+// endpoints to other models.
+func NewOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&offerCommand{})
+}
+
+type offerCommand struct {
+	modelcmd.ModelCommandBase
+	api             OfferAPI
+	applicationName string
+	endpoints       []string
+	offerName       string
+	description     string
+}
+
+var offerDoc = `
+Offers some of an application's endpoints to the other models on the
+controller, so that their applications can relate to it. Users who can
+see the model may find the offer with find-offers, and consume it into
+their own models with consume.
+
+The offer is named after the application, unless an offer name is given.
+Only the model's admins may make offers.
+
+Examples:
+
+    juju offer mysql:db
+    juju offer mysql:db,admin shared-db
+    juju offer --description "Production database" mysql:db
+
+See also:
+    consume
+    find-offers
+`
+
+// OfferAPI defines the API methods used by the offer command.
+type OfferAPI interface {
+	Close() error
+	Offer(params.AddApplicationOffer) error
+}
+
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<application>:<endpoint>[,<endpoint>...] [<offer name>]",
+		Purpose: "Offers application endpoints to other models.",
+		Doc:     offerDoc,
+	}
+}
+
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.description, "description", "", "A description of the offered application")
+}
+
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application endpoints specified")
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("endpoints %q not valid, expected <application>:<endpoint>[,<endpoint>...]", args[0])
+	}
+	c.applicationName = parts[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.NotValidf("application name %q", c.applicationName)
+	}
+	c.endpoints = strings.Split(parts[1], ",")
+	for _, endpoint := range c.endpoints {
+		if endpoint == "" {
+			return errors.Errorf("endpoints %q not valid, expected <application>:<endpoint>[,<endpoint>...]", args[0])
+		}
+	}
+	if len(args) > 1 {
+		c.offerName = args[1]
+		if !crossmodel.IsValidOfferName(c.offerName) {
+			return errors.NotValidf("offer name %q", c.offerName)
+		}
+	}
+	if len(args) > 2 {
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+func (c *offerCommand) getAPI() (OfferAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+func (c *offerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.Offer(params.AddApplicationOffer{
+		OfferName:              c.offerName,
+		ApplicationName:        c.applicationName,
+		ApplicationDescription: c.description,
+		Endpoints:              c.endpoints,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offerName := c.offerName
+	if offerName == "" {
+		offerName = c.applicationName
+	}
+	url := fmt.Sprintf("%s.%s", c.ModelName(), offerName)
+	ctx.Infof("Application %q endpoints %v available at %q", c.applicationName, c.endpoints, url)
+	return nil
+}
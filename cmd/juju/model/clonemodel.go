@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewCloneCommand returns a fully constructed clone-model command.
+func NewCloneCommand() cmd.Command {
+	return modelcmd.WrapController(&cloneCommand{})
+}
+
+type cloneCommand struct {
+	modelcmd.ControllerCommandBase
+	api CloneModelAPI
+
+	source    string
+	name      string
+	owner     string
+	withUnits bool
+}
+
+const cloneModelHelpDoc = `
+Creates a new model on the same controller, holding a copy of the
+source model's config, constraints, applications and their charms,
+settings and endpoint bindings, and the relations between them.
+
+The machines and units of the source model are not copied, so the
+applications in the new model are left waiting for units to be added.
+With --with-units, each application is instead given as many units as
+it has in the source model, each on a new machine.
+
+The new model uses the source model's cloud credential, so a different
+--owner may only be given if that user owns the credential.
+
+The source model keeps running unchanged. Cloning requires admin access
+to the source model and permission to add models to the controller.
+
+Examples:
+
+    juju clone-model production staging
+    juju clone-model --with-units production staging
+    juju clone-model --owner bob production staging
+
+See also:
+    add-model
+    export-model
+`
+
+// Info implements Command.
+func (c *cloneCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "clone-model",
+		Args:    "<source model name> <new model name>",
+		Purpose: "Copies a model's applications into a new model.",
+		Doc:     cloneModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *cloneCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "The owner of the new model if not the current user")
+	f.BoolVar(&c.withUnits, "with-units", false, "Deploy as many units of each application as the source model has")
+}
+
+// Init implements Command.
+func (c *cloneCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no source model specified")
+	case 1:
+		return errors.New("no new model name specified")
+	}
+	c.source, c.name = args[0], args[1]
+	if !names.IsValidModelName(c.name) {
+		return errors.Errorf("%q is not a valid name: model names may only contain lowercase letters, digits and hyphens", c.name)
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.Errorf("%q is not a valid user name", c.owner)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// CloneModelAPI specifies the used function calls of the ModelManager.
+type CloneModelAPI interface {
+	Close() error
+	CloneModel(source names.ModelTag, name, owner string, addUnits bool) (base.ModelInfo, error)
+}
+
+func (c *cloneCommand) getAPI() (CloneModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *cloneCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	accountDetails, err := store.AccountDetails(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	source := c.source
+	if !jujuclient.IsQualifiedModelName(source) {
+		source = jujuclient.JoinOwnerModelName(names.NewUserTag(accountDetails.User), source)
+	}
+	sourceDetails, err := store.ModelByName(controllerName, source)
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	owner := accountDetails.User
+	if c.owner != "" {
+		owner = names.NewUserTag(c.owner).Id()
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	model, err := client.CloneModel(names.NewModelTag(sourceDetails.ModelUUID), c.name, owner, c.withUnits)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "clone a model")
+		}
+		return errors.Trace(err)
+	}
+
+	modelName := jujuclient.JoinOwnerModelName(names.NewUserTag(model.Owner), model.Name)
+	if err := store.UpdateModel(controllerName, modelName, jujuclient.ModelDetails{model.UUID}); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Cloned model %q to %q", source, modelName)
+	return nil
+}
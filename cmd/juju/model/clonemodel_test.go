@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type CloneCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeCloneClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&CloneCommandSuite{})
+
+const clonedModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type fakeCloneClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeCloneClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeCloneClient) CloneModel(source names.ModelTag, name, owner string, addUnits bool) (base.ModelInfo, error) {
+	f.MethodCall(f, "CloneModel", source, name, owner, addUnits)
+	return base.ModelInfo{
+		Name:  name,
+		UUID:  clonedModelUUID,
+		Owner: owner,
+	}, f.NextErr()
+}
+
+func (s *CloneCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/production", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CloneCommandSuite) runClone(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewCloneCommandForTest(&s.fake, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *CloneCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no source model specified",
+	}, {
+		args: []string{"production"},
+		err:  "no new model name specified",
+	}, {
+		args: []string{"production", "Staging"},
+		err:  `"Staging" is not a valid name: .*`,
+	}, {
+		args: []string{"production", "staging", "--owner", "not a user"},
+		err:  `"not a user" is not a valid user name`,
+	}, {
+		args: []string{"production", "staging", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runClone(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *CloneCommandSuite) TestClone(c *gc.C) {
+	out, err := s.runClone(c, "production", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `Cloned model "admin/production" to "admin/staging"`+"\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CloneModel", []interface{}{testing.ModelTag, "staging", "admin", false}},
+		{"Close", nil},
+	})
+
+	details, err := s.store.ModelByName("testing", "admin/staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ModelUUID, gc.Equals, clonedModelUUID)
+}
+
+func (s *CloneCommandSuite) TestCloneWithUnitsAndOwner(c *gc.C) {
+	_, err := s.runClone(c, "--with-units", "--owner", "bob", "production", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "CloneModel", testing.ModelTag, "staging", "bob", true)
+
+	_, err = s.store.ModelByName("testing", "bob/staging")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CloneCommandSuite) TestCloneUnknownModel(c *gc.C) {
+	_, err := s.runClone(c, "development", "staging")
+	c.Assert(err, gc.ErrorMatches, "getting model details: .*not found")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *CloneCommandSuite) TestCloneError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.runClone(c, "production", "staging")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.fake.CheckCallNames(c, "CloneModel", "Close")
+
+	_, err = s.store.ModelByName("testing", "admin/staging")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
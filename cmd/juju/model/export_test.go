@@ -107,3 +107,35 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewSecretsCommandForTest returns a secrets command with the api
+// provided as specified.
+func NewSecretsCommandForTest(api SecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &secretsCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewExportCommandForTest returns an export-model command with the
+// apis provided as specified.
+func NewExportCommandForTest(api ExportModelAPI, binariesAPI ModelBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportCommand{api: api, binariesAPI: binariesAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewImportCommandForTest returns an import-model command with the
+// apis provided as specified.
+func NewImportCommandForTest(api ImportModelAPI, binariesAPI ModelBinariesUploadAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importCommand{api: api, binariesAPI: binariesAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewCloneCommandForTest returns a clone-model command with the api
+// provided as specified.
+func NewCloneCommandForTest(api CloneModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &cloneCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
@@ -0,0 +1,254 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+	"path"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	resourceclient "github.com/juju/juju/resource/api/client"
+	"github.com/juju/juju/resource/resourceadapters"
+)
+
+// NewExportCommand returns a fully constructed export-model command.
+func NewExportCommand() cmd.Command {
+	return modelcmd.WrapController(&exportCommand{})
+}
+
+type exportCommand struct {
+	modelcmd.ControllerCommandBase
+	api         ExportModelAPI
+	binariesAPI ModelBinariesAPI
+
+	model    string
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes a model, along with the charms, agent binaries and resources it
+uses, to an archive file. The archive can be carried to a controller
+that cannot reach this one, and the model recreated there with
+import-model.
+
+The model keeps running on this controller, so its machines are still
+managed from here; import-model refuses a model with provisioned
+machines unless --force is given.
+
+If no model is specified, the current model is exported. The file must
+not already exist.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model mymodel mymodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "[<model name>] <file>",
+		Purpose: "Writes a model and its binaries to an archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+}
+
+// Init implements Command.
+func (c *exportCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no file specified")
+	case 1:
+		c.filename = args[0]
+		return nil
+	case 2:
+		c.model, c.filename = args[0], args[1]
+		return nil
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) (params.SerializedModel, error)
+}
+
+// ModelBinariesAPI specifies the calls used to download the binaries
+// and resources used by a model.
+type ModelBinariesAPI interface {
+	Close() error
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+	ListResources(applications []string) ([]resource.ServiceResources, error)
+}
+
+// modelBinariesAPI joins the client for downloading charms and tools
+// with the one for resources, which share the connection.
+type modelBinariesAPI struct {
+	*api.Client
+	resources *resourceclient.Client
+}
+
+// ListResources implements ModelBinariesAPI.
+func (a modelBinariesAPI) ListResources(applications []string) ([]resource.ServiceResources, error) {
+	return a.resources.ListResources(applications)
+}
+
+// OpenResource implements ModelBinariesAPI.
+func (a modelBinariesAPI) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.resources.OpenResource(application, name)
+}
+
+func (c *exportCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+func (c *exportCommand) getBinariesAPI() (ModelBinariesAPI, error) {
+	if c.binariesAPI != nil {
+		return c.binariesAPI, nil
+	}
+	root, err := c.NewModelAPIRoot(c.model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources, err := resourceadapters.NewAPIClient(root)
+	if err != nil {
+		root.Close()
+		return nil, errors.Trace(err)
+	}
+	return modelBinariesAPI{root.Client(), resources}, nil
+}
+
+// Run implements Command.
+func (c *exportCommand) Run(ctx *cmd.Context) (err error) {
+	store := c.ClientStore()
+	if c.model == "" {
+		c.model, err = store.CurrentModel(c.ControllerName())
+		if err != nil {
+			return err
+		}
+	}
+	modelDetails, err := store.ModelByName(c.ControllerName(), c.model)
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	serialized, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return err
+	}
+	tools := make(map[version.Binary]string)
+	for _, t := range serialized.Tools {
+		v, err := version.ParseBinary(t.Version)
+		if err != nil {
+			return errors.Annotate(err, "bad tools version")
+		}
+		tools[v] = t.URI
+	}
+
+	binaries, err := c.getBinariesAPI()
+	if err != nil {
+		return err
+	}
+	defer binaries.Close()
+	resources, err := modelResources(binaries, serialized.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filename := ctx.AbsPath(c.filename)
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+	err = migration.WriteArchive(f, migration.WriteArchiveConfig{
+		ModelBytes:         serialized.Bytes,
+		Charms:             serialized.Charms,
+		CharmDownloader:    binaries,
+		Tools:              tools,
+		ToolsDownloader:    binaries,
+		Resources:          resources,
+		ResourceDownloader: binaries,
+	})
+	if err != nil {
+		return errors.Annotate(err, "writing model archive")
+	}
+	ctx.Infof("Exported model %q to %s", c.model, c.filename)
+	return nil
+}
+
+// modelResources returns the resources of the model's applications
+// that have content, to be written to the archive with the model.
+func modelResources(binaries ModelBinariesAPI, modelBytes []byte) ([]migration.ArchiveResource, error) {
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	}
+	var applications []string
+	for _, application := range model.Applications() {
+		applications = append(applications, application.Name())
+	}
+	if len(applications) == 0 {
+		return nil, nil
+	}
+	serviceResources, err := binaries.ListResources(applications)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing resources")
+	}
+	var resources []migration.ArchiveResource
+	for i, appResources := range serviceResources {
+		for _, res := range appResources.Resources {
+			if res.IsPlaceholder() {
+				// Nothing has been uploaded or fetched yet.
+				continue
+			}
+			resources = append(resources, migration.ArchiveResource{
+				Application: applications[i],
+				Name:        res.Name,
+				Filename:    path.Base(res.Path),
+			})
+		}
+	}
+	return resources, nil
+}
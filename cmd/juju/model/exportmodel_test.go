@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+)
+
+type ExportCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake     fakeExportClient
+	binaries fakeBinariesClient
+	store    *jujuclienttesting.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&ExportCommandSuite{})
+
+type fakeExportClient struct {
+	gitjujutesting.Stub
+	modelBytes []byte
+}
+
+func (f *fakeExportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportClient) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	f.MethodCall(f, "ExportModel", model)
+	if err := f.NextErr(); err != nil {
+		return params.SerializedModel{}, err
+	}
+	return params.SerializedModel{
+		Bytes:  f.modelBytes,
+		Charms: []string{"cs:xenial/mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.0.0-xenial-amd64",
+			URI:     "/tools/2.0.0-xenial-amd64",
+		}},
+	}, nil
+}
+
+type fakeBinariesClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeBinariesClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeBinariesClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return ioutil.NopCloser(bytes.NewBufferString("charm")), f.NextErr()
+}
+
+func (f *fakeBinariesClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri)
+	return ioutil.NopCloser(bytes.NewBufferString("tools")), f.NextErr()
+}
+
+func (f *fakeBinariesClient) ListResources(applications []string) ([]resource.ServiceResources, error) {
+	f.MethodCall(f, "ListResources", applications)
+	placeholder := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{Name: "config", Path: "config.yaml"},
+		},
+	}
+	uploaded := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{Name: "data", Path: "data.tgz"},
+		},
+		Timestamp: time.Now(),
+	}
+	return []resource.ServiceResources{{
+		Resources: []resource.Resource{placeholder, uploaded},
+	}}, f.NextErr()
+}
+
+func (f *fakeBinariesClient) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	return ioutil.NopCloser(bytes.NewBufferString("resource")), f.NextErr()
+}
+
+func (s *ExportCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.binaries.ResetCalls()
+	m := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("admin"),
+		Config: map[string]interface{}{"name": "mymodel"},
+	})
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/mysql-1",
+	})
+	app.SetStatus(description.StatusArgs{Value: "active"})
+	modelBytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.modelBytes = modelBytes
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err = s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+}
+
+func (s *ExportCommandSuite) runExport(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewExportCommandForTest(&s.fake, &s.binaries, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *ExportCommandSuite) TestInit(c *gc.C) {
+	_, err := s.runExport(c)
+	c.Assert(err, gc.ErrorMatches, "no file specified")
+	_, err = s.runExport(c, "mymodel", "file", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportCommandSuite) TestExport(c *gc.C) {
+	out, err := s.runExport(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `Exported model "admin/mymodel" to `+s.filename+"\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.binaries.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ListResources", []interface{}{[]string{"mysql"}}},
+		{"OpenCharm", []interface{}{charm.MustParseURL("cs:xenial/mysql-1")}},
+		{"OpenURI", []interface{}{"/tools/2.0.0-xenial-amd64"}},
+		{"OpenResource", []interface{}{"mysql", "data"}},
+		{"Close", nil},
+	})
+
+	f, err := os.Open(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.ReadArchive(f, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.ModelBytes, jc.DeepEquals, s.fake.modelBytes)
+	c.Check(archive.Charms, jc.DeepEquals, []*charm.URL{charm.MustParseURL("cs:xenial/mysql-1")})
+	c.Check(archive.Tools, jc.DeepEquals, []version.Binary{version.MustParseBinary("2.0.0-xenial-amd64")})
+	c.Check(archive.Resources, jc.DeepEquals, []migration.ArchiveResource{
+		{"mysql", "data", "data.tgz"},
+	})
+}
+
+func (s *ExportCommandSuite) TestExportNamedModel(c *gc.C) {
+	_, err := s.runExport(c, "admin/mymodel", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "ExportModel", testing.ModelTag)
+}
+
+func (s *ExportCommandSuite) TestExportFileExists(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("precious"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runExport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, ".*file exists")
+	data, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "precious")
+}
+
+func (s *ExportCommandSuite) TestExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.runExport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	_, err = os.Stat(s.filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportCommandSuite) TestExportBinariesError(c *gc.C) {
+	s.binaries.SetErrors(nil, errors.New("boom"))
+	_, err := s.runExport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `writing model archive: cannot open charm: boom`)
+	_, err = os.Stat(s.filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -0,0 +1,272 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	resourceclient "github.com/juju/juju/resource/api/client"
+	"github.com/juju/juju/resource/resourceadapters"
+)
+
+// NewImportCommand returns a fully constructed import-model command.
+func NewImportCommand() cmd.Command {
+	return modelcmd.WrapController(&importCommand{})
+}
+
+type importCommand struct {
+	modelcmd.ControllerCommandBase
+	api         ImportModelAPI
+	binariesAPI ModelBinariesUploadAPI
+
+	filename string
+	force    bool
+}
+
+const importModelHelpDoc = `
+Recreates a model on the controller from an archive file written by
+export-model, uploading the charms, agent binaries and resources
+included in the archive.
+
+The model keeps its UUID, so it cannot be imported into a controller
+that already hosts it. Importing requires superuser access to the
+controller.
+
+The agents of the model's machines are not reconfigured: they continue
+to talk to the controller the model was exported from, which also
+keeps managing the machines. So a model with provisioned machines is
+refused, unless --force is given; use it when the model has been
+destroyed on the controller it was exported from, or that controller
+is gone.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model --force mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Recreates a model from an archive file.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *importCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Import a model whose machines are provisioned")
+}
+
+// Init implements Command.
+func (c *importCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no file specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(migration.ModelInfo) error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+}
+
+// ModelBinariesUploadAPI specifies the calls used to upload the
+// binaries and resources used by a model.
+type ModelBinariesUploadAPI interface {
+	Close() error
+	migration.CharmUploader
+	migration.ToolsUploader
+	migration.ResourceUploader
+}
+
+// modelBinariesUploadAPI joins the client for uploading charms and
+// tools with the one for resources, which share the connection.
+type modelBinariesUploadAPI struct {
+	*api.Client
+	resources *resourceclient.Client
+}
+
+// Upload implements ModelBinariesUploadAPI.
+func (a modelBinariesUploadAPI) Upload(application, name, filename string, reader io.ReadSeeker) error {
+	return a.resources.Upload(application, name, filename, reader)
+}
+
+type importModelAPI struct {
+	*migrationtarget.Client
+	closer func() error
+}
+
+func (a importModelAPI) Close() error {
+	return a.closer()
+}
+
+func (c *importCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return importModelAPI{migrationtarget.NewClient(root), root.Close}, nil
+}
+
+func (c *importCommand) getBinariesAPI(modelName string) (ModelBinariesUploadAPI, error) {
+	if c.binariesAPI != nil {
+		return c.binariesAPI, nil
+	}
+	root, err := c.NewModelAPIRoot(modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources, err := resourceadapters.NewAPIClient(root)
+	if err != nil {
+		root.Close()
+		return nil, errors.Trace(err)
+	}
+	return modelBinariesUploadAPI{root.Client(), resources}, nil
+}
+
+// Run implements Command.
+func (c *importCommand) Run(ctx *cmd.Context) (err error) {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	dir, err := ioutil.TempDir("", "juju-import-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	archive, err := migration.ReadArchive(f, dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := description.Deserialize(archive.ModelBytes)
+	if err != nil {
+		return errors.Annotate(err, "reading model")
+	}
+	info, err := modelInfo(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ids := provisionedMachines(model.Machines()); len(ids) > 0 && !c.force {
+		return errors.Errorf(
+			"model has provisioned machines (%s), which the controller it was exported from still manages; use --force to import it anyway",
+			strings.Join(ids, ", "),
+		)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Prechecks(info); err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+	if err := client.Import(archive.ModelBytes); err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	modelName := jujuclient.JoinOwnerModelName(info.Owner, info.Name)
+	defer func() {
+		if err == nil {
+			return
+		}
+		if abortErr := client.Abort(info.UUID); abortErr != nil {
+			logger.Errorf("cannot abort import of model %q: %v", modelName, abortErr)
+		}
+		if removeErr := store.RemoveModel(controllerName, modelName); removeErr != nil && !errors.IsNotFound(removeErr) {
+			logger.Errorf("cannot remove model %q from the client store: %v", modelName, removeErr)
+		}
+	}()
+	err = store.UpdateModel(controllerName, modelName, jujuclient.ModelDetails{info.UUID})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := c.uploadBinaries(modelName, archive); err != nil {
+		return errors.Annotate(err, "cannot upload model binaries")
+	}
+	if err := client.Activate(info.UUID); err != nil {
+		return errors.Annotate(err, "cannot activate model")
+	}
+	ctx.Infof("Imported model %q from %s", modelName, c.filename)
+	return nil
+}
+
+func (c *importCommand) uploadBinaries(modelName string, archive *migration.Archive) error {
+	uploader, err := c.getBinariesAPI(modelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer uploader.Close()
+	return archive.UploadBinaries(uploader, uploader, uploader)
+}
+
+// provisionedMachines returns the ids of the machines, and their
+// containers, that have an instance.
+func provisionedMachines(machines []description.Machine) []string {
+	var ids []string
+	for _, machine := range machines {
+		if machine.Instance() != nil {
+			ids = append(ids, machine.Id())
+		}
+		ids = append(ids, provisionedMachines(machine.Containers())...)
+	}
+	return ids
+}
+
+// modelInfo returns the details of the serialized model that the
+// target controller checks before importing it.
+func modelInfo(model description.Model) (migration.ModelInfo, error) {
+	config := model.Config()
+	name, _ := config["name"].(string)
+	if name == "" {
+		return migration.ModelInfo{}, errors.NotValidf("model without name")
+	}
+	agentVersion, _ := config["agent-version"].(string)
+	vers, err := version.Parse(agentVersion)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Annotate(err, "bad agent version")
+	}
+	return migration.ModelInfo{
+		UUID:         model.Tag().Id(),
+		Owner:        model.Owner(),
+		Name:         name,
+		AgentVersion: vers,
+	}, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake       fakeImportClient
+	uploader   fakeUploadClient
+	store      *jujuclienttesting.MemStore
+	filename   string
+	modelBytes []byte
+}
+
+var _ = gc.Suite(&ImportCommandSuite{})
+
+type fakeImportClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeImportClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Prechecks(info migration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", string(bytes))
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+type fakeUploadClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeUploadClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeUploadClient) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.MethodCall(f, "UploadCharm", curl, string(data))
+	return curl, f.NextErr()
+}
+
+func (f *fakeUploadClient) Upload(application, name, filename string, r io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.MethodCall(f, "Upload", application, name, filename, string(data))
+	return f.NextErr()
+}
+
+func (f *fakeUploadClient) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.MethodCall(f, "UploadTools", v, string(data))
+	return tools.List{&tools.Tools{Version: v}}, f.NextErr()
+}
+
+func (s *ImportCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.uploader.ResetCalls()
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	s.writeArchive(c, s.newModel())
+}
+
+func (s *ImportCommandSuite) newModel() description.Model {
+	return description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.0.0",
+		},
+	})
+}
+
+func (s *ImportCommandSuite) writeArchive(c *gc.C, m description.Model) {
+	modelBytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	s.modelBytes = modelBytes
+
+	f, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = migration.WriteArchive(f, migration.WriteArchiveConfig{
+		ModelBytes:      modelBytes,
+		Charms:          []string{"cs:xenial/mysql-1"},
+		CharmDownloader: &fakeBinariesClient{},
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/2.0.0-xenial-amd64",
+		},
+		ToolsDownloader: &fakeBinariesClient{},
+		Resources: []migration.ArchiveResource{
+			{"mysql", "data", "data.tgz"},
+		},
+		ResourceDownloader: &fakeBinariesClient{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportCommandSuite) writeProvisionedArchive(c *gc.C) {
+	m := s.newModel()
+	machine := m.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+		Jobs:   []string{"host-units"},
+	})
+	machine.SetInstance(description.CloudInstanceArgs{
+		InstanceId: "inst-0",
+		Status:     "running",
+	})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.0-xenial-amd64"),
+	})
+	machine.SetStatus(description.StatusArgs{Value: "started"})
+	s.writeArchive(c, m)
+}
+
+func (s *ImportCommandSuite) runImport(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewImportCommandForTest(&s.fake, &s.uploader, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stderr(ctx), nil
+}
+
+func (s *ImportCommandSuite) TestInit(c *gc.C) {
+	_, err := s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "no file specified")
+	_, err = s.runImport(c, "file", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportCommandSuite) TestImport(c *gc.C) {
+	out, err := s.runImport(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `Imported model "bob/mymodel" from `+s.filename+"\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Prechecks", []interface{}{migration.ModelInfo{
+			UUID:         testing.ModelTag.Id(),
+			Owner:        names.NewUserTag("bob"),
+			Name:         "mymodel",
+			AgentVersion: version.MustParse("2.0.0"),
+		}}},
+		{"Import", []interface{}{string(s.modelBytes)}},
+		{"Activate", []interface{}{testing.ModelTag.Id()}},
+		{"Close", nil},
+	})
+	s.uploader.CheckCalls(c, []gitjujutesting.StubCall{
+		{"UploadCharm", []interface{}{charm.MustParseURL("cs:xenial/mysql-1"), "charm"}},
+		{"UploadTools", []interface{}{version.MustParseBinary("2.0.0-xenial-amd64"), "tools"}},
+		{"Upload", []interface{}{"mysql", "data", "data.tgz", "resource"}},
+		{"Close", nil},
+	})
+
+	details, err := s.store.ModelByName("testing", "bob/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ModelUUID, gc.Equals, testing.ModelTag.Id())
+}
+
+func (s *ImportCommandSuite) TestImportProvisionedMachines(c *gc.C) {
+	s.writeProvisionedArchive(c)
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `model has provisioned machines \(0\), .*; use --force to import it anyway`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ImportCommandSuite) TestImportProvisionedMachinesForce(c *gc.C) {
+	s.writeProvisionedArchive(c)
+	_, err := s.runImport(c, "--force", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "Prechecks", "Import", "Activate", "Close")
+}
+
+func (s *ImportCommandSuite) TestImportNotArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("not an archive"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "cannot read model archive: .*")
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ImportCommandSuite) TestImportPrechecksFail(c *gc.C) {
+	s.fake.SetErrors(errors.New("model already exists"))
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "cannot import model: model already exists")
+	s.fake.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportCommandSuite) TestImportUploadFailAborts(c *gc.C) {
+	s.uploader.SetErrors(errors.New("boom"))
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `cannot upload model binaries: cannot upload charm "cs:xenial/mysql-1": boom`)
+	s.fake.CheckCallNames(c, "Prechecks", "Import", "Abort", "Close")
+	s.fake.CheckCall(c, 2, "Abort", testing.ModelTag.Id())
+
+	_, err = s.store.ModelByName("testing", "bob/mymodel")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSecretsCommand returns a command that lists the secrets stored
+// by the charms in a model.
+func NewSecretsCommand() cmd.Command {
+	return modelcmd.Wrap(&secretsCommand{})
+}
+
+// secretsCommand lists the secrets stored by the charms in a model.
+type secretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api SecretsAPI
+}
+
+const secretsHelpDoc = `
+Lists the secrets stored by the charms in the model, oldest first, with
+the application owning each secret and who it has been granted to.
+Secret values are never shown. Only model administrators may list
+secrets.
+
+Charms add, read and grant secrets with the secret-add, secret-get and
+secret-grant hook tools.
+
+Examples:
+
+    juju secrets
+    juju secrets --format yaml
+`
+
+// SecretsAPI defines the API methods used by the secrets command.
+type SecretsAPI interface {
+	Close() error
+	ListSecrets() ([]params.SecretDetails, error)
+}
+
+// secretDetails is the secret information written by the secrets
+// command.
+type secretDetails struct {
+	Id          string    `yaml:"id" json:"id"`
+	Owner       string    `yaml:"owner" json:"owner"`
+	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
+	Grants      []string  `yaml:"grants,omitempty" json:"grants,omitempty"`
+	Created     time.Time `yaml:"created" json:"created"`
+}
+
+// Info implements Command.Info.
+func (c *secretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets stored by charms in a model.",
+		Doc:     strings.TrimSpace(secretsHelpDoc),
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *secretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSecretsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *secretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *secretsCommand) getAPI() (SecretsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *secretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.ListSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		fmt.Fprintln(ctx.Stderr, "No secrets to display.")
+		return nil
+	}
+	details := make([]secretDetails, len(results))
+	for i, result := range results {
+		details[i] = secretDetails{
+			Id:          result.Id,
+			Owner:       readableTag(result.Owner),
+			Description: result.Description,
+			Created:     result.Created,
+		}
+		for _, grant := range result.Grants {
+			details[i].Grants = append(details[i].Grants, readableTag(grant))
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+// readableTag returns the id of the entity with the given tag, or the
+// tag itself if it cannot be parsed.
+func readableTag(tag string) string {
+	parsed, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return parsed.Id()
+}
+
+// formatSecretsTabular writes a tabular summary of secrets.
+func formatSecretsTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.([]secretDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("ID", "OWNER", "CREATED", "GRANTED TO", "DESCRIPTION")
+	for _, secret := range details {
+		created := secret.Created
+		w.Println(
+			secret.Id,
+			secret.Owner,
+			common.FormatTime(&created, true),
+			strings.Join(secret.Grants, ","),
+			secret.Description,
+		)
+	}
+	tw.Flush()
+	return nil
+}
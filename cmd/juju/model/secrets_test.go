@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type SecretsCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeSecretsClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&SecretsCommandSuite{})
+
+type fakeSecretsClient struct {
+	gitjujutesting.Stub
+	secrets []params.SecretDetails
+}
+
+func (f *fakeSecretsClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSecretsClient) ListSecrets() ([]params.SecretDetails, error) {
+	f.MethodCall(f, "ListSecrets")
+	return f.secrets, f.NextErr()
+}
+
+func (s *SecretsCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeSecretsClient{
+		secrets: []params.SecretDetails{{
+			Id:          "a1b2",
+			Owner:       "application-mysql",
+			Description: "root password",
+			Grants:      []string{"application-wordpress", "unit-nagios-0"},
+			Created:     time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC),
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *SecretsCommandSuite) TestInit(c *gc.C) {
+	_, err := testing.RunCommand(c, model.NewSecretsCommandForTest(&s.fake, s.store), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *SecretsCommandSuite) TestSecretsTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewSecretsCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ListSecrets", "Close")
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+ID    OWNER  CREATED               GRANTED TO          DESCRIPTION
+a1b2  mysql  2016-11-01 10:00:00Z  wordpress,nagios/0  root password
+`[1:])
+}
+
+func (s *SecretsCommandSuite) TestSecretsJSON(c *gc.C) {
+	ctx, err := testing.RunCommand(c, model.NewSecretsCommandForTest(&s.fake, s.store), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `[{"id":"a1b2","owner":"mysql","description":"root password",`+
+		`"grants":["wordpress","nagios/0"],"created":"2016-11-01T10:00:00Z"}]`+"\n")
+}
+
+func (s *SecretsCommandSuite) TestSecretsNone(c *gc.C) {
+	s.fake.secrets = nil
+	ctx, err := testing.RunCommand(c, model.NewSecretsCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+func (s *SecretsCommandSuite) TestSecretsError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, model.NewSecretsCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/hookhistory"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewHookHistoryCommand returns a command that reports the hooks most
+// recently run by a unit, and how long they took.
+func NewHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&hookHistoryCommand{})
+}
+
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	api     HookHistoryAPI
+	unit    names.UnitTag
+	slowest bool
+}
+
+var hookHistoryDoc = `
+Shows the hooks most recently run by a unit, oldest first: when each
+started, how long it took, the relation it ran for, and whether it
+failed. The uniter records every hook it runs; the last 100 are kept.
+
+Use --slowest to sort the hooks by how long they took, to find the ones
+slowing a deployment down.
+
+Examples:
+
+    juju show-hook-history mysql/0
+    juju show-hook-history --slowest mysql/0
+    juju show-hook-history --format yaml mysql/0
+
+See also:
+    show-status-log
+`
+
+// HookHistoryAPI defines the API methods used by the
+// show-hook-history command.
+type HookHistoryAPI interface {
+	Close() error
+	HookHistory(names.UnitTag) ([]params.HookRecord, error)
+}
+
+// hookRecord is the hook execution information written by the
+// show-hook-history command.
+type hookRecord struct {
+	Hook       string    `yaml:"hook" json:"hook"`
+	Relation   string    `yaml:"relation,omitempty" json:"relation,omitempty"`
+	RemoteUnit string    `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    time.Time `yaml:"started" json:"started"`
+	Duration   string    `yaml:"duration" json:"duration"`
+	Error      string    `yaml:"error,omitempty" json:"error,omitempty"`
+
+	duration time.Duration
+}
+
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit name>",
+		Purpose: "Output the hooks most recently run by a unit, and how long they took.",
+		Doc:     hookHistoryDoc,
+	}
+}
+
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.slowest, "slowest", false, "Sort the hooks by how long they took, longest first")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+}
+
+func (c *hookHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit name specified")
+	case 1:
+		if !names.IsValidUnit(args[0]) {
+			return errors.NotValidf("unit name %q", args[0])
+		}
+		c.unit = names.NewUnitTag(args[0])
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+func (c *hookHistoryCommand) getAPI() (HookHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hookhistory.NewClient(root), nil
+}
+
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	history, err := client.HookHistory(c.unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(history) == 0 && c.out.Name() == "tabular" {
+		fmt.Fprintf(ctx.Stderr, "No hooks have been run by %s.\n", c.unit.Id())
+		return nil
+	}
+	records := make([]hookRecord, len(history))
+	for i, record := range history {
+		duration := record.Duration - record.Duration%time.Millisecond
+		records[i] = hookRecord{
+			Hook:       record.Hook,
+			Relation:   record.Relation,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started,
+			Duration:   duration.String(),
+			Error:      record.Error,
+			duration:   duration,
+		}
+	}
+	if c.slowest {
+		sort.Stable(hookRecordsBySlowest(records))
+	}
+	return c.out.Write(ctx, records)
+}
+
+type hookRecordsBySlowest []hookRecord
+
+func (r hookRecordsBySlowest) Len() int           { return len(r) }
+func (r hookRecordsBySlowest) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r hookRecordsBySlowest) Less(i, j int) bool { return r[i].duration > r[j].duration }
+
+// formatHookHistoryTabular writes a tabular summary of hook executions.
+func formatHookHistoryTabular(writer io.Writer, value interface{}) error {
+	records, ok := value.([]hookRecord)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", records, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("STARTED", "DURATION", "HOOK", "RELATION", "RESULT")
+	for _, record := range records {
+		started := record.Started
+		relation := record.Relation
+		if record.RemoteUnit != "" {
+			relation += " " + record.RemoteUnit
+		}
+		result := "ok"
+		if record.Error != "" {
+			result = record.Error
+		}
+		w.Println(
+			common.FormatTime(&started, true),
+			record.Duration,
+			record.Hook,
+			relation,
+			result,
+		)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookHistorySuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake  fakeHookHistoryClient
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&hookHistorySuite{})
+
+type fakeHookHistoryClient struct {
+	gitjujutesting.Stub
+	history []params.HookRecord
+}
+
+func (f *fakeHookHistoryClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeHookHistoryClient) HookHistory(unit names.UnitTag) ([]params.HookRecord, error) {
+	f.MethodCall(f, "HookHistory", unit)
+	return f.history, f.NextErr()
+}
+
+func (s *hookHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	started := time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC)
+	s.fake = fakeHookHistoryClient{
+		history: []params.HookRecord{{
+			Hook:     "install",
+			Started:  started,
+			Duration: 1500 * time.Millisecond,
+		}, {
+			Hook:       "db-relation-joined",
+			Relation:   "db:2",
+			RemoteUnit: "mysql/0",
+			Started:    started.Add(2 * time.Minute),
+			Duration:   90500*time.Millisecond + 123,
+			Error:      "exit status 1",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		coretesting.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *hookHistorySuite) newCommand() cmd.Command {
+	command := &hookHistoryCommand{api: &s.fake}
+	command.SetClientStore(s.store)
+	return modelcmd.Wrap(command)
+}
+
+func (s *hookHistorySuite) TestInit(c *gc.C) {
+	_, err := coretesting.RunCommand(c, s.newCommand())
+	c.Check(err, gc.ErrorMatches, "no unit name specified")
+	_, err = coretesting.RunCommand(c, s.newCommand(), "mysql")
+	c.Check(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+	_, err = coretesting.RunCommand(c, s.newCommand(), "mysql/0", "mysql/1")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["mysql/1"\]`)
+}
+
+func (s *hookHistorySuite) TestHookHistoryTabular(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"HookHistory", []interface{}{names.NewUnitTag("wordpress/0")}},
+		{"Close", nil},
+	})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+STARTED               DURATION  HOOK                RELATION      RESULT
+2016-10-18 08:13:00Z  1.5s      install                           ok
+2016-10-18 08:15:00Z  1m30.5s   db-relation-joined  db:2 mysql/0  exit status 1
+`[1:])
+}
+
+func (s *hookHistorySuite) TestHookHistorySlowest(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "--slowest", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+STARTED               DURATION  HOOK                RELATION      RESULT
+2016-10-18 08:15:00Z  1m30.5s   db-relation-joined  db:2 mysql/0  exit status 1
+2016-10-18 08:13:00Z  1.5s      install                           ok
+`[1:])
+}
+
+func (s *hookHistorySuite) TestHookHistoryJSON(c *gc.C) {
+	s.fake.history = s.fake.history[1:]
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "--format", "json", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `[{"hook":"db-relation-joined","relation":"db:2",`+
+		`"remote-unit":"mysql/0","started":"2016-10-18T08:15:00Z","duration":"1m30.5s",`+
+		`"error":"exit status 1"}]`+"\n")
+}
+
+func (s *hookHistorySuite) TestHookHistoryNone(c *gc.C) {
+	s.fake.history = nil
+	ctx, err := coretesting.RunCommand(c, s.newCommand(), "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No hooks have been run by wordpress/0.\n")
+}
+
+func (s *hookHistorySuite) TestHookHistoryError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := coretesting.RunCommand(c, s.newCommand(), "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
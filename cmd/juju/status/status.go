@@ -40,6 +40,11 @@ type statusCommand struct {
 	api      statusAPI
 
 	color bool
+	watch bool
+
+	// formatters holds the output formatters by name, for use
+	// when watching.
+	formatters map[string]cmd.Formatter
 }
 
 var usageSummary = `
@@ -73,10 +78,17 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
 
+With --watch, the status is written again each time it changes, until the
+command is interrupted. The changes are streamed from the controller, so
+watching is much lighter on it than running status repeatedly. On a
+terminal each update replaces the last, with the changed lines highlighted;
+otherwise the updates are written one after another.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
+    juju show-status --watch
 
 See also:
     machines
@@ -99,10 +111,11 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.watch, "watch", false, "Keep writing the status as it changes")
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.formatters = map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -110,7 +123,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
+	c.out.AddFlags(f, defaultFormat, c.formatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
@@ -140,16 +154,12 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	if c.watch {
+		return c.watchStatus(ctx, apiclient)
+	}
+	status, err := c.fetchStatus(ctx, apiclient)
 	if err != nil {
-		if status == nil {
-			// Status call completely failed, there is nothing to report
-			return err
-		}
-		// Display any error, but continue to print status if some was returned
-		fmt.Fprintf(ctx.Stderr, "%v\n", err)
-	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return err
 	}
 
 	formatter := newStatusFormatter(status, c.ControllerName(), c.isoTime)
@@ -160,6 +170,23 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, formatted)
 }
 
+// fetchStatus returns the current status, reporting any error that
+// left it incomplete.
+func (c *statusCommand) fetchStatus(ctx *cmd.Context, apiclient statusAPI) (*params.FullStatus, error) {
+	status, err := apiclient.Status(c.patterns)
+	if err != nil {
+		if status == nil {
+			// Status call completely failed, there is nothing to report
+			return nil, err
+		}
+		// Display any error, but continue to print status if some was returned
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	} else if status == nil {
+		return nil, errors.Errorf("unable to obtain the current status")
+	}
+	return status, nil
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
@@ -0,0 +1,388 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// In watch mode the status is fetched once, and then kept up to date by
+// applying the deltas reported by the model's all-watcher. Changes to
+// the entities already shown are applied locally; only when entities
+// are added, removed or moved, which changes the shape of the status,
+// is it fetched again.
+
+const (
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\x1b[H\x1b[2J"
+	// highlightOn and highlightOff mark a changed line in reverse
+	// video.
+	highlightOn  = "\x1b[7m"
+	highlightOff = "\x1b[0m"
+)
+
+// allWatcher is the part of api.AllWatcher used to watch the model.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status with %T", apiclient)
+	}
+	w, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// watchStatus writes the status, and then writes it again each time
+// it changes, until the watcher fails.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	w, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer w.Stop()
+
+	fullStatus, err := c.fetchStatus(ctx, apiclient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	terminal := c.color || isTerminal(ctx.Stdout)
+	if terminal {
+		// Colour the tabular output, even though it is written to
+		// a buffer before reaching the terminal.
+		c.color = true
+	}
+	r := &statusRenderer{
+		out:       ctx.Stdout,
+		format:    c.formatters[c.out.Name()],
+		highlight: terminal,
+	}
+	if err := c.render(r, fullStatus); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		deltas, err := w.Next()
+		if err != nil {
+			return errors.Annotate(err, "watching model")
+		}
+		changed, refresh := applyDeltas(fullStatus, deltas, len(c.patterns) > 0)
+		if refresh {
+			if fullStatus, err = c.fetchStatus(ctx, apiclient); err != nil {
+				return errors.Trace(err)
+			}
+		} else if !changed {
+			continue
+		}
+		if err := c.render(r, fullStatus); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd())
+}
+
+func (c *statusCommand) render(r *statusRenderer, fullStatus *params.FullStatus) error {
+	formatter := newStatusFormatter(fullStatus, c.ControllerName(), c.isoTime)
+	formatted, err := formatter.format()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return r.render(formatted)
+}
+
+// statusRenderer writes successive versions of the status. When
+// writing to a terminal, each version replaces the last on the
+// screen, with the lines not shown before highlighted; otherwise the
+// versions are written one after the other, separated by a blank
+// line.
+type statusRenderer struct {
+	out       io.Writer
+	format    cmd.Formatter
+	highlight bool
+
+	previous set.Strings
+}
+
+func (r *statusRenderer) render(value interface{}) error {
+	var buf bytes.Buffer
+	if err := r.format(&buf, value); err != nil {
+		return errors.Trace(err)
+	}
+	lines := strings.Split(strings.Trim(buf.String(), "\n"), "\n")
+
+	var out bytes.Buffer
+	switch {
+	case r.highlight:
+		out.WriteString(clearScreen)
+	case r.previous != nil:
+		out.WriteString("\n")
+	}
+	for _, line := range lines {
+		if r.highlight && r.previous != nil && !r.previous.Contains(line) && line != "" {
+			// Colour codes within the line reset the highlight,
+			// so it is restored after each of them.
+			line = strings.Replace(line, highlightOff, highlightOff+highlightOn, -1)
+			line = highlightOn + line + highlightOff
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+	r.previous = set.NewStrings(lines...)
+	_, err := r.out.Write(out.Bytes())
+	return errors.Trace(err)
+}
+
+// applyDeltas applies the changes reported by the all-watcher to the
+// status. It reports whether the status changed, and whether it must
+// be fetched again because the changes cannot be applied locally. If
+// the status is filtered, changes to entities not already shown are
+// ignored unless they may belong to an application that is.
+func applyDeltas(fullStatus *params.FullStatus, deltas []multiwatcher.Delta, filtered bool) (changed, refresh bool) {
+	for _, delta := range deltas {
+		var entityChanged, entityRefresh bool
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if !delta.Removed && info.Name != fullStatus.Model.Name {
+				fullStatus.Model.Name = info.Name
+				entityChanged = true
+			}
+		case *multiwatcher.MachineInfo:
+			entityChanged, entityRefresh = applyMachineDelta(fullStatus, info, delta.Removed, filtered)
+		case *multiwatcher.ApplicationInfo:
+			entityChanged, entityRefresh = applyApplicationDelta(fullStatus, info, delta.Removed, filtered)
+		case *multiwatcher.UnitInfo:
+			entityChanged, entityRefresh = applyUnitDelta(fullStatus, info, delta.Removed, filtered)
+		case *multiwatcher.RelationInfo:
+			entityRefresh = applyRelationDelta(fullStatus, info, delta.Removed, filtered)
+		}
+		changed = changed || entityChanged
+		refresh = refresh || entityRefresh
+	}
+	return changed, refresh
+}
+
+func applyMachineDelta(fullStatus *params.FullStatus, info *multiwatcher.MachineInfo, removed, filtered bool) (changed, refresh bool) {
+	found := updateMachine(fullStatus.Machines, info.Id, func(machine *params.MachineStatus) {
+		instanceId := info.InstanceId
+		if instanceId == "" {
+			instanceId = "pending"
+		}
+		switch {
+		case removed:
+			refresh = true
+		case string(machine.InstanceId) != instanceId:
+			// The hardware and addresses come with the instance.
+			refresh = true
+		case !reflect.DeepEqual(machineIPAddresses(info.Addresses), machine.IPAddresses):
+			// The DNS name is chosen from the addresses by the
+			// controller.
+			refresh = true
+		default:
+			updated := *machine
+			updated.AgentStatus = detailedStatus(machine.AgentStatus, info.AgentStatus)
+			updated.AgentStatus.Life = lifeString(info.Life)
+			updated.InstanceStatus = detailedStatus(machine.InstanceStatus, info.InstanceStatus)
+			updated.Series = info.Series
+			updated.HasVote = info.HasVote
+			updated.WantsVote = info.WantsVote
+			changed = !reflect.DeepEqual(updated, *machine)
+			*machine = updated
+		}
+	})
+	if !found {
+		// A machine is only shown in a filtered status if it hosts
+		// a unit that is, and the unit will be reported separately.
+		return false, !removed && !filtered
+	}
+	return changed, refresh
+}
+
+func applyApplicationDelta(fullStatus *params.FullStatus, info *multiwatcher.ApplicationInfo, removed, filtered bool) (changed, refresh bool) {
+	app, ok := fullStatus.Applications[info.Name]
+	if !ok {
+		return false, !removed && !filtered
+	}
+	if removed {
+		return false, true
+	}
+	updated := app
+	updated.Charm = info.CharmURL
+	updated.Exposed = info.Exposed
+	updated.Life = lifeString(info.Life)
+	// An application without a status of its own is shown with one
+	// derived from its units by the controller, which is kept.
+	if info.Status.Current != "" && info.Status.Current != status.Unknown {
+		updated.Status = detailedStatus(app.Status, info.Status)
+	}
+	if reflect.DeepEqual(updated, app) {
+		return false, false
+	}
+	fullStatus.Applications[info.Name] = updated
+	return true, false
+}
+
+func applyUnitDelta(fullStatus *params.FullStatus, info *multiwatcher.UnitInfo, removed, filtered bool) (changed, refresh bool) {
+	if _, ok := fullStatus.Applications[info.Application]; !ok {
+		return false, !removed && !filtered
+	}
+	update := func(unit *params.UnitStatus) {
+		switch {
+		case removed:
+			refresh = true
+		case !info.Subordinate && unit.Machine != info.MachineId:
+			refresh = true
+		default:
+			updated := *unit
+			updated.WorkloadStatus = detailedStatus(unit.WorkloadStatus, info.WorkloadStatus)
+			updated.AgentStatus = detailedStatus(unit.AgentStatus, info.AgentStatus)
+			updated.PublicAddress = info.PublicAddress
+			updated.OpenedPorts = unitOpenedPorts(info)
+			changed = !reflect.DeepEqual(updated, *unit)
+			*unit = updated
+		}
+	}
+	// Subordinate units are shown beneath their principals, in
+	// another application.
+	found := false
+	for _, app := range fullStatus.Applications {
+		if found = updateUnit(app.Units, info.Name, update); found {
+			break
+		}
+	}
+	if !found {
+		// Added units must be placed among their machines and
+		// principals, which only the controller knows.
+		return false, !removed
+	}
+	return changed, refresh
+}
+
+func applyRelationDelta(fullStatus *params.FullStatus, info *multiwatcher.RelationInfo, removed, filtered bool) (refresh bool) {
+	for _, rel := range fullStatus.Relations {
+		if rel.Id == info.Id {
+			// Each application lists its relations, so a removal
+			// is left to the controller to unpick.
+			return removed
+		}
+	}
+	if removed {
+		return false
+	}
+	if !filtered {
+		return true
+	}
+	for _, ep := range info.Endpoints {
+		if _, ok := fullStatus.Applications[ep.ApplicationName]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// updateMachine calls update with the machine or container with the
+// given id, storing the result, and reports whether it was found.
+func updateMachine(machines map[string]params.MachineStatus, id string, update func(*params.MachineStatus)) bool {
+	for key, machine := range machines {
+		if machine.Id == id {
+			update(&machine)
+			machines[key] = machine
+			return true
+		}
+		if updateMachine(machine.Containers, id, update) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateUnit calls update with the unit or subordinate unit with the
+// given name, storing the result, and reports whether it was found.
+func updateUnit(units map[string]params.UnitStatus, name string, update func(*params.UnitStatus)) bool {
+	for key, unit := range units {
+		if key == name {
+			update(&unit)
+			units[key] = unit
+			return true
+		}
+		if updateUnit(unit.Subordinates, name, update) {
+			return true
+		}
+	}
+	return false
+}
+
+// detailedStatus returns the current status updated with that reported
+// by the all-watcher.
+func detailedStatus(current params.DetailedStatus, info multiwatcher.StatusInfo) params.DetailedStatus {
+	current.Status = string(info.Current)
+	current.Info = info.Message
+	current.Data = info.Data
+	current.Since = info.Since
+	current.Version = info.Version
+	current.Err = info.Err
+	return current
+}
+
+// lifeString returns the life shown for an entity, which is only
+// given once the entity is no longer alive.
+func lifeString(life multiwatcher.Life) string {
+	if life == multiwatcher.Life("alive") {
+		return ""
+	}
+	return string(life)
+}
+
+// machineIPAddresses returns the addresses shown for a machine, as
+// chosen by the controller.
+func machineIPAddresses(addrs []multiwatcher.Address) []string {
+	var result []string
+	for _, addr := range addrs {
+		switch network.Scope(addr.Scope) {
+		case network.ScopeMachineLocal, network.ScopeLinkLocal:
+			continue
+		}
+		result = append(result, addr.Value)
+	}
+	return result
+}
+
+func unitOpenedPorts(info *multiwatcher.UnitInfo) []string {
+	var ports []string
+	for _, r := range info.PortRanges {
+		ports = append(ports, network.PortRange{
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+			Protocol: r.Protocol,
+		}.String())
+	}
+	return ports
+}
@@ -0,0 +1,352 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type watchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&watchSuite{})
+
+func newWatchStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "default",
+			CloudTag: "cloud-dummy",
+			Version:  "2.0.0",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:          "0",
+				InstanceId:  "inst-0",
+				IPAddresses: []string{"10.0.0.1"},
+				AgentStatus: params.DetailedStatus{Status: "started"},
+				Containers: map[string]params.MachineStatus{
+					"0/lxd/0": {
+						Id:          "0/lxd/0",
+						InstanceId:  "pending",
+						AgentStatus: params.DetailedStatus{Status: "pending"},
+					},
+				},
+			},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm:  "cs:mysql-1",
+				Status: params.DetailedStatus{Status: "waiting"},
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine:        "0",
+						PublicAddress:  "10.0.0.1",
+						AgentStatus:    params.DetailedStatus{Status: "idle"},
+						WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {
+								AgentStatus:    params.DetailedStatus{Status: "idle"},
+								WorkloadStatus: params.DetailedStatus{Status: "active"},
+							},
+						},
+					},
+				},
+			},
+			"logging": {
+				Charm:         "cs:logging-1",
+				SubordinateTo: []string{"mysql"},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id:  1,
+			Key: "logging:info mysql:juju-info",
+		}},
+	}
+}
+
+func unitDelta(name, application, machine string, workload status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           name,
+			Application:    application,
+			MachineId:      machine,
+			Subordinate:    machine == "",
+			PublicAddress:  "10.0.0.1",
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		},
+	}
+}
+
+func (s *watchSuite) TestApplyUnitChange(c *gc.C) {
+	fullStatus := newWatchStatus()
+	delta := unitDelta("mysql/0", "mysql", "0", status.Active)
+	delta.Entity.(*multiwatcher.UnitInfo).PortRanges = []multiwatcher.PortRange{
+		{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+		{FromPort: 8000, ToPort: 8080, Protocol: "tcp"},
+	}
+
+	changed, refresh := applyDeltas(fullStatus, []multiwatcher.Delta{delta}, false)
+	c.Check(changed, jc.IsTrue)
+	c.Check(refresh, jc.IsFalse)
+	unit := fullStatus.Applications["mysql"].Units["mysql/0"]
+	c.Check(unit.WorkloadStatus.Status, gc.Equals, "active")
+	c.Check(unit.OpenedPorts, jc.DeepEquals, []string{"3306/tcp", "8000-8080/tcp"})
+	c.Check(unit.Subordinates, gc.HasLen, 1)
+}
+
+func (s *watchSuite) TestApplyUnchanged(c *gc.C) {
+	fullStatus := newWatchStatus()
+	deltas := []multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", "0", status.Waiting),
+		{Entity: &multiwatcher.AnnotationInfo{Tag: "unit-mysql-0"}},
+	}
+	changed, refresh := applyDeltas(fullStatus, deltas, false)
+	c.Check(changed, jc.IsFalse)
+	c.Check(refresh, jc.IsFalse)
+}
+
+func (s *watchSuite) TestApplySubordinateChange(c *gc.C) {
+	fullStatus := newWatchStatus()
+	deltas := []multiwatcher.Delta{unitDelta("logging/0", "logging", "", status.Blocked)}
+
+	changed, refresh := applyDeltas(fullStatus, deltas, false)
+	c.Check(changed, jc.IsTrue)
+	c.Check(refresh, jc.IsFalse)
+	sub := fullStatus.Applications["mysql"].Units["mysql/0"].Subordinates["logging/0"]
+	c.Check(sub.WorkloadStatus.Status, gc.Equals, "blocked")
+}
+
+func (s *watchSuite) TestApplyContainerChange(c *gc.C) {
+	fullStatus := newWatchStatus()
+	deltas := []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:          "0/lxd/0",
+			Life:        "dying",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Pending, Message: "waiting"},
+		},
+	}}
+
+	changed, refresh := applyDeltas(fullStatus, deltas, false)
+	c.Check(changed, jc.IsTrue)
+	c.Check(refresh, jc.IsFalse)
+	container := fullStatus.Machines["0"].Containers["0/lxd/0"]
+	c.Check(container.AgentStatus.Info, gc.Equals, "waiting")
+	c.Check(container.AgentStatus.Life, gc.Equals, "dying")
+}
+
+func (s *watchSuite) TestApplyMachineProvisioned(c *gc.C) {
+	fullStatus := newWatchStatus()
+	deltas := []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{Id: "0/lxd/0", InstanceId: "inst-1"},
+	}}
+	_, refresh := applyDeltas(fullStatus, deltas, false)
+	c.Check(refresh, jc.IsTrue)
+}
+
+func (s *watchSuite) TestApplyApplicationStatus(c *gc.C) {
+	fullStatus := newWatchStatus()
+	deltas := []multiwatcher.Delta{{
+		Entity: &multiwatcher.ApplicationInfo{Name: "mysql", CharmURL: "cs:mysql-1", Life: "alive"},
+	}}
+	changed, _ := applyDeltas(fullStatus, deltas, false)
+	c.Check(changed, jc.IsFalse)
+	c.Check(fullStatus.Applications["mysql"].Status.Status, gc.Equals, "waiting")
+
+	deltas[0].Entity.(*multiwatcher.ApplicationInfo).Exposed = true
+	deltas[0].Entity.(*multiwatcher.ApplicationInfo).Status = multiwatcher.StatusInfo{Current: status.Active}
+	changed, _ = applyDeltas(fullStatus, deltas, false)
+	c.Check(changed, jc.IsTrue)
+	c.Check(fullStatus.Applications["mysql"].Exposed, jc.IsTrue)
+	c.Check(fullStatus.Applications["mysql"].Status.Status, gc.Equals, "active")
+}
+
+var refreshTests = []struct {
+	about    string
+	delta    multiwatcher.Delta
+	filtered bool
+	refresh  bool
+}{{
+	about:   "new unit",
+	delta:   unitDelta("mysql/1", "mysql", "", status.Waiting),
+	refresh: true,
+}, {
+	about:    "new unit of shown application when filtered",
+	delta:    unitDelta("mysql/1", "mysql", "", status.Waiting),
+	filtered: true,
+	refresh:  true,
+}, {
+	about:    "unit of hidden application when filtered",
+	delta:    unitDelta("wordpress/0", "wordpress", "1", status.Active),
+	filtered: true,
+}, {
+	about:   "unit moved",
+	delta:   unitDelta("mysql/0", "mysql", "1", status.Waiting),
+	refresh: true,
+}, {
+	about:   "unit removed",
+	delta:   multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"}},
+	refresh: true,
+}, {
+	about:   "new machine",
+	delta:   multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "1"}},
+	refresh: true,
+}, {
+	about:    "new machine when filtered",
+	delta:    multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "1"}},
+	filtered: true,
+}, {
+	about:   "machine addresses",
+	delta:   multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "0", InstanceId: "inst-0"}},
+	refresh: true,
+}, {
+	about: "hidden machine removed",
+	delta: multiwatcher.Delta{Removed: true, Entity: &multiwatcher.MachineInfo{Id: "1"}},
+}, {
+	about:   "application removed",
+	delta:   multiwatcher.Delta{Removed: true, Entity: &multiwatcher.ApplicationInfo{Name: "logging"}},
+	refresh: true,
+}, {
+	about:   "new relation",
+	delta:   multiwatcher.Delta{Entity: &multiwatcher.RelationInfo{Id: 2}},
+	refresh: true,
+}, {
+	about:    "relation of hidden applications when filtered",
+	delta:    multiwatcher.Delta{Entity: &multiwatcher.RelationInfo{Id: 2}},
+	filtered: true,
+}, {
+	about: "relation changed",
+	delta: multiwatcher.Delta{Entity: &multiwatcher.RelationInfo{Id: 1}},
+}, {
+	about:   "relation removed",
+	delta:   multiwatcher.Delta{Removed: true, Entity: &multiwatcher.RelationInfo{Id: 1}},
+	refresh: true,
+}}
+
+func (s *watchSuite) TestApplyRefresh(c *gc.C) {
+	for i, test := range refreshTests {
+		c.Logf("test %d: %s", i, test.about)
+		_, refresh := applyDeltas(newWatchStatus(), []multiwatcher.Delta{test.delta}, test.filtered)
+		c.Check(refresh, gc.Equals, test.refresh)
+	}
+}
+
+type fakeWatchClient struct {
+	statuses    []*params.FullStatus
+	statusCalls int
+}
+
+func (a *fakeWatchClient) Status(patterns []string) (*params.FullStatus, error) {
+	status := a.statuses[a.statusCalls]
+	a.statusCalls++
+	return status, nil
+}
+
+func (a *fakeWatchClient) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher was stopped")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func (s *watchSuite) TestWatch(c *gc.C) {
+	refreshed := newWatchStatus()
+	refreshed.Applications["mysql"].Units["mysql/1"] = params.UnitStatus{
+		AgentStatus:    params.DetailedStatus{Status: "allocating"},
+		WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+	}
+	client := &fakeWatchClient{
+		statuses: []*params.FullStatus{newWatchStatus(), refreshed},
+	}
+	w := &fakeAllWatcher{
+		deltas: [][]multiwatcher.Delta{
+			{unitDelta("mysql/0", "mysql", "0", status.Waiting)},
+			{unitDelta("mysql/0", "mysql", "0", status.Active)},
+			{unitDelta("mysql/1", "mysql", "", status.Waiting)},
+		},
+	}
+	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(apiclient statusAPI) (allWatcher, error) {
+		c.Check(apiclient, gc.Equals, client)
+		return w, nil
+	})
+
+	ctx, err := coretesting.RunCommand(c, &statusCommand{}, "--watch", "--format", "oneline")
+	c.Check(err, gc.ErrorMatches, "watching model: watcher was stopped")
+	c.Check(w.stopped, jc.IsTrue)
+	c.Check(client.statusCalls, gc.Equals, 2)
+	c.Check(coretesting.Stdout(ctx), gc.Equals, `
+- mysql/0: 10.0.0.1 (agent:idle, workload:waiting)
+  - logging/0:  (agent:idle, workload:active)
+
+- mysql/0: 10.0.0.1 (agent:idle, workload:active)
+  - logging/0:  (agent:idle, workload:active)
+
+- mysql/0: 10.0.0.1 (agent:idle, workload:waiting)
+  - logging/0:  (agent:idle, workload:active)
+- mysql/1:  (agent:allocating, workload:waiting)
+`[1:])
+}
+
+func (s *watchSuite) TestRenderHighlight(c *gc.C) {
+	var out bytes.Buffer
+	r := &statusRenderer{
+		out:       &out,
+		format:    FormatOneline,
+		highlight: true,
+	}
+	fs := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"mysql": {
+				Units: map[string]unitStatus{
+					"mysql/0": {WorkloadStatusInfo: statusInfoContents{Current: status.Waiting}},
+					"mysql/1": {WorkloadStatusInfo: statusInfoContents{Current: status.Active}},
+				},
+			},
+		},
+	}
+	err := r.render(fs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.String(), gc.Equals, clearScreen+`- mysql/0:  (agent:, workload:waiting)
+- mysql/1:  (agent:, workload:active)
+`)
+
+	out.Reset()
+	fs.Applications["mysql"].Units["mysql/0"] = unitStatus{
+		WorkloadStatusInfo: statusInfoContents{Current: status.Active},
+	}
+	err = r.render(fs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.String(), gc.Equals, clearScreen+highlightOn+`- mysql/0:  (agent:, workload:active)`+highlightOff+`
+- mysql/1:  (agent:, workload:active)
+`)
+}
@@ -37,6 +37,7 @@ var (
 		"spaces-imported-gate",
 	}
 	aliveModelWorkers = []string{
+		"action-pruner",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -48,6 +49,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"remote-relations",
 		"space-importer",
 		"state-cleaner",
 		"status-history-pruner",
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net"
 	"os"
@@ -63,6 +64,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/looputil"
@@ -71,6 +73,7 @@ import (
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -782,6 +785,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := setSecretsKey(st, agentConfig); err != nil {
+		st.Close()
+		return nil, errors.Trace(err)
+	}
 	return st, nil
 }
 
@@ -876,6 +883,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 	if err != nil {
 		return nil, err
 	}
+	st.SetSecretsKeyFunc(a.secretsKey)
 
 	reportOpenedState(st)
 
@@ -963,7 +971,11 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			stateOpener := func() (*state.State, error) {
 				logger.Debugf("opening state for apiserver worker")
 				st, _, err := openState(agentConfig, stateWorkerDialOpts)
-				return st, err
+				if err != nil {
+					return nil, err
+				}
+				st.SetSecretsKeyFunc(a.secretsKey)
+				return st, nil
 			}
 			runner.StartWorker("apiserver", a.apiserverWorkerStarter(stateOpener, certChangedChan))
 			var stateServingSetter certupdater.StateServingInfoSetter = func(info params.StateServingInfo, done <-chan struct{}) error {
@@ -989,6 +1001,18 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2, clock.WallClock), nil
 			})
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				paths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.NewWorker(backupscheduler.Config{
+					Backend:      st,
+					Backups:      backupscheduler.NewStateBackups(st, a.machineId, paths),
+					Clock:        clock.WallClock,
+					PollInterval: backupscheduler.DefaultPollInterval,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1028,6 +1052,8 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		StatusHistoryPrunerMaxHistoryTime: 336 * time.Hour, // 2 weeks
 		StatusHistoryPrunerMaxHistoryMB:   5120,            // 5G
 		StatusHistoryPrunerInterval:       5 * time.Minute,
+		ActionPrunerInterval:              5 * time.Minute,
+		RemoteRelationsInterval:           10 * time.Second,
 		SpacesImportedGate:                a.discoverSpacesComplete,
 		NewEnvironFunc:                    newEnvirons,
 		NewMigrationMaster:                migrationmaster.NewWorker,
@@ -1098,6 +1124,23 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	auditEntrySink, err := newAuditEntrySink(st, controllerConfig, logDir)
+	if err != nil {
+		listener.Close()
+		return nil, errors.Annotate(err, "cannot create audit entry sink")
+	}
+	// Audit entries are written from their own goroutine, so that a
+	// slow or unreachable sink does not hold up API requests. Entries
+	// bound for the database must not be lost, though, so when it is
+	// one of the sinks requests wait for room in a full queue.
+	newQueuedSink := audit.NewQueuedSink
+	for _, name := range controllerConfig.AuditLogSinks() {
+		if name == audit.DatabaseSinkName {
+			newQueuedSink = audit.NewBlockingQueuedSink
+		}
+	}
+	auditQueue := newQueuedSink(auditEntrySink, audit.DefaultQueueSize, auditErrorHandler)
+
 	server, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
 		Clock:            clock.WallClock,
 		Cert:             cert,
@@ -1115,20 +1158,66 @@ func (a *MachineAgent) newAPIserverWorker(st *state.State, certChanged chan para
 			clock.WallClock,
 			jujuversion.Current,
 			agentConfig.Model().Id(),
-			newAuditEntrySink(st, logDir),
+			userAuditEntries(auditQueue.Handle),
 			auditErrorHandler,
 		),
 	})
 	if err != nil {
+		worker.Stop(auditQueue)
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
 
-	return server, nil
+	return &apiserverWorker{server, auditQueue}, nil
+}
+
+// apiserverWorker wraps the API server worker, stopping the queue
+// through which it writes audit entries once it has stopped.
+type apiserverWorker struct {
+	worker.Worker
+	auditQueue *audit.QueuedSink
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+// Wait is part of the worker.Worker interface.
+func (w *apiserverWorker) Wait() error {
+	err := w.Worker.Wait()
+	if err := worker.Stop(w.auditQueue); err != nil {
+		logger.Errorf("error stopping audit queue: %v", err)
+	}
+	return err
+}
+
+func newAuditEntrySink(st *state.State, controllerConfig controller.Config, logDir string) (audit.AuditEntrySinkFn, error) {
+	registry := audit.NewSinkRegistry()
 	persistFn := st.PutAuditEntryFn()
-	fileSinkFn := audit.NewLogFileSink(logDir)
+	err := registry.Register(audit.DatabaseSinkName, func(audit.SinkConfig) (audit.AuditEntrySinkFn, error) {
+		return persistFn, nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The syslog client key is one of the controller's secrets, kept
+	// apart from its config.
+	secrets, err := st.ControllerSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	syslogConfig, _ := controllerConfig.WithSecrets(secrets).AuditLogSyslog()
+	sinkFn, err := registry.NewSink(controllerConfig.AuditLogSinks(), audit.SinkConfig{
+		ControllerUUID: controllerConfig.ControllerUUID(),
+		LogDir:         logDir,
+		WebhookURL:     controllerConfig.AuditLogWebhookURL(),
+		Syslog:         syslogConfig,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sinkFn, nil
+}
+
+// userAuditEntries returns a sink passing only the entries for user
+// actions on to the supplied sink, so that the requests of agents
+// take no room in the audit queue.
+func userAuditEntries(sink audit.AuditEntrySinkFn) audit.AuditEntrySinkFn {
 	return func(entry audit.AuditEntry) error {
 		// We don't care about auditing anything but user actions.
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
@@ -1138,15 +1227,7 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
-		persistErr := persistFn(entry)
-		sinkErr := fileSinkFn(entry)
-		if persistErr == nil {
-			return errors.Annotate(sinkErr, "cannot save audit record to file")
-		}
-		if sinkErr == nil {
-			return errors.Annotate(persistErr, "cannot save audit record to database")
-		}
-		return errors.Annotate(persistErr, "cannot save audit record to file or database")
+		return sink(entry)
 	}
 }
 
@@ -1555,3 +1636,43 @@ func metricAPI(st api.Connection) (metricsmanager.MetricsManagerClient, error) {
 var newDeployContext = func(st *apideployer.State, agentConfig agent.Config) deployer.Context {
 	return deployer.NewSimpleContext(agentConfig, st)
 }
+
+// setSecretsKey gives the state the key, kept in the agent config,
+// with which charm secrets are encrypted.
+func setSecretsKey(st *state.State, agentConfig agent.Config) error {
+	key, err := decodeSecretsKey(agentConfig)
+	if err != nil || key == nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.SetSecretsKey(key))
+}
+
+// secretsKey returns the key with which charm secrets are encrypted,
+// as kept in the agent's current config, or nil if there is none. The
+// key is read afresh each time, as a controller upgraded from a version
+// without secrets has its key provisioned by an upgrade step.
+func (a *MachineAgent) secretsKey() []byte {
+	// The config cannot be read while the upgrade steps are changing
+	// it, and any key they provision is not written until they finish.
+	if a.isUpgradeRunning() {
+		return nil
+	}
+	key, err := decodeSecretsKey(a.CurrentConfig())
+	if err != nil {
+		logger.Errorf("%v", err)
+		return nil
+	}
+	return key
+}
+
+func decodeSecretsKey(agentConfig agent.Config) ([]byte, error) {
+	info, ok := agentConfig.StateServingInfo()
+	if !ok || info.SecretsKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(info.SecretsKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode secrets key")
+	}
+	return key, nil
+}
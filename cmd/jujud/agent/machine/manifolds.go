@@ -448,8 +448,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Config: sinks.SyslogConfig,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-http",
+				Config: sinks.HTTPConfig,
+				OpenFn: sinks.OpenHTTP,
 			}},
+			Clock: config.Clock,
 		})),
 	}
 }
@@ -76,6 +76,11 @@ func ServingInfoSetterManifold(config ServingInfoSetterConfig) dependency.Manifo
 					// apiState.
 					info.Cert = existing.Cert
 					info.PrivateKey = existing.PrivateKey
+					// A controller that has not yet been given
+					// the secrets key reports none; keep ours.
+					if info.SecretsKey == "" {
+						info.SecretsKey = existing.SecretsKey
+					}
 				}
 				config.SetStateServingInfo(info)
 				return nil
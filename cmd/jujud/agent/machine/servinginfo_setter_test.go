@@ -168,6 +168,19 @@ func (s *ServingInfoSetterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C) {
 	c.Assert(a.conf.ssi.PrivateKey, gc.Equals, existingKey)
 }
 
+func (s *ServingInfoSetterSuite) TestJobManageEnvironNotOverwriteSecretsKey(c *gc.C) {
+	// A controller without the secrets key does not remove ours.
+	a := &mockAgent{}
+	a.conf.SetStateServingInfo(params.StateServingInfo{
+		SecretsKey: "secrets key",
+	})
+
+	s.startManifold(c, a, 1234)
+
+	c.Assert(a.conf.ssiSet, jc.IsTrue)
+	c.Assert(a.conf.ssi.SecretsKey, gc.Equals, "secrets key")
+}
+
 func (s *ServingInfoSetterSuite) TestJobHostUnits(c *gc.C) {
 	// State serving info should not be set for JobHostUnits.
 	s.checkNotController(c, multiwatcher.JobHostUnits)
@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/api/imagemetadata"
 	apimachiner "github.com/juju/juju/api/machiner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/cmd/jujud/agent/model"
 	"github.com/juju/juju/core/migration"
@@ -1442,3 +1443,26 @@ func (w *nullWorker) Kill() {
 func (w *nullWorker) Wait() error {
 	return w.tomb.Wait()
 }
+
+type auditEntriesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditEntriesSuite{})
+
+func (s *auditEntriesSuite) TestUserAuditEntries(c *gc.C) {
+	var handled []string
+	sink := userAuditEntries(func(entry audit.AuditEntry) error {
+		handled = append(handled, entry.OriginName+" "+entry.Operation)
+		return nil
+	})
+	for _, entry := range []audit.AuditEntry{
+		{OriginName: "user-bob", Operation: "Client:v1 - FullStatus"},
+		{OriginName: "machine-0", Operation: "Provisioner:v3 - Life"},
+		{OriginName: "unit-mysql-0", Operation: "Uniter:v5 - Life"},
+		{OriginName: "user-bob", Operation: "Pinger:v1 - Ping"},
+	} {
+		c.Assert(sink(entry), jc.ErrorIsNil)
+	}
+	c.Assert(handled, jc.DeepEquals, []string{"user-bob Client:v1 - FullStatus"})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -36,6 +37,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -84,6 +86,15 @@ type ManifoldsConfig struct {
 	StatusHistoryPrunerMaxHistoryMB   uint
 	StatusHistoryPrunerInterval       time.Duration
 
+	// ActionPrunerInterval determines how often the action pruner
+	// removes old action results, as limited by the model config.
+	ActionPrunerInterval time.Duration
+
+	// RemoteRelationsInterval determines how often the model's
+	// relations to applications in other models are synced with
+	// their counterparts there.
+	RemoteRelationsInterval time.Duration
+
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
@@ -285,6 +296,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			NewTimer: worker.NewTimer,
 		})),
+		actionPrunerName: ifNotMigrating(actionpruner.Manifold(actionpruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			PruneInterval: config.ActionPrunerInterval,
+			// TODO(fwereade): 2016-03-17 lp:1558657
+			NewTimer: worker.NewTimer,
+		})),
+		remoteRelationsName: ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
+			APICallerName: apiCallerName,
+			SyncInterval:  config.RemoteRelationsInterval,
+			// TODO(fwereade): 2016-03-17 lp:1558657
+			NewTimer: worker.NewTimer,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -382,5 +405,7 @@ const (
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	actionPrunerName         = "action-pruner"
+	remoteRelationsName      = "remote-relations"
 	machineUndertakerName    = "machine-undertaker"
 )
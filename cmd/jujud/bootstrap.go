@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -210,6 +211,14 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+
+	// Generate the key with which charm secrets are encrypted; it is
+	// kept in the agent config, not in the database.
+	secretsKey, err := state.NewSecretsKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info.SecretsKey = base64.StdEncoding.EncodeToString(secretsKey)
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
 		return nil
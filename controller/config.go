@@ -4,7 +4,13 @@
 package controller
 
 import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"net/url"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -12,7 +18,10 @@ import (
 	"github.com/juju/utils"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -25,6 +34,99 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogSinks holds a comma-separated list of the names of the
+	// sinks to which audit entries are written when auditing is
+	// enabled. Known sinks are "logfile", "mongo", "syslog" and
+	// "webhook".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogWebhookURL is the URL to which audit entries are
+	// POSTed when the "webhook" audit sink is enabled.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogSyslogHost is the host-port of the syslog server to
+	// which audit entries are forwarded when the "syslog" audit sink
+	// is enabled.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the certificate of the CA that signed
+	// the audit syslog server certificate, in PEM format.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate used to
+	// connect to the audit syslog server, in PEM format.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client key used to connect to
+	// the audit syslog server, in PEM format. It is one of the
+	// controller's secrets; see SecretAttributes.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// BackupSchedule is a cron-style schedule, such as "0 2 * * *",
+	// on which the controller creates backups. If it is empty, no
+	// backups are created automatically.
+	BackupSchedule = "backup-schedule"
+
+	// BackupScheduleStorage names the storage in which scheduled
+	// backups are kept: "controller", "dir" or "s3".
+	BackupScheduleStorage = "backup-schedule-storage"
+
+	// BackupKeepLast is the number of most recent scheduled backups
+	// to keep.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the last
+	// scheduled backup of the day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the last
+	// scheduled backup of the week is kept.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupStorageDir is the path of a directory, which may be an
+	// NFS mount, in which backups are kept when the "dir" backup
+	// storage is requested.
+	BackupStorageDir = "backup-storage-dir"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// in which backups are kept when the "s3" backup storage is
+	// requested.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the S3 backup bucket.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the name of the bucket in which backups are
+	// kept when the "s3" backup storage is requested. Backups are
+	// stored under the "juju-backups/" key prefix.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to access the S3
+	// backup bucket. It is one of the controller's secrets; see
+	// SecretAttributes.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to access the S3
+	// backup bucket. It is one of the controller's secrets; see
+	// SecretAttributes.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupEncryptionKey is a base64-encoded 256-bit key with which
+	// backup archives are encrypted when they are created. It is one
+	// of the controller's secrets; see SecretAttributes.
+	BackupEncryptionKey = "backup-encryption-key"
+
+	// BackupRetiredEncryptionKeys holds the comma-separated keys with
+	// which backups were encrypted before BackupEncryptionKey was
+	// last rotated, so that those backups may still be restored. It
+	// is one of the controller's secrets.
+	BackupRetiredEncryptionKeys = "backup-retired-encryption-keys"
+
+	// BackupEncryptionPublicKey is a PEM-encoded RSA public key with
+	// which backup archives are encrypted when they are created. Only
+	// the holder of the matching private key can decrypt them.
+	BackupEncryptionPublicKey = "backup-encryption-public-key"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -67,6 +169,14 @@ const (
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
 
+	// DefaultAuditLogSinks contains the default value for the
+	// AuditLogSinks config value.
+	DefaultAuditLogSinks = "logfile,mongo"
+
+	// DefaultBackupS3Region contains the default value for the
+	// BackupS3Region config value.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -84,7 +194,27 @@ var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
 	AutocertDNSNameKey,
+	AuditLogSinks,
+	AuditLogSyslogCACert,
+	AuditLogSyslogClientCert,
+	AuditLogSyslogClientKey,
+	AuditLogSyslogHost,
+	AuditLogWebhookURL,
 	AutocertURLKey,
+	BackupEncryptionKey,
+	BackupEncryptionPublicKey,
+	BackupKeepDaily,
+	BackupKeepLast,
+	BackupKeepWeekly,
+	BackupRetiredEncryptionKeys,
+	BackupS3AccessKey,
+	BackupS3Bucket,
+	BackupS3Endpoint,
+	BackupS3Region,
+	BackupS3SecretKey,
+	BackupSchedule,
+	BackupScheduleStorage,
+	BackupStorageDir,
 	CACertKey,
 	ControllerUUIDKey,
 	IdentityPublicKey,
@@ -144,6 +274,17 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// asInt returns the named attribute as an integer, or 0 if it is not
+// found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -181,6 +322,129 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogSinks returns the names of the sinks to which audit entries
+// should be written. See AuditLogSinks for more details.
+func (c Config) AuditLogSinks() []string {
+	value, ok := c[AuditLogSinks].(string)
+	if !ok {
+		value = DefaultAuditLogSinks
+	}
+	var sinks []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sinks = append(sinks, name)
+		}
+	}
+	return sinks
+}
+
+// AuditLogWebhookURL returns the URL to which audit entries are
+// POSTed by the webhook audit sink.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogSyslog returns the configuration of the syslog server used
+// by the syslog audit sink, and whether a host has been configured.
+func (c Config) AuditLogSyslog() (*syslog.RawConfig, bool) {
+	cfg := &syslog.RawConfig{
+		Enabled:    true,
+		Host:       c.asString(AuditLogSyslogHost),
+		CACert:     c.asString(AuditLogSyslogCACert),
+		ClientCert: c.asString(AuditLogSyslogClientCert),
+		ClientKey:  c.asString(AuditLogSyslogClientKey),
+	}
+	return cfg, cfg.Host != ""
+}
+
+// BackupStorageDir returns the directory in which backups are kept
+// when the "dir" backup storage is requested, or the empty string if
+// none has been configured.
+func (c Config) BackupStorageDir() string {
+	return c.asString(BackupStorageDir)
+}
+
+// BackupS3Config holds the configuration of the S3-compatible object
+// store in which backups may be kept.
+type BackupS3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// BackupS3 returns the configuration of the S3-compatible object store
+// in which backups are kept when the "s3" backup storage is requested,
+// and whether a bucket has been configured.
+func (c Config) BackupS3() (BackupS3Config, bool) {
+	cfg := BackupS3Config{
+		Endpoint:  c.asString(BackupS3Endpoint),
+		Region:    c.asString(BackupS3Region),
+		Bucket:    c.asString(BackupS3Bucket),
+		AccessKey: c.asString(BackupS3AccessKey),
+		SecretKey: c.asString(BackupS3SecretKey),
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultBackupS3Region
+	}
+	return cfg, cfg.Bucket != ""
+}
+
+// BackupSchedule returns the schedule on which the controller creates
+// backups, or "" if backups are not created automatically.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupScheduleStorage returns the name of the storage in which
+// scheduled backups are kept, or "" for the controller.
+func (c Config) BackupScheduleStorage() string {
+	return c.asString(BackupScheduleStorage)
+}
+
+// BackupRetention holds the rules determining which scheduled backups
+// are kept. A zero value for a rule means it does not apply.
+type BackupRetention struct {
+	KeepLast   int
+	KeepDaily  int
+	KeepWeekly int
+}
+
+// BackupRetention returns the rules determining which scheduled
+// backups are kept.
+func (c Config) BackupRetention() BackupRetention {
+	return BackupRetention{
+		KeepLast:   c.asInt(BackupKeepLast),
+		KeepDaily:  c.asInt(BackupKeepDaily),
+		KeepWeekly: c.asInt(BackupKeepWeekly),
+	}
+}
+
+// BackupEncryptionKey returns the base64-encoded key with which backup
+// archives are encrypted, or "" if none is configured.
+func (c Config) BackupEncryptionKey() string {
+	return c.asString(BackupEncryptionKey)
+}
+
+// BackupRetiredEncryptionKeys returns the base64-encoded keys with
+// which backups were encrypted before the current key.
+func (c Config) BackupRetiredEncryptionKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(c.asString(BackupRetiredEncryptionKeys), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// BackupEncryptionPublicKey returns the PEM-encoded public key with
+// which backup archives are encrypted, or "" if none is configured.
+func (c Config) BackupEncryptionPublicKey() string {
+	return c.asString(BackupEncryptionPublicKey)
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -280,6 +544,158 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if err := validateAuditLogSinks(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateBackupStorage(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateBackupSchedule(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateBackupEncryption(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// validateBackupStorage ensures that any configured backup storage
+// is complete.
+func validateBackupStorage(c Config) error {
+	if dir := c.BackupStorageDir(); dir != "" && !filepath.IsAbs(dir) {
+		return errors.Errorf("%s: expected absolute path, got %q", BackupStorageDir, dir)
+	}
+	s3cfg, ok := c.BackupS3()
+	if !ok {
+		for _, key := range []string{BackupS3Endpoint, BackupS3AccessKey, BackupS3SecretKey} {
+			if c.asString(key) != "" {
+				return errors.Errorf("%s must be set when %s is set", BackupS3Bucket, key)
+			}
+		}
+		return nil
+	}
+	u, err := url.Parse(s3cfg.Endpoint)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", BackupS3Endpoint)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("%s: expected http or https URL, got %q", BackupS3Endpoint, s3cfg.Endpoint)
+	}
+	if s3cfg.AccessKey == "" || s3cfg.SecretKey == "" {
+		return errors.Errorf("%s and %s must be set when %s is set", BackupS3AccessKey, BackupS3SecretKey, BackupS3Bucket)
+	}
+	return nil
+}
+
+// validateBackupSchedule ensures that the backup schedule can be
+// parsed, that scheduled backups are kept in configured storage, and
+// that the retention rules make sense.
+func validateBackupSchedule(c Config) error {
+	if spec := c.BackupSchedule(); spec != "" {
+		if _, err := cron.Parse(spec); err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupSchedule)
+		}
+	}
+	switch storage := c.BackupScheduleStorage(); storage {
+	case "", "controller":
+	case "dir":
+		if c.BackupStorageDir() == "" {
+			return errors.Errorf("%s must be set when %s is %q", BackupStorageDir, BackupScheduleStorage, storage)
+		}
+	case "s3":
+		if _, ok := c.BackupS3(); !ok {
+			return errors.Errorf("%s must be set when %s is %q", BackupS3Bucket, BackupScheduleStorage, storage)
+		}
+	default:
+		return errors.Errorf("%s: unknown backup storage %q", BackupScheduleStorage, storage)
+	}
+	for _, key := range []string{BackupKeepLast, BackupKeepDaily, BackupKeepWeekly} {
+		if c.asInt(key) < 0 {
+			return errors.Errorf("%s: expected non-negative value, got %d", key, c.asInt(key))
+		}
+	}
+	return nil
+}
+
+// validateBackupEncryption ensures that at most one backup encryption
+// key is configured, and that it can be used.
+func validateBackupEncryption(c Config) error {
+	key, publicKey := c.BackupEncryptionKey(), c.BackupEncryptionPublicKey()
+	if key != "" && publicKey != "" {
+		return errors.Errorf("only one of %s and %s may be set", BackupEncryptionKey, BackupEncryptionPublicKey)
+	}
+	if key != "" {
+		if err := validateSymmetricKey(BackupEncryptionKey, key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, key := range c.BackupRetiredEncryptionKeys() {
+		if err := validateSymmetricKey(BackupRetiredEncryptionKeys, key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if publicKey != "" {
+		block, _ := pem.Decode([]byte(publicKey))
+		if block == nil {
+			return errors.Errorf("%s: expected PEM-encoded public key", BackupEncryptionPublicKey)
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupEncryptionPublicKey)
+		}
+		if _, ok := pub.(*rsa.PublicKey); !ok {
+			return errors.Errorf("%s: expected RSA public key, got %T", BackupEncryptionPublicKey, pub)
+		}
+	}
+	return nil
+}
+
+// validateSymmetricKey ensures that the value of the named attribute
+// is a base64-encoded 256-bit key.
+func validateSymmetricKey(name, key string) error {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", name)
+	}
+	if len(data) != 32 {
+		return errors.Errorf("%s: expected 32 bytes, got %d", name, len(data))
+	}
+	return nil
+}
+
+var knownAuditLogSinks = map[string]bool{
+	audit.LogFileSinkName:  true,
+	audit.DatabaseSinkName: true,
+	audit.SyslogSinkName:   true,
+	audit.WebhookSinkName:  true,
+}
+
+// validateAuditLogSinks ensures that every configured audit sink is
+// known, and that any sink requiring further configuration has it.
+func validateAuditLogSinks(c Config) error {
+	for _, name := range c.AuditLogSinks() {
+		if !knownAuditLogSinks[name] {
+			return errors.Errorf("%s: unknown audit sink %q", AuditLogSinks, name)
+		}
+		switch name {
+		case audit.WebhookSinkName:
+			if err := audit.ValidateWebhookURL(c.AuditLogWebhookURL()); err != nil {
+				return errors.Annotatef(err, "invalid %s", AuditLogWebhookURL)
+			}
+		case audit.SyslogSinkName:
+			cfg, ok := c.AuditLogSyslog()
+			if !ok {
+				return errors.Errorf("%s must be set when using the %q audit sink", AuditLogSyslogHost, name)
+			}
+			if err := cfg.Validate(); err != nil {
+				return errors.Annotate(err, "invalid audit syslog config")
+			}
+		}
+	}
 	return nil
 }
 
@@ -290,23 +706,63 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
+	AuditingEnabled:             schema.Bool(),
+	AuditLogSinks:               schema.String(),
+	AuditLogWebhookURL:          schema.String(),
+	AuditLogSyslogHost:          schema.String(),
+	AuditLogSyslogCACert:        schema.String(),
+	AuditLogSyslogClientCert:    schema.String(),
+	AuditLogSyslogClientKey:     schema.String(),
+	APIPort:                     schema.ForceInt(),
+	StatePort:                   schema.ForceInt(),
+	IdentityURL:                 schema.String(),
+	IdentityPublicKey:           schema.String(),
+	SetNUMAControlPolicyKey:     schema.Bool(),
+	AutocertURLKey:              schema.String(),
+	AutocertDNSNameKey:          schema.String(),
+	AllowModelAccessKey:         schema.Bool(),
+	BackupStorageDir:            schema.String(),
+	BackupS3Endpoint:            schema.String(),
+	BackupS3Region:              schema.String(),
+	BackupS3Bucket:              schema.String(),
+	BackupS3AccessKey:           schema.String(),
+	BackupS3SecretKey:           schema.String(),
+	BackupSchedule:              schema.String(),
+	BackupScheduleStorage:       schema.String(),
+	BackupKeepLast:              schema.ForceInt(),
+	BackupKeepDaily:             schema.ForceInt(),
+	BackupKeepWeekly:            schema.ForceInt(),
+	BackupEncryptionKey:         schema.String(),
+	BackupRetiredEncryptionKeys: schema.String(),
+	BackupEncryptionPublicKey:   schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
+	APIPort:                     DefaultAPIPort,
+	AuditingEnabled:             DefaultAuditingEnabled,
+	AuditLogSinks:               DefaultAuditLogSinks,
+	AuditLogWebhookURL:          schema.Omit,
+	AuditLogSyslogHost:          schema.Omit,
+	AuditLogSyslogCACert:        schema.Omit,
+	AuditLogSyslogClientCert:    schema.Omit,
+	AuditLogSyslogClientKey:     schema.Omit,
+	StatePort:                   DefaultStatePort,
+	IdentityURL:                 schema.Omit,
+	IdentityPublicKey:           schema.Omit,
+	SetNUMAControlPolicyKey:     DefaultNUMAControlPolicy,
+	AutocertURLKey:              schema.Omit,
+	AutocertDNSNameKey:          schema.Omit,
+	AllowModelAccessKey:         schema.Omit,
+	BackupStorageDir:            schema.Omit,
+	BackupS3Endpoint:            schema.Omit,
+	BackupS3Region:              schema.Omit,
+	BackupS3Bucket:              schema.Omit,
+	BackupS3AccessKey:           schema.Omit,
+	BackupS3SecretKey:           schema.Omit,
+	BackupSchedule:              schema.Omit,
+	BackupScheduleStorage:       schema.Omit,
+	BackupKeepLast:              schema.Omit,
+	BackupKeepDaily:             schema.Omit,
+	BackupKeepWeekly:            schema.Omit,
+	BackupEncryptionKey:         schema.Omit,
+	BackupRetiredEncryptionKeys: schema.Omit,
+	BackupEncryptionPublicKey:   schema.Omit,
 })
@@ -120,8 +120,176 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "unknown audit sink",
+	config: controller.Config{
+		controller.AuditLogSinks: "logfile,bogus",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `audit-log-sinks: unknown audit sink "bogus"`,
+}, {
+	about: "webhook audit sink requires URL",
+	config: controller.Config{
+		controller.AuditLogSinks: "webhook",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `invalid audit-log-webhook-url: empty webhook URL not valid`,
+}, {
+	about: "webhook audit sink OK",
+	config: controller.Config{
+		controller.AuditLogSinks:      "logfile, webhook",
+		controller.AuditLogWebhookURL: "https://audit.example.com/hook",
+		controller.CACertKey:          testing.CACert,
+	},
+}, {
+	about: "syslog audit sink requires host",
+	config: controller.Config{
+		controller.AuditLogSinks: "syslog",
+		controller.CACertKey:     testing.CACert,
+	},
+	expectError: `audit-log-syslog-host must be set when using the "syslog" audit sink`,
+}, {
+	about: "relative backup storage dir",
+	config: controller.Config{
+		controller.BackupStorageDir: "backups",
+		controller.CACertKey:        testing.CACert,
+	},
+	expectError: `backup-storage-dir: expected absolute path, got "backups"`,
+}, {
+	about: "S3 backup storage requires bucket",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://s3.example.com",
+		controller.CACertKey:        testing.CACert,
+	},
+	expectError: `backup-s3-bucket must be set when backup-s3-endpoint is set`,
+}, {
+	about: "S3 backup storage requires credentials",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://s3.example.com",
+		controller.BackupS3Bucket:   "juju-backups",
+		controller.CACertKey:        testing.CACert,
+	},
+	expectError: `backup-s3-access-key and backup-s3-secret-key must be set when backup-s3-bucket is set`,
+}, {
+	about: "S3 backup storage requires endpoint URL",
+	config: controller.Config{
+		controller.BackupS3Endpoint:  "s3.example.com",
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+		controller.CACertKey:         testing.CACert,
+	},
+	expectError: `backup-s3-endpoint: expected http or https URL, got "s3.example.com"`,
+}, {
+	about: "backup storage OK",
+	config: controller.Config{
+		controller.BackupStorageDir:  "/mnt/nfs/backups",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+		controller.CACertKey:         testing.CACert,
+	},
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "0 25 * * *",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid backup-schedule: schedule "0 25 \* \* \*": hour "25" not valid`,
+}, {
+	about: "scheduled backup storage must be configured",
+	config: controller.Config{
+		controller.BackupSchedule:        "@daily",
+		controller.BackupScheduleStorage: "dir",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-storage-dir must be set when backup-schedule-storage is "dir"`,
+}, {
+	about: "unknown scheduled backup storage",
+	config: controller.Config{
+		controller.BackupScheduleStorage: "nfs",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-schedule-storage: unknown backup storage "nfs"`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.BackupKeepDaily: -1,
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `backup-keep-daily: expected non-negative value, got -1`,
+}, {
+	about: "backup schedule OK",
+	config: controller.Config{
+		controller.BackupSchedule:        "30 2 * * *",
+		controller.BackupScheduleStorage: "dir",
+		controller.BackupStorageDir:      "/mnt/nfs/backups",
+		controller.BackupKeepLast:        3,
+		controller.BackupKeepDaily:       7,
+		controller.BackupKeepWeekly:      4,
+		controller.CACertKey:             testing.CACert,
+	},
+}, {
+	about: "backup encryption key must be 256 bits",
+	config: controller.Config{
+		controller.BackupEncryptionKey: "c2hvcnQ=",
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `backup-encryption-key: expected 32 bytes, got 5`,
+}, {
+	about: "backup encryption public key must be RSA",
+	config: controller.Config{
+		controller.BackupEncryptionPublicKey: ecPublicKey,
+		controller.CACertKey:                 testing.CACert,
+	},
+	expectError: `backup-encryption-public-key: expected RSA public key, got \*ecdsa.PublicKey`,
+}, {
+	about: "only one backup encryption key",
+	config: controller.Config{
+		controller.BackupEncryptionKey:       backupEncryptionKey,
+		controller.BackupEncryptionPublicKey: rsaPublicKey,
+		controller.CACertKey:                 testing.CACert,
+	},
+	expectError: `only one of backup-encryption-key and backup-encryption-public-key may be set`,
+}, {
+	about: "retired backup encryption keys must be 256 bits",
+	config: controller.Config{
+		controller.BackupEncryptionKey:         backupEncryptionKey,
+		controller.BackupRetiredEncryptionKeys: backupEncryptionKey + ",c2hvcnQ=",
+		controller.CACertKey:                   testing.CACert,
+	},
+	expectError: `backup-retired-encryption-keys: expected 32 bytes, got 5`,
+}, {
+	about: "backup encryption key OK",
+	config: controller.Config{
+		controller.BackupEncryptionKey: backupEncryptionKey,
+		controller.CACertKey:           testing.CACert,
+	},
+}, {
+	about: "backup encryption public key OK",
+	config: controller.Config{
+		controller.BackupEncryptionPublicKey: rsaPublicKey,
+		controller.CACertKey:                 testing.CACert,
+	},
 }}
 
+const backupEncryptionKey = "kjMMiFOZp8E3MHlyIS8HEwwtwXnK9xnFovI5W0GyPtg="
+
+const rsaPublicKey = `-----BEGIN PUBLIC KEY-----
+MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC1BEK7GsY56vRHXc1CjzgqNKN3
+q+hcKncfLSUvH1kT1W7FdHJSimUM4vdJVfwvjVdR62g/+gX84h5lELbN1px6gNRc
+kCXwlZPbQ05QHXiQFkSGd1XFsALDE96SOj8wz73w37JEfnLkSd8LfquD+4PCF8Xj
+KF5IBzLZVo7qbyl46QIDAQAB
+-----END PUBLIC KEY-----
+`
+
+const ecPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEUvYC+N9sq3k6gwxNGa2/mBQb75DM
+xbJgNDUqTs9VPevxYaOAAIfSQilFq+Oasf/iL53h4G4XIJ89JtePCc/9Ew==
+-----END PUBLIC KEY-----
+`
+
 func (s *ConfigSuite) TestValidate(c *gc.C) {
 	for i, test := range validateTests {
 		c.Logf("test %d: %v", i, test.about)
@@ -133,3 +301,62 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestAuditLogSinks(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"logfile", "mongo"})
+
+	cfg[controller.AuditLogSinks] = " mongo, webhook ,"
+	c.Assert(cfg.AuditLogSinks(), jc.DeepEquals, []string{"mongo", "webhook"})
+
+	cfg[controller.AuditLogSinks] = ""
+	c.Assert(cfg.AuditLogSinks(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestAuditLogSyslog(c *gc.C) {
+	cfg := controller.Config{}
+	_, ok := cfg.AuditLogSyslog()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg[controller.AuditLogSyslogHost] = "10.0.0.1:6514"
+	cfg[controller.AuditLogSyslogCACert] = testing.CACert
+	syslogCfg, ok := cfg.AuditLogSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg.Enabled, jc.IsTrue)
+	c.Assert(syslogCfg.Host, gc.Equals, "10.0.0.1:6514")
+	c.Assert(syslogCfg.CACert, gc.Equals, testing.CACert)
+}
+
+func (s *ConfigSuite) TestBackupRetention(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.BackupRetention(), jc.DeepEquals, controller.BackupRetention{})
+
+	cfg[controller.BackupKeepLast] = 3
+	// Values obtained over the API are float64.
+	cfg[controller.BackupKeepWeekly] = float64(4)
+	c.Assert(cfg.BackupRetention(), jc.DeepEquals, controller.BackupRetention{
+		KeepLast:   3,
+		KeepWeekly: 4,
+	})
+}
+
+func (s *ConfigSuite) TestBackupS3(c *gc.C) {
+	cfg := controller.Config{}
+	_, ok := cfg.BackupS3()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg[controller.BackupS3Endpoint] = "https://s3.example.com"
+	cfg[controller.BackupS3Bucket] = "juju-backups"
+	cfg[controller.BackupS3AccessKey] = "access"
+	cfg[controller.BackupS3SecretKey] = "secret"
+	s3cfg, ok := cfg.BackupS3()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(s3cfg, jc.DeepEquals, controller.BackupS3Config{
+		Endpoint:  "https://s3.example.com",
+		Region:    "us-east-1",
+		Bucket:    "juju-backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+}
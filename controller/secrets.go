@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+// SecretAttributes are the controller config attributes holding keys
+// or credentials. They may be given when a controller is bootstrapped,
+// but are then kept apart from the rest of the controller config,
+// which is handed out to every agent, so that only the controller
+// itself can read them.
+var SecretAttributes = []string{
+	AuditLogSyslogClientKey,
+	BackupS3AccessKey,
+	BackupS3SecretKey,
+	BackupEncryptionKey,
+	BackupRetiredEncryptionKeys,
+}
+
+// SecretAttribute returns true if the specified attribute name holds
+// a secret.
+func SecretAttribute(attr string) bool {
+	for _, a := range SecretAttributes {
+		if attr == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Secrets holds the values of a controller's secret attributes.
+type Secrets map[string]interface{}
+
+// SplitSecrets returns a copy of the config without its secret
+// attributes, and the values of those attributes.
+func (c Config) SplitSecrets() (Config, Secrets) {
+	config := make(Config)
+	secrets := make(Secrets)
+	for k, v := range c {
+		if SecretAttribute(k) {
+			secrets[k] = v
+		} else {
+			config[k] = v
+		}
+	}
+	return config, secrets
+}
+
+// WithoutSecrets returns a copy of the config without its secret
+// attributes.
+func (c Config) WithoutSecrets() Config {
+	config, _ := c.SplitSecrets()
+	return config
+}
+
+// WithSecrets returns a copy of the config with the values of the
+// given secret attributes added. The result is for use within the
+// controller only, and must never be handed to agents or clients.
+func (c Config) WithSecrets(secrets Secrets) Config {
+	config := make(Config)
+	for k, v := range c {
+		config[k] = v
+	}
+	for k, v := range secrets {
+		if SecretAttribute(k) {
+			config[k] = v
+		}
+	}
+	return config
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+)
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestSplitSecrets(c *gc.C) {
+	cfg := controller.Config{
+		controller.APIPort:                 17070,
+		controller.AuditLogSyslogClientKey: "private",
+	}
+	public, secrets := cfg.SplitSecrets()
+	c.Check(public, jc.DeepEquals, controller.Config{
+		controller.APIPort: 17070,
+	})
+	c.Check(secrets, jc.DeepEquals, controller.Secrets{
+		controller.AuditLogSyslogClientKey: "private",
+	})
+	// The original config is unchanged.
+	c.Check(cfg[controller.AuditLogSyslogClientKey], gc.Equals, "private")
+	c.Check(cfg.WithoutSecrets(), jc.DeepEquals, public)
+}
+
+func (s *SecretsSuite) TestBackupS3CredentialsAreSecret(c *gc.C) {
+	cfg := controller.Config{
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	}
+	public, secrets := cfg.SplitSecrets()
+	c.Check(public, jc.DeepEquals, controller.Config{
+		controller.BackupS3Bucket: "juju-backups",
+	})
+	c.Check(secrets, jc.DeepEquals, controller.Secrets{
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	})
+}
+
+func (s *SecretsSuite) TestWithSecrets(c *gc.C) {
+	cfg := controller.Config{
+		controller.APIPort: 17070,
+	}
+	merged := cfg.WithSecrets(controller.Secrets{
+		controller.AuditLogSyslogClientKey: "private",
+		controller.APIPort:                 1234,
+	})
+	c.Check(merged, jc.DeepEquals, controller.Config{
+		controller.APIPort:                 17070,
+		controller.AuditLogSyslogClientKey: "private",
+	})
+	c.Check(cfg, gc.HasLen, 1)
+}
@@ -5,12 +5,29 @@
 package actions
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
 )
 
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// timeoutKey is the key in an action's definition in actions.yaml
+// that holds the default timeout for the action.
+const timeoutKey = "timeout"
+
+// parallelKey is the key in an action's definition in actions.yaml
+// that declares whether the action may run in parallel with hooks and
+// other actions.
+const parallelKey = "parallel"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: charm.ActionSpec{
@@ -33,3 +50,75 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 		},
 	},
 }
+
+// Settings holds what a charm declares in actions.yaml about how one
+// of its actions is run. These are not part of the action's spec, which
+// only describes its params.
+type Settings struct {
+	// Timeout is how long the action may run for when it is enqueued
+	// without a timeout, or zero if it may run for as long as it needs.
+	Timeout time.Duration
+
+	// Parallel is whether the action is safe to run in parallel with
+	// the unit's hooks and other actions. Such actions must not change
+	// the unit, so are typically read-only diagnostics.
+	Parallel bool
+}
+
+// ReadSettings reads the settings declared for each action in the
+// actions.yaml content read from r.
+func ReadSettings(r io.Reader) (map[string]Settings, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var raw map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Annotate(err, "cannot parse actions.yaml")
+	}
+	settings := make(map[string]Settings)
+	for name, spec := range raw {
+		var s Settings
+		if value, ok := spec[timeoutKey]; ok {
+			if s.Timeout, err = parseTimeout(value); err != nil {
+				return nil, errors.Annotatef(err, "action %q", name)
+			}
+		}
+		if value, ok := spec[parallelKey]; ok {
+			if s.Parallel, ok = value.(bool); !ok {
+				return nil, errors.Errorf("action %q: parallel %v is not a boolean", name, value)
+			}
+		}
+		settings[name] = s
+	}
+	return settings, nil
+}
+
+// ReadCharmSettings reads the settings declared for each action in the
+// actions.yaml of the charm in charmDir. A charm without actions.yaml
+// declares none.
+func ReadCharmSettings(charmDir string) (map[string]Settings, error) {
+	f, err := os.Open(filepath.Join(charmDir, "actions.yaml"))
+	if os.IsNotExist(err) {
+		return map[string]Settings{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	return ReadSettings(f)
+}
+
+func parseTimeout(value interface{}) (time.Duration, error) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, errors.Errorf("timeout %v is not a duration", value)
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid timeout")
+	}
+	if timeout < 0 {
+		return 0, errors.Errorf("timeout %v is negative", timeout)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type actionsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&actionsSuite{})
+
+func (s *actionsSuite) TestReadSettings(c *gc.C) {
+	settings, err := actions.ReadSettings(strings.NewReader(`
+snapshot:
+  description: Take a snapshot of the database.
+  timeout: 30m
+  params:
+    outfile:
+      description: The file to write out to.
+      type: string
+report:
+  description: Report on the database.
+  parallel: true
+restore:
+  description: Restore the database.
+  parallel: false
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]actions.Settings{
+		"snapshot": {Timeout: 30 * time.Minute},
+		"report":   {Parallel: true},
+		"restore":  {},
+	})
+}
+
+func (s *actionsSuite) TestReadSettingsInvalidParallel(c *gc.C) {
+	_, err := actions.ReadSettings(strings.NewReader(`
+report:
+  parallel: sometimes
+`))
+	c.Assert(err, gc.ErrorMatches, `action "report": parallel sometimes is not a boolean`)
+}
+
+func (s *actionsSuite) TestReadSettingsInvalidTimeout(c *gc.C) {
+	for i, test := range []struct {
+		timeout string
+		err     string
+	}{{
+		timeout: "30",
+		err:     `action "snapshot": timeout 30 is not a duration`,
+	}, {
+		timeout: "soon",
+		err:     `action "snapshot": invalid timeout: .*`,
+	}, {
+		timeout: "-1s",
+		err:     `action "snapshot": timeout -1s is negative`,
+	}} {
+		c.Logf("test %d: %s", i, test.timeout)
+		_, err := actions.ReadSettings(strings.NewReader(
+			"snapshot:\n  timeout: " + test.timeout + "\n",
+		))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *actionsSuite) TestReadCharmSettings(c *gc.C) {
+	dir := c.MkDir()
+	settings, err := actions.ReadCharmSettings(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "actions.yaml"), []byte("snapshot:\n  timeout: 10s\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = actions.ReadCharmSettings(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]actions.Settings{
+		"snapshot": {Timeout: 10 * time.Second},
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedules and computes the times at
+// which they fire.
+//
+// A schedule has five space-separated fields: minute (0-59), hour
+// (0-23), day of month (1-31), month (1-12) and day of week (0-6, with
+// 0 or 7 meaning Sunday). Each field is "*" or a comma-separated list
+// of values and ranges ("1-5"), any of which may be followed by a step
+// ("*/15", "0-30/10"). As with cron, if both the day of month and the
+// day of week are restricted, a time matching either of them fires.
+//
+// The descriptors "@hourly", "@daily", "@weekly" and "@monthly" are
+// also accepted.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxSearch bounds the search for the next firing time, so that a
+// schedule which can never fire (such as "0 0 31 2 *") does not
+// search forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+type fieldRange struct {
+	name     string
+	min, max int
+}
+
+var fields = []fieldRange{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron-style schedule.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day fields were
+	// unrestricted, which changes how they are combined.
+	domStar, dowStar bool
+}
+
+// Parse parses the cron-style schedule spec.
+func Parse(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if strings.HasPrefix(expanded, "@") {
+		var ok bool
+		if expanded, ok = descriptors[expanded]; !ok {
+			return nil, errors.NotValidf("schedule descriptor %q", spec)
+		}
+	}
+	parts := strings.Fields(expanded)
+	if len(parts) != len(fields) {
+		return nil, errors.NotValidf("schedule %q: expected %d fields, got %d", spec, len(fields), len(parts))
+	}
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		sets[i] = set
+	}
+	s := &Schedule{
+		spec:    spec,
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}
+	// Sunday may be written as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField parses a single comma-separated schedule field into a
+// bit set of the values it matches.
+func parseField(field string, r fieldRange) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		lo, hi, step := r.min, r.max, 1
+		rangePart := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", r.name, item[i+1:])
+			}
+			rangePart = item[:i]
+		}
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], r); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseValue(bounds[1], r); err != nil {
+					return 0, errors.Trace(err)
+				}
+			} else if step != 1 {
+				// "5/10" means every 10th value from 5.
+				hi = r.max
+			}
+			if hi < lo {
+				return 0, errors.NotValidf("%s range %q", r.name, rangePart)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, r fieldRange) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < r.min || v > r.max {
+		return 0, errors.NotValidf("%s %q", r.name, s)
+	}
+	return v, nil
+}
+
+// String returns the schedule as it was specified.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t at which the schedule fires,
+// in t's location. If the schedule never fires, the zero time is
+// returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct{}
+
+var _ = gc.Suite(&CronSuite{})
+
+func mustParseTime(c *gc.C, s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+var nextTests = []struct {
+	spec  string
+	after string
+	next  string
+}{{
+	spec:  "* * * * *",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-18 10:31",
+}, {
+	spec:  "*/15 * * * *",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-18 10:45",
+}, {
+	spec:  "30 2 * * *",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-19 02:30",
+}, {
+	spec:  "0 0 1 * *",
+	after: "2016-12-18 10:30",
+	next:  "2017-01-01 00:00",
+}, {
+	spec:  "0 12 * * 1-5",
+	after: "2016-10-21 12:00", // Friday
+	next:  "2016-10-24 12:00",
+}, {
+	spec:  "0 0 * * 7",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-23 00:00",
+}, {
+	// Either day field may match when both are restricted.
+	spec:  "0 0 1 * 0",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-23 00:00",
+}, {
+	spec:  "0 0 29 2 *",
+	after: "2017-01-01 00:00",
+	next:  "2020-02-29 00:00",
+}, {
+	spec:  "5,35 1-3/2 * * *",
+	after: "2016-10-18 01:35",
+	next:  "2016-10-18 03:05",
+}, {
+	spec:  "@daily",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-19 00:00",
+}, {
+	spec:  "@weekly",
+	after: "2016-10-18 10:30",
+	next:  "2016-10-23 00:00",
+}, {
+	spec:  "0 0 31 2 *",
+	after: "2016-10-18 10:30",
+	next:  "",
+}}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	for i, test := range nextTests {
+		c.Logf("test %d: %q after %s", i, test.spec, test.after)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		next := schedule.Next(mustParseTime(c, test.after))
+		if test.next == "" {
+			c.Check(next.IsZero(), jc.IsTrue)
+		} else {
+			c.Check(next, gc.Equals, mustParseTime(c, test.next))
+		}
+	}
+}
+
+func (s *CronSuite) TestNextKeepsLocation(c *gc.C) {
+	loc := time.FixedZone("test", 2*60*60)
+	schedule, err := cron.Parse("0 3 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	next := schedule.Next(time.Date(2016, 10, 18, 4, 0, 0, 0, loc))
+	c.Check(next, gc.Equals, time.Date(2016, 10, 19, 3, 0, 0, 0, loc))
+}
+
+func (s *CronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.String(), gc.Equals, "@daily")
+}
+
+var parseErrorTests = []struct {
+	spec string
+	err  string
+}{{
+	spec: "",
+	err:  `schedule "": expected 5 fields, got 0 not valid`,
+}, {
+	spec: "* * * *",
+	err:  `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+}, {
+	spec: "@fortnightly",
+	err:  `schedule descriptor "@fortnightly" not valid`,
+}, {
+	spec: "60 * * * *",
+	err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+}, {
+	spec: "* 24 * * *",
+	err:  `schedule "\* 24 \* \* \*": hour "24" not valid`,
+}, {
+	spec: "* * 0 * *",
+	err:  `schedule "\* \* 0 \* \*": day of month "0" not valid`,
+}, {
+	spec: "* * * 13 *",
+	err:  `schedule "\* \* \* 13 \*": month "13" not valid`,
+}, {
+	spec: "* * * * mon",
+	err:  `schedule "\* \* \* \* mon": day of week "mon" not valid`,
+}, {
+	spec: "*/0 * * * *",
+	err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+}, {
+	spec: "30-10 * * * *",
+	err:  `schedule "30-10 \* \* \* \*": minute range "30-10" not valid`,
+}}
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
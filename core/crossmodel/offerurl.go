@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel holds the concepts shared by the applications
+// that relate across models: the offers one model makes of its
+// application endpoints, and the URLs by which they are consumed.
+package crossmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// OfferURL identifies an application offer on a controller, in the
+// form "[<owner>/]<model>.<offer>".
+type OfferURL struct {
+	// Owner is the name of the user owning the model; it may be
+	// empty, meaning the user consuming the offer.
+	Owner string
+
+	// ModelName is the name of the model making the offer.
+	ModelName string
+
+	// OfferName is the name of the offer within the model.
+	OfferName string
+}
+
+// String returns the offer URL in its textual form.
+func (u OfferURL) String() string {
+	url := fmt.Sprintf("%s.%s", u.ModelName, u.OfferName)
+	if u.Owner != "" {
+		url = u.Owner + "/" + url
+	}
+	return url
+}
+
+// WithOwner returns a copy of the URL, with the owner set to the given
+// user if it was not specified.
+func (u OfferURL) WithOwner(owner string) OfferURL {
+	if u.Owner == "" {
+		u.Owner = owner
+	}
+	return u
+}
+
+// ParseOfferURL parses an offer URL of the form
+// "[<owner>/]<model>.<offer>".
+func ParseOfferURL(url string) (OfferURL, error) {
+	var result OfferURL
+	rest := url
+	if i := strings.Index(rest, "/"); i != -1 {
+		result.Owner, rest = rest[:i], rest[i+1:]
+		if !names.IsValidUser(result.Owner) {
+			return OfferURL{}, errors.NotValidf("owner %q in offer URL %q", result.Owner, url)
+		}
+	}
+	i := strings.LastIndex(rest, ".")
+	if i == -1 {
+		return OfferURL{}, errors.NotValidf("offer URL %q, expected [<owner>/]<model>.<offer>,", url)
+	}
+	result.ModelName, result.OfferName = rest[:i], rest[i+1:]
+	if !names.IsValidModelName(result.ModelName) {
+		return OfferURL{}, errors.NotValidf("model name %q in offer URL %q", result.ModelName, url)
+	}
+	if !IsValidOfferName(result.OfferName) {
+		return OfferURL{}, errors.NotValidf("offer name %q in offer URL %q", result.OfferName, url)
+	}
+	return result, nil
+}
+
+// IsValidOfferName returns whether name is a valid offer name. Offers
+// are consumed as applications, so their names follow the same rules.
+func IsValidOfferName(name string) bool {
+	return names.IsValidApplication(name)
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type OfferURLSuite struct{}
+
+var _ = gc.Suite(&OfferURLSuite{})
+
+var parseOfferURLTests = []struct {
+	url    string
+	expect crossmodel.OfferURL
+	err    string
+}{{
+	url:    "prod.mysql",
+	expect: crossmodel.OfferURL{ModelName: "prod", OfferName: "mysql"},
+}, {
+	url:    "fred/prod.db",
+	expect: crossmodel.OfferURL{Owner: "fred", ModelName: "prod", OfferName: "db"},
+}, {
+	url: "mysql",
+	err: `offer URL "mysql", expected \[<owner>/\]<model>.<offer>, not valid`,
+}, {
+	url: "prod.",
+	err: `offer name "" in offer URL "prod." not valid`,
+}, {
+	url: "prod.Bad",
+	err: `offer name "Bad" in offer URL "prod.Bad" not valid`,
+}, {
+	url: "bad~user/prod.db",
+	err: `owner "bad~user" in offer URL "bad~user/prod.db" not valid`,
+}, {
+	url: ".db",
+	err: `model name "" in offer URL ".db" not valid`,
+}}
+
+func (s *OfferURLSuite) TestParseOfferURL(c *gc.C) {
+	for i, test := range parseOfferURLTests {
+		c.Logf("test %d: %s", i, test.url)
+		url, err := crossmodel.ParseOfferURL(test.url)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(url, jc.DeepEquals, test.expect)
+		c.Check(url.String(), gc.Equals, test.url)
+	}
+}
+
+func (s *OfferURLSuite) TestWithOwner(c *gc.C) {
+	url := crossmodel.OfferURL{ModelName: "prod", OfferName: "db"}
+	c.Check(url.WithOwner("fred").String(), gc.Equals, "fred/prod.db")
+	url.Owner = "mary"
+	c.Check(url.WithOwner("fred").String(), gc.Equals, "mary/prod.db")
+}
@@ -28,6 +28,25 @@ type action struct {
 	Status_    string                 `yaml:"status"`
 	Message_   string                 `yaml:"message"`
 	Results_   map[string]interface{} `yaml:"results"`
+	Messages_  []*actionMessage       `yaml:"messages,omitempty"`
+	// Timeout_ is held in nanoseconds.
+	Timeout_   int64  `yaml:"timeout,omitempty"`
+	Operation_ string `yaml:"operation,omitempty"`
+}
+
+type actionMessage struct {
+	Timestamp_ time.Time `yaml:"timestamp"`
+	Message_   string    `yaml:"message"`
+}
+
+// Timestamp implements ActionMessage.
+func (m *actionMessage) Timestamp() time.Time {
+	return m.Timestamp_
+}
+
+// Message implements ActionMessage.
+func (m *actionMessage) Message() string {
+	return m.Message_
 }
 
 // Id implements Action.
@@ -88,6 +107,25 @@ func (i *action) Results() map[string]interface{} {
 	return i.Results_
 }
 
+// Timeout implements Action.
+func (i *action) Timeout() time.Duration {
+	return time.Duration(i.Timeout_)
+}
+
+// Operation implements Action.
+func (i *action) Operation() string {
+	return i.Operation_
+}
+
+// Messages implements Action.
+func (i *action) Messages() []ActionMessage {
+	var result []ActionMessage
+	for _, message := range i.Messages_ {
+		result = append(result, message)
+	}
+	return result
+}
+
 // ActionArgs is an argument struct used to create a
 // new internal action type that supports the Action interface.
 type ActionArgs struct {
@@ -101,6 +139,16 @@ type ActionArgs struct {
 	Status     string
 	Message    string
 	Results    map[string]interface{}
+	Messages   []ActionMessageArgs
+	Timeout    time.Duration
+	Operation  string
+}
+
+// ActionMessageArgs is an argument struct used to add a progress
+// message to an action.
+type ActionMessageArgs struct {
+	Timestamp time.Time
+	Message   string
 }
 
 func newAction(args ActionArgs) *action {
@@ -113,6 +161,8 @@ func newAction(args ActionArgs) *action {
 		Message_:    args.Message,
 		Id_:         args.Id,
 		Results_:    args.Results,
+		Timeout_:    int64(args.Timeout),
+		Operation_:  args.Operation,
 	}
 	if !args.Started.IsZero() {
 		value := args.Started
@@ -122,6 +172,12 @@ func newAction(args ActionArgs) *action {
 		value := args.Completed
 		action.Completed_ = &value
 	}
+	for _, message := range args.Messages {
+		action.Messages_ = append(action.Messages_, &actionMessage{
+			Timestamp_: message.Timestamp,
+			Message_:   message.Message,
+		})
+	}
 	return action
 }
 
@@ -176,11 +232,17 @@ func importActionV1(source map[string]interface{}) (*action, error) {
 		"message":    schema.String(),
 		"results":    schema.StringMap(schema.Any()),
 		"id":         schema.String(),
+		"messages":   schema.List(schema.StringMap(schema.Any())),
+		"timeout":    schema.Int(),
+		"operation":  schema.String(),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"started":   time.Time{},
 		"completed": time.Time{},
+		"messages":  schema.Omit,
+		"timeout":   int64(0),
+		"operation": "",
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -198,6 +260,8 @@ func importActionV1(source map[string]interface{}) (*action, error) {
 		Parameters_: valid["parameters"].(map[string]interface{}),
 		Enqueued_:   valid["enqueued"].(time.Time).UTC(),
 		Results_:    valid["results"].(map[string]interface{}),
+		Timeout_:    valid["timeout"].(int64),
+		Operation_:  valid["operation"].(string),
 	}
 
 	started := valid["started"].(time.Time)
@@ -210,5 +274,32 @@ func importActionV1(source map[string]interface{}) (*action, error) {
 		completed = completed.UTC()
 		action.Completed_ = &completed
 	}
+	if messages, ok := valid["messages"]; ok {
+		for i, value := range messages.([]interface{}) {
+			message, err := importActionMessageV1(value.(map[string]interface{}))
+			if err != nil {
+				return nil, errors.Annotatef(err, "message %d", i)
+			}
+			action.Messages_ = append(action.Messages_, message)
+		}
+	}
 	return action, nil
 }
+
+func importActionMessageV1(source map[string]interface{}) (*actionMessage, error) {
+	fields := schema.Fields{
+		"timestamp": schema.Time(),
+		"message":   schema.String(),
+	}
+	checker := schema.FieldMap(fields, nil)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "action message v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return &actionMessage{
+		Timestamp_: valid["timestamp"].(time.Time).UTC(),
+		Message_:   valid["message"].(string),
+	}, nil
+}
@@ -41,6 +41,12 @@ func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
 		Status:     "happy",
 		Message:    "a message",
 		Results:    map[string]interface{}{"the": 3, "thing": "bam"},
+		Messages: []ActionMessageArgs{
+			{Timestamp: time.Now(), Message: "started"},
+			{Timestamp: time.Now(), Message: "finished"},
+		},
+		Timeout:   time.Minute,
+		Operation: "3",
 	}
 	action := newAction(args)
 	c.Check(action.Id(), gc.Equals, args.Id)
@@ -53,6 +59,14 @@ func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
 	c.Check(action.Status(), gc.Equals, args.Status)
 	c.Check(action.Message(), gc.Equals, args.Message)
 	c.Check(action.Results(), jc.DeepEquals, args.Results)
+	c.Check(action.Timeout(), gc.Equals, args.Timeout)
+	c.Check(action.Operation(), gc.Equals, args.Operation)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	for i, message := range messages {
+		c.Check(message.Timestamp(), gc.Equals, args.Messages[i].Timestamp)
+		c.Check(message.Message(), gc.Equals, args.Messages[i].Message)
+	}
 }
 
 func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
@@ -70,6 +84,11 @@ func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				Status:     "happy",
 				Message:    "a message",
 				Results:    map[string]interface{}{"the": 3, "thing": "bam"},
+				Messages: []ActionMessageArgs{
+					{Timestamp: time.Now().UTC(), Message: "started"},
+				},
+				Timeout:   time.Minute,
+				Operation: "3",
 			}),
 			newAction(ActionArgs{
 				Name:       "bing",
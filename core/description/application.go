@@ -42,6 +42,8 @@ type application struct {
 
 	MetricsCredentials_ string `yaml:"metrics-creds,omitempty"`
 
+	EndpointBindings_ map[string]string `yaml:"endpoint-bindings,omitempty"`
+
 	// unit count will be assumed by the number of units associated.
 	Units_ units `yaml:"units"`
 
@@ -67,6 +69,7 @@ type ApplicationArgs struct {
 	LeadershipSettings   map[string]interface{}
 	StorageConstraints   map[string]StorageConstraintArgs
 	MetricsCredentials   []byte
+	EndpointBindings     map[string]string
 }
 
 func newApplication(args ApplicationArgs) *application {
@@ -85,6 +88,7 @@ func newApplication(args ApplicationArgs) *application {
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
+		EndpointBindings_:     args.EndpointBindings,
 		StatusHistory_:        newStatusHistory(),
 	}
 	app.setUnits(nil)
@@ -181,6 +185,11 @@ func (s *application) MetricsCredentials() []byte {
 	return creds
 }
 
+// EndpointBindings implements Application.
+func (s *application) EndpointBindings() map[string]string {
+	return s.EndpointBindings_
+}
+
 // Status implements Application.
 func (s *application) Status() Status {
 	// To avoid typed nils check nil here.
@@ -321,6 +330,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
 		"metrics-creds":       schema.String(),
+		"endpoint-bindings":   schema.StringMap(schema.String()),
 		"units":               schema.StringMap(schema.Any()),
 	}
 
@@ -332,6 +342,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"leader":              "",
 		"metrics-creds":       "",
 		"storage-constraints": schema.Omit,
+		"endpoint-bindings":   schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -381,6 +392,10 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		result.StorageConstraints_ = constraints
 	}
 
+	if bindings, ok := valid["endpoint-bindings"]; ok {
+		result.EndpointBindings_ = convertToStringMap(bindings)
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -229,6 +229,18 @@ func (s *ApplicationSerializationSuite) TestStorageConstraints(c *gc.C) {
 	c.Check(second.Count(), gc.Equals, uint64(7))
 }
 
+func (s *ApplicationSerializationSuite) TestEndpointBindings(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.EndpointBindings = map[string]string{
+		"juju-info": "",
+		"website":   "public",
+	}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// CloneArgs describes the new model a model is cloned into.
+type CloneArgs struct {
+	// UUID is the UUID of the new model.
+	UUID string
+
+	// Name is the name of the new model.
+	Name string
+
+	// Owner is the owner of the new model, and its only user.
+	Owner names.UserTag
+}
+
+// Validate returns an error if the args are not valid.
+func (args CloneArgs) Validate() error {
+	if !names.IsValidModel(args.UUID) {
+		return errors.NotValidf("model UUID %q", args.UUID)
+	}
+	if !names.IsValidModelName(args.Name) {
+		return errors.NotValidf("model name %q", args.Name)
+	}
+	if args.Owner.Id() == "" {
+		return errors.NotValidf("missing owner")
+	}
+	return nil
+}
+
+// relationSequence is the name of the sequence relation ids are
+// taken from. The cloned relations keep their ids, so later relations
+// must not reuse them.
+const relationSequence = "relation"
+
+// Clone returns a new model with the identity given by args, holding
+// the source model's config, constraints, applications and their
+// charms, settings, constraints and endpoint bindings, and the
+// relations between them. Nothing belonging to the source model's
+// machines or units is cloned, so the new model has neither, and the
+// applications are left waiting, with a status saying they have no
+// units, until units are added.
+func Clone(source Model, args CloneArgs) (Model, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Round-tripping the source through its serialized form gives a
+	// deep copy that can be changed freely.
+	bytes, err := Serialize(source)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	clone, err := Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m := clone.(*model)
+
+	now := time.Now().UTC()
+	m.Owner_ = args.Owner.Id()
+	m.UpdateConfig(map[string]interface{}{
+		"uuid": args.UUID,
+		"name": args.Name,
+	})
+	m.Blocks_ = nil
+	m.setUsers(nil)
+	m.AddUser(UserArgs{
+		Name:        args.Owner,
+		CreatedBy:   args.Owner,
+		DateCreated: now,
+		Access:      "admin",
+	})
+
+	sequences := make(map[string]int)
+	if value, ok := m.Sequences_[relationSequence]; ok {
+		sequences[relationSequence] = value
+	}
+	m.Sequences_ = sequences
+
+	for _, application := range m.Applications_.Applications_ {
+		application.setUnits(nil)
+		application.Leader_ = ""
+		application.LeadershipSettings_ = map[string]interface{}{}
+		application.MetricsCredentials_ = ""
+		application.StatusHistory_ = newStatusHistory()
+		application.SetStatus(StatusArgs{
+			Value:   "waiting",
+			Message: "no units",
+			Updated: now,
+		})
+	}
+	for _, relation := range m.Relations_.Relations_ {
+		for _, endpoint := range relation.Endpoints_.Endpoints_ {
+			endpoint.UnitSettings_ = make(map[string]map[string]interface{})
+		}
+	}
+
+	m.setMachines(nil)
+	m.setLinkLayerDevices(nil)
+	m.setIPAddresses(nil)
+	m.setSSHHostKeys(nil)
+	m.setActions(nil)
+	m.setOperations(nil)
+	m.setVolumes(nil)
+	m.setFilesystems(nil)
+	m.setStorages(nil)
+
+	if err := m.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cloned model")
+	}
+	return m, nil
+}
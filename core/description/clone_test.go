@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+)
+
+type CloneSuite struct {
+	ModelSerializationSuite
+}
+
+var _ = gc.Suite(&CloneSuite{})
+
+const cloneUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *CloneSuite) cloneArgs() CloneArgs {
+	return CloneArgs{
+		UUID:  cloneUUID,
+		Name:  "staging",
+		Owner: names.NewUserTag("bob"),
+	}
+}
+
+func (s *CloneSuite) sourceModel() Model {
+	model := s.wordpressModelWithSettings()
+	model.UpdateConfig(map[string]interface{}{
+		"name":    "production",
+		"logging": "<root>=DEBUG",
+	})
+	model.AddUser(UserArgs{
+		Name:      names.NewUserTag("owner"),
+		CreatedBy: names.NewUserTag("owner"),
+		Access:    "admin",
+	})
+	model.SetConstraints(ConstraintsArgs{Memory: 8 * gig})
+	model.SetSequence("relation", 43)
+	model.SetSequence("machine", 3)
+	model.SetSequence("application-wordpress", 2)
+	return model
+}
+
+func (s *CloneSuite) TestCloneIdentity(c *gc.C) {
+	clone, err := Clone(s.sourceModel(), s.cloneArgs())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(clone.Tag(), gc.Equals, names.NewModelTag(cloneUUID))
+	c.Check(clone.Owner(), gc.Equals, names.NewUserTag("bob"))
+	c.Check(clone.Config()["name"], gc.Equals, "staging")
+	c.Check(clone.Config()["logging"], gc.Equals, "<root>=DEBUG")
+	c.Check(clone.Constraints().Memory(), gc.Equals, 8*gig)
+
+	users := clone.Users()
+	c.Assert(users, gc.HasLen, 1)
+	c.Check(users[0].Name(), gc.Equals, names.NewUserTag("bob"))
+	c.Check(users[0].Access(), gc.Equals, "admin")
+	c.Check(clone.Sequences(), jc.DeepEquals, map[string]int{"relation": 43})
+}
+
+func (s *CloneSuite) TestCloneLeavesSourceUnchanged(c *gc.C) {
+	source := s.sourceModel()
+	_, err := Clone(source, s.cloneArgs())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(source.Tag(), gc.Equals, names.NewModelTag("some-uuid"))
+	c.Check(source.Config()["name"], gc.Equals, "production")
+	c.Check(source.Machines(), gc.HasLen, 3)
+	c.Check(source.Applications()[0].Units(), gc.HasLen, 2)
+}
+
+func (s *CloneSuite) TestCloneApplicationsWithoutUnits(c *gc.C) {
+	clone, err := Clone(s.sourceModel(), s.cloneArgs())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(clone.Machines(), gc.HasLen, 0)
+	applications := clone.Applications()
+	c.Assert(applications, gc.HasLen, 2)
+	for _, application := range applications {
+		c.Check(application.Units(), gc.HasLen, 0)
+		c.Check(application.Leader(), gc.Equals, "")
+		c.Check(application.Status().Value(), gc.Equals, "waiting")
+		c.Check(application.Status().Message(), gc.Equals, "no units")
+		c.Check(application.StatusHistory(), gc.HasLen, 0)
+	}
+
+	relations := clone.Relations()
+	c.Assert(relations, gc.HasLen, 1)
+	c.Check(relations[0].Id(), gc.Equals, 42)
+	for _, endpoint := range relations[0].Endpoints() {
+		c.Check(endpoint.UnitCount(), gc.Equals, 0)
+	}
+}
+
+func (s *CloneSuite) TestCloneKeepsEndpointBindings(c *gc.C) {
+	source := s.sourceModel()
+	application := source.AddApplication(ApplicationArgs{
+		Tag:                names.NewApplicationTag("haproxy"),
+		Settings:           map[string]interface{}{},
+		LeadershipSettings: map[string]interface{}{},
+		EndpointBindings:   map[string]string{"website": "public"},
+	})
+	application.SetStatus(minimalStatusArgs())
+
+	clone, err := Clone(source, s.cloneArgs())
+	c.Assert(err, jc.ErrorIsNil)
+	applications := clone.Applications()
+	c.Assert(applications, gc.HasLen, 3)
+	c.Check(applications[2].EndpointBindings(), jc.DeepEquals, map[string]string{"website": "public"})
+}
+
+func (s *CloneSuite) TestCloneInvalidArgs(c *gc.C) {
+	args := s.cloneArgs()
+	args.Name = "not valid!"
+	_, err := Clone(s.sourceModel(), args)
+	c.Assert(err, gc.ErrorMatches, `model name "not valid!" not valid`)
+
+	args = s.cloneArgs()
+	args.UUID = "wat"
+	_, err = Clone(s.sourceModel(), args)
+	c.Assert(err, gc.ErrorMatches, `model UUID "wat" not valid`)
+}
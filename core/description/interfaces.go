@@ -91,6 +91,9 @@ type Model interface {
 	Actions() []Action
 	AddAction(ActionArgs) Action
 
+	Operations() []Operation
+	AddOperation(OperationArgs) Operation
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -266,6 +269,7 @@ type Application interface {
 
 	MetricsCredentials() []byte
 	StorageConstraints() map[string]StorageConstraint
+	EndpointBindings() map[string]string
 
 	Units() []Unit
 	AddUnit(UnitArgs) Unit
@@ -427,6 +431,22 @@ type Action interface {
 	Results() map[string]interface{}
 	Status() string
 	Message() string
+	Messages() []ActionMessage
+	Timeout() time.Duration
+	Operation() string
+}
+
+// Operation represents a group of actions enqueued together.
+type Operation interface {
+	Id() string
+	Summary() string
+	Enqueued() time.Time
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage interface {
+	Timestamp() time.Time
+	Message() string
 }
 
 // Volume represents a volume (disk, logical volume, etc.) in the model.
@@ -52,6 +52,7 @@ func NewModel(args ModelArgs) Model {
 	m.setSSHHostKeys(nil)
 	m.setCloudImageMetadatas(nil)
 	m.setActions(nil)
+	m.setOperations(nil)
 	m.setVolumes(nil)
 	m.setFilesystems(nil)
 	m.setStorages(nil)
@@ -129,7 +130,8 @@ type model struct {
 
 	CloudImageMetadata_ cloudimagemetadataset `yaml:"cloud-image-metadata"`
 
-	Actions_ actions `yaml:"actions"`
+	Actions_    actions    `yaml:"actions"`
+	Operations_ operations `yaml:"operations"`
 
 	SSHHostKeys_ sshHostKeys `yaml:"ssh-host-keys"`
 
@@ -456,6 +458,29 @@ func (m *model) setActions(actionsList []*action) {
 	}
 }
 
+// Operations implements Model.
+func (m *model) Operations() []Operation {
+	var result []Operation
+	for _, op := range m.Operations_.Operations_ {
+		result = append(result, op)
+	}
+	return result
+}
+
+// AddOperation implements Model.
+func (m *model) AddOperation(args OperationArgs) Operation {
+	op := newOperation(args)
+	m.Operations_.Operations_ = append(m.Operations_.Operations_, op)
+	return op
+}
+
+func (m *model) setOperations(operationsList []*operation) {
+	m.Operations_ = operations{
+		Version:     1,
+		Operations_: operationsList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -906,6 +931,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"ssh-host-keys":        schema.StringMap(schema.Any()),
 		"cloud-image-metadata": schema.StringMap(schema.Any()),
 		"actions":              schema.StringMap(schema.Any()),
+		"operations":           schema.StringMap(schema.Any()),
 		"ip-addresses":         schema.StringMap(schema.Any()),
 		"spaces":               schema.StringMap(schema.Any()),
 		"subnets":              schema.StringMap(schema.Any()),
@@ -921,6 +947,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
 		"cloud-region": schema.Omit,
+		"operations":   schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -1049,6 +1076,17 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setActions(actions)
 
+	// Models exported before operations were added have none.
+	if operationsMap, ok := valid["operations"]; ok {
+		operations, err := importOperations(operationsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "operations")
+		}
+		result.setOperations(operations)
+	} else {
+		result.setOperations(nil)
+	}
+
 	volumes, err := importVolumes(valid["volumes"].(map[string]interface{}))
 	if err != nil {
 		return nil, errors.Annotate(err, "volumes")
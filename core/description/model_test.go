@@ -701,6 +701,28 @@ func (s *ModelSerializationSuite) TestAction(c *gc.C) {
 	c.Assert(model.Actions(), jc.DeepEquals, actions)
 }
 
+func (s *ModelSerializationSuite) TestOperation(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	enqueued := time.Now().UTC()
+	operation := initial.AddOperation(OperationArgs{
+		Id:       "3",
+		Summary:  "foo run on 2 units",
+		Enqueued: enqueued,
+	})
+	c.Assert(operation.Id(), gc.Equals, "3")
+	c.Assert(operation.Enqueued(), gc.Equals, enqueued)
+	operations := initial.Operations()
+	c.Assert(operations, gc.HasLen, 1)
+	c.Assert(operations[0], jc.DeepEquals, operation)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Operations(), jc.DeepEquals, operations)
+}
+
 func (s *ModelSerializationSuite) TestVolumeValidation(c *gc.C) {
 	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddVolume(testVolumeArgs())
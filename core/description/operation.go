@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type operations struct {
+	Version     int          `yaml:"version"`
+	Operations_ []*operation `yaml:"operations"`
+}
+
+type operation struct {
+	Id_       string    `yaml:"id"`
+	Summary_  string    `yaml:"summary"`
+	Enqueued_ time.Time `yaml:"enqueued"`
+}
+
+// Id implements Operation.
+func (i *operation) Id() string {
+	return i.Id_
+}
+
+// Summary implements Operation.
+func (i *operation) Summary() string {
+	return i.Summary_
+}
+
+// Enqueued implements Operation.
+func (i *operation) Enqueued() time.Time {
+	return i.Enqueued_
+}
+
+// OperationArgs is an argument struct used to create a
+// new internal operation type that supports the Operation interface.
+type OperationArgs struct {
+	Id       string
+	Summary  string
+	Enqueued time.Time
+}
+
+func newOperation(args OperationArgs) *operation {
+	return &operation{
+		Id_:       args.Id,
+		Summary_:  args.Summary,
+		Enqueued_: args.Enqueued,
+	}
+}
+
+func importOperations(source map[string]interface{}) ([]*operation, error) {
+	checker := versionedChecker("operations")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "operations version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := operationDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["operations"].([]interface{})
+	return importOperationList(sourceList, importFunc)
+}
+
+func importOperationList(sourceList []interface{}, importFunc operationDeserializationFunc) ([]*operation, error) {
+	result := make([]*operation, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for operation %d, %T", i, value)
+		}
+		operation, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "operation %d", i)
+		}
+		result = append(result, operation)
+	}
+	return result, nil
+}
+
+type operationDeserializationFunc func(map[string]interface{}) (*operation, error)
+
+var operationDeserializationFuncs = map[int]operationDeserializationFunc{
+	1: importOperationV1,
+}
+
+func importOperationV1(source map[string]interface{}) (*operation, error) {
+	fields := schema.Fields{
+		"id":       schema.String(),
+		"summary":  schema.String(),
+		"enqueued": schema.Time(),
+	}
+	checker := schema.FieldMap(fields, nil)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "operation v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	return &operation{
+		Id_:       valid["id"].(string),
+		Summary_:  valid["summary"].(string),
+		Enqueued_: valid["enqueued"].(time.Time).UTC(),
+	}, nil
+}
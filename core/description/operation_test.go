@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type OperationSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&OperationSerializationSuite{})
+
+func (s *OperationSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "operations"
+	s.sliceName = "operations"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importOperations(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["operations"] = []interface{}{}
+	}
+}
+
+func (s *OperationSerializationSuite) TestNewOperation(c *gc.C) {
+	args := OperationArgs{
+		Id:       "3",
+		Summary:  "backup run on 2 units",
+		Enqueued: time.Now(),
+	}
+	operation := newOperation(args)
+	c.Check(operation.Id(), gc.Equals, args.Id)
+	c.Check(operation.Summary(), gc.Equals, args.Summary)
+	c.Check(operation.Enqueued(), gc.Equals, args.Enqueued)
+}
+
+func (s *OperationSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := operations{
+		Version: 1,
+		Operations_: []*operation{
+			newOperation(OperationArgs{
+				Id:       "3",
+				Summary:  "backup run on 2 units",
+				Enqueued: time.Now().UTC(),
+			}),
+			newOperation(OperationArgs{
+				Id:       "4",
+				Enqueued: time.Now().UTC(),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operations, err := importOperations(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(operations, jc.DeepEquals, initial.Operations_)
+}
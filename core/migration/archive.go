@@ -0,0 +1,397 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/tools"
+)
+
+// CharmDownloader defines a single method that is used to download a
+// charm from a controller.
+type CharmDownloader interface {
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+}
+
+// ToolsDownloader defines a single method that is used to download
+// tools from a controller.
+type ToolsDownloader interface {
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+}
+
+// CharmUploader defines a single method that is used to upload a
+// charm to a controller.
+type CharmUploader interface {
+	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
+}
+
+// ToolsUploader defines a single method that is used to upload tools
+// to a controller.
+type ToolsUploader interface {
+	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+}
+
+// ResourceDownloader defines a single method that is used to download
+// the content of an application's resource from a controller.
+type ResourceDownloader interface {
+	OpenResource(application, name string) (io.ReadCloser, error)
+}
+
+// ResourceUploader defines a single method that is used to upload the
+// content of an application's resource to a controller.
+type ResourceUploader interface {
+	Upload(application, name, filename string, reader io.ReadSeeker) error
+}
+
+// ArchiveResource identifies the content of an application's resource
+// held in a model archive.
+type ArchiveResource struct {
+	// Application is the name of the application.
+	Application string
+
+	// Name is the name of the resource.
+	Name string
+
+	// Filename is the name the content is uploaded with, which needs
+	// the extension of the path declared for the resource.
+	Filename string
+}
+
+// The layout of a model archive: the serialized model, and a
+// directory each for the charm archives, tools tarballs and resources
+// it uses.
+const (
+	archiveModelFile     = "model.yaml"
+	archiveCharmsDir     = "charms"
+	archiveToolsDir      = "tools"
+	archiveResourcesDir  = "resources"
+	archiveResourceParts = 4
+)
+
+// WriteArchiveConfig holds the serialized model and the binaries to be
+// written to a model archive.
+type WriteArchiveConfig struct {
+	ModelBytes         []byte
+	Charms             []string
+	CharmDownloader    CharmDownloader
+	Tools              map[version.Binary]string
+	ToolsDownloader    ToolsDownloader
+	Resources          []ArchiveResource
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are non-nil.
+func (c *WriteArchiveConfig) Validate() error {
+	if len(c.ModelBytes) == 0 {
+		return errors.NotValidf("missing ModelBytes")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	for _, res := range c.Resources {
+		if !validArchiveResource(res) {
+			return errors.NotValidf("resource %q of application %q", res.Name, res.Application)
+		}
+	}
+	return nil
+}
+
+// WriteArchive writes a gzipped tar archive holding a serialized model
+// along with the charms, tools and resources it uses, so the model can
+// be imported into a controller that cannot reach the one it came
+// from.
+func WriteArchive(w io.Writer, config WriteArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	modelBytes := bytes.NewReader(config.ModelBytes)
+	if err := writeArchiveFile(tw, archiveModelFile, modelBytes.Size(), modelBytes); err != nil {
+		return errors.Trace(err)
+	}
+	for _, charmURL := range config.Charms {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotate(err, "cannot open charm")
+		}
+		err = writeArchiveStream(tw, archiveCharmPath(curl), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %q", charmURL)
+		}
+	}
+	for v, uri := range config.Tools {
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot open tools")
+		}
+		err = writeArchiveStream(tw, archiveToolsPath(v), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive tools %q", v)
+		}
+	}
+	for _, res := range config.Resources {
+		reader, err := config.ResourceDownloader.OpenResource(res.Application, res.Name)
+		if err != nil {
+			return errors.Annotate(err, "cannot open resource")
+		}
+		err = writeArchiveStream(tw, archiveResourcePath(res), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %q of application %q", res.Name, res.Application)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// writeArchiveStream writes the content of r to the archive. The size
+// of a tar entry must be known before its content is written, so the
+// content is spooled to a temporary file first.
+func writeArchiveStream(tw *tar.Writer, name string, r io.Reader) error {
+	tempFile, err := ioutil.TempFile("", "juju-model-archive")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	size, err := io.Copy(tempFile, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	return writeArchiveFile(tw, name, size, tempFile)
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: size,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := io.Copy(tw, r)
+	return errors.Trace(err)
+}
+
+func archiveCharmPath(curl *charm.URL) string {
+	return path.Join(archiveCharmsDir, url.QueryEscape(curl.String()))
+}
+
+func archiveToolsPath(v version.Binary) string {
+	return path.Join(archiveToolsDir, v.String()+".tgz")
+}
+
+func archiveResourcePath(res ArchiveResource) string {
+	return path.Join(archiveResourcesDir, res.Application, res.Name, res.Filename)
+}
+
+// validArchiveResource returns whether the resource can be held in an
+// archive, each part of its path being a single path element.
+func validArchiveResource(res ArchiveResource) bool {
+	if !names.IsValidApplication(res.Application) {
+		return false
+	}
+	for _, part := range []string{res.Name, res.Filename} {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, "/\\") {
+			return false
+		}
+	}
+	return true
+}
+
+// Archive is a model archive that has been unpacked to a directory.
+type Archive struct {
+	// ModelBytes holds the serialized model.
+	ModelBytes []byte
+
+	// Charms holds the URLs of the charms in the archive.
+	Charms []*charm.URL
+
+	// Tools holds the versions of the tools in the archive.
+	Tools []version.Binary
+
+	// Resources holds the resources in the archive.
+	Resources []ArchiveResource
+
+	dir string
+}
+
+// ReadArchive unpacks a model archive written by WriteArchive into
+// the given directory.
+func ReadArchive(r io.Reader, dir string) (*Archive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive")
+	}
+	defer gzr.Close()
+	for _, sub := range []string{archiveCharmsDir, archiveToolsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	archive := &Archive{dir: dir}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "cannot read model archive")
+		}
+		if err := archive.readEntry(hdr.Name, tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if len(archive.ModelBytes) == 0 {
+		return nil, errors.NotFoundf("model in archive")
+	}
+	return archive, nil
+}
+
+func (a *Archive) readEntry(name string, r io.Reader) error {
+	if name == archiveModelFile {
+		modelBytes, err := ioutil.ReadAll(r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		a.ModelBytes = modelBytes
+		return nil
+	}
+	// Only the entries written by WriteArchive are accepted, so that
+	// nothing is unpacked outside the directory.
+	if parts := strings.Split(name, "/"); parts[0] == archiveResourcesDir {
+		if len(parts) != archiveResourceParts {
+			return errors.NotValidf("archive entry %q", name)
+		}
+		res := ArchiveResource{
+			Application: parts[1],
+			Name:        parts[2],
+			Filename:    parts[3],
+		}
+		if !validArchiveResource(res) {
+			return errors.NotValidf("archive entry %q", name)
+		}
+		if err := os.MkdirAll(filepath.Dir(a.path(name)), 0755); err != nil {
+			return errors.Trace(err)
+		}
+		a.Resources = append(a.Resources, res)
+		return a.writeFile(name, r)
+	}
+	dir, base := path.Split(name)
+	switch strings.TrimSuffix(dir, "/") {
+	case archiveCharmsDir:
+		charmURL, err := url.QueryUnescape(base)
+		if err != nil {
+			return errors.NotValidf("archive entry %q", name)
+		}
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil || archiveCharmPath(curl) != name {
+			return errors.NotValidf("archive entry %q", name)
+		}
+		a.Charms = append(a.Charms, curl)
+	case archiveToolsDir:
+		v, err := version.ParseBinary(strings.TrimSuffix(base, ".tgz"))
+		if err != nil || archiveToolsPath(v) != name {
+			return errors.NotValidf("archive entry %q", name)
+		}
+		a.Tools = append(a.Tools, v)
+	default:
+		return errors.NotValidf("archive entry %q", name)
+	}
+	return a.writeFile(name, r)
+}
+
+func (a *Archive) writeFile(name string, r io.Reader) error {
+	f, err := os.Create(a.path(name))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return errors.Trace(err)
+}
+
+func (a *Archive) path(name string) string {
+	return filepath.Join(a.dir, filepath.FromSlash(name))
+}
+
+// UploadBinaries sends the charms, tools and resources in the archive
+// to the controller hosting the imported model. The charms go first,
+// as the resources are described by them.
+func (a *Archive) UploadBinaries(charmUploader CharmUploader, toolsUploader ToolsUploader, resourceUploader ResourceUploader) error {
+	for _, curl := range a.Charms {
+		err := a.upload(archiveCharmPath(curl), func(content io.ReadSeeker) error {
+			_, err := charmUploader.UploadCharm(curl, content)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload charm %q", curl)
+		}
+	}
+	for _, v := range a.Tools {
+		err := a.upload(archiveToolsPath(v), func(content io.ReadSeeker) error {
+			_, err := toolsUploader.UploadTools(content, v)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload tools %q", v)
+		}
+	}
+	for _, res := range a.Resources {
+		res := res
+		err := a.upload(archiveResourcePath(res), func(content io.ReadSeeker) error {
+			return resourceUploader.Upload(res.Application, res.Name, res.Filename, content)
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload resource %q of application %q", res.Name, res.Application)
+		}
+	}
+	return nil
+}
+
+func (a *Archive) upload(name string, upload func(io.ReadSeeker) error) error {
+	f, err := os.Open(a.path(name))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return upload(f)
+}
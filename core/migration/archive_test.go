@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ArchiveSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) TestWriteArchiveConfigValidate(c *gc.C) {
+	check := func(modify func(*migration.WriteArchiveConfig), missing string) {
+		config := migration.WriteArchiveConfig{
+			ModelBytes:         []byte("model"),
+			CharmDownloader:    struct{ migration.CharmDownloader }{},
+			ToolsDownloader:    struct{ migration.ToolsDownloader }{},
+			ResourceDownloader: struct{ migration.ResourceDownloader }{},
+		}
+		modify(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, missing)
+	}
+
+	check(func(c *migration.WriteArchiveConfig) { c.ModelBytes = nil }, "missing ModelBytes not valid")
+	check(func(c *migration.WriteArchiveConfig) { c.CharmDownloader = nil }, "missing CharmDownloader not valid")
+	check(func(c *migration.WriteArchiveConfig) { c.ToolsDownloader = nil }, "missing ToolsDownloader not valid")
+	check(func(c *migration.WriteArchiveConfig) { c.ResourceDownloader = nil }, "missing ResourceDownloader not valid")
+	check(func(c *migration.WriteArchiveConfig) {
+		c.Resources = []migration.ArchiveResource{{"mysql", "data", "../data.tgz"}}
+	}, `resource "data" of application "mysql" not valid`)
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	v := version.MustParseBinary("2.0.0-xenial-amd64")
+	err := migration.WriteArchive(&buf, migration.WriteArchiveConfig{
+		ModelBytes:      []byte("model-uuid: some-uuid\n"),
+		Charms:          []string{"local:trusty/magic", "cs:trusty/postgresql-42"},
+		CharmDownloader: fakeDownloader{},
+		Tools:           map[version.Binary]string{v: "/tools/0"},
+		ToolsDownloader: fakeDownloader{},
+		Resources: []migration.ArchiveResource{
+			{"mysql", "data", "data.tgz"},
+		},
+		ResourceDownloader: fakeDownloader{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	archive, err := migration.ReadArchive(&buf, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(archive.ModelBytes), gc.Equals, "model-uuid: some-uuid\n")
+	c.Assert(archive.Charms, jc.DeepEquals, []*charm.URL{
+		charm.MustParseURL("local:trusty/magic"),
+		charm.MustParseURL("cs:trusty/postgresql-42"),
+	})
+	c.Assert(archive.Tools, jc.DeepEquals, []version.Binary{v})
+	c.Assert(archive.Resources, jc.DeepEquals, []migration.ArchiveResource{
+		{"mysql", "data", "data.tgz"},
+	})
+
+	uploader := &fakeUploader{
+		charms:    make(map[string]string),
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	err = archive.UploadBinaries(uploader, uploader, uploader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploader.charms, jc.DeepEquals, map[string]string{
+		"local:trusty/magic":      "local:trusty/magic content",
+		"cs:trusty/postgresql-42": "cs:trusty/postgresql-42 content",
+	})
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{v: "/tools/0"})
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"mysql/data/data.tgz": "mysql/data content",
+	})
+}
+
+func (s *ArchiveSuite) TestUploadBinariesError(c *gc.C) {
+	var buf bytes.Buffer
+	err := migration.WriteArchive(&buf, migration.WriteArchiveConfig{
+		ModelBytes:         []byte("model-uuid: some-uuid\n"),
+		Charms:             []string{"cs:trusty/postgresql-42"},
+		CharmDownloader:    fakeDownloader{},
+		ToolsDownloader:    fakeDownloader{},
+		ResourceDownloader: fakeDownloader{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := migration.ReadArchive(&buf, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	uploader := &fakeUploader{err: errors.New("boom")}
+	err = archive.UploadBinaries(uploader, uploader, uploader)
+	c.Assert(err, gc.ErrorMatches, `cannot upload charm "cs:trusty/postgresql-42": boom`)
+}
+
+func (s *ArchiveSuite) TestReadArchiveNotArchive(c *gc.C) {
+	_, err := migration.ReadArchive(bytes.NewBufferString("not an archive"), c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "cannot read model archive: .*")
+}
+
+func (s *ArchiveSuite) TestReadArchiveMissingModel(c *gc.C) {
+	_, err := migration.ReadArchive(makeArchive(c, "charms/cs%3Atrusty%2Fpostgresql-42"), c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model in archive not found")
+}
+
+func (s *ArchiveSuite) TestReadArchiveUnexpectedEntry(c *gc.C) {
+	for _, name := range []string{
+		"../model.yaml",
+		"charms/../../evil",
+		"charms/not-a-charm%",
+		"tools/2.0.0-xenial-amd64",
+		"other/model.yaml",
+		"resources/mysql/data",
+		"resources/mysql/../data.tgz",
+		"resources/no_such_app/data/data.tgz",
+	} {
+		c.Logf("entry %q", name)
+		_, err := migration.ReadArchive(makeArchive(c, name), c.MkDir())
+		c.Check(err, gc.ErrorMatches, `archive entry ".*" not valid`)
+	}
+}
+
+func makeArchive(c *gc.C, name string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+type fakeDownloader struct{}
+
+func (fakeDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	// Return the charm URL string as the fake charm content.
+	return ioutil.NopCloser(bytes.NewBufferString(curl.String() + " content")), nil
+}
+
+func (fakeDownloader) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	// Return the URI string as the fake tools content.
+	return ioutil.NopCloser(bytes.NewBufferString(uri)), nil
+}
+
+func (fakeDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	// Return the application and resource names as the fake content.
+	return ioutil.NopCloser(bytes.NewBufferString(application + "/" + name + " content")), nil
+}
+
+type fakeUploader struct {
+	charms    map[string]string
+	tools     map[version.Binary]string
+	resources map[string]string
+	err       error
+}
+
+func (f *fakeUploader) Upload(application, name, filename string, r io.ReadSeeker) error {
+	if f.err != nil {
+		return f.err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f.resources[application+"/"+name+"/"+filename] = string(data)
+	return nil
+}
+
+func (f *fakeUploader) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.charms[curl.String()] = string(data)
+	return curl, nil
+}
+
+func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.tools[v] = string(data)
+	return tools.List{&tools.Tools{Version: v}}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +23,8 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -121,6 +124,42 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the URL of the HTTP log forwarding target.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPFormat sets the wire format used when forwarding logs
+	// over HTTP, either "json" or "elasticsearch".
+	LogFwdHTTPFormat = "logforward-http-format"
+
+	// LogFwdHTTPIndex sets the index into which logs are written when
+	// forwarding to Elasticsearch.
+	LogFwdHTTPIndex = "logforward-http-index"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log forwarding target's certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdLevel sets the minimum level of the log records which
+	// are forwarded, e.g. "WARNING".
+	LogFwdLevel = "logforward-level"
+
+	// LogFwdIncludeEntity sets a comma-separated list of the entities
+	// whose log records are forwarded. Entities may end with a "*"
+	// wildcard, e.g. "unit-mysql-*".
+	LogFwdIncludeEntity = "logforward-include-entity"
+
+	// LogFwdExcludeEntity sets a comma-separated list of the entities
+	// whose log records are not forwarded.
+	LogFwdExcludeEntity = "logforward-exclude-entity"
+
+	// LogFwdIncludeModule sets a comma-separated list of the logging
+	// modules whose log records are forwarded.
+	LogFwdIncludeModule = "logforward-include-module"
+
+	// LogFwdExcludeModule sets a comma-separated list of the logging
+	// modules whose log records are not forwarded.
+	LogFwdExcludeModule = "logforward-exclude-module"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -129,6 +168,14 @@ const (
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
 
+	// MaxActionResultsAge is the age, e.g. "336h", beyond which
+	// completed actions and their results are pruned from the model.
+	MaxActionResultsAge = "max-action-results-age"
+
+	// MaxActionResultsCount is the number of completed actions kept
+	// in the model, beyond which the oldest are pruned.
+	MaxActionResultsCount = "max-action-results-count"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -139,6 +186,10 @@ const (
 	IgnoreMachineAddresses = "ignore-machine-addresses"
 )
 
+// DefaultActionResultsAge is the age beyond which completed actions
+// are pruned if none is configured.
+const DefaultActionResultsAge = 336 * time.Hour // 2 weeks
+
 // ParseHarvestMode parses description of harvesting method and
 // returns the representation.
 func ParseHarvestMode(description string) (HarvestMode, error) {
@@ -442,6 +493,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
+
+	if filter, err := cfg.logFwdFilter(); err != nil {
+		return errors.Annotate(err, "invalid log forwarding filter")
+	} else if err := filter.Validate(); err != nil {
+		return errors.Annotate(err, "invalid log forwarding filter")
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -451,6 +514,13 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if _, err := cfg.maxActionResultsAge(); err != nil {
+		return errors.Trace(err)
+	}
+	if count := cfg.MaxActionResultsCount(); count < 0 {
+		return errors.Errorf("%s %d is negative", MaxActionResultsCount, count)
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -682,6 +752,68 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the HTTP log forwarding config, and whether an
+// HTTP log forwarding target has been configured.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	url, _ := c.defined[LogFwdHTTPURL].(string)
+	if url == "" {
+		return nil, false
+	}
+	lfCfg := httpjson.RawConfig{
+		URL: url,
+	}
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+	if s, ok := c.defined[LogFwdHTTPFormat]; ok {
+		lfCfg.Format = s.(string)
+	}
+	if s, ok := c.defined[LogFwdHTTPIndex]; ok {
+		lfCfg.Index = s.(string)
+	}
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok {
+		lfCfg.CACert = s.(string)
+	}
+	return &lfCfg, true
+}
+
+// LogFwdFilter returns the filter which selects the log records to
+// be forwarded.
+func (c *Config) LogFwdFilter() logfwd.Filter {
+	// The filter has already been validated.
+	filter, _ := c.logFwdFilter()
+	return filter
+}
+
+func (c *Config) logFwdFilter() (logfwd.Filter, error) {
+	filter := logfwd.Filter{
+		IncludeEntity: c.asList(LogFwdIncludeEntity),
+		ExcludeEntity: c.asList(LogFwdExcludeEntity),
+		IncludeModule: c.asList(LogFwdIncludeModule),
+		ExcludeModule: c.asList(LogFwdExcludeModule),
+	}
+	if s := c.asString(LogFwdLevel); s != "" {
+		level, ok := loggo.ParseLevel(s)
+		if !ok {
+			return logfwd.Filter{}, errors.NotValidf("level %q", s)
+		}
+		filter.MinLevel = level
+	}
+	return filter, nil
+}
+
+// asList returns the named comma-separated attribute as a list of
+// its non-empty, trimmed elements.
+func (c *Config) asList(name string) []string {
+	var values []string
+	for _, value := range strings.Split(c.asString(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -778,6 +910,38 @@ func (c *Config) TransmitVendorMetrics() bool {
 	}
 }
 
+// MaxActionResultsAge returns the age beyond which completed actions
+// are pruned from the model. Zero means they are kept however old
+// they are.
+func (c *Config) MaxActionResultsAge() time.Duration {
+	// The age has already been validated.
+	age, _ := c.maxActionResultsAge()
+	return age
+}
+
+func (c *Config) maxActionResultsAge() (time.Duration, error) {
+	s, ok := c.defined[MaxActionResultsAge].(string)
+	if !ok {
+		return DefaultActionResultsAge, nil
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid %s", MaxActionResultsAge)
+	}
+	if age < 0 {
+		return 0, errors.Errorf("%s %v is negative", MaxActionResultsAge, age)
+	}
+	return age, nil
+}
+
+// MaxActionResultsCount returns the number of completed actions kept
+// in the model, beyond which the oldest are pruned. Zero means there
+// is no limit.
+func (c *Config) MaxActionResultsCount() int {
+	count, _ := c.defined[MaxActionResultsCount].(int)
+	return count
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -939,6 +1103,15 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPFormat:       schema.Omit,
+	LogFwdHTTPIndex:        schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdLevel:            schema.Omit,
+	LogFwdIncludeEntity:    schema.Omit,
+	LogFwdExcludeEntity:    schema.Omit,
+	LogFwdIncludeModule:    schema.Omit,
+	LogFwdExcludeModule:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -972,6 +1145,8 @@ var alwaysOptional = schema.Defaults{
 	AutomaticallyRetryHooks:      schema.Omit,
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
+	MaxActionResultsAge:          schema.Omit,
+	MaxActionResultsCount:        schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1287,6 +1462,51 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which logs are forwarded over HTTP.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPFormat: {
+		Description: `The format used when forwarding logs over HTTP, "json" (the default) or "elasticsearch".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPIndex: {
+		Description: `The index into which logs are written when forwarding to Elasticsearch (default "juju").`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLevel: {
+		Description: `The minimum level of the log messages which are forwarded, e.g. "WARNING".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeEntity: {
+		Description: `A comma-separated list of the entities whose log messages are forwarded, e.g. "unit-mysql-*,machine-0".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeEntity: {
+		Description: `A comma-separated list of the entities whose log messages are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeModule: {
+		Description: `A comma-separated list of the logging modules whose log messages are forwarded, e.g. "juju.worker.uniter".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeModule: {
+		Description: `A comma-separated list of the logging modules whose log messages are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1327,4 +1547,14 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxActionResultsAge: {
+		Description: `The age beyond which completed actions and their results are pruned, e.g. "72h" (default "336h"); "0" keeps them however old they are`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxActionResultsCount: {
+		Description: "The number of completed actions kept in the model, beyond which the oldest are pruned (default 0, meaning no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/testing"
 )
 
@@ -544,6 +545,67 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":      true,
+			"logforward-http-url":     "https://10.0.0.1:9200",
+			"logforward-http-format":  "elasticsearch",
+			"logforward-http-index":   "juju-logs",
+			"logforward-http-ca-cert": testing.CACert,
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding format",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-url":    "https://10.0.0.1:9200",
+			"logforward-http-format": "xml",
+		}),
+		err: `invalid HTTP log forwarding config: format "xml" not valid`,
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-http-url": "ftp://10.0.0.1",
+		}),
+		err: `invalid HTTP log forwarding config: URL scheme "ftp" not valid`,
+	}, {
+		about:       "Valid log forwarding filter",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-level":          "WARNING",
+			"logforward-include-entity": "unit-mysql-*, machine-0",
+			"logforward-exclude-module": "juju.worker.uniter",
+		}),
+	}, {
+		about:       "Invalid log forwarding level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-level": "LOUD",
+		}),
+		err: `invalid log forwarding filter: level "LOUD" not valid`,
+	}, {
+		about:       "Invalid max action results age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-action-results-age": "a fortnight",
+		}),
+		err: `invalid max-action-results-age: .*`,
+	}, {
+		about:       "Negative max action results age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-action-results-age": "-1h",
+		}),
+		err: `max-action-results-age -1h0m0s is negative`,
+	}, {
+		about:       "Negative max action results count",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-action-results-count": -1,
+		}),
+		err: `max-action-results-count -1 is negative`,
 	},
 }
 
@@ -646,6 +708,17 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, ok := test.attrs["logforward-http-url"].(string); ok && v != "" {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		c.Assert(httpCfg.Format, gc.Equals, test.attrs["logforward-http-format"])
+		c.Assert(httpCfg.Index, gc.Equals, test.attrs["logforward-http-index"])
+		c.Assert(httpCfg.CACert, gc.Equals, test.attrs["logforward-http-ca-cert"])
+	} else {
+		c.Assert(hasHTTPCfg, jc.IsFalse)
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -731,6 +804,42 @@ func (test configTest) assertDuration(c *gc.C, name string, actual time.Duration
 	}
 }
 
+func (s *ConfigSuite) TestLogFwdFilter(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-level":          "warning",
+		"logforward-include-entity": "unit-mysql-*, machine-0",
+		"logforward-exclude-entity": "",
+		"logforward-include-module": "juju.worker",
+		"logforward-exclude-module": "juju.worker.uniter,,juju.apiserver",
+	})
+	c.Assert(cfg.LogFwdFilter(), jc.DeepEquals, logfwd.Filter{
+		MinLevel:      loggo.WARNING,
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter", "juju.apiserver"},
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdFilterDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LogFwdFilter().IsZero(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestMaxActionResults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-action-results-age":   "72h",
+		"max-action-results-count": 1000,
+	})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, 72*time.Hour)
+	c.Assert(cfg.MaxActionResultsCount(), gc.Equals, 1000)
+}
+
+func (s *ConfigSuite) TestMaxActionResultsDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, config.DefaultActionResultsAge)
+	c.Assert(cfg.MaxActionResultsCount(), gc.Equals, 0)
+}
+
 func (s *ConfigSuite) TestConfigAttrs(c *gc.C) {
 	// Normally this is handled by gitjujutesting.FakeHome
 	s.PatchEnvironment(osenv.JujuLoggingConfigEnvKey, "")
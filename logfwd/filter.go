@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// Filter describes which log records should be forwarded. The
+// semantics match those of the filters supported by debug-log: entity
+// patterns may end with (or contain) a "*" wildcard, and module names
+// also match any of their sub-modules.
+type Filter struct {
+	// MinLevel is the minimum level of the records to forward. If
+	// unspecified, records of all levels are forwarded.
+	MinLevel loggo.Level
+
+	// IncludeEntity lists the tags of the entities whose records are
+	// forwarded. If empty, records from all entities are forwarded.
+	IncludeEntity []string
+
+	// ExcludeEntity lists the tags of the entities whose records are
+	// not forwarded.
+	ExcludeEntity []string
+
+	// IncludeModule lists the logging modules whose records are
+	// forwarded. If empty, records from all modules are forwarded.
+	IncludeModule []string
+
+	// ExcludeModule lists the logging modules whose records are not
+	// forwarded.
+	ExcludeModule []string
+}
+
+// IsZero reports whether the filter selects every record.
+func (f Filter) IsZero() bool {
+	return f.MinLevel <= loggo.UNSPECIFIED &&
+		len(f.IncludeEntity) == 0 &&
+		len(f.ExcludeEntity) == 0 &&
+		len(f.IncludeModule) == 0 &&
+		len(f.ExcludeModule) == 0
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	_, err := f.Compile()
+	return errors.Trace(err)
+}
+
+// Compile returns a Matcher which selects the records described by
+// the filter.
+func (f Filter) Compile() (*Matcher, error) {
+	if f.MinLevel < loggo.UNSPECIFIED || f.MinLevel > loggo.CRITICAL {
+		return nil, errors.NotValidf("level %d", f.MinLevel)
+	}
+	m := &Matcher{minLevel: f.MinLevel}
+	var err error
+	if m.includeEntity, err = compileEntityPattern(f.IncludeEntity); err != nil {
+		return nil, errors.Annotate(err, "include entity")
+	}
+	if m.excludeEntity, err = compileEntityPattern(f.ExcludeEntity); err != nil {
+		return nil, errors.Annotate(err, "exclude entity")
+	}
+	if m.includeModule, err = compileModulePattern(f.IncludeModule); err != nil {
+		return nil, errors.Annotate(err, "include module")
+	}
+	if m.excludeModule, err = compileModulePattern(f.ExcludeModule); err != nil {
+		return nil, errors.Annotate(err, "exclude module")
+	}
+	return m, nil
+}
+
+// Matcher selects log records according to a compiled Filter.
+type Matcher struct {
+	minLevel      loggo.Level
+	includeEntity *regexp.Regexp
+	excludeEntity *regexp.Regexp
+	includeModule *regexp.Regexp
+	excludeModule *regexp.Regexp
+}
+
+// Match reports whether the record should be forwarded.
+func (m *Matcher) Match(rec Record) bool {
+	return m.MatchEntry(rec.Level, originEntity(rec.Origin), rec.Location.Module)
+}
+
+// MatchEntry reports whether a record with the given level, entity
+// tag and module should be forwarded.
+func (m *Matcher) MatchEntry(level loggo.Level, entity, module string) bool {
+	if m.minLevel > loggo.UNSPECIFIED && level < m.minLevel {
+		return false
+	}
+	if m.includeEntity != nil && !m.includeEntity.MatchString(entity) {
+		return false
+	}
+	if m.excludeEntity != nil && m.excludeEntity.MatchString(entity) {
+		return false
+	}
+	if m.includeModule != nil && !m.includeModule.MatchString(module) {
+		return false
+	}
+	if m.excludeModule != nil && m.excludeModule.MatchString(module) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the records which should be forwarded, preserving
+// their order.
+func (m *Matcher) Filter(records []Record) []Record {
+	var selected []Record
+	for _, rec := range records {
+		if m.Match(rec) {
+			selected = append(selected, rec)
+		}
+	}
+	return selected
+}
+
+// originEntity returns the tag of the entity described by the
+// origin, or the empty string if it is not known.
+func originEntity(origin Origin) string {
+	if origin.Type.ValidateName(origin.Name) != nil {
+		return ""
+	}
+	switch origin.Type {
+	case OriginTypeMachine:
+		return names.NewMachineTag(origin.Name).String()
+	case OriginTypeUnit:
+		return names.NewUnitTag(origin.Name).String()
+	case OriginTypeUser:
+		return names.NewUserTag(origin.Name).String()
+	}
+	return ""
+}
+
+func compileEntityPattern(entities []string) (*regexp.Regexp, error) {
+	if len(entities) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	for _, entity := range entities {
+		if entity == "" {
+			return nil, errors.NotValidf("empty entity")
+		}
+		// Convert * wildcard to the regex equivalent, quoting
+		// everything else.
+		quoted := regexp.QuoteMeta(entity)
+		patterns = append(patterns, strings.Replace(quoted, `\*`, ".*", -1))
+	}
+	return regexp.Compile(`^(` + strings.Join(patterns, "|") + `)$`)
+}
+
+func compileModulePattern(modules []string) (*regexp.Regexp, error) {
+	if len(modules) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	for _, module := range modules {
+		if module == "" {
+			return nil, errors.NotValidf("empty module")
+		}
+		patterns = append(patterns, regexp.QuoteMeta(module))
+	}
+	return regexp.Compile(`^(` + strings.Join(patterns, "|") + `)(\..+)?$`)
+}
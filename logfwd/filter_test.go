@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func filterRecord(originType logfwd.OriginType, name, module string, level loggo.Level) logfwd.Record {
+	rec := validRecord
+	rec.Origin.Type = originType
+	rec.Origin.Name = name
+	rec.Location.Module = module
+	rec.Level = level
+	return rec
+}
+
+var filterTests = []struct {
+	about  string
+	filter logfwd.Filter
+	rec    logfwd.Record
+	match  bool
+}{{
+	about: "zero filter matches everything",
+	rec:   filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.TRACE),
+	match: true,
+}, {
+	about:  "level below minimum",
+	filter: logfwd.Filter{MinLevel: loggo.WARNING},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.DEBUG),
+}, {
+	about:  "level at minimum",
+	filter: logfwd.Filter{MinLevel: loggo.WARNING},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.WARNING),
+	match:  true,
+}, {
+	about:  "included entity",
+	filter: logfwd.Filter{IncludeEntity: []string{"unit-mysql-0"}},
+	rec:    filterRecord(logfwd.OriginTypeUnit, "mysql/0", "juju.worker", loggo.INFO),
+	match:  true,
+}, {
+	about:  "entity not included",
+	filter: logfwd.Filter{IncludeEntity: []string{"unit-mysql-0"}},
+	rec:    filterRecord(logfwd.OriginTypeUnit, "mysql/1", "juju.worker", loggo.INFO),
+}, {
+	about:  "wildcard entity",
+	filter: logfwd.Filter{IncludeEntity: []string{"unit-mysql-*"}},
+	rec:    filterRecord(logfwd.OriginTypeUnit, "mysql/1", "juju.worker", loggo.INFO),
+	match:  true,
+}, {
+	about:  "excluded entity",
+	filter: logfwd.Filter{ExcludeEntity: []string{"machine-0-lxd-*"}},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0/lxd/1", "juju.worker", loggo.INFO),
+}, {
+	about:  "included module",
+	filter: logfwd.Filter{IncludeModule: []string{"juju.worker"}},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker.uniter", loggo.INFO),
+	match:  true,
+}, {
+	about:  "module prefix is not a parent",
+	filter: logfwd.Filter{IncludeModule: []string{"juju.work"}},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker", loggo.INFO),
+}, {
+	about:  "excluded module",
+	filter: logfwd.Filter{ExcludeModule: []string{"juju.worker"}},
+	rec:    filterRecord(logfwd.OriginTypeMachine, "0", "juju.worker.uniter", loggo.INFO),
+}}
+
+func (s *FilterSuite) TestMatch(c *gc.C) {
+	for i, test := range filterTests {
+		c.Logf("test %d: %s", i, test.about)
+		m, err := test.filter.Compile()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.Match(test.rec), gc.Equals, test.match)
+	}
+}
+
+func (s *FilterSuite) TestMatchEntry(c *gc.C) {
+	m, err := logfwd.Filter{
+		MinLevel:      loggo.INFO,
+		IncludeEntity: []string{"unit-mysql-*"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}.Compile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.MatchEntry(loggo.INFO, "unit-mysql-0", "juju.worker"), jc.IsTrue)
+	c.Check(m.MatchEntry(loggo.DEBUG, "unit-mysql-0", "juju.worker"), jc.IsFalse)
+	c.Check(m.MatchEntry(loggo.INFO, "machine-0", "juju.worker"), jc.IsFalse)
+	c.Check(m.MatchEntry(loggo.INFO, "unit-mysql-0", "juju.worker.uniter.operation"), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestFilter(c *gc.C) {
+	m, err := logfwd.Filter{MinLevel: loggo.WARNING}.Compile()
+	c.Assert(err, jc.ErrorIsNil)
+	debug := filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.DEBUG)
+	warning := filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.WARNING)
+	errorRec := filterRecord(logfwd.OriginTypeMachine, "0", "juju", loggo.ERROR)
+
+	records := m.Filter([]logfwd.Record{debug, warning, debug, errorRec})
+
+	c.Check(records, jc.DeepEquals, []logfwd.Record{warning, errorRec})
+}
+
+func (s *FilterSuite) TestIsZero(c *gc.C) {
+	c.Check(logfwd.Filter{}.IsZero(), jc.IsTrue)
+	c.Check(logfwd.Filter{MinLevel: loggo.INFO}.IsZero(), jc.IsFalse)
+	c.Check(logfwd.Filter{ExcludeModule: []string{"juju"}}.IsZero(), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestValidateEmptyEntity(c *gc.C) {
+	err := logfwd.Filter{IncludeEntity: []string{""}}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "include entity: empty entity not valid")
+}
+
+func (s *FilterSuite) TestValidateBadLevel(c *gc.C) {
+	err := logfwd.Filter{MinLevel: loggo.Level(42)}.Validate()
+	c.Check(err, gc.ErrorMatches, "level 42 not valid")
+}
@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout is the maximum time a single batch may take to send.
+const requestTimeout = 30 * time.Second
+
+// Doer sends HTTP requests. It is implemented by *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client is the wrapper around an HTTP log forwarding target.
+type Client struct {
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+
+	config RawConfig
+}
+
+// Open validates the config and returns a new client for the target
+// it describes.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+	doer := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	return OpenForDoer(cfg, doer)
+}
+
+// OpenForDoer validates the config and returns a new client for the
+// target it describes, which will use the supplied Doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		Doer:   doer,
+		config: cfg,
+	}, nil
+}
+
+// Close implements io.Closer. There is no persistent connection to
+// close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the remote host in a single request.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body []byte
+	var contentType, target string
+	var err error
+	switch client.config.format() {
+	case FormatElasticsearch:
+		body, err = bulkIndexBody(client.config.index(), records)
+		contentType = "application/x-ndjson"
+		target = strings.TrimSuffix(client.config.URL, "/") + "/_bulk"
+	default:
+		body, err = jsonBody(records)
+		contentType = "application/json"
+		target = client.config.URL
+	}
+	if err != nil {
+		return errors.Annotate(err, "encoding records")
+	}
+
+	req, err := http.NewRequest("POST", target, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending records")
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return errors.Annotate(err, "reading response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("sending records: unexpected response %q", resp.Status)
+	}
+	if client.config.format() == FormatElasticsearch {
+		return errors.Trace(checkBulkResponse(respBody))
+	}
+	return nil
+}
+
+// jsonRecord is the JSON representation of a log record.
+type jsonRecord struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module"`
+	Location        string    `json:"location"`
+	Message         string    `json:"message"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name"`
+	SoftwareName    string    `json:"software-name"`
+	SoftwareVersion string    `json:"software-version"`
+}
+
+func newJSONRecord(rec logfwd.Record) jsonRecord {
+	return jsonRecord{
+		ID:              rec.ID,
+		Timestamp:       rec.Timestamp.UTC(),
+		Level:           rec.Level.String(),
+		Module:          rec.Location.Module,
+		Location:        fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line),
+		Message:         rec.Message,
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		Hostname:        rec.Origin.Hostname,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		SoftwareName:    rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+	}
+}
+
+func jsonBody(records []logfwd.Record) ([]byte, error) {
+	out := make([]jsonRecord, len(records))
+	for i, rec := range records {
+		out[i] = newJSONRecord(rec)
+	}
+	return json.Marshal(out)
+}
+
+// bulkIndexBody returns the body of an Elasticsearch bulk request
+// indexing each record. Document IDs are derived from the model and
+// record ID, so that records resent after a failure are not
+// duplicated.
+func bulkIndexBody(index string, records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index": index,
+				"_type":  "log",
+				"_id":    fmt.Sprintf("%s-%d", rec.Origin.ModelUUID, rec.ID),
+			},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := enc.Encode(newJSONRecord(rec)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// checkBulkResponse returns an error if the bulk response reports
+// that any item failed.
+func checkBulkResponse(body []byte) error {
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int         `json:"status"`
+			Error  interface{} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return errors.Annotate(err, "parsing bulk response")
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return errors.Errorf("bulk index failed: status %d: %v", result.Status, result.Error)
+			}
+		}
+	}
+	return errors.New("bulk index failed")
+}
@@ -0,0 +1,180 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer *stubDoer
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.doer = &stubDoer{status: http.StatusOK, body: "{}"}
+	s.rec = logfwd.Record{
+		ID: 10,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.1.0"),
+			},
+		},
+		Timestamp: time.Date(2016, time.November, 1, 10, 0, 0, 0, time.UTC),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker",
+			Filename: "worker.go",
+			Line:     42,
+		},
+		Message: "started",
+	}
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	}, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	req := s.doer.requests[0]
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.String(), gc.Equals, "https://logs.example.com/ingest")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+
+	var sent []map[string]interface{}
+	err = json.Unmarshal(s.doer.bodies[0], &sent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sent, jc.DeepEquals, []map[string]interface{}{{
+		"id":               float64(10),
+		"timestamp":        "2016-11-01T10:00:00Z",
+		"level":            "INFO",
+		"module":           "juju.worker",
+		"location":         "worker.go:42",
+		"message":          "started",
+		"controller-uuid":  "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":      "machine",
+		"origin-name":      "99",
+		"software-name":    "jujud-machine-agent",
+		"software-version": "2.1.0",
+	}})
+}
+
+func (s *ClientSuite) TestSendElasticsearch(c *gc.C) {
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1:9200/",
+		Format:  httpjson.FormatElasticsearch,
+		Index:   "logs",
+	}, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec2 := s.rec
+	rec2.ID = 11
+	err = client.Send([]logfwd.Record{s.rec, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	req := s.doer.requests[0]
+	c.Check(req.URL.String(), gc.Equals, "http://10.0.0.1:9200/_bulk")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+
+	lines := strings.Split(strings.TrimSpace(string(s.doer.bodies[0])), "\n")
+	c.Assert(lines, gc.HasLen, 4)
+	c.Check(lines[0], gc.Equals, `{"index":{"_id":"deadbeef-2f18-4fd2-967d-db9663db7bea-10","_index":"logs","_type":"log"}}`)
+	c.Check(lines[2], gc.Equals, `{"index":{"_id":"deadbeef-2f18-4fd2-967d-db9663db7bea-11","_index":"logs","_type":"log"}}`)
+	var doc map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(lines[3]), &doc), jc.ErrorIsNil)
+	c.Check(doc["id"], gc.Equals, float64(11))
+}
+
+func (s *ClientSuite) TestSendElasticsearchItemError(c *gc.C) {
+	s.doer.body = `{"errors":true,"items":[{"index":{"status":400,"error":"mapper_parsing_exception"}}]}`
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		URL:    "http://10.0.0.1:9200",
+		Format: httpjson.FormatElasticsearch,
+	}, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, "bulk index failed: status 400: mapper_parsing_exception")
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.doer.status = http.StatusBadGateway
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{URL: "http://10.0.0.1"}, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending records: unexpected response "502 Bad Gateway"`)
+}
+
+func (s *ClientSuite) TestSendNothing(c *gc.C) {
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{URL: "http://10.0.0.1"}, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.doer.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestOpenInvalid(c *gc.C) {
+	_, err := httpjson.OpenForDoer(httpjson.RawConfig{Enabled: true}, s.doer)
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+type stubDoer struct {
+	status   int
+	body     string
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", d.status, http.StatusText(d.status)),
+		StatusCode: d.status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(d.body)),
+	}, nil
+}
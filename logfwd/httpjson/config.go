@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+// These are the supported wire formats.
+const (
+	// FormatJSON sends each batch of records as a JSON array.
+	FormatJSON = "json"
+
+	// FormatElasticsearch sends each batch of records as an
+	// Elasticsearch bulk index request.
+	FormatElasticsearch = "elasticsearch"
+)
+
+// DefaultIndex is the index into which records are written when using
+// FormatElasticsearch and no index is configured.
+const DefaultIndex = "juju"
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the URL to which batches of records are POSTed. For
+	// FormatElasticsearch this is the base URL of the cluster, to
+	// which "/_bulk" is appended.
+	URL string
+
+	// Format is the wire format used, one of FormatJSON or
+	// FormatElasticsearch. If empty, FormatJSON is used.
+	Format string
+
+	// Index is the Elasticsearch index into which records are
+	// written. It is ignored for FormatJSON.
+	Index string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate. If empty, the system
+	// roots are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	switch cfg.format() {
+	case FormatJSON, FormatElasticsearch:
+	default:
+		return errors.NotValidf("format %q", cfg.Format)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) format() string {
+	if cfg.Format == "" {
+		return FormatJSON
+	}
+	return cfg.Format
+}
+
+func (cfg RawConfig) index() string {
+	if cfg.Index == "" {
+		return DefaultIndex
+	}
+	return cfg.Index
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		config httpjson.RawConfig
+		expect string
+	}{{
+		about: "disabled and empty",
+	}, {
+		about:  "enabled without URL",
+		config: httpjson.RawConfig{Enabled: true},
+		expect: "empty URL not valid",
+	}, {
+		about:  "json",
+		config: httpjson.RawConfig{Enabled: true, URL: "https://logs.example.com/ingest"},
+	}, {
+		about: "elasticsearch with CA cert",
+		config: httpjson.RawConfig{
+			Enabled: true,
+			URL:     "https://10.0.0.1:9200",
+			Format:  httpjson.FormatElasticsearch,
+			CACert:  coretesting.CACert,
+		},
+	}, {
+		about:  "bad scheme",
+		config: httpjson.RawConfig{URL: "tcp://10.0.0.1:9200"},
+		expect: `URL scheme "tcp" not valid`,
+	}, {
+		about:  "bad format",
+		config: httpjson.RawConfig{URL: "http://10.0.0.1", Format: "xml"},
+		expect: `format "xml" not valid`,
+	}, {
+		about:  "bad CA cert",
+		config: httpjson.RawConfig{URL: "https://10.0.0.1", CACert: "foo"},
+		expect: "validating TLS config: parsing CA certificate: .*",
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		err := test.config.Validate()
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, either as batches of JSON
+// records or via an Elasticsearch-compatible bulk index API.
+package httpjson
@@ -11,12 +11,12 @@ import (
 	"gopkg.in/juju/names.v2"
 )
 
-// canonicalPEN is the IANA-registered Private Enterprise Number
+// CanonicalPEN is the IANA-registered Private Enterprise Number
 // assigned to Canonical. Among other things, this is used in RFC 5424
 // structured data.
 //
 // See https://www.iana.org/assignments/enterprise-numbers/enterprise-numbers.
-const canonicalPEN = 28978
+const CanonicalPEN = 28978
 
 // These are the recognized origin types.
 const (
@@ -145,9 +145,9 @@ func originForJuju(oType OriginType, name, controller, model string, ver version
 		Type:           oType,
 		Name:           name,
 		Software: Software{
-			PrivateEnterpriseNumber: canonicalPEN,
-			Name:    "juju",
-			Version: ver,
+			PrivateEnterpriseNumber: CanonicalPEN,
+			Name:                    "juju",
+			Version:                 ver,
 		},
 	}
 }
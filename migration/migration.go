@@ -6,19 +6,19 @@ package migration
 import (
 	"io"
 	"io/ioutil"
-	"net/url"
 	"os"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
-	"github.com/juju/juju/tools"
 )
 
 var logger = loggo.GetLogger("juju.migration")
@@ -62,10 +62,73 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	return dbModel, dbState, nil
 }
 
+// ImportPrecheckIssues checks that the serialized model could be
+// imported into the controller, by importing it as ImportModel does
+// and then removing it again. It reports the issues found, including
+// a cloud credential the model uses that the controller doesn't have.
+func ImportPrecheckIssues(st *state.State, bytes []byte) PrecheckIssues {
+	var issues PrecheckIssues
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		issues.add(errors.Trace(err))
+		return issues
+	}
+	if credential := model.CloudCredential(); credential != "" {
+		if !names.IsValidCloudCredential(credential) {
+			issues.add(errors.NotValidf("cloud credential %q", credential))
+		} else if _, err := st.CloudCredential(names.NewCloudCredentialTag(credential)); errors.IsNotFound(err) {
+			issues.add(errors.Errorf("cloud credential %q not found", credential))
+		} else if err != nil {
+			issues.add(errors.Annotate(err, "retrieving cloud credential"))
+		}
+	}
+
+	// The trial import is removed afterwards, so it must not be made
+	// over a model already in the controller, even one left behind by
+	// an earlier migration attempt.
+	if existing, err := st.GetModel(model.Tag()); err == nil {
+		if existing.MigrationMode() == state.MigrationModeImporting {
+			issues.add(errors.Errorf("model with UUID %s is already being imported", model.Tag().Id()))
+		} else {
+			issues.add(errors.AlreadyExistsf("model with UUID %s", model.Tag().Id()))
+		}
+		return issues
+	} else if !errors.IsNotFound(err) {
+		issues.add(errors.Trace(err))
+		return issues
+	}
+	_, importedSt, importErr := st.Import(model)
+	if errors.IsAlreadyExists(importErr) {
+		// The model was added since the check above, so it isn't
+		// ours to remove.
+		issues.add(errors.Annotate(importErr, "importing model"))
+		return issues
+	} else if importErr != nil {
+		issues.add(errors.Annotate(importErr, "importing model"))
+		// The import may have failed part way through, after
+		// creating the model.
+		if _, err := st.GetModel(model.Tag()); errors.IsNotFound(err) {
+			return issues
+		} else if err != nil {
+			issues.add(errors.Trace(err))
+			return issues
+		}
+		if importedSt, err = st.ForModel(model.Tag()); err != nil {
+			issues.add(errors.Trace(err))
+			return issues
+		}
+	}
+	defer importedSt.Close()
+	if err := importedSt.RemoveImportingModelDocs(); err != nil {
+		issues.add(errors.Annotate(err, "removing imported model"))
+	}
+	return issues
+}
+
 // CharmDownlaoder defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
-	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	coremigration.CharmDownloader
 }
 
 // UploadBackend define the methods on *state.State that are needed for
@@ -81,19 +144,19 @@ type UploadBackend interface {
 // CharmUploader defines a single method that is used to upload a
 // charm to the target controller in a migration.
 type CharmUploader interface {
-	UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error)
+	coremigration.CharmUploader
 }
 
 // ToolsDownloader defines a single method that is used to download
 // tools from the source controller in a migration.
 type ToolsDownloader interface {
-	OpenURI(string, url.Values) (io.ReadCloser, error)
+	coremigration.ToolsDownloader
 }
 
 // ToolsUploader defines a single method that is used to upload tools
 // to the target controller in a migration.
 type ToolsUploader interface {
-	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+	coremigration.ToolsUploader
 }
 
 // UploadBinariesConfig provides all the configuration that the
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/migration"
@@ -77,6 +78,43 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
+func (s *ImportSuite) TestImportPrecheckIssues(c *gc.C) {
+	model, err := s.State.Export()
+	c.Check(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
+	})
+	bytes, err := description.Serialize(model)
+	c.Check(err, jc.ErrorIsNil)
+
+	issues := migration.ImportPrecheckIssues(s.State, bytes)
+	c.Assert(issues, gc.HasLen, 0)
+
+	// The trial import has been removed.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ImportSuite) TestImportPrecheckIssuesExistingModel(c *gc.C) {
+	model, err := s.State.Export()
+	c.Check(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Check(err, jc.ErrorIsNil)
+
+	issues := migration.ImportPrecheckIssues(s.State, bytes)
+	c.Assert(issues.Messages(), jc.DeepEquals, []string{
+		fmt.Sprintf("model with UUID %s already exists", s.State.ModelUUID()),
+	})
+}
+
+func (s *ImportSuite) TestImportPrecheckIssuesBadBytes(c *gc.C) {
+	issues := migration.ImportPrecheckIssues(s.State, []byte("not a model"))
+	c.Assert(issues, gc.HasLen, 1)
+	c.Assert(issues[0], gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
 	type T migration.UploadBinariesConfig // alias for brevity
 
@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -21,6 +22,7 @@ import (
 // PrecheckBackend defines the interface to query Juju's state
 // for migration prechecks.
 type PrecheckBackend interface {
+	ExportPrecheckBackend
 	AgentVersion() (version.Number, error)
 	NeedsCleanup() (bool, error)
 	Model() (PrecheckModel, error)
@@ -32,6 +34,14 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 }
 
+// ExportPrecheckBackend defines the queries needed to check that the
+// contents of a model can be carried by an exported copy of it.
+type ExportPrecheckBackend interface {
+	HasSecrets() (bool, error)
+	RemoteApplicationNames() ([]string, error)
+	ApplicationOfferNames() ([]string, error)
+}
+
 // PrecheckModel describes the state interface a model as needed by
 // the migration prechecks.
 type PrecheckModel interface {
@@ -80,55 +90,135 @@ type PrecheckUnit interface {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
-		return errors.Trace(err)
-	}
-
-	if err := checkMachines(backend); err != nil {
-		return errors.Trace(err)
-	}
+	return SourcePrecheckIssues(backend).first()
+}
 
-	if err := checkApplications(backend); err != nil {
-		return errors.Trace(err)
-	}
+// SourcePrecheckIssues runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem found it reports every
+// issue that would prevent the model from being migrated.
+func SourcePrecheckIssues(backend PrecheckBackend) PrecheckIssues {
+	var issues PrecheckIssues
+	checkModel(backend, &issues)
+	checkMachines(backend, &issues)
+	checkApplications(backend, &issues)
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		issues.add(errors.Annotate(err, "checking cleanups"))
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		issues.add(errors.New("cleanup needed"))
 	}
 
+	checkExportable(backend, &issues)
+
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		issues.add(errors.Trace(err))
+		return issues
 	}
-	if err := checkController(controllerBackend); err != nil {
-		return errors.Annotate(err, "controller")
+	var controllerIssues PrecheckIssues
+	checkController(controllerBackend, &controllerIssues)
+	for _, err := range controllerIssues {
+		issues.add(errors.Annotate(err, "controller"))
 	}
-	return nil
+	return issues
+}
+
+// ExportPrecheck checks that nothing in the model would be lost or
+// broken by exporting it, whether to migrate, clone or archive it.
+// The error returned describes every problem found.
+func ExportPrecheck(backend ExportPrecheckBackend) error {
+	var issues PrecheckIssues
+	checkExportable(backend, &issues)
+	if len(issues) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(issues.Messages(), "; "))
+}
+
+// PrecheckIssues holds the problems found by the migration prechecks,
+// in the order they were found.
+type PrecheckIssues []error
+
+func (issues *PrecheckIssues) add(err error) {
+	*issues = append(*issues, err)
+}
+
+// first returns the first issue found, or nil if there were none.
+func (issues PrecheckIssues) first() error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return issues[0]
+}
+
+// Messages returns the messages of the issues found.
+func (issues PrecheckIssues) Messages() []string {
+	messages := make([]string, len(issues))
+	for i, err := range issues {
+		messages[i] = err.Error()
+	}
+	return messages
 }
 
-func checkModel(backend PrecheckBackend) error {
+func checkModel(backend PrecheckBackend, issues *PrecheckIssues) {
 	model, err := backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		issues.add(errors.Annotate(err, "retrieving model"))
+		return
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		issues.add(errors.Errorf("model is %s", model.Life()))
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		issues.add(errors.New("model is being imported as part of another migration"))
+	}
+}
+
+// checkExportable reports the parts of the model which an exported
+// copy of it cannot carry.
+func checkExportable(backend ExportPrecheckBackend, issues *PrecheckIssues) {
+	// The values of secrets are encrypted with a key belonging to
+	// the source controller, so cannot be read by the target.
+	if hasSecrets, err := backend.HasSecrets(); err != nil {
+		issues.add(errors.Annotate(err, "checking secrets"))
+	} else if hasSecrets {
+		issues.add(errors.New("model has secrets, which cannot be migrated"))
+	}
+	checkCrossModelRelations(backend, issues)
+}
+
+// checkCrossModelRelations reports the remote applications and
+// application offers in the model, as cross-model relations are tied
+// to models hosted by the same controller, and cannot be migrated.
+func checkCrossModelRelations(backend ExportPrecheckBackend, issues *PrecheckIssues) {
+	if names, err := backend.RemoteApplicationNames(); err != nil {
+		issues.add(errors.Annotate(err, "retrieving remote applications"))
+	} else if len(names) > 0 {
+		issues.add(errors.Errorf("model has remote applications (%s), which cannot be migrated", strings.Join(names, ", ")))
+	}
+	if names, err := backend.ApplicationOfferNames(); err != nil {
+		issues.add(errors.Annotate(err, "retrieving application offers"))
+	} else if len(names) > 0 {
+		issues.add(errors.Errorf("model has application offers (%s), which cannot be migrated", strings.Join(names, ", ")))
 	}
-	return nil
 }
 
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	return TargetPrecheckIssues(backend, modelInfo).first()
+}
+
+// TargetPrecheckIssues runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem found it reports every
+// issue that would prevent the model from being migrated.
+func TargetPrecheckIssues(backend PrecheckBackend, modelInfo coremigration.ModelInfo) PrecheckIssues {
+	var issues PrecheckIssues
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		issues.add(errors.Trace(err))
+		return issues
 	}
 
 	// This check is necessary because there is a window between the
@@ -139,156 +229,153 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		issues.add(errors.Annotate(err, "checking for active migration"))
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		issues.add(errors.New("model is being migrated out of target controller"))
 	}
 
-	controllerVersion, err := backend.AgentVersion()
-	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+	if controllerVersion, err := backend.AgentVersion(); err != nil {
+		issues.add(errors.Annotate(err, "retrieving model version"))
+	} else if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
+		issues.add(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
 	}
 
-	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
-	}
-
-	if err := checkController(backend); err != nil {
-		return errors.Trace(err)
-	}
+	checkController(backend, &issues)
 
 	// Check for conflicts with existing models
 	models, err := backend.AllModels()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		issues.add(errors.Annotate(err, "retrieving models"))
+		return issues
 	}
 	for _, model := range models {
 		// If the model is importing then it's probably left behind
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			issues.add(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			issues.add(errors.Errorf("model named %q already exists", model.Name()))
 		}
 	}
-
-	return nil
+	return issues
 }
 
-func checkController(backend PrecheckBackend) error {
+func checkController(backend PrecheckBackend, issues *PrecheckIssues) {
 	model, err := backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		issues.add(errors.Annotate(err, "retrieving model"))
+		return
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		issues.add(errors.Errorf("model is %s", model.Life()))
 	}
 
 	if upgrading, err := backend.IsUpgrading(); err != nil {
-		return errors.Annotate(err, "checking for upgrades")
+		issues.add(errors.Annotate(err, "checking for upgrades"))
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		issues.add(errors.New("upgrade in progress"))
 	}
 
-	err = checkMachines(backend)
-	return errors.Trace(err)
+	checkMachines(backend, issues)
 }
 
-func checkMachines(backend PrecheckBackend) error {
+func checkMachines(backend PrecheckBackend, issues *PrecheckIssues) {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		issues.add(errors.Annotate(err, "retrieving model version"))
+		return
 	}
 
 	machines, err := backend.AllMachines()
 	if err != nil {
-		return errors.Annotate(err, "retrieving machines")
+		issues.add(errors.Annotate(err, "retrieving machines"))
+		return
 	}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			issues.add(errors.Errorf("machine %s is %s", machine.Id(), machine.Life()))
+			continue
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+			issues.add(errors.Annotatef(err, "retrieving machine %s instance status", machine.Id()))
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			issues.add(newStatusError("machine %s not running", machine.Id(), statusInfo.Status))
 		}
 
 		if statusInfo, err := common.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+			issues.add(errors.Annotatef(err, "retrieving machine %s status", machine.Id()))
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			issues.add(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status))
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+			issues.add(errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id()))
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			issues.add(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction))
 		}
 
 		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
+			issues.add(errors.Trace(err))
 		}
 	}
-	return nil
 }
 
-func checkApplications(backend PrecheckBackend) error {
+func checkApplications(backend PrecheckBackend, issues *PrecheckIssues) {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		issues.add(errors.Annotate(err, "retrieving model version"))
+		return
 	}
 	apps, err := backend.AllApplications()
 	if err != nil {
-		return errors.Annotate(err, "retrieving applications")
+		issues.add(errors.Annotate(err, "retrieving applications"))
+		return
 	}
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return errors.Errorf("application %s is %s", app.Name(), app.Life())
-		}
-		err := checkUnits(app, modelVersion)
-		if err != nil {
-			return errors.Trace(err)
+			issues.add(errors.Errorf("application %s is %s", app.Name(), app.Life()))
+			continue
 		}
+		checkUnits(app, modelVersion, issues)
 	}
-	return nil
 }
 
-func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
+func checkUnits(app PrecheckApplication, modelVersion version.Number, issues *PrecheckIssues) {
 	units, err := app.AllUnits()
 	if err != nil {
-		return errors.Annotatef(err, "retrieving units for %s", app.Name())
+		issues.add(errors.Annotatef(err, "retrieving units for %s", app.Name()))
+		return
 	}
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		issues.add(errors.Errorf("application %s is below its minimum units threshold", app.Name()))
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			issues.add(errors.Errorf("unit %s is %s", unit.Name(), unit.Life()))
+			continue
 		}
 
 		if err := checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
+			issues.add(errors.Trace(err))
 		}
 
 		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-			return errors.Trace(err)
+			issues.add(errors.Trace(err))
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			issues.add(errors.Errorf("unit %s is upgrading", unit.Name()))
 		}
 	}
-	return nil
 }
 
 func checkUnitAgentStatus(unit PrecheckUnit) error {
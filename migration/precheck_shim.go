@@ -4,6 +4,8 @@
 package migration
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -87,6 +89,34 @@ func (s *precheckShim) AllApplications() ([]PrecheckApplication, error) {
 	return out, nil
 }
 
+// RemoteApplicationNames implements PrecheckBackend.
+func (s *precheckShim) RemoteApplicationNames() ([]string, error) {
+	apps, err := s.State.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ApplicationOfferNames implements PrecheckBackend.
+func (s *precheckShim) ApplicationOfferNames() ([]string, error) {
+	offers, err := s.State.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(offers))
+	for i, offer := range offers {
+		names[i] = offer.OfferName()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	model, err := s.State.ControllerModel()
@@ -89,6 +89,41 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestHasSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecretsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestHasSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecrets = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has secrets, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestRemoteApplications(c *gc.C) {
+	backend := newFakeBackend()
+	backend.remoteApps = []string{"mysql", "postgresql"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has remote applications \(mysql, postgresql\), which cannot be migrated`)
+}
+
+func (*SourcePrecheckSuite) TestRemoteApplicationsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.remoteAppsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving remote applications: boom")
+}
+
+func (*SourcePrecheckSuite) TestApplicationOffers(c *gc.C) {
+	backend := newFakeBackend()
+	backend.offers = []string{"hosted-mysql"}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has application offers \(hosted-mysql\), which cannot be migrated`)
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -282,6 +317,77 @@ func (s *SourcePrecheckSuite) TestProvisioningControllerMachine(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "controller: machine 0 not running (allocating)")
 }
 
+func (s *SourcePrecheckSuite) TestIssuesReportsEveryProblem(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.cleanupNeeded = true
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name: "foo",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "foo/0", agentStatus: status.Failed},
+				&fakeUnit{name: "foo/1", version: version.MustParseBinary("1.2.4-trusty-ppc64")},
+			},
+		},
+	}
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	issues := migration.SourcePrecheckIssues(backend)
+	c.Assert(issues.Messages(), jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"unit foo/0 not idle (failed)",
+		"unit foo/1 tools don't match model (1.2.4 != 1.2.3)",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (s *SourcePrecheckSuite) TestIssuesNone(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	issues := migration.SourcePrecheckIssues(backend)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+type ExportPrecheckSuite struct {
+	precheckBaseSuite
+}
+
+var _ = gc.Suite(&ExportPrecheckSuite{})
+
+func (*ExportPrecheckSuite) TestSuccess(c *gc.C) {
+	err := migration.ExportPrecheck(newFakeBackend())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*ExportPrecheckSuite) TestHasSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecrets = true
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has secrets, which cannot be migrated")
+}
+
+func (*ExportPrecheckSuite) TestRemoteApplications(c *gc.C) {
+	backend := newFakeBackend()
+	backend.remoteApps = []string{"mysql"}
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has remote applications \(mysql\), which cannot be migrated`)
+}
+
+func (*ExportPrecheckSuite) TestApplicationOffers(c *gc.C) {
+	backend := newFakeBackend()
+	backend.offers = []string{"hosted-mysql"}
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has application offers \(hosted-mysql\), which cannot be migrated`)
+}
+
+func (*ExportPrecheckSuite) TestReportsAllIssues(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecrets = true
+	backend.offers = []string{"hosted-mysql"}
+	err := migration.ExportPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has secrets, which cannot be migrated; `+
+		`model has application offers \(hosted-mysql\), which cannot be migrated`)
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -428,6 +534,24 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestIssuesReportsEveryProblem(c *gc.C) {
+	backend := newBackendWithProvisioningMachine()
+	backend.migrationActive = true
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: modelUUID},
+		&fakeModel{name: modelName, owner: modelOwner},
+	}
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	issues := migration.TargetPrecheckIssues(backend, s.modelInfo)
+	c.Assert(issues.Messages(), jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"machine 0 not running (allocating)",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -542,6 +666,15 @@ type fakeBackend struct {
 	cleanupNeeded bool
 	cleanupErr    error
 
+	hasSecrets    bool
+	hasSecretsErr error
+
+	remoteApps    []string
+	remoteAppsErr error
+
+	offers    []string
+	offersErr error
+
 	isUpgrading    bool
 	isUpgradingErr error
 
@@ -569,6 +702,18 @@ func (b *fakeBackend) NeedsCleanup() (bool, error) {
 	return b.cleanupNeeded, b.cleanupErr
 }
 
+func (b *fakeBackend) HasSecrets() (bool, error) {
+	return b.hasSecrets, b.hasSecretsErr
+}
+
+func (b *fakeBackend) RemoteApplicationNames() ([]string, error) {
+	return b.remoteApps, b.remoteAppsErr
+}
+
+func (b *fakeBackend) ApplicationOfferNames() ([]string, error) {
+	return b.offers, b.offersErr
+}
+
 func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
@@ -800,6 +800,14 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 			logger.Debugf("setting password for %q to %q", owner.Name(), icfg.Controller.MongoInfo.Password)
 			owner.SetPassword(icfg.Controller.MongoInfo.Password)
 
+			secretsKey, err := state.NewSecretsKey()
+			if err != nil {
+				return err
+			}
+			if err := st.SetSecretsKey(secretsKey); err != nil {
+				return err
+			}
+
 			estate.apiStatePool = state.NewStatePool(st)
 
 			estate.apiServer, err = apiserver.NewServer(st, estate.apiListener, apiserver.ServerConfig{
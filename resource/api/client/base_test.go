@@ -25,9 +25,10 @@ import (
 type BaseSuite struct {
 	testing.IsolationSuite
 
-	stub     *testing.Stub
-	facade   *stubFacade
-	response *api.UploadResult
+	stub         *testing.Stub
+	facade       *stubFacade
+	response     *api.UploadResult
+	httpResponse *http.Response
 }
 
 func (s *BaseSuite) SetUpTest(c *gc.C) {
@@ -36,6 +37,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.stub = &testing.Stub{}
 	s.facade = newStubFacade(c, s.stub)
 	s.response = &api.UploadResult{}
+	s.httpResponse = &http.Response{}
 }
 
 func (s *BaseSuite) Do(req *http.Request, body io.ReadSeeker, resp interface{}) error {
@@ -44,6 +46,11 @@ func (s *BaseSuite) Do(req *http.Request, body io.ReadSeeker, resp interface{})
 		return errors.Trace(err)
 	}
 
+	if httpResp, ok := resp.(**http.Response); ok {
+		*httpResp = s.httpResponse
+		return nil
+	}
+
 	result, ok := resp.(*api.UploadResult)
 	if !ok {
 		msg := fmt.Sprintf("bad response type %T, expected api.UploadResult", resp)
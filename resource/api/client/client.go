@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -102,6 +103,24 @@ func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) err
 	return nil
 }
 
+// OpenResource returns a reader for the content of the application's
+// resource, as last uploaded or fetched from the charm store.
+func (c Client) OpenResource(service, name string) (io.ReadCloser, error) {
+	if !names.IsValidApplication(service) {
+		return nil, errors.Errorf("invalid application %q", service)
+	}
+	req, err := http.NewRequest("GET", api.NewEndpointPath(service, name), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var resp *http.Response
+	if err := c.doer.Do(req, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
 // AddPendingResourcesArgs holds the arguments to AddPendingResources().
 type AddPendingResourcesArgs struct {
 	// ApplicationID identifies the application being deployed.
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&OpenResourceSuite{})
+
+type OpenResourceSuite struct {
+	BaseSuite
+}
+
+func (s *OpenResourceSuite) TestOkay(c *gc.C) {
+	s.httpResponse.Body = ioutil.NopCloser(strings.NewReader("<data>"))
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	reader, err := cl.OpenResource("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<data>")
+
+	s.stub.CheckCallNames(c, "Do")
+	req := s.stub.Calls()[0].Args[0].(*http.Request)
+	c.Check(req.Method, gc.Equals, "GET")
+	c.Check(req.URL.Path, gc.Equals, "/applications/a-application/resources/spam")
+}
+
+func (s *OpenResourceSuite) TestBadService(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.OpenResource("???", "spam")
+
+	c.Check(err, gc.ErrorMatches, `.*invalid application.*`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *OpenResourceSuite) TestRequestFailed(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+
+	_, err := cl.OpenResource("a-application", "spam")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
@@ -80,6 +80,8 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+	ReturnOpenResource          resource.Resource
+	ReturnOpenResourceReader    io.ReadCloser
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -136,6 +138,15 @@ func (s *stubDataStore) UpdatePendingResource(applicationID, pendingID, userID s
 	return s.ReturnUpdatePendingResource, nil
 }
 
+func (s *stubDataStore) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
+	s.stub.AddCall("OpenResource", applicationID, name)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+
+	return s.ReturnOpenResource, s.ReturnOpenResourceReader, nil
+}
+
 type stubCSClient struct {
 	*testing.Stub
 
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+)
+
+// DownloadDataStore describes the the portion of Juju's "state"
+// needed for handling download requests.
+type DownloadDataStore interface {
+	// OpenResource returns the identified resource and its content.
+	OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error)
+}
+
+// DownloadHandler provides the functionality to handle download
+// requests, made by clients fetching an application's resource.
+type DownloadHandler struct {
+	// Store is the data store from which the resource is read.
+	Store DownloadDataStore
+}
+
+// HandleRequest handles a resource download request.
+func (dh DownloadHandler) HandleRequest(req *http.Request) (resource.Opened, error) {
+	service, name := api.ExtractEndpointDetails(req.URL)
+	res, reader, err := dh.Store.OpenResource(service, name)
+	if err != nil {
+		return resource.Opened{}, errors.Trace(err)
+	}
+	return resource.Opened{
+		Resource:   res,
+		ReadCloser: reader,
+	}, nil
+}
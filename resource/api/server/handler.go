@@ -4,11 +4,13 @@
 package server
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 )
 
@@ -21,6 +23,9 @@ type LegacyHTTPHandler struct {
 
 	// HandleUpload provides the upload functionality.
 	HandleUpload func(username string, st DataStore, req *http.Request) (*api.UploadResult, error)
+
+	// HandleDownload provides the download functionality.
+	HandleDownload func(st DataStore, req *http.Request) (resource.Opened, error)
 }
 
 // TODO(ericsnow) Can username be extracted from the request?
@@ -36,6 +41,12 @@ func NewLegacyHTTPHandler(connect func(*http.Request) (DataStore, names.Tag, err
 			}
 			return uh.HandleRequest(req)
 		},
+		HandleDownload: func(st DataStore, req *http.Request) (resource.Opened, error) {
+			dh := DownloadHandler{
+				Store: st,
+			}
+			return dh.HandleRequest(req)
+		},
 	}
 }
 
@@ -68,6 +79,24 @@ func (h *LegacyHTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 		}
 		api.SendHTTPStatusAndJSON(resp, http.StatusOK, &response)
 		logger.Infof("resource upload request successful")
+	case "GET":
+		logger.Infof("handling resource download request")
+		opened, err := h.HandleDownload(st, req)
+		if err != nil {
+			api.SendHTTPError(resp, err)
+			return
+		}
+		defer opened.Close()
+
+		api.UpdateDownloadResponse(resp, opened.Resource)
+		resp.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(resp, opened); err != nil {
+			// We cannot use api.SendHTTPError here, so we log the error
+			// and move on.
+			logger.Errorf("unable to complete stream for resource: %v", err)
+			return
+		}
+		logger.Infof("resource download request successful")
 	default:
 		api.SendHTTPError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
 	}
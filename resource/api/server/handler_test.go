@@ -6,6 +6,7 @@ package server_test
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,9 +15,11 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/resource/api/server"
 )
@@ -29,6 +32,7 @@ type LegacyHTTPHandlerSuite struct {
 	header   http.Header
 	resp     *stubHTTPResponseWriter
 	result   *api.UploadResult
+	opened   resource.Opened
 }
 
 var _ = gc.Suite(&LegacyHTTPHandlerSuite{})
@@ -49,6 +53,7 @@ func (s *LegacyHTTPHandlerSuite) SetUpTest(c *gc.C) {
 		returnHeader: s.header,
 	}
 	s.result = &api.UploadResult{}
+	s.opened = resource.Opened{}
 }
 
 func (s *LegacyHTTPHandlerSuite) connect(req *http.Request) (server.DataStore, names.Tag, error) {
@@ -197,6 +202,78 @@ func (s *LegacyHTTPHandlerSuite) TestServeHTTPPutHandleUploadFailure(c *gc.C) {
 	})
 }
 
+func (s *LegacyHTTPHandlerSuite) handleDownload(st server.DataStore, req *http.Request) (resource.Opened, error) {
+	s.stub.AddCall("HandleDownload", st, req)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Opened{}, errors.Trace(err)
+	}
+
+	return s.opened, nil
+}
+
+func (s *LegacyHTTPHandlerSuite) TestServeHTTPGetSuccess(c *gc.C) {
+	data := "some data"
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	s.opened.Resource.Fingerprint = fp
+	s.opened.Resource.Size = int64(len(data))
+	s.opened.ReadCloser = ioutil.NopCloser(strings.NewReader(data))
+	s.username = "youknowwho"
+	handler := server.LegacyHTTPHandler{
+		Connect:        s.connect,
+		HandleDownload: s.handleDownload,
+	}
+	s.req.Method = "GET"
+	copied := *s.req
+	req := &copied
+
+	handler.ServeHTTP(s.resp, req)
+
+	s.stub.CheckCallNames(c,
+		"Connect",
+		"HandleDownload",
+		"Header",
+		"Header",
+		"Header",
+		"WriteHeader",
+		"Write",
+	)
+	s.stub.CheckCall(c, 1, "HandleDownload", s.data, req)
+	s.stub.CheckCall(c, 5, "WriteHeader", http.StatusOK)
+	s.stub.CheckCall(c, 6, "Write", data)
+	c.Check(s.header, jc.DeepEquals, http.Header{
+		"Content-Type":   []string{api.ContentTypeRaw},
+		"Content-Length": []string{fmt.Sprint(len(data))},
+		"Content-Sha384": []string{fp.String()},
+	})
+}
+
+func (s *LegacyHTTPHandlerSuite) TestServeHTTPGetHandleDownloadFailure(c *gc.C) {
+	s.username = "youknowwho"
+	handler := server.LegacyHTTPHandler{
+		Connect:        s.connect,
+		HandleDownload: s.handleDownload,
+	}
+	s.req.Method = "GET"
+	copied := *s.req
+	req := &copied
+	failure, expected := apiFailure(c, "<failure>", "")
+	s.stub.SetErrors(nil, failure)
+
+	handler.ServeHTTP(s.resp, req)
+
+	s.stub.CheckCallNames(c,
+		"Connect",
+		"HandleDownload",
+		"Header",
+		"Header",
+		"WriteHeader",
+		"Write",
+	)
+	s.stub.CheckCall(c, 4, "WriteHeader", http.StatusInternalServerError)
+	s.stub.CheckCall(c, 5, "Write", expected)
+}
+
 func apiFailure(c *gc.C, msg, code string) (error, string) {
 	failure := errors.New(msg)
 
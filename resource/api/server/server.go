@@ -32,6 +32,7 @@ const (
 type DataStore interface {
 	resourceInfoStore
 	UploadDataStore
+	DownloadDataStore
 }
 
 // CharmStore exposes the functionality of the charm store as needed here.
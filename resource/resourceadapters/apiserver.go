@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -52,11 +53,47 @@ func NewUploadHandler(args apihttp.NewHandlerArgs) http.Handler {
 				return nil, nil, errors.Trace(err)
 			}
 
-			return resources, entity.Tag(), nil
+			return uploadDataStore{resources, st}, entity.Tag(), nil
 		},
 	)
 }
 
+// uploadDataStore lets resources be uploaded for applications that
+// have no record of them, as for applications in a model imported
+// from an archive. The resource is then described by the
+// application's charm.
+type uploadDataStore struct {
+	corestate.Resources
+	st *corestate.State
+}
+
+// GetResource implements server.UploadDataStore.
+func (ds uploadDataStore) GetResource(applicationID, name string) (resource.Resource, error) {
+	res, err := ds.Resources.GetResource(applicationID, name)
+	if !errors.IsNotFound(err) {
+		return res, errors.Trace(err)
+	}
+	app, err := ds.st.Application(applicationID)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	meta, ok := ch.Meta().Resources[name]
+	if !ok {
+		return resource.Resource{}, errors.NotFoundf("resource %q of application %q", name, applicationID)
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta:   meta,
+			Origin: charmresource.OriginUpload,
+		},
+		ApplicationID: applicationID,
+	}, nil
+}
+
 // NewDownloadHandler returns a new HTTP handler for the given args.
 func NewDownloadHandler(args apihttp.NewHandlerArgs) http.Handler {
 	extractor := &httpDownloadRequestExtractor{
@@ -5,6 +5,7 @@ package state
 
 import (
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -17,6 +18,14 @@ import (
 
 const (
 	actionMarker = "_a_"
+
+	// maxActionMessages is the number of progress messages kept for
+	// an action; older messages are discarded as new ones are logged.
+	maxActionMessages = 1000
+
+	// maxActionMessageLength is the length in bytes beyond which a
+	// logged progress message is truncated.
+	maxActionMessageLength = 4096
 )
 
 var (
@@ -45,6 +54,13 @@ const (
 
 	// ActionRunning indicates that the Action is currently running.
 	ActionRunning ActionStatus = "running"
+
+	// ActionAborting indicates that the Action is running, but has been
+	// asked to stop.
+	ActionAborting ActionStatus = "aborting"
+
+	// ActionAborted means that the Action was stopped while running.
+	ActionAborted ActionStatus = "aborted"
 )
 
 type actionNotificationDoc struct {
@@ -104,6 +120,25 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while
+	// running, oldest first.
+	Logs []ActionMessage `bson:"logs"`
+
+	// Timeout is how long the action may run for before its receiver
+	// stops it and marks it failed. Zero means it may run for as long
+	// as it needs.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// Operation is the id of the operation the action was enqueued
+	// as part of, if any.
+	Operation string `bson:"operation,omitempty"`
+}
+
+// ActionMessage is a progress message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +171,18 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Timeout returns how long the action may run for, or zero if it may
+// run for as long as it needs.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// Operation returns the id of the operation the action is part of, or
+// "" if it was enqueued alone.
+func (a *action) Operation() string {
+	return a.doc.Operation
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -162,6 +209,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,6 +253,63 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log records a progress message for the action. Messages longer than
+// maxActionMessageLength are truncated. It asserts that the action is
+// currently running or aborting.
+func (a *action) Log(message string) error {
+	if len(message) > maxActionMessageLength {
+		// Cut on a rune boundary, so the message stays valid UTF-8.
+		end := maxActionMessageLength
+		for end > 0 && !utf8.RuneStart(message[end]) {
+			end--
+		}
+		message = message[:end]
+	}
+	entry := ActionMessage{
+		Timestamp: a.st.clock.Now().UTC(),
+		Message:   message,
+	}
+	err := a.st.runTransaction([]txn.Op{
+		{
+			C:  actionsC,
+			Id: a.doc.DocId,
+			Assert: bson.D{{"status", bson.D{
+				{"$in", []interface{}{ActionRunning, ActionAborting}}}}},
+			Update: bson.D{{"$push", bson.D{{"logs", bson.D{
+				{"$each", []ActionMessage{entry}},
+				{"$slice", -maxActionMessages},
+			}}}}},
+		}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("action %q is not running", a.Id())
+	}
+	return errors.Trace(err)
+}
+
+// Abort asks for the running action to be stopped, by marking it as
+// aborting. It is left to the action's receiver to stop the action and
+// finish it as aborted. It asserts that the action is currently running
+// or aborting.
+func (a *action) Abort() (Action, error) {
+	err := a.st.runTransaction([]txn.Op{
+		{
+			C:  actionsC,
+			Id: a.doc.DocId,
+			Assert: bson.D{{"status", bson.D{
+				{"$in", []interface{}{ActionRunning, ActionAborting}}}}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", ActionAborting},
+			}}},
+		}})
+	if err == txn.ErrAborted {
+		return nil, errors.Errorf("action %q is not running", a.Id())
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.st.Action(a.Id())
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -220,6 +329,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 					ActionCompleted,
 					ActionCancelled,
 					ActionFailed,
+					ActionAborted,
 				}}}}},
 			Update: bson.D{{"$set", bson.D{
 				{"status", finalStatus},
@@ -246,8 +356,9 @@ func newAction(st *State, adoc actionDoc) Action {
 	}
 }
 
-// newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+// newActionDoc builds the actionDoc with the given operation, name,
+// parameters and timeout.
+func newActionDoc(st *State, operationId string, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -263,6 +374,8 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   st.NowToTheSecond(),
 			Status:     ActionPending,
+			Timeout:    timeout,
+			Operation:  operationId,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
 			ModelUUID: modelUUID,
@@ -356,16 +469,38 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.EnqueueActionWithTimeout(receiver, actionName, payload, 0)
+}
+
+// EnqueueActionWithTimeout queues an action that the receiver must
+// stop if it runs for longer than the timeout. A zero timeout lets the
+// action run for as long as it needs.
+func (st *State) EnqueueActionWithTimeout(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
+	return st.EnqueueOperationAction("", receiver, actionName, payload, timeout)
+}
+
+// EnqueueOperationAction queues an action as EnqueueActionWithTimeout
+// does, as part of the operation with the given id. If the id is "",
+// the action is not part of any operation.
+func (st *State) EnqueueOperationAction(operationId string, receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if timeout < 0 {
+		return nil, errors.Errorf("invalid timeout %v", timeout)
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if operationId != "" {
+		if _, err := st.Operation(operationId); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, operationId, receiver, actionName, payload, timeout)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -385,6 +520,13 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		Assert: txn.DocMissing,
 		Insert: ndoc,
 	}}
+	if operationId != "" {
+		ops = append(ops, txn.Op{
+			C:      operationsC,
+			Id:     st.docID(operationId),
+			Assert: txn.DocExists,
+		})
+	}
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
@@ -430,9 +572,12 @@ func (st *State) matchingActionsPending(ar ActionReceiver) ([]Action, error) {
 }
 
 // matchingActionsRunning finds actions that match ActionReceiver and
-// that are running.
+// that are running, including those being aborted.
 func (st *State) matchingActionsRunning(ar ActionReceiver) ([]Action, error) {
-	completed := bson.D{{"status", ActionRunning}}
+	completed := bson.D{{"$or", []bson.D{
+		{{"status", ActionRunning}},
+		{{"status", ActionAborting}},
+	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
 
@@ -443,6 +588,7 @@ func (st *State) matchingActionsCompleted(ar ActionReceiver) ([]Action, error) {
 		{{"status", ActionCompleted}},
 		{{"status", ActionCancelled}},
 		{{"status", ActionFailed}},
+		{{"status", ActionAborted}},
 	}}}
 	return st.matchingActionsByReceiverAndStatus(ar.Tag(), completed)
 }
@@ -464,3 +610,88 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 	}
 	return actions, errors.Trace(iter.Close())
 }
+
+// PruneActions removes the completed actions in the model, with their
+// results, that finished longer than maxAge ago, and then the oldest
+// of those remaining until no more than maxCount are left. A zero
+// maxAge or maxCount imposes no limit. Operations left without any
+// actions are removed too.
+func PruneActions(st *State, maxAge time.Duration, maxCount int) error {
+	if maxAge < 0 {
+		return errors.NotValidf("negative maxAge")
+	}
+	if maxCount < 0 {
+		return errors.NotValidf("negative maxCount")
+	}
+	prunedBefore, err := pruneActions(st, maxAge, maxCount)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if prunedBefore.IsZero() {
+		return nil
+	}
+	// An operation has its actions added as soon as it is enqueued,
+	// so one enqueued before the pruned actions finished that has
+	// no actions left is done with.
+	return errors.Trace(pruneOperations(st, prunedBefore))
+}
+
+// pruneActions prunes actions as described by PruneActions, and
+// returns the latest completion time of any action it may have
+// removed.
+func pruneActions(st *State, maxAge time.Duration, maxCount int) (time.Time, error) {
+	var prunedBefore time.Time
+	actions, closer := st.getCollection(actionsC)
+	defer closer()
+
+	completed := func(completedCondition bson.D) bson.D {
+		return bson.D{
+			{"status", bson.D{{"$in", []ActionStatus{
+				ActionCompleted,
+				ActionCancelled,
+				ActionFailed,
+				ActionAborted,
+			}}}},
+			{"completed", completedCondition},
+		}
+	}
+
+	if maxAge > 0 {
+		cutoff := st.clock.Now().Add(-maxAge)
+		info, err := actions.Writeable().RemoveAll(completed(bson.D{{"$lt", cutoff}}))
+		if err != nil {
+			return time.Time{}, errors.Annotate(err, "pruning actions by age")
+		}
+		actionLogger.Debugf("pruned %d actions completed before %v", info.Removed, cutoff)
+		prunedBefore = cutoff
+	}
+	if maxCount == 0 {
+		return prunedBefore, nil
+	}
+
+	// Every completed action has a completion time.
+	all := completed(bson.D{{"$exists", true}})
+	count, err := actions.Find(all).Count()
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "counting completed actions")
+	}
+	if count <= maxCount {
+		return prunedBefore, nil
+	}
+	var newestPruned actionDoc
+	err = actions.Find(all).Sort("-completed").Skip(maxCount).One(&newestPruned)
+	if err == mgo.ErrNotFound {
+		return prunedBefore, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	info, err := actions.Writeable().RemoveAll(completed(bson.D{{"$lte", newestPruned.Completed}}))
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "pruning actions by count")
+	}
+	actionLogger.Debugf("pruned %d of %d completed actions", info.Removed, count)
+	if newestPruned.Completed.After(prunedBefore) {
+		prunedBefore = newestPruned.Completed
+	}
+	return prunedBefore, nil
+}
@@ -7,8 +7,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
 	"github.com/juju/utils"
@@ -463,6 +466,172 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("starting")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "starting")
+	c.Check(messages[1].Message, gc.Equals, "halfway there")
+	c.Check(messages[0].Timestamp.IsZero(), jc.IsFalse)
+	c.Check(messages[1].Timestamp.Before(messages[0].Timestamp), jc.IsFalse)
+
+	// The messages are kept once the action is finished.
+	action, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Messages(), gc.HasLen, 2)
+}
+
+func (s *ActionSuite) TestLogTruncatesLongMessages(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log(strings.Repeat("x", 5000))
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, strings.Repeat("x", 4096))
+}
+
+func (s *ActionSuite) TestLogTruncatesOnRuneBoundary(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cutting the message at 4096 bytes would split an "é" in two.
+	err = a.Log("x" + strings.Repeat("é", 3000))
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, "x"+strings.Repeat("é", 2047))
+	c.Check(utf8.ValidString(messages[0].Message), jc.IsTrue)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+	c.Check(a.Messages(), gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	// The dummy charm declares no default timeout.
+	a, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeout(), gc.Equals, time.Duration(0))
+
+	_, err = s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "invalid timeout -1s")
+}
+
+func (s *ActionSuite) TestAbort(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = a.Abort()
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Status(), gc.Equals, state.ActionAborting)
+
+	// Asking again is not an error.
+	a, err = a.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Status(), gc.Equals, state.ActionAborting)
+
+	// An aborting action is still running, and can still log.
+	running, err := s.unit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	c.Check(running[0].Id(), gc.Equals, a.Id())
+	err = a.Log("cleaning up")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = a.Finish(state.ActionResults{Status: state.ActionAborted, Message: "aborted"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Status(), gc.Equals, state.ActionAborted)
+	completed, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.HasLen, 1)
+
+	_, err = a.Abort()
+	c.Assert(err, gc.ErrorMatches, `action ".*" is not running`)
+}
+
+func (s *ActionSuite) TestWatchAbortingActions(c *gc.C) {
+	a1, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err = a1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err = a2.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err = other.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.unit.WatchAbortingActions()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	_, err = other.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	_, err = a2.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(a2.Id())
+	wc.AssertNoChange()
+
+	_, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -878,6 +1047,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(string, map[string]interface{}, time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -938,3 +1110,70 @@ func (h *uuidMockHelper) mask(uuid utils.UUID) utils.UUID {
 	}
 	return uuid
 }
+
+func (s *ActionSuite) addCompletedAction(c *gc.C) state.Action {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	return a
+}
+
+func (s *ActionSuite) assertActionsExist(c *gc.C, exist bool, actions ...state.Action) {
+	for _, a := range actions {
+		_, err := s.State.Action(a.Id())
+		if exist {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotFound)
+		}
+	}
+}
+
+func (s *ActionSuite) TestPruneActionsByAge(c *gc.C) {
+	clock := jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old := s.addCompletedAction(c)
+	clock.Advance(48 * time.Hour)
+	recent := s.addCompletedAction(c)
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionsExist(c, false, old)
+	s.assertActionsExist(c, true, recent, pending)
+}
+
+func (s *ActionSuite) TestPruneActionsByCount(c *gc.C) {
+	clock := jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var completed []state.Action
+	for i := 0; i < 4; i++ {
+		completed = append(completed, s.addCompletedAction(c))
+		clock.Advance(time.Minute)
+	}
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, 0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionsExist(c, false, completed[:2]...)
+	s.assertActionsExist(c, true, completed[2], completed[3], pending)
+
+	// Nothing more is pruned while the limit is not exceeded.
+	err = state.PruneActions(s.State, 0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionsExist(c, true, completed[2], completed[3])
+}
+
+func (s *ActionSuite) TestPruneActionsInvalid(c *gc.C) {
+	err := state.PruneActions(s.State, -time.Hour, 0)
+	c.Check(err, gc.ErrorMatches, "negative maxAge not valid")
+	err = state.PruneActions(s.State, 0, -1)
+	c.Check(err, gc.ErrorMatches, "negative maxCount not valid")
+}
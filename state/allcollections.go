@@ -328,13 +328,25 @@ func allCollections() collectionSchema {
 
 		// -----
 
-		// These collections hold information associated with actions.
+		// These collections hold information associated with actions,
+		// and the operations grouping them.
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "status", "completed"},
+			}, {
+				Key: []string{"model-uuid", "operation"},
 			}},
 		},
 		actionNotificationsC: {},
+		operationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "sequence"},
+			}, {
+				Key: []string{"model-uuid", "enqueued"},
+			}},
+		},
 
 		// -----
 
@@ -347,6 +359,30 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds the secrets stored by charms, with
+		// their values encrypted.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+
+		// This collection holds the most recent hook executions of each
+		// unit, recorded by the uniter.
+		hookHistoryC: {
+			rawAccess: true,
+		},
+
+		// These collections hold the application endpoints offered to
+		// other models, and the applications in other models that this
+		// one relates to.
+		applicationOffersC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+		remoteApplicationsC: {},
+
 		// This collection holds information associated with charm resources.
 		// See resource/persistence/mongo.go, where it should never have
 		// been put in the first place.
@@ -394,6 +430,11 @@ func allCollections() collectionSchema {
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"time"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}},
 		},
 	}
 }
@@ -407,6 +448,7 @@ const (
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	applicationOffersC       = "applicationOffers"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
@@ -427,6 +469,7 @@ const (
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	hookHistoryC             = "hookhistory"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -444,13 +487,16 @@ const (
 	modelsC                  = "models"
 	modelEntityRefsC         = "modelEntityRefs"
 	openedPortsC             = "openedPorts"
+	operationsC              = "operations"
 	payloadsC                = "payloads"
 	permissionsC             = "permissions"
 	providerIDsC             = "providerIDs"
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
+	remoteApplicationsC      = "remoteApplications"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// applicationOfferDoc records the endpoints of an application that
+// are offered for applications in other models to relate to.
+type applicationOfferDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// OfferName is the name by which the offer is consumed.
+	OfferName string `bson:"offer-name"`
+
+	// ApplicationName is the name of the offered application.
+	ApplicationName string `bson:"application"`
+
+	// ApplicationDescription describes the offered application for
+	// the users finding it.
+	ApplicationDescription string `bson:"application-description"`
+
+	// Endpoints holds the names of the offered endpoints.
+	Endpoints []string `bson:"endpoints"`
+
+	// Owner is the tag of the user who made the offer.
+	Owner string `bson:"owner"`
+}
+
+// ApplicationOffer is an offer of some of an application's endpoints
+// to the applications in other models.
+type ApplicationOffer struct {
+	st  *State
+	doc applicationOfferDoc
+}
+
+// OfferName returns the name of the offer.
+func (o *ApplicationOffer) OfferName() string {
+	return o.doc.OfferName
+}
+
+// ApplicationName returns the name of the offered application.
+func (o *ApplicationOffer) ApplicationName() string {
+	return o.doc.ApplicationName
+}
+
+// ApplicationDescription returns the description of the offered
+// application.
+func (o *ApplicationOffer) ApplicationDescription() string {
+	return o.doc.ApplicationDescription
+}
+
+// Owner returns the tag of the user who made the offer.
+func (o *ApplicationOffer) Owner() names.UserTag {
+	return names.NewUserTag(o.doc.Owner)
+}
+
+// Endpoints returns the offered endpoints of the application.
+func (o *ApplicationOffer) Endpoints() ([]Endpoint, error) {
+	app, err := o.st.Application(o.doc.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps := make([]Endpoint, len(o.doc.Endpoints))
+	for i, name := range o.doc.Endpoints {
+		if eps[i], err = app.Endpoint(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return eps, nil
+}
+
+// AddApplicationOfferArgs holds the arguments for offering an
+// application's endpoints.
+type AddApplicationOfferArgs struct {
+	// OfferName is the name by which the offer is consumed; it
+	// defaults to the application name.
+	OfferName string
+
+	// ApplicationName is the name of the application to offer.
+	ApplicationName string
+
+	// ApplicationDescription describes the application for the users
+	// finding the offer.
+	ApplicationDescription string
+
+	// Endpoints holds the names of the endpoints to offer.
+	Endpoints []string
+
+	// Owner is the user making the offer.
+	Owner names.UserTag
+}
+
+// AddApplicationOffer offers some of an application's endpoints to the
+// applications in other models.
+func (st *State) AddApplicationOffer(args AddApplicationOfferArgs) (_ *ApplicationOffer, err error) {
+	if args.OfferName == "" {
+		args.OfferName = args.ApplicationName
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot add application offer %q", args.OfferName)
+
+	if !crossmodel.IsValidOfferName(args.OfferName) {
+		return nil, errors.NotValidf("offer name")
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.New("no endpoints specified")
+	}
+	app, err := st.Application(args.ApplicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, name := range args.Endpoints {
+		ep, err := app.Endpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("endpoint %q cannot be offered", name)
+		}
+	}
+	doc := applicationOfferDoc{
+		DocID:                  st.docID(args.OfferName),
+		ModelUUID:              st.ModelUUID(),
+		OfferName:              args.OfferName,
+		ApplicationName:        args.ApplicationName,
+		ApplicationDescription: args.ApplicationDescription,
+		Endpoints:              args.Endpoints,
+		Owner:                  args.Owner.Id(),
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(args.ApplicationName),
+		Assert: isAliveDoc,
+	}, {
+		C:      applicationOffersC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if exists, err := isNotDead(st, applicationsC, args.ApplicationName); err != nil {
+			return nil, errors.Trace(err)
+		} else if !exists {
+			return nil, errors.Errorf("application %q is not alive", args.ApplicationName)
+		}
+		return nil, errors.AlreadyExistsf("offer")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ApplicationOffer{st: st, doc: doc}, nil
+}
+
+// ApplicationOffer returns the application offer with the given name.
+func (st *State) ApplicationOffer(offerName string) (*ApplicationOffer, error) {
+	offers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var doc applicationOfferDoc
+	err := offers.FindId(offerName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("application offer %q", offerName)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get application offer %q", offerName)
+	}
+	return &ApplicationOffer{st: st, doc: doc}, nil
+}
+
+// AllApplicationOffers returns all the application offers in the
+// model, ordered by name.
+func (st *State) AllApplicationOffers() ([]*ApplicationOffer, error) {
+	offers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []applicationOfferDoc
+	if err := offers.Find(nil).Sort("offer-name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all application offers")
+	}
+	results := make([]*ApplicationOffer, len(docs))
+	for i, doc := range docs {
+		results[i] = &ApplicationOffer{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// RemoveApplicationOffer removes the named application offer. The
+// relations already made through the offer are not affected.
+func (st *State) RemoveApplicationOffer(offerName string) error {
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     st.docID(offerName),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("application offer %q", offerName)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove application offer %q", offerName)
+	}
+	return nil
+}
+
+// removeApplicationOffersOps returns the operations needed to remove
+// the offers of the named application, when it is removed.
+func removeApplicationOffersOps(st *State, applicationName string) ([]txn.Op, error) {
+	offers, closer := st.getCollection(applicationOffersC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	sel := bson.D{{"application", applicationName}}
+	if err := offers.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      applicationOffersC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
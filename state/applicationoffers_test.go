@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ApplicationOffersSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&ApplicationOffersSuite{})
+
+func (s *ApplicationOffersSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *ApplicationOffersSuite) addOffer(c *gc.C) *state.ApplicationOffer {
+	offer, err := s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		OfferName:              "db",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "shared database",
+		Endpoints:              []string{"server"},
+		Owner:                  names.NewUserTag("admin"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return offer
+}
+
+func (s *ApplicationOffersSuite) TestAddApplicationOffer(c *gc.C) {
+	s.addOffer(c)
+	offer, err := s.State.ApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer.OfferName(), gc.Equals, "db")
+	c.Check(offer.ApplicationName(), gc.Equals, "mysql")
+	c.Check(offer.ApplicationDescription(), gc.Equals, "shared database")
+	c.Check(offer.Owner(), gc.Equals, names.NewUserTag("admin"))
+	eps, err := offer.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, gc.HasLen, 1)
+	c.Check(eps[0].Name, gc.Equals, "server")
+
+	all, err := s.State.AllApplicationOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Check(all[0].OfferName(), gc.Equals, "db")
+}
+
+func (s *ApplicationOffersSuite) TestAddApplicationOfferDefaultName(c *gc.C) {
+	offer, err := s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer.OfferName(), gc.Equals, "mysql")
+}
+
+func (s *ApplicationOffersSuite) TestAddApplicationOfferInvalid(c *gc.C) {
+	_, err := s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		ApplicationName: "mysql",
+		Endpoints:       []string{"nope"},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add application offer "mysql": application "mysql" has no "nope" relation`)
+
+	_, err = s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		ApplicationName: "mysql",
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add application offer "mysql": no endpoints specified`)
+
+	_, err = s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		ApplicationName: "wordpress",
+		Endpoints:       []string{"db"},
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationOffersSuite) TestAddApplicationOfferTwice(c *gc.C) {
+	s.addOffer(c)
+	_, err := s.State.AddApplicationOffer(state.AddApplicationOfferArgs{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+	})
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationOffersSuite) TestRemoveApplicationOffer(c *gc.C) {
+	s.addOffer(c)
+	err := s.State.RemoveApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("db")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveApplicationOffer("db")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationOffersSuite) TestOffersRemovedWithApplication(c *gc.C) {
+	s.addOffer(c)
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationOffer("db")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
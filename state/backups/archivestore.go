@@ -0,0 +1,299 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// These are the names of the places in which backup archives may be
+// kept. ControllerStorage, the controller's own database, is used if
+// no other storage is requested.
+const (
+	ControllerStorage = "controller"
+	DirStorage        = "dir"
+	S3Storage         = "s3"
+)
+
+const (
+	archiveSuffix  = ".tar.gz"
+	metadataSuffix = ".json"
+)
+
+// ArchiveStore is a place, other than the controller's database, in
+// which backup archives are kept. Each archive is stored alongside a
+// copy of its metadata, so that the backups in a store may be found
+// even if the controller that created them has been lost.
+type ArchiveStore interface {
+	// Name returns the name by which the store is selected.
+	Name() string
+
+	// Add stores the archive and its metadata. The metadata's ID
+	// must already be set.
+	Add(meta *Metadata, archive io.Reader) error
+
+	// Metadata returns the metadata of the identified backup. If the
+	// backup is not in the store, an error satisfying
+	// errors.IsNotFound is returned.
+	Metadata(id string) (*Metadata, error)
+
+	// Get returns the metadata and archive of the identified
+	// backup. If the backup is not in the store, an error
+	// satisfying errors.IsNotFound is returned.
+	Get(id string) (*Metadata, io.ReadCloser, error)
+
+	// List returns the metadata of every backup in the store.
+	List() ([]*Metadata, error)
+
+	// Remove deletes the identified backup from the store.
+	Remove(id string) error
+}
+
+// NewArchiveStores returns the archive stores configured for the
+// controller.
+func NewArchiveStores(cfg controller.Config) ([]ArchiveStore, error) {
+	var stores []ArchiveStore
+	if dir := cfg.BackupStorageDir(); dir != "" {
+		stores = append(stores, NewDirArchiveStore(dir))
+	}
+	if s3cfg, ok := cfg.BackupS3(); ok {
+		store, err := NewS3ArchiveStore(S3Config{
+			Endpoint:  s3cfg.Endpoint,
+			Region:    s3cfg.Region,
+			Bucket:    s3cfg.Bucket,
+			AccessKey: s3cfg.AccessKey,
+			SecretKey: s3cfg.SecretKey,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "opening S3 backup storage")
+		}
+		stores = append(stores, store)
+	}
+	return stores, nil
+}
+
+// blobStore holds named blobs on behalf of a blobArchiveStore.
+type blobStore interface {
+	// put stores the contents of the reader, which has the given
+	// size, under the name.
+	put(name string, r io.Reader, size int64) error
+
+	// get returns the named blob, or an error satisfying
+	// errors.IsNotFound.
+	get(name string) (io.ReadCloser, error)
+
+	// remove deletes the named blob, or returns an error
+	// satisfying errors.IsNotFound.
+	remove(name string) error
+
+	// list returns the names of all blobs in the store.
+	list() ([]string, error)
+}
+
+// blobArchiveStore implements ArchiveStore on top of a blobStore,
+// keeping each archive in "<id>.tar.gz" and its metadata in
+// "<id>.json".
+type blobArchiveStore struct {
+	name  string
+	blobs blobStore
+}
+
+// Name implements ArchiveStore.
+func (s *blobArchiveStore) Name() string {
+	return s.name
+}
+
+// Add implements ArchiveStore.
+func (s *blobArchiveStore) Add(meta *Metadata, archive io.Reader) error {
+	id := meta.ID()
+	if id == "" {
+		return errors.New("missing ID")
+	}
+	if err := s.blobs.put(id+archiveSuffix, archive, meta.Size()); err != nil {
+		return errors.Annotate(err, "storing archive")
+	}
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	stored := time.Now().UTC()
+	meta.SetStored(&stored)
+	meta.Storage = s.name
+	if err := s.putMetadata(meta); err != nil {
+		// Don't leave an archive that can't be found.
+		if err := s.blobs.remove(id + archiveSuffix); err != nil {
+			logger.Errorf("cannot remove orphaned backup archive %q: %v", id, err)
+		}
+		return errors.Annotate(err, "storing metadata")
+	}
+	return nil
+}
+
+func (s *blobArchiveStore) putMetadata(meta *Metadata) error {
+	buf, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.blobs.put(meta.ID()+metadataSuffix, bytes.NewReader(data), int64(len(data))))
+}
+
+// Metadata implements ArchiveStore.
+func (s *blobArchiveStore) Metadata(id string) (*Metadata, error) {
+	r, err := s.blobs.get(id + metadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup %q in %s storage", id, s.name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	meta, err := NewMetadataJSONReader(r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading metadata for backup %q", id)
+	}
+	meta.Storage = s.name
+	return meta, nil
+}
+
+// Get implements ArchiveStore.
+func (s *blobArchiveStore) Get(id string) (*Metadata, io.ReadCloser, error) {
+	meta, err := s.Metadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := s.blobs.get(id + archiveSuffix)
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("archive for backup %q in %s storage", id, s.name)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, archive, nil
+}
+
+// List implements ArchiveStore.
+func (s *blobArchiveStore) List() ([]*Metadata, error) {
+	names, err := s.blobs.list()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metaList []*Metadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, metadataSuffix) {
+			continue
+		}
+		meta, err := s.Metadata(strings.TrimSuffix(name, metadataSuffix))
+		if errors.IsNotFound(err) {
+			// Removed while we were listing.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		metaList = append(metaList, meta)
+	}
+	return metaList, nil
+}
+
+// Remove implements ArchiveStore.
+func (s *blobArchiveStore) Remove(id string) error {
+	// Remove the metadata first, so that a partially removed
+	// backup is not listed.
+	err := s.blobs.remove(id + metadataSuffix)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup %q in %s storage", id, s.name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.blobs.remove(id + archiveSuffix)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewDirArchiveStore returns an ArchiveStore which keeps backups in
+// the given directory, which may be local or a mounted network
+// filesystem such as NFS. The directory is created if necessary.
+func NewDirArchiveStore(dir string) ArchiveStore {
+	return &blobArchiveStore{
+		name:  DirStorage,
+		blobs: dirBlobStore{dir},
+	}
+}
+
+type dirBlobStore struct {
+	dir string
+}
+
+func (s dirBlobStore) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s dirBlobStore) put(name string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file first, so that a partially written
+	// blob is never visible under its final name.
+	tempFile, err := os.Create(s.path("." + name + ".tmp"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tempPath := tempFile.Name()
+	_, err = io.Copy(tempFile, r)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return errors.Trace(err)
+	}
+	if err := os.Rename(tempPath, s.path(name)); err != nil {
+		os.Remove(tempPath)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (s dirBlobStore) get(name string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(name))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q", name)
+	}
+	return f, errors.Trace(err)
+}
+
+func (s dirBlobStore) remove(name string) error {
+	err := os.Remove(s.path(name))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q", name)
+	}
+	return errors.Trace(err)
+}
+
+func (s dirBlobStore) list() ([]string, error) {
+	dir, err := os.Open(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer dir.Close()
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
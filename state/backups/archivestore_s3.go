@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+)
+
+// s3ListMax is the maximum number of keys requested in each S3
+// bucket listing.
+const s3ListMax = 1000
+
+// s3KeyPrefix is prepended to the name of every object the S3
+// archive store keeps, so that the bucket may be shared with other
+// users.
+const s3KeyPrefix = "juju-backups/"
+
+// S3Config holds the configuration of an S3-compatible object store
+// in which backups are kept.
+type S3Config struct {
+	// Endpoint is the URL of the object store, e.g.
+	// "https://s3.amazonaws.com".
+	Endpoint string
+
+	// Region is the name of the region in which the bucket lives.
+	Region string
+
+	// Bucket is the name of the bucket in which backups are kept.
+	// The bucket must already exist.
+	Bucket string
+
+	// AccessKey and SecretKey are the credentials used to access
+	// the bucket.
+	AccessKey string
+	SecretKey string
+}
+
+// Validate ensures that the config is usable.
+func (cfg S3Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.NotValidf("missing endpoint")
+	}
+	if cfg.Bucket == "" {
+		return errors.NotValidf("missing bucket")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	return nil
+}
+
+// s3Bucket is the subset of *s3.Bucket used by the S3 archive store.
+type s3Bucket interface {
+	PutReader(path string, r io.Reader, length int64, contType string, perm s3.ACL) error
+	GetReader(path string) (io.ReadCloser, error)
+	Del(path string) error
+	Head(path string, headers map[string][]string) (*http.Response, error)
+	List(prefix, delim, marker string, max int) (*s3.ListResp, error)
+}
+
+// NewS3ArchiveStore returns an ArchiveStore which keeps backups in
+// a bucket of an S3-compatible object store.
+func NewS3ArchiveStore(cfg S3Config) (ArchiveStore, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	region := aws.Region{
+		Name:                 cfg.Region,
+		S3Endpoint:           cfg.Endpoint,
+		S3LocationConstraint: true,
+	}
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &blobArchiveStore{
+		name:  S3Storage,
+		blobs: s3BlobStore{bucket, s3KeyPrefix},
+	}, nil
+}
+
+type s3BlobStore struct {
+	bucket s3Bucket
+	prefix string
+}
+
+func (s s3BlobStore) put(name string, r io.Reader, size int64) error {
+	err := s.bucket.PutReader(s.prefix+name, r, size, "application/octet-stream", s3.Private)
+	return errors.Trace(err)
+}
+
+func (s s3BlobStore) get(name string) (io.ReadCloser, error) {
+	r, err := s.bucket.GetReader(s.prefix + name)
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("%q", name)
+	}
+	return r, errors.Trace(err)
+}
+
+func (s s3BlobStore) remove(name string) error {
+	// S3 does not report an error when deleting a missing key, so
+	// we have to check first.
+	key := s.prefix + name
+	resp, err := s.bucket.Head(key, nil)
+	if isS3NotFound(err) {
+		return errors.NotFoundf("%q", name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.NotFoundf("%q", name)
+	}
+	return errors.Trace(s.bucket.Del(key))
+}
+
+func (s s3BlobStore) list() ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		resp, err := s.bucket.List(s.prefix, "", marker, s3ListMax)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, key := range resp.Contents {
+			names = append(names, strings.TrimPrefix(key.Key, s.prefix))
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			return names, nil
+		}
+		marker = resp.Contents[len(resp.Contents)-1].Key
+	}
+}
+
+func isS3NotFound(err error) bool {
+	if s3err, ok := err.(*s3.Error); ok {
+		return s3err.StatusCode == http.StatusNotFound
+	}
+	return false
+}
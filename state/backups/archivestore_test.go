@@ -0,0 +1,241 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+// archiveStoreSuite holds the tests common to every ArchiveStore
+// implementation.
+type archiveStoreSuite struct {
+	backupstesting.BaseSuite
+	store backups.ArchiveStore
+}
+
+func (s *archiveStoreSuite) newMeta(c *gc.C, id string, data string) *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	err := meta.MarkComplete(int64(len(data)), "<checksum>")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *archiveStoreSuite) TestAddGet(c *gc.C) {
+	meta := s.newMeta(c, "20160101-120000.model", "<archive>")
+	err := s.store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Stored(), gc.NotNil)
+	c.Check(meta.Storage, gc.Equals, s.store.Name())
+
+	gotMeta, archive, err := s.store.Get("20160101-120000.model")
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+	c.Check(gotMeta.ID(), gc.Equals, "20160101-120000.model")
+	c.Check(gotMeta.Size(), gc.Equals, int64(9))
+	c.Check(gotMeta.Checksum(), gc.Equals, "<checksum>")
+	c.Check(gotMeta.Storage, gc.Equals, s.store.Name())
+}
+
+func (s *archiveStoreSuite) TestAddMissingID(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	err := s.store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, gc.ErrorMatches, "missing ID")
+}
+
+func (s *archiveStoreSuite) TestGetNotFound(c *gc.C) {
+	_, _, err := s.store.Get("20160101-120000.model")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *archiveStoreSuite) TestList(c *gc.C) {
+	metaList, err := s.store.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metaList, gc.HasLen, 0)
+
+	for _, id := range []string{"20160101-120000.model", "20160102-120000.model"} {
+		err := s.store.Add(s.newMeta(c, id, "<archive>"), bytes.NewBufferString("<archive>"))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	metaList, err = s.store.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 2)
+	c.Check(metaList[0].ID(), gc.Equals, "20160101-120000.model")
+	c.Check(metaList[1].ID(), gc.Equals, "20160102-120000.model")
+}
+
+func (s *archiveStoreSuite) TestRemove(c *gc.C) {
+	meta := s.newMeta(c, "20160101-120000.model", "<archive>")
+	err := s.store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.Remove("20160101-120000.model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.store.Get("20160101-120000.model")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	metaList, err := s.store.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metaList, gc.HasLen, 0)
+}
+
+func (s *archiveStoreSuite) TestRemoveNotFound(c *gc.C) {
+	err := s.store.Remove("20160101-120000.model")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type dirArchiveStoreSuite struct {
+	archiveStoreSuite
+	dir string
+}
+
+var _ = gc.Suite(&dirArchiveStoreSuite{})
+
+func (s *dirArchiveStoreSuite) SetUpTest(c *gc.C) {
+	s.archiveStoreSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+	s.store = backups.NewDirArchiveStore(s.dir)
+}
+
+func (s *dirArchiveStoreSuite) TestName(c *gc.C) {
+	c.Assert(s.store.Name(), gc.Equals, "dir")
+}
+
+func (s *dirArchiveStoreSuite) TestFiles(c *gc.C) {
+	meta := s.newMeta(c, "20160101-120000.model", "<archive>")
+	err := s.store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	dir, err := os.Open(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	defer dir.Close()
+	names, err := dir.Readdirnames(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.SameContents, []string{
+		"20160101-120000.model.tar.gz",
+		"20160101-120000.model.json",
+	})
+}
+
+type s3ArchiveStoreSuite struct {
+	archiveStoreSuite
+	server *s3test.Server
+	bucket *s3.Bucket
+}
+
+var _ = gc.Suite(&s3ArchiveStoreSuite{})
+
+func (s *s3ArchiveStoreSuite) SetUpTest(c *gc.C) {
+	s.archiveStoreSuite.SetUpTest(c)
+	server, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.server = server
+	s.AddCleanup(func(*gc.C) { server.Quit() })
+
+	cfg := backups.S3Config{
+		Endpoint:  server.URL(),
+		Region:    "faux-region-1",
+		Bucket:    "juju-backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+	region := aws.Region{
+		Name:                 cfg.Region,
+		S3Endpoint:           cfg.Endpoint,
+		S3LocationConstraint: true,
+	}
+	bucket, err := s3.New(aws.Auth{AccessKey: "access", SecretKey: "secret"}, region).Bucket(cfg.Bucket)
+	c.Assert(err, jc.ErrorIsNil)
+	err = bucket.PutBucket(s3.Private)
+	c.Assert(err, jc.ErrorIsNil)
+	s.bucket = bucket
+
+	s.store, err = backups.NewS3ArchiveStore(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *s3ArchiveStoreSuite) TestName(c *gc.C) {
+	c.Assert(s.store.Name(), gc.Equals, "s3")
+}
+
+func (s *s3ArchiveStoreSuite) TestKeys(c *gc.C) {
+	meta := s.newMeta(c, "20160101-120000.model", "<archive>")
+	err := s.store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp, err := s.bucket.List("", "", "", 1000)
+	c.Assert(err, jc.ErrorIsNil)
+	var keys []string
+	for _, key := range resp.Contents {
+		keys = append(keys, key.Key)
+	}
+	c.Assert(keys, jc.SameContents, []string{
+		"juju-backups/20160101-120000.model.tar.gz",
+		"juju-backups/20160101-120000.model.json",
+	})
+}
+
+func (s *s3ArchiveStoreSuite) TestListIgnoresOtherKeys(c *gc.C) {
+	err := s.bucket.Put("20160101-120000.model.json", []byte("{}"), "application/json", s3.Private)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metaList, err := s.store.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metaList, gc.HasLen, 0)
+
+	err = s.store.Remove("20160101-120000.model")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *s3ArchiveStoreSuite) TestInvalidConfig(c *gc.C) {
+	_, err := backups.NewS3ArchiveStore(backups.S3Config{
+		Endpoint: s.server.URL(),
+	})
+	c.Assert(err, gc.ErrorMatches, "missing bucket not valid")
+}
+
+type newArchiveStoresSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&newArchiveStoresSuite{})
+
+func (s *newArchiveStoresSuite) TestNone(c *gc.C) {
+	stores, err := backups.NewArchiveStores(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stores, gc.HasLen, 0)
+}
+
+func (s *newArchiveStoresSuite) TestAll(c *gc.C) {
+	stores, err := backups.NewArchiveStores(controller.Config{
+		controller.BackupStorageDir:  c.MkDir(),
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "juju-backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stores, gc.HasLen, 2)
+	c.Check(stores[0].Name(), gc.Equals, "dir")
+	c.Check(stores[1].Name(), gc.Equals, "s3")
+}
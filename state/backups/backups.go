@@ -37,12 +37,15 @@ package backups
 
 import (
 	"io"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/filestorage"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/controller"
 )
 
 const (
@@ -58,6 +61,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -99,6 +103,10 @@ type Backups interface {
 	// Remove deletes the backup from storage.
 	Remove(id string) error
 
+	// Verify reads the backup archive in full, without restoring it,
+	// and reports whether it could be restored.
+	Verify(id string) (*Verification, error)
+
 	// Restore updates juju's state to the contents of the backup archive,
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
@@ -106,22 +114,152 @@ type Backups interface {
 }
 
 type backups struct {
-	storage filestorage.FileStorage
+	storage   filestorage.FileStorage
+	stores    []ArchiveStore
+	encrypter Encrypter
+	// decryptionKeys holds the keys with which encrypted backups
+	// may be verified and restored: the current key, and any that
+	// it replaced.
+	decryptionKeys []*DecryptionKey
+	// configErr, if set, prevents backups from being created, so
+	// that a misconfiguration never causes an archive to be written
+	// without the encryption the controller asks for.
+	configErr error
 }
 
 // NewBackups creates a new Backups value using the FileStorage provided.
-func NewBackups(stor filestorage.FileStorage) Backups {
+// Backups may also be kept in, and are listed from, any of the supplied
+// archive stores.
+func NewBackups(stor filestorage.FileStorage, stores ...ArchiveStore) Backups {
 	b := backups{
 		storage: stor,
+		stores:  stores,
 	}
 	return &b
 }
 
+// ControllerConfigGetter provides the controller config, and the
+// secrets kept apart from it.
+type ControllerConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+	ControllerSecrets() (controller.Secrets, error)
+}
+
+// NewControllerBackups creates a new Backups value using the
+// FileStorage provided, together with the archive stores and the
+// encryption configured for the controller. Misconfigured archive
+// stores are logged and skipped, so that backups kept in the
+// controller remain available. If the encryption config cannot be
+// read or used, backups may still be listed, fetched and removed,
+// but creating a backup fails.
+func NewControllerBackups(stor filestorage.FileStorage, st ControllerConfigGetter) Backups {
+	b := &backups{storage: stor}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		logger.Errorf("cannot read controller config for backups: %v", err)
+		b.configErr = errors.Annotate(err, "reading controller config")
+		return b
+	}
+	// The S3 credentials are among the controller's secrets.
+	secrets, err := st.ControllerSecrets()
+	if err != nil {
+		logger.Errorf("cannot read controller secrets for backups: %v", err)
+		b.configErr = errors.Annotate(err, "reading controller secrets")
+		return b
+	}
+	cfg = cfg.WithSecrets(secrets)
+	if b.stores, err = NewArchiveStores(cfg); err != nil {
+		logger.Errorf("%v", err)
+	}
+	if b.encrypter, err = NewEncrypter(cfg); err != nil {
+		logger.Errorf("%v", err)
+		b.configErr = errors.Trace(err)
+	}
+	keys := cfg.BackupRetiredEncryptionKeys()
+	if key := cfg.BackupEncryptionKey(); key != "" {
+		keys = append([]string{key}, keys...)
+	}
+	for _, key := range keys {
+		secret, err := ParseSymmetricKey(key)
+		if err != nil {
+			logger.Errorf("cannot use backup decryption key: %v", err)
+			continue
+		}
+		decryptionKey, _ := NewSymmetricDecryptionKey(secret)
+		b.decryptionKeys = append(b.decryptionKeys, decryptionKey)
+	}
+	return b
+}
+
+// decryptionKey returns the key held by the controller with which
+// archives encrypted as described may be decrypted, or nil if it
+// holds none.
+func (b *backups) decryptionKey(info EncryptionInfo) *DecryptionKey {
+	for _, key := range b.decryptionKeys {
+		if key.CheckDecrypts(info) == nil {
+			return key
+		}
+	}
+	return nil
+}
+
+// archiveStore returns the archive store in which backups with the
+// given storage name are kept, or nil if they are kept in the
+// controller.
+func (b *backups) archiveStore(name string) (ArchiveStore, error) {
+	if name == "" || name == ControllerStorage {
+		return nil, nil
+	}
+	for _, store := range b.stores {
+		if store.Name() == name {
+			return store, nil
+		}
+	}
+	return nil, errors.NotFoundf("backup storage %q", name)
+}
+
+// storeArchive stores the archive in the storage named by the
+// metadata, setting the metadata's ID.
+func (b *backups) storeArchive(meta *Metadata, archive io.Reader) error {
+	store, err := b.archiveStore(meta.Storage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if store == nil {
+		meta.Storage = ""
+		return errors.Trace(storeArchive(b.storage, meta, archive))
+	}
+	doc := newStorageMetaDoc(meta)
+	meta.SetID(newStorageID(&doc))
+	return errors.Trace(store.Add(meta, archive))
+}
+
 // Create creates and stores a new juju backup archive and updates the
-// provided metadata.
+// provided metadata. If the metadata names a parent backup, the
+// archive holds only the changes made since the parent was created.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+	if b.configErr != nil {
+		return errors.Annotate(b.configErr, "cannot create backup")
+	}
+	// Check the requested storage before doing any work.
+	if _, err := b.archiveStore(meta.Storage); err != nil {
+		return errors.Trace(err)
+	}
+
+	var parent *Metadata
+	if meta.IsIncremental() {
+		var err error
+		if parent, err = b.metadata(meta.Parent); err != nil {
+			return errors.Annotate(err, "cannot create incremental backup")
+		}
+		if err := checkParent(meta, parent, dbInfo); err != nil {
+			return errors.Annotate(err, "cannot create incremental backup")
+		}
+	}
+
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	meta.OplogPosition = dbInfo.Oplog.Last
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -133,16 +271,33 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 		return errors.Annotate(err, "while preparing the metadata")
 	}
 
+	// The metadata file describes the archive once decrypted, so the
+	// encryption is recorded only in the stored metadata.
+	meta.Encryption = EncryptionInfo{}
+	if b.encrypter != nil {
+		meta.Encryption = b.encrypter.Info()
+	}
+
 	// Create the archive.
 	filesToBackUp, err := getFilesToBackUp("", paths, meta.Origin.Machine)
 	if err != nil {
 		return errors.Annotate(err, "while listing files to back up")
 	}
-	dumper, err := getDBDumper(dbInfo)
+	var dumper DBDumper
+	if parent != nil {
+		dumper, err = getOplogDumper(dbInfo, parent.OplogPosition)
+	} else {
+		dumper, err = getDBDumper(dbInfo)
+	}
 	if err != nil {
 		return errors.Annotate(err, "while preparing for DB dump")
 	}
-	args := createArgs{filesToBackUp, dumper, metadataFile}
+	args := createArgs{
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		encrypter:      b.encrypter,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
@@ -156,7 +311,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	}
 
 	// Store the archive.
-	err = storeArchive(b.storage, meta, result.archiveFile)
+	err = b.storeArchive(meta, result.archiveFile)
 	if err != nil {
 		return errors.Annotate(err, "while storing backup archive")
 	}
@@ -164,10 +319,20 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	return nil
 }
 
+// metadata returns the metadata of the identified backup.
+func (b *backups) metadata(id string) (*Metadata, error) {
+	meta, archive, err := b.Get(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive.Close()
+	return meta, nil
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
-	err := storeArchive(b.storage, meta, archive)
+	err := b.storeArchive(meta, archive)
 	if err != nil {
 		return "", errors.Annotate(err, "while storing backup archive")
 	}
@@ -178,6 +343,15 @@ func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 // Get retrieves the associated metadata and archive file from model storage.
 func (b *backups) Get(id string) (*Metadata, io.ReadCloser, error) {
 	rawmeta, archiveFile, err := b.storage.Get(id)
+	if errors.IsNotFound(err) {
+		for _, store := range b.stores {
+			meta, archiveFile, storeErr := store.Get(id)
+			if errors.IsNotFound(storeErr) {
+				continue
+			}
+			return meta, archiveFile, errors.Trace(storeErr)
+		}
+	}
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -205,10 +379,58 @@ func (b *backups) List() ([]*Metadata, error) {
 		}
 		result[i] = m
 	}
+	for _, store := range b.stores {
+		// An unavailable store should not prevent the remaining
+		// backups from being listed.
+		storeList, err := store.List()
+		if err != nil {
+			logger.Errorf("cannot list backups in %s storage: %v", store.Name(), err)
+			continue
+		}
+		result = append(result, storeList...)
+	}
 	return result, nil
 }
 
-// Remove deletes the backup from storage.
+// Remove deletes the backup from storage. A backup on which incremental
+// backups build cannot be removed until they have been, since they
+// could no longer be restored without it.
 func (b *backups) Remove(id string) error {
-	return errors.Trace(b.storage.Remove(id))
+	metaList, err := b.List()
+	if err != nil {
+		return errors.Annotate(err, "checking for incremental backups")
+	}
+	var children []string
+	for _, meta := range metaList {
+		if meta.Parent == id {
+			children = append(children, meta.ID())
+		}
+	}
+	if len(children) > 0 {
+		return errors.Errorf("backup %q has incremental backups built on it (%s); remove them first", id, strings.Join(children, ", "))
+	}
+
+	err = b.storage.Remove(id)
+	if errors.IsNotFound(err) {
+		for _, store := range b.stores {
+			storeErr := store.Remove(id)
+			if errors.IsNotFound(storeErr) {
+				continue
+			}
+			return errors.Trace(storeErr)
+		}
+	}
+	return errors.Trace(err)
+}
+
+// Verify reads the backup archive in full, without restoring it, and
+// reports whether it could be restored. An encrypted backup's contents
+// are checked only if the controller holds its decryption key.
+func (b *backups) Verify(id string) (*Verification, error) {
+	meta, archive, err := b.Get(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+	return VerifyArchive(archive, meta, b.decryptionKey(meta.Encryption)), nil
 }
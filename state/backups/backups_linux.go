@@ -67,19 +67,21 @@ func ensureMongoService(agentConfig agent.Config) error {
 // * updates existing db entries to make sure they hold no references to
 // old instances
 // * updates config in all agents.
+//
+// An incremental backup is restored by restoring the full backup it
+// builds on, then replaying the oplog of each backup in the chain.
 func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (names.Tag, error) {
-	meta, backupReader, err := b.Get(backupId)
+	chain, workspaces, err := b.openChain(backupId)
 	if err != nil {
-		return nil, errors.Annotatef(err, "could not fetch backup %q", backupId)
-	}
-
-	defer backupReader.Close()
-
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot unpack backup file")
+		return nil, errors.Trace(err)
 	}
-	defer workspace.Close()
+	defer func() {
+		for _, ws := range workspaces {
+			ws.Close()
+		}
+	}()
+	// The files and agent config come from the backup being restored.
+	meta, workspace := chain[len(chain)-1], workspaces[len(workspaces)-1]
 
 	// This might actually work, but we don't have a guarantee so we don't allow it.
 	if meta.Origin.Series != args.NewInstSeries {
@@ -206,9 +208,15 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 	if err != nil {
 		return nil, errors.Annotate(err, "error preparing for restore")
 	}
-	if err := restorer.Restore(workspace.DBDumpDir, oldDialInfo); err != nil {
+	if err := restorer.Restore(workspaces[0].DBDumpDir, oldDialInfo); err != nil {
 		return nil, errors.Annotate(err, "error restoring state from backup")
 	}
+	for i, ws := range workspaces[1:] {
+		logger.Infof("replaying oplog of incremental backup %q", chain[i+1].ID())
+		if err := restorer.ReplayOplog(ws.DBDumpDir); err != nil {
+			return nil, errors.Annotatef(err, "error restoring state from backup %q", chain[i+1].ID())
+		}
+	}
 
 	// Re-start replicaset with the new value for server address
 	logger.Infof("restarting replicaset")
@@ -5,14 +5,17 @@ package backups_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"io/ioutil"
 	"time" // Only used for time types.
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
@@ -55,7 +58,9 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: targets, MongoVersion: mongo.Mongo32wt,
+	}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 	err := s.api.Create(meta, &paths, &dbInfo)
@@ -94,7 +99,13 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	// Run the backup.
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: targets, MongoVersion: mongo.Mongo32wt,
+		Oplog: backups.OplogRange{
+			First: backups.NewOplogPosition(1000, 1),
+			Last:  backups.NewOplogPosition(2000, 1),
+		},
+	}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
@@ -122,6 +133,8 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(meta.Origin.Machine, gc.Equals, "<machine ID>")
 	c.Check(meta.Origin.Hostname, gc.Equals, "<hostname>")
 	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Parent, gc.Equals, "")
+	c.Check(meta.OplogPosition, gc.Equals, backups.NewOplogPosition(2000, 1))
 
 	// Check the file storage.
 	s.Storage.Meta = meta
@@ -188,3 +201,344 @@ func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	c.Assert(meta.ID(), gc.Equals, "spam")
 	c.Assert(meta.Stored(), jc.DeepEquals, stored)
 }
+
+func (s *backupsSuite) TestCreateInArchiveStore(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 20, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	store := backups.NewDirArchiveStore(c.MkDir())
+	api := backups.NewBackups(s.Storage, store)
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Storage = "dir"
+	err := api.Create(meta, &paths, &dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+	c.Check(meta.ID(), gc.Not(gc.Equals), "")
+	c.Check(meta.Stored(), gc.NotNil)
+
+	storedMeta, storedFile, err := api.Get(meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	defer storedFile.Close()
+	c.Check(storedMeta.ID(), gc.Equals, meta.ID())
+	c.Check(storedMeta.Storage, gc.Equals, "dir")
+	data, err := ioutil.ReadAll(storedFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+}
+
+// createIncremental creates an incremental backup of a model whose
+// oplog holds the given range, building on a backup stored with the
+// given oplog position.
+func (s *backupsSuite) createIncremental(c *gc.C, parentPosition backups.OplogPosition, oplog backups.OplogRange) (*backups.Metadata, error) {
+	s.Storage.Error = errors.NotFoundf("backup")
+	store := backups.NewDirArchiveStore(c.MkDir())
+	parent := backupstesting.NewMetadataStarted()
+	parent.SetID("parent")
+	parent.OplogPosition = parentPosition
+	err := store.Add(parent, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	api := backups.NewBackups(s.Storage, store)
+
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 20, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, errors.New("full dump of incremental backup")
+	})
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+		Oplog: oplog,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Storage = "dir"
+	meta.Parent = "parent"
+	return meta, api.Create(meta, &paths, &dbInfo)
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	var since backups.OplogPosition
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, position backups.OplogPosition) (backups.DBDumper, error) {
+		since = position
+		return &fakeDumper{}, nil
+	})
+	meta, err := s.createIncremental(c, backups.NewOplogPosition(1500, 2), backups.OplogRange{
+		First: backups.NewOplogPosition(1000, 1),
+		Last:  backups.NewOplogPosition(2000, 1),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(since, gc.Equals, backups.NewOplogPosition(1500, 2))
+	c.Check(meta.IsIncremental(), jc.IsTrue)
+	c.Check(meta.Parent, gc.Equals, "parent")
+	c.Check(meta.OplogPosition, gc.Equals, backups.NewOplogPosition(2000, 1))
+}
+
+func (s *backupsSuite) TestCreateIncrementalOplogGap(c *gc.C) {
+	_, err := s.createIncremental(c, backups.NewOplogPosition(500, 2), backups.OplogRange{
+		First: backups.NewOplogPosition(1000, 1),
+		Last:  backups.NewOplogPosition(2000, 1),
+	})
+	c.Check(err, gc.ErrorMatches, `cannot create incremental backup: oplog no longer holds the changes made since backup "parent"; create a full backup`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalUnknownOplog(c *gc.C) {
+	_, err := s.createIncremental(c, backups.NewOplogPosition(1500, 2), backups.OplogRange{})
+	c.Check(err, gc.ErrorMatches, `cannot create incremental backup: oplog position not known`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalParentWithoutPosition(c *gc.C) {
+	_, err := s.createIncremental(c, 0, backups.OplogRange{
+		First: backups.NewOplogPosition(1000, 1),
+		Last:  backups.NewOplogPosition(2000, 1),
+	})
+	c.Check(err, gc.ErrorMatches, `cannot create incremental backup: backup "parent" has no oplog position to build on`)
+}
+
+func (s *backupsSuite) TestCreateUnknownStorage(c *gc.C) {
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Storage = "nfs"
+	err := s.api.Create(meta, &paths, &dbInfo)
+
+	c.Check(err, gc.ErrorMatches, `backup storage "nfs" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *backupsSuite) TestListIncludesArchiveStores(c *gc.C) {
+	s.setStored("spam")
+	s.Storage.MetaList = []filestorage.Metadata{s.Storage.Meta}
+	store := backups.NewDirArchiveStore(c.MkDir())
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID("eggs")
+	err := store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	api := backups.NewBackups(s.Storage, store)
+
+	metaList, err := api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metaList, gc.HasLen, 2)
+	c.Check(metaList[0].ID(), gc.Equals, "spam")
+	c.Check(metaList[0].Storage, gc.Equals, "")
+	c.Check(metaList[1].ID(), gc.Equals, "eggs")
+	c.Check(metaList[1].Storage, gc.Equals, "dir")
+}
+
+// emptyStorage is a FakeStorage which lists no backups, whatever
+// error it is set to return.
+type emptyStorage struct {
+	*backupstesting.FakeStorage
+}
+
+func (emptyStorage) List() ([]filestorage.Metadata, error) {
+	return nil, nil
+}
+
+func (s *backupsSuite) TestRemoveFromArchiveStore(c *gc.C) {
+	s.Storage.Error = errors.NotFoundf("backup")
+	store := backups.NewDirArchiveStore(c.MkDir())
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID("eggs")
+	err := store.Add(meta, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	api := backups.NewBackups(emptyStorage{s.Storage}, store)
+
+	err = api.Remove("eggs")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = store.Metadata("eggs")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = api.Remove("eggs")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *backupsSuite) TestRemoveRefusesParent(c *gc.C) {
+	store := backups.NewDirArchiveStore(c.MkDir())
+	parent := backupstesting.NewMetadataStarted()
+	parent.SetID("parent")
+	err := store.Add(parent, bytes.NewBufferString("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	child := backupstesting.NewMetadataStarted()
+	child.SetID("child")
+	child.Parent = "parent"
+	err = store.Add(child, bytes.NewBufferString("<oplog>"))
+	c.Assert(err, jc.ErrorIsNil)
+	api := backups.NewBackups(s.Storage, store)
+
+	err = api.Remove("parent")
+	c.Check(err, gc.ErrorMatches, `backup "parent" has incremental backups built on it \(child\); remove them first`)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"List"})
+	_, err = store.Metadata("parent")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+type fakeControllerConfig struct {
+	cfg        controller.Config
+	secrets    controller.Secrets
+	err        error
+	secretsErr error
+}
+
+func (f fakeControllerConfig) ControllerConfig() (controller.Config, error) {
+	return f.cfg, f.err
+}
+
+func (f fakeControllerConfig) ControllerSecrets() (controller.Secrets, error) {
+	return f.secrets, f.secretsErr
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<encrypted tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	s.setStored("spam")
+	key := bytes.Repeat([]byte{1}, backups.SymmetricKeySize)
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{secrets: controller.Secrets{
+		controller.BackupEncryptionKey: base64.StdEncoding.EncodeToString(key),
+	}})
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	err := api.Create(meta, &paths, &dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	decryptionKey, err := backups.NewSymmetricDecryptionKey(key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Encryption, jc.DeepEquals, backups.EncryptionInfo{
+		Scheme:         backups.EncryptionSymmetric,
+		KeyFingerprint: decryptionKey.Fingerprint(),
+	})
+}
+
+func (s *backupsSuite) TestDecryptionKeysIncludeRetiredKeys(c *gc.C) {
+	current := bytes.Repeat([]byte{1}, backups.SymmetricKeySize)
+	retired := bytes.Repeat([]byte{2}, backups.SymmetricKeySize)
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{
+		secrets: controller.Secrets{
+			controller.BackupEncryptionKey:         base64.StdEncoding.EncodeToString(current),
+			controller.BackupRetiredEncryptionKeys: base64.StdEncoding.EncodeToString(retired),
+		},
+	})
+
+	for _, key := range [][]byte{current, retired} {
+		decryptionKey, err := backups.NewSymmetricDecryptionKey(key)
+		c.Assert(err, jc.ErrorIsNil)
+		info := backups.EncryptionInfo{
+			Scheme:         backups.EncryptionSymmetric,
+			KeyFingerprint: decryptionKey.Fingerprint(),
+		}
+		c.Check(backups.DecryptionKeyFor(api, info), jc.DeepEquals, decryptionKey)
+	}
+
+	// A key the controller never held is not found.
+	c.Check(backups.DecryptionKeyFor(api, backups.EncryptionInfo{
+		Scheme:         backups.EncryptionSymmetric,
+		KeyFingerprint: "sha256:unknown",
+	}), gc.IsNil)
+}
+
+func (s *backupsSuite) TestCreateFailsWithoutControllerConfig(c *gc.C) {
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{err: errors.New("boom")})
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	err := api.Create(meta, &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, "cannot create backup: reading controller config: boom")
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+
+	// Existing backups are still available.
+	s.setStored("spam")
+	_, _, err = api.Get("spam")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *backupsSuite) TestCreateFailsWithoutControllerSecrets(c *gc.C) {
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{secretsErr: errors.New("boom")})
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	err := api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, "cannot create backup: reading controller secrets: boom")
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestS3CredentialsReadFromSecrets(c *gc.C) {
+	cfg := controller.Config{
+		controller.BackupS3Endpoint: "https://s3.example.com",
+		controller.BackupS3Bucket:   "juju-backups",
+	}
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+
+	// Without the credentials the S3 store cannot be opened.
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{cfg: cfg})
+	meta := backupstesting.NewMetadataStarted()
+	meta.Storage = backups.S3Storage
+	err := api.Create(meta, &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, `backup storage "s3" not found`)
+
+	// With them, creating the backup gets as far as dumping the DB.
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, errors.New("no dumper")
+	})
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return nil, nil
+	})
+	api = backups.NewControllerBackups(s.Storage, fakeControllerConfig{
+		cfg: cfg,
+		secrets: controller.Secrets{
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		},
+	})
+	err = api.Create(meta, &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, "while preparing for DB dump: no dumper")
+}
+
+func (s *backupsSuite) TestCreateFailsWithBadEncryptionConfig(c *gc.C) {
+	api := backups.NewControllerBackups(s.Storage, fakeControllerConfig{secrets: controller.Secrets{
+		controller.BackupEncryptionKey: "c2hvcnQ=",
+	}})
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju"), MongoVersion: mongo.Mongo32wt,
+	}
+	err := api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, `cannot create backup: reading backup-encryption-key: symmetric key of 5 bytes \(want 32\) not valid`)
+}
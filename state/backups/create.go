@@ -31,6 +31,8 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	// encrypter, if set, encrypts the archive.
+	encrypter Encrypter
 }
 
 type createResult struct {
@@ -43,7 +45,7 @@ type createResult struct {
 // updates the metadata with the file info.
 func create(args *createArgs) (_ *createResult, err error) {
 	// Prepare the backup builder.
-	builder, err := newBuilder(args.filesToBackUp, args.db)
+	builder, err := newBuilder(args.filesToBackUp, args.db, args.encrypter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -96,6 +98,8 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// encrypter, if set, encrypts the archive file.
+	encrypter Encrypter
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -109,7 +113,7 @@ type builder struct {
 // directories which backup uses as its staging area while building the
 // archive.  It also creates the archive
 // (temp root, tarball root, DB dumpdir), along with any error.
-func newBuilder(filesToBackUp []string, db DBDumper) (b *builder, err error) {
+func newBuilder(filesToBackUp []string, db DBDumper, encrypter Encrypter) (b *builder, err error) {
 	// Create the backups workspace root directory.
 	rootDir, err := ioutil.TempDir("", tempPrefix)
 	if err != nil {
@@ -123,6 +127,7 @@ func newBuilder(filesToBackUp []string, db DBDumper) (b *builder, err error) {
 		filename:      filepath.Join(rootDir, tempFilename),
 		filesToBackUp: filesToBackUp,
 		db:            db,
+		encrypter:     encrypter,
 	}
 	defer func() {
 		if err != nil {
@@ -312,9 +317,24 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// If the archive is encrypted, the hash is of the encrypted file,
+	// for the same reason.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encrypter == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		encrypted, err := b.encrypter.Encrypt(hasher)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypted); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypted.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -4,7 +4,10 @@
 package backups_test
 
 import (
+	"bytes"
+	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 
 	jc "github.com/juju/testing/checkers"
@@ -56,6 +59,45 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, expected := s.createTestFiles(c)
+	key := bytes.Repeat([]byte{1}, backups.SymmetricKeySize)
+	encrypter, err := backups.NewSymmetricEncrypter(key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestEncryptedCreateArgs(testFiles, dumper, metadataFile, encrypter)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+	defer file.Close()
+
+	// The size and checksum are those of the encrypted file.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	decryptionKey, err := backups.NewSymmetricDecryptionKey(key)
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := backups.NewDecryptingReader(file, decryptionKey)
+	c.Assert(err, jc.ErrorIsNil)
+	decryptedFile, err := os.Create(filepath.Join(c.MkDir(), "decrypted.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer decryptedFile.Close()
+	_, err = io.Copy(decryptedFile, decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	resetFile(c, decryptedFile)
+	s.checkArchive(c, decryptedFile, expected)
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var testFiles []string
 	dumper := &TestDBDumper{}
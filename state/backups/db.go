@@ -44,6 +44,10 @@ type DBInfo struct {
 	Targets set.Strings
 	// MongoVersion the version of the running mongo db.
 	MongoVersion mongo.Version
+	// Oplog is the range of entries in the oplog when the backup
+	// starts. It must be set for an incremental backup, and for a
+	// full backup to be the parent of one.
+	Oplog OplogRange
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -250,6 +254,10 @@ var getMongorestorePath = func() (string, error) {
 type DBRestorer interface {
 	// Dump something to dumpDir.
 	Restore(dumpDir string, dialInfo *mgo.DialInfo) error
+
+	// ReplayOplog applies the oplog entries dumped by an incremental
+	// backup to the database restored by Restore.
+	ReplayOplog(dumpDir string) error
 }
 
 type mongoRestorer struct {
@@ -301,6 +309,26 @@ func (md *mongoRestorer24) Restore(dumpDir string, _ *mgo.DialInfo) error {
 	return nil
 }
 
+func (md *mongoRestorer24) ReplayOplog(dumpDir string) error {
+	if err := md.stopMongo(); err != nil {
+		return errors.Annotate(err, "cannot stop mongo to replay oplog")
+	}
+	options := []string{
+		"--journal",
+		"--oplogReplay",
+		"--dbpath", filepath.Join(agent.DefaultPaths.DataDir, "db"),
+		dumpDir,
+	}
+	logger.Infof("replaying oplog with params %v", options)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	if err := md.startMongo(); err != nil {
+		return errors.Annotate(err, "cannot start mongo after replaying oplog")
+	}
+	return nil
+}
+
 // GetDB wraps mgo.Session.DB to ease testing.
 func GetDB(s string, session MongoSession) MongoDB {
 	return session.DB(s)
@@ -513,3 +541,23 @@ func (md *mongoRestorer32) Restore(dumpDir string, dialInfo *mgo.DialInfo) error
 	}
 	return nil
 }
+
+// ReplayOplog relies on Restore having granted the permissions needed
+// to replay the oplog.
+func (md *mongoRestorer32) ReplayOplog(dumpDir string) error {
+	options := []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", md.Addrs[0],
+		"--username", md.Username,
+		"--password", md.Password,
+		"--oplogReplay",
+		"--batchSize", "10",
+		dumpDir,
+	}
+	logger.Infof("replaying oplog from %s", dumpDir)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	return nil
+}
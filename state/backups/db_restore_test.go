@@ -136,6 +136,30 @@ func (s *mongoRestoreSuite) TestRestoreDatabase32(c *gc.C) {
 	c.Assert(mgoSession.cmd, gc.DeepEquals, mgoSessionCmd)
 }
 
+func (s *mongoRestoreSuite) TestReplayOplog32(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) { return "/a/fake/mongorestore", nil })
+	var ranWithArgs []string
+	args := backups.RestorerArgs{
+		DialInfo: &mgo.DialInfo{
+			Username: "fakeUsername",
+			Password: "fakePassword",
+			Addrs:    []string{"127.0.0.1"},
+		},
+		Version: mongo.Mongo32wt,
+		RunCommandFn: func(c string, args ...string) error {
+			ranWithArgs = args
+			return nil
+		},
+	}
+	s.PatchValue(backups.MongoInstalledVersion, func() mongo.Version { return mongo.Mongo32wt })
+	restorer, err := backups.NewDBRestorer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	err = restorer.ReplayOplog("fakePath")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(ranWithArgs, gc.DeepEquals, []string{"--ssl", "--authenticationDatabase", "admin", "--host", "127.0.0.1", "--username", "fakeUsername", "--password", "fakePassword", "--oplogReplay", "--batchSize", "10", "fakePath"})
+}
+
 func (s *mongoRestoreSuite) TestRestoreFailsOnOlderMongo(c *gc.C) {
 	s.PatchValue(backups.GetMongorestorePath, func() (string, error) { return "/a/fake/mongorestore", nil })
 	args := backups.RestorerArgs{
@@ -0,0 +1,532 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// These are the schemes with which backup archives may be encrypted.
+const (
+	// EncryptionSymmetric encrypts each archive with AES-256-GCM,
+	// using a key derived from a secret key held in the controller
+	// config and a random per-archive salt.
+	EncryptionSymmetric = "aes-256-gcm"
+
+	// EncryptionPublicKey encrypts each archive with AES-256-GCM,
+	// using a random per-archive key which is itself encrypted with
+	// an RSA public key held in the controller config. Only the
+	// holder of the matching private key can decrypt the archive.
+	EncryptionPublicKey = "rsa-oaep+aes-256-gcm"
+)
+
+// SymmetricKeySize is the size in bytes of the secret key used for
+// symmetric encryption.
+const SymmetricKeySize = 32
+
+// An encrypted archive starts with encryptedArchiveMagic, followed by
+// a byte identifying the scheme and a length-prefixed field holding
+// the salt or the encrypted archive key. The rest of the archive is a
+// sequence of records, each holding up to encryptedChunkSize bytes of
+// plaintext sealed with AES-GCM. Each record is prefixed with its
+// length, the top bit of which marks the final record, so that a
+// truncated archive is always detected. The record's sequence number
+// and finality are bound into its nonce, and the header is bound
+// into every record as additional data.
+const (
+	encryptedArchiveMagic = "JUJUBACKUPCRYPT1"
+	encryptedChunkSize    = 64 * 1024
+	finalRecordFlag       = 1 << 31
+
+	schemeSymmetricByte = 1
+	schemePublicKeyByte = 2
+)
+
+// publicKeyLabel is the OAEP label used when encrypting archive keys.
+var publicKeyLabel = []byte("juju-backup")
+
+// EncryptionInfo records how a backup archive was encrypted.
+type EncryptionInfo struct {
+	// Scheme is the encryption scheme. It is empty if the archive
+	// is not encrypted.
+	Scheme string
+
+	// KeyFingerprint identifies the key needed to decrypt the
+	// archive, without revealing it.
+	KeyFingerprint string
+}
+
+// IsEncrypted reports whether the archive is encrypted.
+func (info EncryptionInfo) IsEncrypted() bool {
+	return info.Scheme != ""
+}
+
+// Encrypter encrypts backup archives as they are written.
+type Encrypter interface {
+	// Info describes the encryption applied to archives.
+	Info() EncryptionInfo
+
+	// Encrypt returns a writer which encrypts everything written to
+	// it into w. The writer must be closed to complete the archive;
+	// closing it does not close w.
+	Encrypt(w io.Writer) (io.WriteCloser, error)
+}
+
+// NewEncrypter returns the Encrypter configured for the controller,
+// or nil if backups are not to be encrypted.
+func NewEncrypter(cfg controller.Config) (Encrypter, error) {
+	key, publicKey := cfg.BackupEncryptionKey(), cfg.BackupEncryptionPublicKey()
+	switch {
+	case key != "" && publicKey != "":
+		return nil, errors.Errorf("cannot encrypt backups with both %s and %s", controller.BackupEncryptionKey, controller.BackupEncryptionPublicKey)
+	case key != "":
+		secret, err := ParseSymmetricKey(key)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", controller.BackupEncryptionKey)
+		}
+		return NewSymmetricEncrypter(secret)
+	case publicKey != "":
+		pub, err := ParsePublicKey([]byte(publicKey))
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", controller.BackupEncryptionPublicKey)
+		}
+		return NewPublicKeyEncrypter(pub), nil
+	}
+	return nil, nil
+}
+
+// ParseSymmetricKey decodes a base64-encoded symmetric key.
+func ParseSymmetricKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.NotValidf("symmetric key (%v)", err)
+	}
+	if len(key) != SymmetricKeySize {
+		return nil, errors.NotValidf("symmetric key of %d bytes (want %d)", len(key), SymmetricKeySize)
+	}
+	return key, nil
+}
+
+// ParsePublicKey decodes a PEM-encoded RSA public key.
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.NotValidf("public key (no PEM data)")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.NotValidf("public key (%v)", err)
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.NotValidf("public key of type %T (want RSA)", key)
+	}
+	return pub, nil
+}
+
+func symmetricFingerprint(key []byte) string {
+	return fingerprint(key)
+}
+
+func publicKeyFingerprint(pub *rsa.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		// Marshalling an RSA public key cannot fail.
+		panic(err)
+	}
+	return fingerprint(der)
+}
+
+func fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("sha256:%x", sum)
+}
+
+// NewSymmetricEncrypter returns an Encrypter which encrypts archives
+// with keys derived from the given secret key.
+func NewSymmetricEncrypter(key []byte) (Encrypter, error) {
+	if len(key) != SymmetricKeySize {
+		return nil, errors.NotValidf("symmetric key of %d bytes (want %d)", len(key), SymmetricKeySize)
+	}
+	return &symmetricEncrypter{key: key}, nil
+}
+
+type symmetricEncrypter struct {
+	key []byte
+}
+
+// Info is part of the Encrypter interface.
+func (e *symmetricEncrypter) Info() EncryptionInfo {
+	return EncryptionInfo{
+		Scheme:         EncryptionSymmetric,
+		KeyFingerprint: symmetricFingerprint(e.key),
+	}
+}
+
+// Encrypt is part of the Encrypter interface.
+func (e *symmetricEncrypter) Encrypt(w io.Writer) (io.WriteCloser, error) {
+	salt := make([]byte, SymmetricKeySize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Annotate(err, "generating salt")
+	}
+	return newEncryptingWriter(w, schemeSymmetricByte, salt, deriveArchiveKey(e.key, salt))
+}
+
+// deriveArchiveKey returns the key with which an archive encrypted
+// with the symmetric scheme is sealed.
+func deriveArchiveKey(key, salt []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(salt)
+	return mac.Sum(nil)
+}
+
+// NewPublicKeyEncrypter returns an Encrypter which encrypts archives
+// such that only the holder of the private key matching pub can
+// decrypt them.
+func NewPublicKeyEncrypter(pub *rsa.PublicKey) Encrypter {
+	return &publicKeyEncrypter{pub: pub}
+}
+
+type publicKeyEncrypter struct {
+	pub *rsa.PublicKey
+}
+
+// Info is part of the Encrypter interface.
+func (e *publicKeyEncrypter) Info() EncryptionInfo {
+	return EncryptionInfo{
+		Scheme:         EncryptionPublicKey,
+		KeyFingerprint: publicKeyFingerprint(e.pub),
+	}
+}
+
+// Encrypt is part of the Encrypter interface.
+func (e *publicKeyEncrypter) Encrypt(w io.Writer) (io.WriteCloser, error) {
+	archiveKey := make([]byte, SymmetricKeySize)
+	if _, err := io.ReadFull(rand.Reader, archiveKey); err != nil {
+		return nil, errors.Annotate(err, "generating archive key")
+	}
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, e.pub, archiveKey, publicKeyLabel)
+	if err != nil {
+		return nil, errors.Annotate(err, "encrypting archive key")
+	}
+	return newEncryptingWriter(w, schemePublicKeyByte, wrapped, archiveKey)
+}
+
+// encryptingWriter seals what is written to it into records.
+type encryptingWriter struct {
+	out     io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	seq     uint64
+	buf     []byte
+	started bool
+	closed  bool
+}
+
+func newEncryptingWriter(out io.Writer, scheme byte, material, archiveKey []byte) (*encryptingWriter, error) {
+	aead, err := newArchiveAEAD(archiveKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := make([]byte, 0, len(encryptedArchiveMagic)+3+len(material))
+	header = append(header, encryptedArchiveMagic...)
+	header = append(header, scheme, 0, 0)
+	binary.BigEndian.PutUint16(header[len(header)-2:], uint16(len(material)))
+	header = append(header, material...)
+	return &encryptingWriter{
+		out:    out,
+		aead:   aead,
+		header: header,
+	}, nil
+}
+
+func newArchiveAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return aead, nil
+}
+
+// recordNonce returns the nonce for the record with the given sequence
+// number.
+func recordNonce(aead cipher.AEAD, seq uint64, final bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce, seq)
+	if final {
+		nonce[8] = 1
+	}
+	return nonce
+}
+
+// Write implements io.Writer.
+func (w *encryptingWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to closed encrypting writer")
+	}
+	w.buf = append(w.buf, p...)
+	// Always hold back at least one byte, so that the final record
+	// is never empty unless the whole archive is.
+	for len(w.buf) > encryptedChunkSize {
+		if err := w.writeRecord(w.buf[:encryptedChunkSize], false); err != nil {
+			return 0, errors.Trace(err)
+		}
+		w.buf = w.buf[encryptedChunkSize:]
+	}
+	return len(p), nil
+}
+
+// Close writes the final record. It does not close the underlying
+// writer.
+func (w *encryptingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.writeRecord(w.buf, true)
+	w.buf = nil
+	return errors.Trace(err)
+}
+
+func (w *encryptingWriter) writeRecord(plaintext []byte, final bool) error {
+	if !w.started {
+		if _, err := w.out.Write(w.header); err != nil {
+			return errors.Trace(err)
+		}
+		w.started = true
+	}
+	sealed := w.aead.Seal(nil, recordNonce(w.aead, w.seq, final), plaintext, w.header)
+	w.seq++
+	length := uint32(len(sealed))
+	if final {
+		length |= finalRecordFlag
+	}
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], length)
+	if _, err := w.out.Write(prefix[:]); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := w.out.Write(sealed)
+	return errors.Trace(err)
+}
+
+// DecryptionKey is a key with which encrypted backup archives may be
+// decrypted: either the symmetric key or the private key matching the
+// public key with which they were encrypted.
+type DecryptionKey struct {
+	symmetric []byte
+	private   *rsa.PrivateKey
+}
+
+// NewSymmetricDecryptionKey returns a DecryptionKey for archives
+// encrypted with the given symmetric key.
+func NewSymmetricDecryptionKey(key []byte) (*DecryptionKey, error) {
+	if len(key) != SymmetricKeySize {
+		return nil, errors.NotValidf("symmetric key of %d bytes (want %d)", len(key), SymmetricKeySize)
+	}
+	return &DecryptionKey{symmetric: key}, nil
+}
+
+// NewPrivateDecryptionKey returns a DecryptionKey for archives
+// encrypted with the public half of the given key.
+func NewPrivateDecryptionKey(key *rsa.PrivateKey) *DecryptionKey {
+	return &DecryptionKey{private: key}
+}
+
+// ParseDecryptionKey reads a decryption key, as held in a key file.
+// This is either a PEM-encoded RSA private key or a base64-encoded
+// symmetric key.
+func ParseDecryptionKey(data []byte) (*DecryptionKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return NewPrivateDecryptionKey(key), nil
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.NotValidf("private key (%v)", err)
+		}
+		private, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.NotValidf("private key of type %T (want RSA)", key)
+		}
+		return NewPrivateDecryptionKey(private), nil
+	}
+	key, err := ParseSymmetricKey(string(data))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSymmetricDecryptionKey(key)
+}
+
+// Scheme returns the encryption scheme of the archives which the key
+// decrypts.
+func (k *DecryptionKey) Scheme() string {
+	if k.private != nil {
+		return EncryptionPublicKey
+	}
+	return EncryptionSymmetric
+}
+
+// Fingerprint returns the fingerprint recorded in the metadata of
+// archives which the key decrypts.
+func (k *DecryptionKey) Fingerprint() string {
+	if k.private != nil {
+		return publicKeyFingerprint(&k.private.PublicKey)
+	}
+	return symmetricFingerprint(k.symmetric)
+}
+
+// CheckDecrypts returns an error if the key cannot decrypt archives
+// encrypted as described.
+func (k *DecryptionKey) CheckDecrypts(info EncryptionInfo) error {
+	if info.Scheme != k.Scheme() {
+		return errors.Errorf("backup is encrypted with %q, not %q", info.Scheme, k.Scheme())
+	}
+	if info.KeyFingerprint != "" && info.KeyFingerprint != k.Fingerprint() {
+		return errors.Errorf("backup is encrypted with key %s, not %s", info.KeyFingerprint, k.Fingerprint())
+	}
+	return nil
+}
+
+func (k *DecryptionKey) archiveKey(scheme byte, material []byte) ([]byte, error) {
+	switch scheme {
+	case schemeSymmetricByte:
+		if k.symmetric == nil {
+			return nil, errors.New("archive is encrypted with a symmetric key, not a public key")
+		}
+		return deriveArchiveKey(k.symmetric, material), nil
+	case schemePublicKeyByte:
+		if k.private == nil {
+			return nil, errors.New("archive is encrypted with a public key, not a symmetric key")
+		}
+		archiveKey, err := rsa.DecryptOAEP(sha256.New(), nil, k.private, material, publicKeyLabel)
+		if err != nil {
+			return nil, errors.New("archive key cannot be decrypted with this private key")
+		}
+		return archiveKey, nil
+	}
+	return nil, errors.NotValidf("encryption scheme %d", scheme)
+}
+
+// IsEncryptedArchive reports whether the data, which must be at least
+// the first 16 bytes of a backup archive, starts an encrypted archive.
+func IsEncryptedArchive(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedArchiveMagic))
+}
+
+// NewDecryptingReader returns a reader which decrypts the encrypted
+// archive read from r. Reads fail if the archive has been truncated or
+// tampered with.
+func NewDecryptingReader(r io.Reader, key *DecryptionKey) (io.Reader, error) {
+	header := make([]byte, len(encryptedArchiveMagic)+3)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Annotate(err, "reading encrypted archive header")
+	}
+	if !IsEncryptedArchive(header) {
+		return nil, errors.New("backup archive is not encrypted")
+	}
+	scheme := header[len(encryptedArchiveMagic)]
+	material := make([]byte, binary.BigEndian.Uint16(header[len(header)-2:]))
+	if _, err := io.ReadFull(r, material); err != nil {
+		return nil, errors.Annotate(err, "reading encrypted archive header")
+	}
+	archiveKey, err := key.archiveKey(scheme, material)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := newArchiveAEAD(archiveKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &decryptingReader{
+		in:     r,
+		aead:   aead,
+		header: append(header, material...),
+	}, nil
+}
+
+type decryptingReader struct {
+	in     io.Reader
+	aead   cipher.AEAD
+	header []byte
+	seq    uint64
+	buf    []byte
+	done   bool
+	err    error
+}
+
+// Read implements io.Reader.
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.readRecord()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *decryptingReader) readRecord() error {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r.in, prefix[:]); err != nil {
+		return truncatedErr(err)
+	}
+	length := binary.BigEndian.Uint32(prefix[:])
+	final := length&finalRecordFlag != 0
+	length &^= finalRecordFlag
+	if length > encryptedChunkSize+uint32(r.aead.Overhead()) {
+		return errors.New("encrypted backup archive corrupted")
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(r.in, sealed); err != nil {
+		return truncatedErr(err)
+	}
+	plaintext, err := r.aead.Open(nil, recordNonce(r.aead, r.seq, final), sealed, r.header)
+	if err != nil {
+		return errors.New("encrypted backup archive corrupted or tampered with")
+	}
+	r.seq++
+	if final {
+		var extra [1]byte
+		if n, _ := io.ReadFull(r.in, extra[:]); n != 0 {
+			return errors.New("encrypted backup archive has trailing data")
+		}
+		r.done = true
+	}
+	r.buf = plaintext
+	return nil
+}
+
+func truncatedErr(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("encrypted backup archive truncated")
+	}
+	return errors.Trace(err)
+}
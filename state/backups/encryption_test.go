@@ -0,0 +1,230 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	testing.BaseSuite
+
+	key        []byte
+	privateKey *rsa.PrivateKey
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func (s *encryptionSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.key = bytes.Repeat([]byte{7}, backups.SymmetricKeySize)
+	var err error
+	s.privateKey, err = rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *encryptionSuite) symmetricKey(c *gc.C) *backups.DecryptionKey {
+	key, err := backups.NewSymmetricDecryptionKey(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	return key
+}
+
+func encrypt(c *gc.C, encrypter backups.Encrypter, plaintext []byte) []byte {
+	var buf bytes.Buffer
+	w, err := encrypter.Encrypt(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(plaintext)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func decrypt(encrypted []byte, key *backups.DecryptionKey) ([]byte, error) {
+	r, err := backups.NewDecryptingReader(bytes.NewReader(encrypted), key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func (s *encryptionSuite) TestSymmetricRoundTrip(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, size := range []int{
+		0, 1, backups.EncryptedChunkSize, backups.EncryptedChunkSize + 1, 3*backups.EncryptedChunkSize + 7,
+	} {
+		c.Logf("size %d", size)
+		plaintext := make([]byte, size)
+		_, err := io.ReadFull(rand.Reader, plaintext)
+		c.Assert(err, jc.ErrorIsNil)
+
+		encrypted := encrypt(c, encrypter, plaintext)
+		c.Check(backups.IsEncryptedArchive(encrypted), jc.IsTrue)
+		decrypted, err := decrypt(encrypted, s.symmetricKey(c))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(bytes.Equal(decrypted, plaintext), jc.IsTrue)
+	}
+}
+
+func (s *encryptionSuite) TestSymmetricSaltsEachArchive(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	first := encrypt(c, encrypter, []byte("backup"))
+	second := encrypt(c, encrypter, []byte("backup"))
+	c.Check(bytes.Equal(first, second), jc.IsFalse)
+}
+
+func (s *encryptionSuite) TestPublicKeyRoundTrip(c *gc.C) {
+	encrypter := backups.NewPublicKeyEncrypter(&s.privateKey.PublicKey)
+	plaintext := bytes.Repeat([]byte("backup"), backups.EncryptedChunkSize)
+	encrypted := encrypt(c, encrypter, plaintext)
+
+	decrypted, err := decrypt(encrypted, backups.NewPrivateDecryptionKey(s.privateKey))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bytes.Equal(decrypted, plaintext), jc.IsTrue)
+}
+
+func (s *encryptionSuite) TestInfo(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	info := encrypter.Info()
+	c.Check(info.Scheme, gc.Equals, backups.EncryptionSymmetric)
+	c.Check(info.KeyFingerprint, gc.Matches, "sha256:[0-9a-f]{64}")
+	c.Check(info.KeyFingerprint, gc.Equals, s.symmetricKey(c).Fingerprint())
+	c.Check(s.symmetricKey(c).CheckDecrypts(info), jc.ErrorIsNil)
+
+	privateKey := backups.NewPrivateDecryptionKey(s.privateKey)
+	info = backups.NewPublicKeyEncrypter(&s.privateKey.PublicKey).Info()
+	c.Check(info.Scheme, gc.Equals, backups.EncryptionPublicKey)
+	c.Check(info.KeyFingerprint, gc.Equals, privateKey.Fingerprint())
+	c.Check(privateKey.CheckDecrypts(info), jc.ErrorIsNil)
+
+	err = s.symmetricKey(c).CheckDecrypts(info)
+	c.Check(err, gc.ErrorMatches, `backup is encrypted with "rsa-oaep\+aes-256-gcm", not "aes-256-gcm"`)
+}
+
+func (s *encryptionSuite) TestWrongKey(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := encrypt(c, encrypter, []byte("backup"))
+
+	otherKey, err := backups.NewSymmetricDecryptionKey(bytes.Repeat([]byte{8}, backups.SymmetricKeySize))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = decrypt(encrypted, otherKey)
+	c.Check(err, gc.ErrorMatches, "encrypted backup archive corrupted or tampered with")
+
+	_, err = decrypt(encrypted, backups.NewPrivateDecryptionKey(s.privateKey))
+	c.Check(err, gc.ErrorMatches, "archive is encrypted with a symmetric key, not a public key")
+}
+
+func (s *encryptionSuite) TestWrongPrivateKey(c *gc.C) {
+	encrypter := backups.NewPublicKeyEncrypter(&s.privateKey.PublicKey)
+	encrypted := encrypt(c, encrypter, []byte("backup"))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = decrypt(encrypted, backups.NewPrivateDecryptionKey(otherKey))
+	c.Check(err, gc.ErrorMatches, "archive key cannot be decrypted with this private key")
+}
+
+func (s *encryptionSuite) TestTampered(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := encrypt(c, encrypter, []byte("backup"))
+	encrypted[len(encrypted)-1] ^= 1
+
+	_, err = decrypt(encrypted, s.symmetricKey(c))
+	c.Check(err, gc.ErrorMatches, "encrypted backup archive corrupted or tampered with")
+}
+
+func (s *encryptionSuite) TestTruncated(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	plaintext := make([]byte, backups.EncryptedChunkSize+1)
+	encrypted := encrypt(c, encrypter, plaintext)
+
+	_, err = decrypt(encrypted[:len(encrypted)-1], s.symmetricKey(c))
+	c.Check(err, gc.ErrorMatches, "encrypted backup archive truncated")
+
+	// Dropping the whole final record is detected too. It holds a
+	// single byte of plaintext, a 16 byte tag and a 4 byte prefix.
+	_, err = decrypt(encrypted[:len(encrypted)-21], s.symmetricKey(c))
+	c.Check(err, gc.ErrorMatches, "encrypted backup archive truncated")
+}
+
+func (s *encryptionSuite) TestTrailingData(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := encrypt(c, encrypter, []byte("backup"))
+
+	_, err = decrypt(append(encrypted, 0), s.symmetricKey(c))
+	c.Check(err, gc.ErrorMatches, "encrypted backup archive has trailing data")
+}
+
+func (s *encryptionSuite) TestNotEncrypted(c *gc.C) {
+	_, err := decrypt(bytes.Repeat([]byte{0}, 100), s.symmetricKey(c))
+	c.Check(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
+
+func (s *encryptionSuite) TestParseDecryptionKey(c *gc.C) {
+	key, err := backups.ParseDecryptionKey([]byte(base64.StdEncoding.EncodeToString(s.key) + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key.Scheme(), gc.Equals, backups.EncryptionSymmetric)
+	c.Check(key.Fingerprint(), gc.Equals, s.symmetricKey(c).Fingerprint())
+
+	data := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(s.privateKey),
+	})
+	key, err = backups.ParseDecryptionKey(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key.Scheme(), gc.Equals, backups.EncryptionPublicKey)
+	c.Check(key.Fingerprint(), gc.Equals, backups.NewPrivateDecryptionKey(s.privateKey).Fingerprint())
+
+	_, err = backups.ParseDecryptionKey([]byte("c2hvcnQ="))
+	c.Check(err, gc.ErrorMatches, `symmetric key of 5 bytes \(want 32\) not valid`)
+}
+
+func (s *encryptionSuite) TestNewEncrypter(c *gc.C) {
+	encrypter, err := backups.NewEncrypter(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypter, gc.IsNil)
+
+	encrypter, err = backups.NewEncrypter(controller.Config{
+		controller.BackupEncryptionKey: base64.StdEncoding.EncodeToString(s.key),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypter.Info().Scheme, gc.Equals, backups.EncryptionSymmetric)
+
+	der, err := x509.MarshalPKIXPublicKey(&s.privateKey.PublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	encrypter, err = backups.NewEncrypter(controller.Config{
+		controller.BackupEncryptionPublicKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: der,
+		})),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypter.Info().Scheme, gc.Equals, backups.EncryptionPublicKey)
+
+	_, err = backups.NewEncrypter(controller.Config{
+		controller.BackupEncryptionKey: "not base64!",
+	})
+	c.Check(err, gc.ErrorMatches, `reading backup-encryption-key: symmetric key \(.*\) not valid`)
+}
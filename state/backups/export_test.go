@@ -22,6 +22,7 @@ var (
 
 	TestGetFilesToBackUp  = &getFilesToBackUp
 	GetDBDumper           = &getDBDumper
+	GetOplogDumper        = &getOplogDumper
 	RunCreate             = &runCreate
 	FinishMeta            = &finishMeta
 	StoreArchiveRef       = &storeArchive
@@ -32,6 +33,28 @@ var (
 	MongoInstalledVersion = &mongoInstalledVersion
 )
 
+const EncryptedChunkSize = encryptedChunkSize
+
+// RestoreChain returns the IDs of the backups needed to restore the
+// given backup, fetching parents with the supplied function.
+func RestoreChain(meta *Metadata, getParent func(string) (*Metadata, error)) ([]string, error) {
+	chain, err := restoreChain(meta, getParent)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(chain))
+	for i, meta := range chain {
+		ids[i] = meta.ID()
+	}
+	return ids, nil
+}
+
+// DecryptionKeyFor returns the key the backups hold for archives
+// encrypted as described, or nil.
+func DecryptionKeyFor(b Backups, info EncryptionInfo) *DecryptionKey {
+	return b.(*backups).decryptionKey(info)
+}
+
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
 
@@ -104,6 +127,14 @@ func NewTestCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader) *cr
 	return &args
 }
 
+// NewTestEncryptedCreateArgs builds a new args value for create()
+// calls which encrypt the archive.
+func NewTestEncryptedCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader, encrypter Encrypter) *createArgs {
+	args := NewTestCreateArgs(filesToBackUp, db, metar)
+	args.encrypter = encrypter
+	return args
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) ([]string, DBDumper) {
 	return args.filesToBackUp, args.db
@@ -0,0 +1,304 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// An incremental backup holds, instead of a full dump of the
+// databases, the oplog entries written since the backup it builds on
+// (its parent). Restoring it means restoring the full backup at the
+// root of its chain of parents and then replaying the oplog of each
+// incremental backup in the chain, oldest first.
+
+// OplogPosition identifies an entry in the mongo oplog by its
+// timestamp: the high 32 bits hold the seconds since the epoch and the
+// low 32 bits an ordinal distinguishing entries within a second.
+type OplogPosition int64
+
+// NewOplogPosition returns the position with the given time and
+// ordinal.
+func NewOplogPosition(seconds, ordinal uint32) OplogPosition {
+	return OplogPosition(int64(seconds)<<32 | int64(ordinal))
+}
+
+// IsZero reports whether the position is unknown.
+func (p OplogPosition) IsZero() bool {
+	return p == 0
+}
+
+// Seconds returns the time of the entry, in seconds since the epoch.
+func (p OplogPosition) Seconds() uint32 {
+	return uint32(p >> 32)
+}
+
+// Ordinal returns the ordinal of the entry within its second.
+func (p OplogPosition) Ordinal() uint32 {
+	return uint32(p)
+}
+
+// String returns the position in the form used by the mongo shell.
+func (p OplogPosition) String() string {
+	return fmt.Sprintf("Timestamp(%d, %d)", p.Seconds(), p.Ordinal())
+}
+
+// queryJSON returns the position as mongo extended JSON.
+func (p OplogPosition) queryJSON() string {
+	return fmt.Sprintf(`{"$timestamp": {"t": %d, "i": %d}}`, p.Seconds(), p.Ordinal())
+}
+
+// OplogRange holds the positions of the oldest and newest entries in
+// the oplog.
+type OplogRange struct {
+	// First is the position of the oldest entry still in the oplog.
+	First OplogPosition
+	// Last is the position of the newest entry in the oplog.
+	Last OplogPosition
+}
+
+// Covers reports whether the oplog holds every entry written after
+// the given position.
+func (r OplogRange) Covers(p OplogPosition) bool {
+	return !r.First.IsZero() && r.First <= p && p <= r.Last
+}
+
+// ReadOplogRange returns the range of entries currently held in the
+// replica set oplog.
+func ReadOplogRange(session *mgo.Session) (OplogRange, error) {
+	oplog := session.DB("local").C("oplog.rs")
+	var entry struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+	}
+	var result OplogRange
+	query := oplog.Find(nil).Select(bson.M{"ts": 1})
+	if err := query.Sort("$natural").One(&entry); err != nil {
+		return OplogRange{}, errors.Annotate(err, "reading oldest oplog entry")
+	}
+	result.First = OplogPosition(entry.Timestamp)
+	if err := query.Sort("-$natural").One(&entry); err != nil {
+		return OplogRange{}, errors.Annotate(err, "reading newest oplog entry")
+	}
+	result.Last = OplogPosition(entry.Timestamp)
+	return result, nil
+}
+
+// IsIncremental reports whether the backup builds on another.
+func (m *Metadata) IsIncremental() bool {
+	return m.Parent != ""
+}
+
+// LatestParent returns the most recently started backup of the given
+// model on which an incremental backup can build, or nil if there is
+// none.
+func LatestParent(metaList []*Metadata, model string) *Metadata {
+	var latest *Metadata
+	for _, meta := range metaList {
+		if meta.Origin.Model != model || meta.OplogPosition.IsZero() {
+			continue
+		}
+		if latest == nil || meta.Started.After(latest.Started) {
+			latest = meta
+		}
+	}
+	return latest
+}
+
+// checkParent returns an error if an incremental backup of the given
+// database cannot build on the parent.
+func checkParent(meta, parent *Metadata, dbInfo *DBInfo) error {
+	if parent.Origin.Model != meta.Origin.Model {
+		return errors.Errorf("backup %q is of model %q, not %q", parent.ID(), parent.Origin.Model, meta.Origin.Model)
+	}
+	if parent.OplogPosition.IsZero() {
+		return errors.Errorf("backup %q has no oplog position to build on", parent.ID())
+	}
+	if dbInfo.Oplog.Last.IsZero() {
+		return errors.New("oplog position not known")
+	}
+	if !dbInfo.Oplog.Covers(parent.OplogPosition) {
+		return errors.Errorf("oplog no longer holds the changes made since backup %q; create a full backup", parent.ID())
+	}
+	return nil
+}
+
+// restoreChain returns the metadata of the backups needed to restore
+// the given backup, starting with the full backup at the root of its
+// chain of parents and ending with the backup itself.
+func restoreChain(meta *Metadata, getParent func(id string) (*Metadata, error)) ([]*Metadata, error) {
+	chain := []*Metadata{meta}
+	seen := set.NewStrings(meta.ID())
+	for meta.IsIncremental() {
+		if seen.Contains(meta.Parent) {
+			return nil, errors.Errorf("backup %q is its own ancestor", meta.Parent)
+		}
+		seen.Add(meta.Parent)
+		parent, err := getParent(meta.Parent)
+		if errors.IsNotFound(err) {
+			return nil, errors.Errorf("backup %q builds on backup %q, which is missing", meta.ID(), meta.Parent)
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "fetching backup %q", meta.Parent)
+		}
+		if parent.Origin.Model != meta.Origin.Model {
+			return nil, errors.Errorf("backup %q builds on backup %q of another model", meta.ID(), parent.ID())
+		}
+		if parent.OplogPosition >= meta.OplogPosition {
+			return nil, errors.Errorf("backup %q does not follow its parent %q", meta.ID(), parent.ID())
+		}
+		chain = append([]*Metadata{parent}, chain...)
+		meta = parent
+	}
+	return chain, nil
+}
+
+// openWorkspace fetches and unpacks the identified backup, decrypting
+// it if need be.
+func (b *backups) openWorkspace(id string) (*Metadata, *ArchiveWorkspace, error) {
+	meta, backupReader, err := b.Get(id)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "could not fetch backup %q", id)
+	}
+	defer backupReader.Close()
+
+	var archive io.Reader = backupReader
+	if meta.Encryption.IsEncrypted() {
+		key := b.decryptionKey(meta.Encryption)
+		if key == nil {
+			return nil, nil, errors.Errorf("backup %q is encrypted with %s key %s, which the controller does not hold; download it and restore from the file with its decryption key", id, meta.Encryption.Scheme, meta.Encryption.KeyFingerprint)
+		}
+		if archive, err = NewDecryptingReader(backupReader, key); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot decrypt backup file")
+		}
+	}
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot unpack backup file")
+	}
+	return meta, workspace, nil
+}
+
+// openChain unpacks the backups needed to restore the identified
+// backup, in the order given by restoreChain. All of them are
+// unpacked before anything is restored, so that a missing or damaged
+// backup is found while the controller is still untouched.
+func (b *backups) openChain(id string) (_ []*Metadata, _ []*ArchiveWorkspace, err error) {
+	var workspaces []*ArchiveWorkspace
+	defer func() {
+		if err != nil {
+			for _, ws := range workspaces {
+				ws.Close()
+			}
+		}
+	}()
+
+	meta, workspace, err := b.openWorkspace(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	workspaces = append(workspaces, workspace)
+	byID := map[string]*ArchiveWorkspace{id: workspace}
+	chain, err := restoreChain(meta, func(parentID string) (*Metadata, error) {
+		parent, workspace, err := b.openWorkspace(parentID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		workspaces = append(workspaces, workspace)
+		byID[parentID] = workspace
+		return parent, nil
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ordered := make([]*ArchiveWorkspace, len(chain))
+	for i, meta := range chain {
+		ordered[i] = byID[meta.ID()]
+	}
+	return chain, ordered, nil
+}
+
+type mongoOplogDumper struct {
+	*DBInfo
+	// binPath is the path to the dump executable.
+	binPath string
+	// since is the position after which entries are dumped.
+	since OplogPosition
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping
+// the oplog entries written after the given position, up to the end
+// of the oplog range recorded in the DBInfo.
+func NewOplogDumper(info *DBInfo, since OplogPosition) (DBDumper, error) {
+	mongodumpPath, err := getMongodumpPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongodump not available")
+	}
+
+	dumper := mongoOplogDumper{
+		DBInfo:  info,
+		binPath: mongodumpPath,
+		since:   since,
+	}
+	return &dumper, nil
+}
+
+// namespaceJSON returns, as mongo extended JSON, a regular expression
+// matching the namespaces of the databases held by a full backup, so
+// that only changes to those databases are dumped. The oplog also
+// records changes to databases that are never restored, such as the
+// one holding the backups themselves, and replaying those would undo
+// or duplicate work done since.
+func (md *mongoOplogDumper) namespaceJSON() string {
+	var names []string
+	for _, name := range md.Targets.SortedValues() {
+		if name != "local" {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+	pattern, _ := json.Marshal("^(" + strings.Join(names, "|") + `)\.`)
+	return string(pattern)
+}
+
+func (md *mongoOplogDumper) options(dumpDir string) []string {
+	query := fmt.Sprintf(`{"ts": {"$gt": %s, "$lte": %s}, "ns": {"$regex": %s}}`,
+		md.since.queryJSON(), md.Oplog.Last.queryJSON(), md.namespaceJSON())
+	options := []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", md.Address,
+		"--username", md.Username,
+		"--password", md.Password,
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+	}
+	return options
+}
+
+// Dump dumps the oplog entries into the file that mongorestore
+// replays, oplog.bson at the root of the dump directory.
+func (md *mongoOplogDumper) Dump(baseDumpDir string) error {
+	options := md.options(baseDumpDir)
+	if err := runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+	localDir := filepath.Join(baseDumpDir, "local")
+	err := os.Rename(filepath.Join(localDir, "oplog.rs.bson"), filepath.Join(baseDumpDir, "oplog.bson"))
+	if err != nil {
+		return errors.Annotate(err, "moving oplog dump")
+	}
+	return errors.Trace(os.RemoveAll(localDir))
+}
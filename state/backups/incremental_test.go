@@ -0,0 +1,186 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type incrementalSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&incrementalSuite{})
+
+func (s *incrementalSuite) TestOplogPosition(c *gc.C) {
+	p := backups.NewOplogPosition(1476784800, 3)
+	c.Check(p.Seconds(), gc.Equals, uint32(1476784800))
+	c.Check(p.Ordinal(), gc.Equals, uint32(3))
+	c.Check(p.String(), gc.Equals, "Timestamp(1476784800, 3)")
+	c.Check(p.IsZero(), jc.IsFalse)
+	c.Check(backups.OplogPosition(0).IsZero(), jc.IsTrue)
+	c.Check(backups.NewOplogPosition(1, 0) > backups.NewOplogPosition(0, 100), jc.IsTrue)
+}
+
+func (s *incrementalSuite) TestOplogRangeCovers(c *gc.C) {
+	r := backups.OplogRange{
+		First: backups.NewOplogPosition(100, 0),
+		Last:  backups.NewOplogPosition(200, 0),
+	}
+	c.Check(r.Covers(backups.NewOplogPosition(100, 0)), jc.IsTrue)
+	c.Check(r.Covers(backups.NewOplogPosition(200, 0)), jc.IsTrue)
+	c.Check(r.Covers(backups.NewOplogPosition(99, 5)), jc.IsFalse)
+	c.Check(r.Covers(backups.NewOplogPosition(200, 1)), jc.IsFalse)
+	c.Check(backups.OplogRange{}.Covers(0), jc.IsFalse)
+}
+
+func newChainMeta(id, parent, model string, position uint32) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Parent = parent
+	meta.Origin.Model = model
+	meta.OplogPosition = backups.NewOplogPosition(position, 0)
+	return meta
+}
+
+func (s *incrementalSuite) TestLatestParent(c *gc.C) {
+	older := newChainMeta("older", "", "model", 100)
+	newer := newChainMeta("newer", "older", "model", 200)
+	newer.Started = older.Started.Add(time.Hour)
+	other := newChainMeta("other", "", "other", 300)
+	other.Started = older.Started.Add(2 * time.Hour)
+	unpositioned := newChainMeta("unpositioned", "", "model", 0)
+	unpositioned.Started = older.Started.Add(3 * time.Hour)
+
+	metaList := []*backups.Metadata{older, newer, other, unpositioned}
+	c.Check(backups.LatestParent(metaList, "model"), gc.Equals, newer)
+	c.Check(backups.LatestParent(metaList, "other"), gc.Equals, other)
+	c.Check(backups.LatestParent(metaList, "missing"), gc.IsNil)
+}
+
+func chainGetter(metaList ...*backups.Metadata) func(string) (*backups.Metadata, error) {
+	return func(id string) (*backups.Metadata, error) {
+		for _, meta := range metaList {
+			if meta.ID() == id {
+				return meta, nil
+			}
+		}
+		return nil, errors.NotFoundf("backup %q", id)
+	}
+}
+
+var restoreChainTests = []struct {
+	about  string
+	chain  []*backups.Metadata
+	expect []string
+	err    string
+}{{
+	about:  "full backup",
+	chain:  []*backups.Metadata{newChainMeta("full", "", "model", 100)},
+	expect: []string{"full"},
+}, {
+	about: "incremental chain",
+	chain: []*backups.Metadata{
+		newChainMeta("inc2", "inc1", "model", 300),
+		newChainMeta("inc1", "full", "model", 200),
+		newChainMeta("full", "", "model", 100),
+	},
+	expect: []string{"full", "inc1", "inc2"},
+}, {
+	about: "missing parent",
+	chain: []*backups.Metadata{
+		newChainMeta("inc2", "inc1", "model", 300),
+		newChainMeta("inc1", "full", "model", 200),
+	},
+	err: `backup "inc1" builds on backup "full", which is missing`,
+}, {
+	about: "parent of another model",
+	chain: []*backups.Metadata{
+		newChainMeta("inc", "full", "model", 200),
+		newChainMeta("full", "", "other", 100),
+	},
+	err: `backup "inc" builds on backup "full" of another model`,
+}, {
+	about: "parent out of order",
+	chain: []*backups.Metadata{
+		newChainMeta("inc", "full", "model", 100),
+		newChainMeta("full", "", "model", 200),
+	},
+	err: `backup "inc" does not follow its parent "full"`,
+}, {
+	about: "cycle",
+	chain: []*backups.Metadata{
+		newChainMeta("inc2", "inc1", "model", 300),
+		newChainMeta("inc1", "inc2", "model", 200),
+	},
+	err: `backup "inc2" is its own ancestor`,
+}}
+
+func (s *incrementalSuite) TestRestoreChain(c *gc.C) {
+	for i, test := range restoreChainTests {
+		c.Logf("test %d: %s", i, test.about)
+		ids, err := backups.RestoreChain(test.chain[0], chainGetter(test.chain[1:]...))
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ids, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *incrementalSuite) TestOplogDumper(c *gc.C) {
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	dumpDir := c.MkDir()
+	var ranArgs []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ranArgs = args
+		err := os.Mkdir(filepath.Join(dumpDir, "local"), 0777)
+		c.Assert(err, jc.ErrorIsNil)
+		return ioutil.WriteFile(filepath.Join(dumpDir, "local", "oplog.rs.bson"), []byte("<oplog>"), 0644)
+	})
+	dbInfo := &backups.DBInfo{
+		Address: "a", Username: "b", Password: "c", Targets: set.NewStrings("juju", "logs", "local"), MongoVersion: mongo.Mongo32wt,
+		Oplog: backups.OplogRange{
+			First: backups.NewOplogPosition(100, 0),
+			Last:  backups.NewOplogPosition(200, 4),
+		},
+	}
+	dumper, err := backups.NewOplogDumper(dbInfo, backups.NewOplogPosition(150, 2))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = dumper.Dump(dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ranArgs, jc.DeepEquals, []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", `{"ts": {"$gt": {"$timestamp": {"t": 150, "i": 2}}, "$lte": {"$timestamp": {"t": 200, "i": 4}}}, "ns": {"$regex": "^(juju|logs)\\."}}`,
+	})
+	data, err := ioutil.ReadFile(filepath.Join(dumpDir, "oplog.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<oplog>")
+	_, err = os.Stat(filepath.Join(dumpDir, "local"))
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
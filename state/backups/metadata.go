@@ -67,6 +67,23 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Storage is the name of the archive store in which the backup
+	// is kept. If empty, the backup is kept in the controller.
+	Storage string
+
+	// Encryption records how the archive was encrypted, if at all.
+	Encryption EncryptionInfo
+
+	// Parent is the ID of the backup on which an incremental backup
+	// builds. It is empty for a full backup.
+	Parent string
+
+	// OplogPosition is the position of the newest oplog entry when
+	// the backup was started; the backup holds at least the changes
+	// up to it. An incremental backup holds the entries between its
+	// parent's position and its own.
+	OplogPosition OplogPosition
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -166,6 +183,13 @@ type flatMetadata struct {
 	Hostname    string
 	Version     version.Number
 	Series      string
+	Storage     string `json:",omitempty"`
+
+	EncryptionScheme         string `json:",omitempty"`
+	EncryptionKeyFingerprint string `json:",omitempty"`
+
+	Parent        string `json:",omitempty"`
+	OplogPosition int64  `json:",omitempty"`
 
 	CACert       string
 	CAPrivateKey string
@@ -189,8 +213,15 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Hostname:     m.Origin.Hostname,
 		Version:      m.Origin.Version,
 		Series:       m.Origin.Series,
+		Storage:      m.Storage,
 		CACert:       m.CACert,
 		CAPrivateKey: m.CAPrivateKey,
+
+		EncryptionScheme:         m.Encryption.Scheme,
+		EncryptionKeyFingerprint: m.Encryption.KeyFingerprint,
+
+		Parent:        m.Parent,
+		OplogPosition: int64(m.OplogPosition),
 	}
 
 	stored := m.Stored()
@@ -233,6 +264,13 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Storage = flat.Storage
+	meta.Encryption = EncryptionInfo{
+		Scheme:         flat.EncryptionScheme,
+		KeyFingerprint: flat.EncryptionKeyFingerprint,
+	}
+	meta.Parent = flat.Parent
+	meta.OplogPosition = OplogPosition(flat.OplogPosition)
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
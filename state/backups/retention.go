@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// ScheduledNotes is the note recorded against backups created on the
+// controller's backup schedule. Only backups with this note are ever
+// pruned by the retention policy; backups created by hand are kept
+// until they are removed by hand.
+const ScheduledNotes = "scheduled backup"
+
+// RetentionPolicy determines which of a set of backups are kept. A
+// backup is kept if any of the rules selects it.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days, counting back from the most
+	// recent backup, for which the last backup of the day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks, counting back from the most
+	// recent backup, for which the last backup of the week is kept.
+	KeepWeekly int
+}
+
+// IsZero reports whether the policy has no rules, in which case no
+// backups are pruned.
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast == 0 && p.KeepDaily == 0 && p.KeepWeekly == 0
+}
+
+// Validate ensures that the policy is usable.
+func (p RetentionPolicy) Validate() error {
+	if p.KeepLast < 0 {
+		return errors.NotValidf("negative KeepLast")
+	}
+	if p.KeepDaily < 0 {
+		return errors.NotValidf("negative KeepDaily")
+	}
+	if p.KeepWeekly < 0 {
+		return errors.NotValidf("negative KeepWeekly")
+	}
+	return nil
+}
+
+// Expired returns those of the supplied backups which the policy does
+// not keep, oldest first. Days and weeks are reckoned in UTC, using
+// the time each backup was started.
+func (p RetentionPolicy) Expired(metaList []*Metadata) []*Metadata {
+	if p.IsZero() {
+		return nil
+	}
+	sorted := make([]*Metadata, len(metaList))
+	copy(sorted, metaList)
+	sort.Sort(byStartedDesc(sorted))
+
+	keep := make(map[*Metadata]bool)
+	for i := 0; i < p.KeepLast && i < len(sorted); i++ {
+		keep[sorted[i]] = true
+	}
+	keepPeriods(sorted, p.KeepDaily, keep, func(meta *Metadata) interface{} {
+		year, month, day := meta.Started.UTC().Date()
+		return [3]int{year, int(month), day}
+	})
+	keepPeriods(sorted, p.KeepWeekly, keep, func(meta *Metadata) interface{} {
+		year, week := meta.Started.UTC().ISOWeek()
+		return [2]int{year, week}
+	})
+
+	var expired []*Metadata
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep[sorted[i]] {
+			expired = append(expired, sorted[i])
+		}
+	}
+	return expired
+}
+
+// keepPeriods marks as kept the most recent backup in each of the n
+// most recent periods containing a backup. The backups must be sorted
+// newest first.
+func keepPeriods(sorted []*Metadata, n int, keep map[*Metadata]bool, period func(*Metadata) interface{}) {
+	seen := make(map[interface{}]bool)
+	for _, meta := range sorted {
+		if len(seen) >= n {
+			return
+		}
+		key := period(meta)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keep[meta] = true
+	}
+}
+
+type byStartedDesc []*Metadata
+
+func (s byStartedDesc) Len() int           { return len(s) }
+func (s byStartedDesc) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byStartedDesc) Less(i, j int) bool { return s[i].Started.After(s[j].Started) }
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+// newMetaList returns metadata for backups started at each of the
+// given times, identified by their time.
+func newMetaList(c *gc.C, times ...string) []*backups.Metadata {
+	var metaList []*backups.Metadata
+	for _, t := range times {
+		started, err := time.Parse("2006-01-02 15:04", t)
+		c.Assert(err, jc.ErrorIsNil)
+		meta := backups.NewMetadata()
+		meta.SetID(t)
+		meta.Started = started
+		metaList = append(metaList, meta)
+	}
+	return metaList
+}
+
+func ids(metaList []*backups.Metadata) []string {
+	result := make([]string, len(metaList))
+	for i, meta := range metaList {
+		result[i] = meta.ID()
+	}
+	return result
+}
+
+var retentionTests = []struct {
+	about   string
+	policy  backups.RetentionPolicy
+	expired []string
+}{{
+	about:  "empty policy keeps everything",
+	policy: backups.RetentionPolicy{},
+}, {
+	about:  "keep last",
+	policy: backups.RetentionPolicy{KeepLast: 2},
+	expired: []string{
+		"2016-10-02 00:00",
+		"2016-10-09 00:00",
+		"2016-10-16 00:00",
+		"2016-10-16 12:00",
+		"2016-10-17 00:00",
+	},
+}, {
+	about:  "keep daily",
+	policy: backups.RetentionPolicy{KeepDaily: 3},
+	expired: []string{
+		"2016-10-02 00:00",
+		"2016-10-09 00:00",
+		"2016-10-16 00:00",
+		"2016-10-18 00:00",
+	},
+}, {
+	about:  "keep weekly",
+	policy: backups.RetentionPolicy{KeepWeekly: 2},
+	expired: []string{
+		"2016-10-02 00:00",
+		"2016-10-09 00:00",
+		"2016-10-16 00:00",
+		"2016-10-17 00:00",
+		"2016-10-18 00:00",
+	},
+}, {
+	about:  "rules combine",
+	policy: backups.RetentionPolicy{KeepLast: 1, KeepDaily: 2, KeepWeekly: 4},
+	expired: []string{
+		"2016-10-16 00:00",
+		"2016-10-18 00:00",
+	},
+}, {
+	about:  "keeping more than there are",
+	policy: backups.RetentionPolicy{KeepLast: 100},
+}}
+
+func (s *retentionSuite) TestExpired(c *gc.C) {
+	// 2016-10-02, 09 and 16 are Sundays, the last days of ISO weeks.
+	metaList := newMetaList(c,
+		"2016-10-18 12:00",
+		"2016-10-02 00:00",
+		"2016-10-16 00:00",
+		"2016-10-18 00:00",
+		"2016-10-09 00:00",
+		"2016-10-16 12:00",
+		"2016-10-17 00:00",
+	)
+	for i, test := range retentionTests {
+		c.Logf("test %d: %s", i, test.about)
+		expired := test.policy.Expired(metaList)
+		if len(test.expired) == 0 {
+			c.Check(expired, gc.HasLen, 0)
+		} else {
+			c.Check(ids(expired), jc.DeepEquals, test.expired)
+		}
+	}
+}
+
+func (s *retentionSuite) TestValidate(c *gc.C) {
+	err := backups.RetentionPolicy{KeepLast: 1, KeepDaily: 7, KeepWeekly: 4}.Validate()
+	c.Check(err, jc.ErrorIsNil)
+	err = backups.RetentionPolicy{KeepDaily: -1}.Validate()
+	c.Check(err, gc.ErrorMatches, "negative KeepDaily not valid")
+}
@@ -47,6 +47,16 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	// encryption
+
+	EncryptionScheme         string `bson:"encryptionscheme,omitempty"`
+	EncryptionKeyFingerprint string `bson:"encryptionkeyfingerprint,omitempty"`
+
+	// incremental
+
+	Parent        string `bson:"parent,omitempty"`
+	OplogPosition int64  `bson:"oplogposition,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -123,6 +133,10 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Encryption.Scheme = doc.EncryptionScheme
+	meta.Encryption.KeyFingerprint = doc.EncryptionKeyFingerprint
+	meta.Parent = doc.Parent
+	meta.OplogPosition = OplogPosition(doc.OplogPosition)
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -174,6 +188,10 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.EncryptionScheme = meta.Encryption.Scheme
+	doc.EncryptionKeyFingerprint = meta.Encryption.KeyFingerprint
+	doc.Parent = meta.Parent
+	doc.OplogPosition = int64(meta.OplogPosition)
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -27,6 +27,8 @@ type FakeBackups struct {
 	MetaList []*backups.Metadata
 	// Archive holds the archive file to return.
 	Archive io.ReadCloser
+	// Verification holds the Verification to return.
+	Verification *backups.Verification
 	// Error holds the error to return.
 	Error error
 
@@ -96,6 +98,13 @@ func (b *FakeBackups) Remove(id string) error {
 	return errors.Trace(b.Error)
 }
 
+// Verify reports whether the backup could be restored.
+func (b *FakeBackups) Verify(id string) (*backups.Verification, error) {
+	b.Calls = append(b.Calls, "Verify")
+	b.IDArg = id
+	return b.Verification, b.Error
+}
+
 // Restore restores a machine to a backed up status.
 func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups.RestoreArgs) (names.Tag, error) {
 	b.Calls = append(b.Calls, "Restore")
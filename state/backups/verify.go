@@ -0,0 +1,304 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxBSONDocumentSize is the largest document mongo will store, plus
+// some slack for the dump format.
+const maxBSONDocumentSize = 16*1024*1024 + 16*1024
+
+// countedCollections are the collections in the juju database whose
+// documents are counted when verifying a backup.
+var countedCollections = []string{"models", "machines", "applications", "units"}
+
+// Verification reports the outcome of checking a backup archive.
+type Verification struct {
+	// Metadata is the metadata found in the archive, if any.
+	Metadata *Metadata
+
+	// ChecksumVerified reports whether the archive's size and
+	// checksum were found to match those recorded when it was stored.
+	// They cannot be checked for an archive with no stored metadata.
+	ChecksumVerified bool
+
+	// ContentsVerified reports whether the contents of the archive
+	// were read. They cannot be read from an encrypted archive
+	// without its decryption key.
+	ContentsVerified bool
+
+	// Counts holds the number of documents in each counted collection
+	// of the juju database, keyed by collection name.
+	Counts map[string]int
+
+	// OplogEntries is the number of oplog entries in the archive.
+	OplogEntries int
+
+	// Missing lists the files expected in the archive but not found.
+	Missing []string
+
+	// Problems describes anything else that would prevent the backup
+	// from being restored.
+	Problems []string
+}
+
+// OK reports whether the backup was found to be usable.
+func (v *Verification) OK() bool {
+	return len(v.Missing) == 0 && len(v.Problems) == 0
+}
+
+func (v *Verification) problemf(format string, args ...interface{}) {
+	v.Problems = append(v.Problems, fmt.Sprintf(format, args...))
+}
+
+// VerifyArchive reads a backup archive in full, without restoring it,
+// and reports whether it could be restored. If meta is not nil, it
+// holds the metadata recorded when the archive was stored, against
+// which the archive is checked. If key is not nil, it is used to
+// decrypt an encrypted archive.
+func VerifyArchive(archive io.Reader, meta *Metadata, key *DecryptionKey) *Verification {
+	v := &Verification{Counts: make(map[string]int)}
+
+	hasher := hash.NewHashingWriter(ioutil.Discard, sha1.New())
+	counter := &countingWriter{}
+	raw := io.TeeReader(archive, io.MultiWriter(hasher, counter))
+
+	v.verifyContents(raw, meta, key)
+
+	// Whatever became of the contents, the whole of the archive must
+	// be read for its checksum to be known.
+	if _, err := io.Copy(ioutil.Discard, raw); err != nil {
+		v.problemf("reading archive: %v", err)
+		return v
+	}
+	if meta == nil || meta.Checksum() == "" {
+		return v
+	}
+	switch {
+	case meta.ChecksumFormat() != checksumFormat:
+		v.problemf("unsupported checksum format %q", meta.ChecksumFormat())
+	case counter.n != meta.Size():
+		v.problemf("archive is %d bytes, but %d were recorded", counter.n, meta.Size())
+	case hasher.Base64Sum() != meta.Checksum():
+		v.problemf("archive checksum %s does not match recorded checksum %s", hasher.Base64Sum(), meta.Checksum())
+	default:
+		v.ChecksumVerified = true
+	}
+	return v
+}
+
+func (v *Verification) verifyContents(raw io.Reader, meta *Metadata, key *DecryptionKey) {
+	br := bufio.NewReader(raw)
+	header, _ := br.Peek(len(encryptedArchiveMagic))
+	var plain io.Reader = br
+	if IsEncryptedArchive(header) {
+		if key == nil {
+			return
+		}
+		if meta != nil {
+			if err := key.CheckDecrypts(meta.Encryption); err != nil {
+				v.problemf("%v", err)
+				return
+			}
+		}
+		var err error
+		if plain, err = NewDecryptingReader(br, key); err != nil {
+			v.problemf("%v", err)
+			return
+		}
+	}
+	gzr, err := gzip.NewReader(plain)
+	if err != nil {
+		v.problemf("archive is not gzip compressed: %v", err)
+		return
+	}
+	defer gzr.Close()
+	v.ContentsVerified = true
+
+	paths := NewCanonicalArchivePaths()
+	var bundle []string
+	var haveBundle, haveOplog bool
+	collections := make(map[string]bool)
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			v.problemf("reading archive: %v", err)
+			return
+		}
+		name := strings.TrimSuffix(hdr.Name, "/")
+		switch {
+		case name == paths.MetadataFile:
+			if v.Metadata, err = NewMetadataJSONReader(tr); err != nil {
+				v.problemf("reading %s: %v", name, err)
+			}
+		case name == paths.FilesBundle:
+			haveBundle = true
+			if bundle, err = listBundle(tr); err != nil {
+				v.problemf("reading %s: %v", name, err)
+			}
+		case name == path.Join(paths.DBDumpDir, "oplog.bson"):
+			haveOplog = true
+			if v.OplogEntries, err = countBSON(tr); err != nil {
+				v.problemf("reading %s: %v", name, err)
+			}
+		case strings.HasPrefix(name, paths.DBDumpDir+"/") && path.Ext(name) == ".bson":
+			count, err := countBSON(tr)
+			if err != nil {
+				v.problemf("reading %s: %v", name, err)
+			}
+			rel := strings.TrimPrefix(name, paths.DBDumpDir+"/")
+			if db, file := path.Split(rel); db == "juju/" {
+				collection := strings.TrimSuffix(file, ".bson")
+				collections[collection] = true
+				if isCounted(collection) {
+					v.Counts[collection] = count
+				}
+			}
+		}
+	}
+
+	if v.Metadata == nil {
+		v.Missing = append(v.Missing, paths.MetadataFile)
+	} else {
+		v.checkMetadata(meta)
+	}
+	if !haveBundle {
+		v.Missing = append(v.Missing, paths.FilesBundle)
+	} else {
+		v.checkBundle(bundle)
+	}
+	switch {
+	case v.Metadata != nil && v.Metadata.IsIncremental():
+		if !haveOplog {
+			v.Missing = append(v.Missing, path.Join(paths.DBDumpDir, "oplog.bson"))
+		}
+	case !collections["models"]:
+		v.Missing = append(v.Missing, path.Join(paths.DBDumpDir, "juju", "models.bson"))
+	}
+	sort.Strings(v.Missing)
+}
+
+// checkMetadata compares the metadata in the archive with that
+// recorded when the archive was stored.
+func (v *Verification) checkMetadata(meta *Metadata) {
+	if meta == nil {
+		return
+	}
+	if v.Metadata.Origin.Model != meta.Origin.Model {
+		v.problemf("archive is of model %q, but model %q was recorded", v.Metadata.Origin.Model, meta.Origin.Model)
+	}
+	if v.Metadata.Parent != meta.Parent {
+		v.problemf("archive builds on backup %q, but %q was recorded", v.Metadata.Parent, meta.Parent)
+	}
+}
+
+// checkBundle reports the files needed to restore the controller
+// machine that are missing from the files bundle.
+func (v *Verification) checkBundle(bundle []string) {
+	root := strings.TrimPrefix(dataDir, "/")
+	required := []string{
+		path.Join(root, toolsDir),
+		path.Join(root, sshIdentFile),
+		path.Join(root, dbPEM),
+		path.Join(root, dbSecret),
+	}
+	if v.Metadata != nil && v.Metadata.Origin.Machine != "" {
+		agentConf := path.Join(root, agentsDir, "machine-"+v.Metadata.Origin.Machine, "agent.conf")
+		required = append(required, agentConf)
+	}
+	for _, file := range required {
+		if !containsPath(bundle, file) {
+			v.Missing = append(v.Missing, path.Join(filesBundle, file))
+		}
+	}
+}
+
+func containsPath(names []string, file string) bool {
+	for _, name := range names {
+		if name == file || strings.HasPrefix(name, file+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func isCounted(collection string) bool {
+	for _, counted := range countedCollections {
+		if collection == counted {
+			return true
+		}
+	}
+	return false
+}
+
+// listBundle returns the names of the files in a files bundle.
+func listBundle(r io.Reader) ([]string, error) {
+	var names []string
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return names, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		names = append(names, strings.TrimSuffix(hdr.Name, "/"))
+	}
+}
+
+// countBSON parses the documents dumped by mongodump to r, returning
+// how many there are.
+func countBSON(r io.Reader) (int, error) {
+	count := 0
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(r, size[:]); err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, errors.Annotatef(err, "document %d", count)
+		}
+		n := int(int32(binary.LittleEndian.Uint32(size[:])))
+		if n < 5 || n > maxBSONDocumentSize {
+			return count, errors.Errorf("document %d has invalid size %d", count, n)
+		}
+		data := make([]byte, n)
+		copy(data, size[:])
+		if _, err := io.ReadFull(r, data[4:]); err != nil {
+			return count, errors.Annotatef(err, "document %d", count)
+		}
+		var doc bson.M
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return count, errors.Annotatef(err, "document %d", count)
+		}
+		count++
+	}
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
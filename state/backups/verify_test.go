@@ -0,0 +1,209 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+var verifyFiles = []bt.File{
+	{Name: "var/lib/juju/tools/2.0.0-xenial-amd64/jujud", Content: "<jujud>"},
+	{Name: "var/lib/juju/system-identity", Content: "<ssh key>"},
+	{Name: "var/lib/juju/server.pem", Content: "<pem>"},
+	{Name: "var/lib/juju/shared-secret", Content: "<secret>"},
+	{Name: "var/lib/juju/agents/machine-0/agent.conf", Content: "<conf>"},
+}
+
+func bsonDocs(c *gc.C, n int) string {
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		data, err := bson.Marshal(bson.M{"_id": i})
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *verifySuite) newArchive(c *gc.C, meta *backups.Metadata, files, dump []bt.File) []byte {
+	archive, err := bt.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive.Bytes()
+}
+
+func (s *verifySuite) fullDump(c *gc.C) []bt.File {
+	return []bt.File{
+		{Name: "juju", IsDir: true},
+		{Name: "juju/models.bson", Content: bsonDocs(c, 2)},
+		{Name: "juju/machines.bson", Content: bsonDocs(c, 3)},
+		{Name: "juju/applications.bson", Content: bsonDocs(c, 1)},
+		{Name: "juju/units.bson", Content: bsonDocs(c, 4)},
+		{Name: "juju/settings.bson", Content: bsonDocs(c, 5)},
+	}
+}
+
+// recordArchive records the size and checksum of the archive in the
+// metadata, as is done when a backup is stored.
+func recordArchive(c *gc.C, meta *backups.Metadata, archive []byte) *backups.Metadata {
+	sum := sha1.Sum(archive)
+	err := meta.MarkComplete(int64(len(archive)), base64.StdEncoding.EncodeToString(sum[:]))
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *verifySuite) TestVerifyOkay(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.newArchive(c, meta, verifyFiles, s.fullDump(c))
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), recordArchive(c, meta, archive), nil)
+	c.Check(v.Problems, gc.HasLen, 0)
+	c.Check(v.Missing, gc.HasLen, 0)
+	c.Check(v.OK(), jc.IsTrue)
+	c.Check(v.ChecksumVerified, jc.IsTrue)
+	c.Check(v.ContentsVerified, jc.IsTrue)
+	c.Check(v.Counts, jc.DeepEquals, map[string]int{
+		"models":       2,
+		"machines":     3,
+		"applications": 1,
+		"units":        4,
+	})
+	c.Assert(v.Metadata, gc.NotNil)
+	c.Check(v.Metadata.Origin.Model, gc.Equals, meta.Origin.Model)
+}
+
+func (s *verifySuite) TestVerifyWithoutMetadata(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.newArchive(c, meta, verifyFiles, s.fullDump(c))
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Check(v.OK(), jc.IsTrue)
+	c.Check(v.ChecksumVerified, jc.IsFalse)
+	c.Check(v.ContentsVerified, jc.IsTrue)
+}
+
+func (s *verifySuite) TestVerifyIncremental(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	meta.Parent = "parent-id"
+	dump := []bt.File{{Name: "oplog.bson", Content: bsonDocs(c, 7)}}
+	archive := s.newArchive(c, meta, verifyFiles, dump)
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Check(v.OK(), jc.IsTrue)
+	c.Check(v.OplogEntries, gc.Equals, 7)
+	c.Check(v.Counts, gc.HasLen, 0)
+}
+
+func (s *verifySuite) TestVerifyMissingFiles(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.newArchive(c, meta, verifyFiles[:2], nil)
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Check(v.OK(), jc.IsFalse)
+	c.Check(v.Missing, jc.DeepEquals, []string{
+		"juju-backup/dump/juju/models.bson",
+		"root.tar/var/lib/juju/agents/machine-0/agent.conf",
+		"root.tar/var/lib/juju/server.pem",
+		"root.tar/var/lib/juju/shared-secret",
+	})
+}
+
+func (s *verifySuite) TestVerifyMissingMetadata(c *gc.C) {
+	archive := s.newArchive(c, nil, verifyFiles, s.fullDump(c))
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Check(v.Missing, jc.DeepEquals, []string{"juju-backup/metadata.json"})
+}
+
+func (s *verifySuite) TestVerifyCorruptDump(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	dump := append(s.fullDump(c), bt.File{
+		Name:    "juju/units.bson",
+		Content: bsonDocs(c, 1) + "\xff\xff\xff\x7f",
+	})
+	archive := s.newArchive(c, meta, verifyFiles, dump)
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, nil)
+	c.Check(v.OK(), jc.IsFalse)
+	c.Check(v.Problems, jc.DeepEquals, []string{
+		"reading juju-backup/dump/juju/units.bson: document 1 has invalid size 2147483647",
+	})
+}
+
+func (s *verifySuite) TestVerifyChecksumMismatch(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.newArchive(c, meta, verifyFiles, s.fullDump(c))
+	recorded := recordArchive(c, meta, archive)
+	err := recorded.SetFileInfo(recorded.Size(), "bogus", recorded.ChecksumFormat())
+	c.Assert(err, jc.ErrorIsNil)
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), recorded, nil)
+	c.Check(v.ChecksumVerified, jc.IsFalse)
+	c.Check(v.ContentsVerified, jc.IsTrue)
+	c.Assert(v.Problems, gc.HasLen, 1)
+	c.Check(v.Problems[0], gc.Matches, "archive checksum .* does not match recorded checksum bogus")
+}
+
+func (s *verifySuite) TestVerifyModelMismatch(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	archive := s.newArchive(c, meta, verifyFiles, s.fullDump(c))
+	recorded := recordArchive(c, meta, archive)
+	recorded.Origin.Model = "other-model"
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), recorded, nil)
+	c.Check(v.ChecksumVerified, jc.IsTrue)
+	c.Check(v.Problems, jc.DeepEquals, []string{
+		`archive is of model "49db53ac-a42f-4ab2-86e1-0c6fa0fec762", but model "other-model" was recorded`,
+	})
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	secret := bytes.Repeat([]byte{7}, backups.SymmetricKeySize)
+	encrypter, err := backups.NewSymmetricEncrypter(secret)
+	c.Assert(err, jc.ErrorIsNil)
+	meta := bt.NewMetadataStarted()
+	archive := encrypt(c, encrypter, s.newArchive(c, meta, verifyFiles, s.fullDump(c)))
+	recorded := recordArchive(c, meta, archive)
+	recorded.Encryption = encrypter.Info()
+
+	v := backups.VerifyArchive(bytes.NewReader(archive), recorded, nil)
+	c.Check(v.OK(), jc.IsTrue)
+	c.Check(v.ChecksumVerified, jc.IsTrue)
+	c.Check(v.ContentsVerified, jc.IsFalse)
+
+	key, err := backups.NewSymmetricDecryptionKey(secret)
+	c.Assert(err, jc.ErrorIsNil)
+	v = backups.VerifyArchive(bytes.NewReader(archive), recorded, key)
+	c.Check(v.OK(), jc.IsTrue)
+	c.Check(v.ChecksumVerified, jc.IsTrue)
+	c.Check(v.ContentsVerified, jc.IsTrue)
+	c.Check(v.Counts["units"], gc.Equals, 4)
+}
+
+func (s *verifySuite) TestVerifyEncryptedWrongKey(c *gc.C) {
+	encrypter, err := backups.NewSymmetricEncrypter(bytes.Repeat([]byte{7}, backups.SymmetricKeySize))
+	c.Assert(err, jc.ErrorIsNil)
+	meta := bt.NewMetadataStarted()
+	archive := encrypt(c, encrypter, s.newArchive(c, meta, verifyFiles, s.fullDump(c)))
+
+	key, err := backups.NewSymmetricDecryptionKey(bytes.Repeat([]byte{8}, backups.SymmetricKeySize))
+	c.Assert(err, jc.ErrorIsNil)
+	v := backups.VerifyArchive(bytes.NewReader(archive), nil, key)
+	c.Check(v.OK(), jc.IsFalse)
+	c.Check(v.ContentsVerified, jc.IsFalse)
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/mongo/utils"
+	"github.com/juju/juju/status"
+)
+
+// backupScheduleGlobalKey is the global key under which the outcomes
+// of scheduled backups are recorded in the status history.
+const backupScheduleGlobalKey = "backup-schedule"
+
+// SetBackupScheduleStatus records the outcome of a scheduled backup,
+// or of pruning scheduled backups, in the controller's status history.
+func (st *State) SetBackupScheduleStatus(sInfo status.StatusInfo) error {
+	if !st.IsController() {
+		return errors.New("backups are only scheduled on the controller model")
+	}
+	updated := st.clock.Now()
+	if sInfo.Since != nil {
+		updated = *sInfo.Since
+	}
+	doc := statusDoc{
+		Status:     sInfo.Status,
+		StatusInfo: sInfo.Message,
+		StatusData: utils.EscapeKeys(sInfo.Data),
+		Updated:    updated.UnixNano(),
+	}
+	probablyUpdateStatusHistory(st, backupScheduleGlobalKey, doc)
+	return nil
+}
+
+// BackupScheduleStatusHistory returns the recorded outcomes of
+// scheduled backups, most recent first.
+func (st *State) BackupScheduleStatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		st:        st,
+		globalKey: backupScheduleGlobalKey,
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/status"
+)
+
+type BackupScheduleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BackupScheduleSuite{})
+
+func (s *BackupScheduleSuite) TestStatusHistory(c *gc.C) {
+	t0 := time.Date(2016, 10, 18, 2, 30, 0, 0, time.UTC)
+	t1 := t0.Add(24 * time.Hour)
+	err := s.State.SetBackupScheduleStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "created backup 20161018-023000.deadbeef",
+		Since:   &t0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetBackupScheduleStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "creating backup: boom",
+		Data:    map[string]interface{}{"storage.name": "s3"},
+		Since:   &t1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.State.BackupScheduleStatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Status, gc.Equals, status.Error)
+	c.Check(history[0].Message, gc.Equals, "creating backup: boom")
+	c.Check(history[0].Data, jc.DeepEquals, map[string]interface{}{"storage.name": "s3"})
+	c.Check(history[0].Since.Equal(t1), jc.IsTrue)
+	c.Check(history[1].Status, gc.Equals, status.Active)
+	c.Check(history[1].Since.Equal(t0), jc.IsTrue)
+}
+
+func (s *BackupScheduleSuite) TestHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := st.SetBackupScheduleStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, gc.ErrorMatches, "backups are only scheduled on the controller model")
+}
@@ -373,6 +373,9 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 	if err := Apply(st.database, change); err != nil {
 		return errors.Trace(err)
 	}
+	if err := st.removeHookHistory(unitGlobalKey(unitId)); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 
@@ -15,6 +16,10 @@ const (
 	// controllerSettingsGlobalKey is the key for the controller and its settings.
 	controllerSettingsGlobalKey = "controllerSettings"
 
+	// controllerSecretsGlobalKey is the key for the controller's secret
+	// settings.
+	controllerSecretsGlobalKey = "controllerSecrets"
+
 	// controllerGlobalKey is the key for controller.
 	controllerGlobalKey = "c"
 )
@@ -33,3 +38,60 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	}
 	return settings.Map(), nil
 }
+
+// ControllerSecrets returns the values of the controller's secret
+// attributes, which are kept apart from the controller config. See
+// controller.SecretAttributes.
+func (st *State) ControllerSecrets() (jujucontroller.Secrets, error) {
+	settings, err := readSettings(st, controllersC, controllerSecretsGlobalKey)
+	if errors.IsNotFound(err) {
+		return jujucontroller.Secrets{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
+// RotateBackupEncryptionKey makes key the controller's backup
+// encryption key. The key it replaces is retired rather than
+// discarded, so that backups already encrypted with it can still be
+// restored.
+func (st *State) RotateBackupEncryptionKey(key string) error {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := readSettings(st, controllersC, controllerSecretsGlobalKey)
+	if errors.IsNotFound(err) {
+		settings, err = createSettings(st, controllersC, controllerSecretsGlobalKey, nil)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The whole config is validated, so that a controller encrypting
+	// backups with a public key cannot be given a symmetric key too.
+	full := cfg.WithSecrets(settings.Map())
+	current := full.BackupEncryptionKey()
+	var retired []string
+	if current != "" && current != key {
+		retired = append(retired, current)
+	}
+	for _, old := range full.BackupRetiredEncryptionKeys() {
+		if old != key && old != current {
+			retired = append(retired, old)
+		}
+	}
+	full[jujucontroller.BackupEncryptionKey] = key
+	full[jujucontroller.BackupRetiredEncryptionKeys] = strings.Join(retired, ",")
+	if err := full.Validate(); err != nil {
+		return errors.Annotate(err, "cannot rotate backup encryption key")
+	}
+	settings.Set(jujucontroller.BackupEncryptionKey, key)
+	if len(retired) > 0 {
+		settings.Set(jujucontroller.BackupRetiredEncryptionKeys, strings.Join(retired, ","))
+	} else {
+		settings.Delete(jujucontroller.BackupRetiredEncryptionKeys)
+	}
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
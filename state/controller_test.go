@@ -24,11 +24,31 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
+		controller.IdentityURL:                 true,
+		controller.IdentityPublicKey:           true,
+		controller.AutocertURLKey:              true,
+		controller.AutocertDNSNameKey:          true,
+		controller.AllowModelAccessKey:         true,
+		controller.AuditLogSinks:               true,
+		controller.AuditLogSyslogHost:          true,
+		controller.AuditLogSyslogCACert:        true,
+		controller.AuditLogSyslogClientCert:    true,
+		controller.AuditLogSyslogClientKey:     true,
+		controller.AuditLogWebhookURL:          true,
+		controller.BackupStorageDir:            true,
+		controller.BackupS3Endpoint:            true,
+		controller.BackupS3Region:              true,
+		controller.BackupS3Bucket:              true,
+		controller.BackupS3AccessKey:           true,
+		controller.BackupS3SecretKey:           true,
+		controller.BackupSchedule:              true,
+		controller.BackupScheduleStorage:       true,
+		controller.BackupKeepLast:              true,
+		controller.BackupKeepDaily:             true,
+		controller.BackupKeepWeekly:            true,
+		controller.BackupEncryptionKey:         true,
+		controller.BackupRetiredEncryptionKeys: true,
+		controller.BackupEncryptionPublicKey:   true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -46,3 +66,44 @@ func (s *ControllerConfigSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg["controller-uuid"], gc.Equals, m.ControllerUUID())
 }
+
+const (
+	backupKey1 = "kjMMiFOZp8E3MHlyIS8HEwwtwXnK9xnFovI5W0GyPtg="
+	backupKey2 = "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+)
+
+func (s *ControllerConfigSuite) TestRotateBackupEncryptionKey(c *gc.C) {
+	err := s.State.RotateBackupEncryptionKey(backupKey1)
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err := s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets[controller.BackupEncryptionKey], gc.Equals, backupKey1)
+	_, ok := secrets[controller.BackupRetiredEncryptionKeys]
+	c.Check(ok, jc.IsFalse)
+
+	err = s.State.RotateBackupEncryptionKey(backupKey2)
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err = s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets[controller.BackupEncryptionKey], gc.Equals, backupKey2)
+	c.Check(secrets[controller.BackupRetiredEncryptionKeys], gc.Equals, backupKey1)
+
+	// Neither key is ever in the controller config.
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupEncryptionKey(), gc.Equals, "")
+	c.Check(cfg.BackupRetiredEncryptionKeys(), gc.HasLen, 0)
+
+	// Going back to a retired key retires the current one.
+	err = s.State.RotateBackupEncryptionKey(backupKey1)
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err = s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets[controller.BackupEncryptionKey], gc.Equals, backupKey1)
+	c.Check(secrets[controller.BackupRetiredEncryptionKeys], gc.Equals, backupKey2)
+}
+
+func (s *ControllerConfigSuite) TestRotateBackupEncryptionKeyInvalid(c *gc.C) {
+	err := s.State.RotateBackupEncryptionKey("c2hvcnQ=")
+	c.Assert(err, gc.ErrorMatches, "cannot rotate backup encryption key: backup-encryption-key: expected 32 bytes, got 5")
+}
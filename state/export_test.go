@@ -22,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/utils"
@@ -202,6 +203,23 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 	return sch
 }
 
+// SetCloudAuthTypes replaces the auth types supported by the named
+// cloud.
+func SetCloudAuthTypes(c *gc.C, st *State, name string, authTypes ...cloud.AuthType) {
+	values := make([]string, len(authTypes))
+	for i, authType := range authTypes {
+		values[i] = string(authType)
+	}
+	ops := []txn.Op{{
+		C:      cloudsC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"auth-types", values}}}},
+	}}
+	err := st.runTransaction(ops)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 // SetCharmBundleURL sets the deprecated bundleurl field in the
 // charm document for the charm with the specified URL.
 func SetCharmBundleURL(c *gc.C, st *State, curl *charm.URL, bundleURL string) {
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxHookHistory is the number of hook executions kept in a unit's
+// hook history; older ones are discarded as new ones are recorded.
+const maxHookHistory = 100
+
+// HookRecord describes one execution of a hook by a unit.
+type HookRecord struct {
+	// Hook is the name of the hook.
+	Hook string
+
+	// Relation identifies the relation the hook ran for, if any,
+	// as "<endpoint>:<relation id>".
+	Relation string
+
+	// RemoteUnit is the name of the remote unit the hook ran for,
+	// if any.
+	RemoteUnit string
+
+	// Started is the time the hook started.
+	Started time.Time
+
+	// Duration is how long the hook took to run.
+	Duration time.Duration
+
+	// Error holds the reason the hook failed, if it did.
+	Error string
+}
+
+// hookHistoryDoc holds the most recent hook executions of a unit.
+type hookHistoryDoc struct {
+	DocId     string          `bson:"_id"`
+	ModelUUID string          `bson:"model-uuid"`
+	Records   []hookRecordDoc `bson:"records"`
+}
+
+type hookRecordDoc struct {
+	Hook       string `bson:"hook"`
+	Relation   string `bson:"relation,omitempty"`
+	RemoteUnit string `bson:"remote-unit,omitempty"`
+	Started    int64  `bson:"started"`
+	Duration   int64  `bson:"duration"`
+	Error      string `bson:"error,omitempty"`
+}
+
+// AddHookRecord records an execution of a hook in the unit's hook
+// history, discarding the oldest records if there are more than the
+// history holds.
+func (u *Unit) AddHookRecord(record HookRecord) error {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	doc := hookRecordDoc{
+		Hook:       record.Hook,
+		Relation:   record.Relation,
+		RemoteUnit: record.RemoteUnit,
+		Started:    record.Started.UTC().UnixNano(),
+		Duration:   int64(record.Duration),
+		Error:      record.Error,
+	}
+	// The history is only of interest while the unit exists, and is
+	// written after every hook, so it's updated directly rather than
+	// in a transaction.
+	_, err := history.Writeable().UpsertId(u.st.docID(u.globalKey()), bson.D{
+		{"$set", bson.D{{"model-uuid", u.st.ModelUUID()}}},
+		{"$push", bson.D{{"records", bson.D{
+			{"$each", []hookRecordDoc{doc}},
+			{"$slice", -maxHookHistory},
+		}}}},
+	})
+	return errors.Annotatef(err, "cannot record %q hook for unit %q", record.Hook, u.Name())
+}
+
+// HookHistory returns the unit's most recent hook executions, oldest
+// first.
+func (u *Unit) HookHistory() ([]HookRecord, error) {
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	var doc hookHistoryDoc
+	err := history.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u.Name())
+	}
+	records := make([]HookRecord, len(doc.Records))
+	for i, r := range doc.Records {
+		records[i] = HookRecord{
+			Hook:       r.Hook,
+			Relation:   r.Relation,
+			RemoteUnit: r.RemoteUnit,
+			Started:    time.Unix(0, r.Started).UTC(),
+			Duration:   time.Duration(r.Duration),
+			Error:      r.Error,
+		}
+	}
+	return records, nil
+}
+
+// removeHookHistory removes the hook history of the unit with the
+// given global key.
+func (st *State) removeHookHistory(globalKey string) error {
+	history, closer := st.getCollection(hookHistoryC)
+	defer closer()
+
+	err := history.Writeable().RemoveId(globalKey)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotate(err, "cannot remove hook history")
+	}
+	return nil
+}
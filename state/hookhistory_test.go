@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) TestHookHistoryEmpty(c *gc.C) {
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestAddHookRecord(c *gc.C) {
+	started := time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC)
+	records := []state.HookRecord{{
+		Hook:     "install",
+		Started:  started,
+		Duration: 90 * time.Second,
+	}, {
+		Hook:       "db-relation-joined",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(2 * time.Minute),
+		Duration:   1500 * time.Millisecond,
+		Error:      "exit status 1",
+	}}
+	for _, record := range records {
+		err := s.unit.AddHookRecord(record)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(history, jc.DeepEquals, records)
+}
+
+func (s *HookHistorySuite) TestAddHookRecordDiscardsOldest(c *gc.C) {
+	started := time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC)
+	for i := 0; i < 105; i++ {
+		err := s.unit.AddHookRecord(state.HookRecord{
+			Hook:    fmt.Sprintf("hook-%d", i),
+			Started: started.Add(time.Duration(i) * time.Second),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 100)
+	c.Check(history[0].Hook, gc.Equals, "hook-5")
+	c.Check(history[99].Hook, gc.Equals, "hook-104")
+}
+
+func (s *HookHistorySuite) TestHookHistoryPerUnit(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	err := s.unit.AddHookRecord(state.HookRecord{
+		Hook:    "install",
+		Started: time.Date(2016, 10, 18, 8, 13, 0, 0, time.UTC),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := other.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(history, gc.HasLen, 0)
+}
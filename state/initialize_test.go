@@ -267,6 +267,49 @@ func (s *InitializeSuite) TestInitializeWithControllerInheritedConfig(c *gc.C) {
 	c.Assert(cfg.AllAttrs(), jc.DeepEquals, expected)
 }
 
+func (s *InitializeSuite) TestInitializeKeepsControllerSecretsApart(c *gc.C) {
+	cfg := testing.ModelConfig(c)
+	owner := names.NewLocalUserTag("initialize-admin")
+	controllerCfg := testing.FakeControllerConfig()
+	controllerCfg[controller.AuditLogSyslogClientKey] = "private"
+
+	st, err := state.Initialize(state.InitializeParams{
+		Clock:            clock.WallClock,
+		ControllerConfig: controllerCfg,
+		ControllerModelArgs: state.ModelArgs{
+			CloudName:               "dummy",
+			Owner:                   owner,
+			Config:                  cfg,
+			StorageProviderRegistry: storage.StaticProviderRegistry{},
+		},
+		CloudName: "dummy",
+		Cloud: cloud.Cloud{
+			Type:      "dummy",
+			AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+		},
+		MongoInfo:     statetesting.NewMongoInfo(),
+		MongoDialOpts: mongotest.DialOpts(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	modelTag := st.ModelTag()
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.openState(c, modelTag)
+
+	storedCfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := storedCfg[controller.AuditLogSyslogClientKey]
+	c.Check(ok, jc.IsFalse)
+	c.Check(storedCfg.ControllerUUID(), gc.Equals, controllerCfg.ControllerUUID())
+
+	secrets, err := s.State.ControllerSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, jc.DeepEquals, controller.Secrets{
+		controller.AuditLogSyslogClientKey: "private",
+	})
+}
+
 func (s *InitializeSuite) TestDoubleInitializeConfig(c *gc.C) {
 	cfg := testing.ModelConfig(c)
 	owner := names.NewLocalUserTag("initialize-admin")
@@ -157,6 +157,16 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeout queues an action as AddAction does, to be
+	// stopped and marked failed if it runs for longer than the given
+	// timeout. If the timeout is zero, any default timeout for the
+	// action is used.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
+	// AddOperationAction queues an action as AddActionWithTimeout
+	// does, as part of the operation with the given id.
+	AddOperationAction(operationId, name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -208,6 +218,14 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// Timeout returns how long the action may run for, or zero if it
+	// may run for as long as it needs.
+	Timeout() time.Duration
+
+	// Operation returns the id of the operation the action is part of,
+	// or "" if it was enqueued alone.
+	Operation() string
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
@@ -224,6 +242,9 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -232,6 +253,17 @@ type Action interface {
 	// It asserts that the action is currently pending.
 	Begin() (Action, error)
 
+	// Log records a progress message for the action. Messages longer
+	// than 4096 bytes are truncated. It asserts that the action is
+	// currently running or aborting.
+	Log(message string) error
+
+	// Abort asks for the running action to be stopped, by marking it as
+	// aborting. It is left to the action's receiver to stop the action and
+	// finish it as aborted. It asserts that the action is currently running
+	// or aborting.
+	Abort() (Action, error)
+
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
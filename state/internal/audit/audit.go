@@ -4,17 +4,24 @@
 package audit
 
 import (
-	"github.com/juju/errors"
+	"regexp"
+	"time"
 
-	"github.com/juju/juju/audit"
+	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
 )
 
 // auditEntryDoc is the doc that is persisted to the audit collection.
 type auditEntryDoc struct {
 
+	// DocID identifies the entry. Since it is generated when the
+	// entry is written, entries are ordered by it.
+	DocID bson.ObjectId `bson:"_id,omitempty"`
+
 	// JujuServerVersion is the version of jujud that recorded this
 	// entry.
 	JujuServerVersion version.Number `bson:"juju-server-version"`
@@ -27,6 +34,11 @@ type auditEntryDoc struct {
 	// unmarshaled via time.Time::UnmarshalText.
 	Timestamp string `bson:"timestamp"`
 
+	// Time is also when the audit entry was written, held as a date
+	// (with millisecond precision) so that entries can be selected by
+	// time in queries.
+	Time time.Time `bson:"time"`
+
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
 	RemoteAddress string `bson:"remote-address"`
@@ -73,9 +85,11 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 	}
 
 	return auditEntryDoc{
+		DocID:             bson.NewObjectId(),
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
 		Timestamp:         string(timeAsBlob),
+		Time:              auditEntry.Timestamp.UTC(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
@@ -83,3 +97,98 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// Iterator is the subset of *mgo.Iter used to read audit entry
+// documents.
+type Iterator interface {
+	Next(result interface{}) bool
+	Close() error
+}
+
+// QueryForFilter returns the mongo query selecting the audit entry
+// documents that may satisfy the supplied filter. Since stored times
+// only have millisecond precision, the lower time bound is widened to
+// a whole millisecond, and CollectAuditEntries checks the exact
+// timestamps.
+func QueryForFilter(filter audit.Filter) bson.D {
+	query := bson.D{}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.OriginType != "" {
+		query = append(query, bson.DocElem{"origin-type", filter.OriginType})
+	}
+	if filter.OriginName != "" {
+		query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+	}
+	if filter.Operation != "" {
+		query = append(query, bson.DocElem{"operation", bson.RegEx{Pattern: regexp.QuoteMeta(filter.Operation)}})
+	}
+	if filter.RemoteAddress != "" {
+		query = append(query, bson.DocElem{"remote-address", filter.RemoteAddress})
+	}
+	var timeBounds bson.D
+	if !filter.After.IsZero() {
+		timeBounds = append(timeBounds, bson.DocElem{"$gte", filter.After.UTC().Truncate(time.Millisecond)})
+	}
+	if !filter.Before.IsZero() {
+		timeBounds = append(timeBounds, bson.DocElem{"$lt", filter.Before.UTC()})
+	}
+	if len(timeBounds) > 0 {
+		query = append(query, bson.DocElem{"time", timeBounds})
+	}
+	return query
+}
+
+// SortFields are the fields by which audit entry documents must be
+// sorted, most recent first, for CollectAuditEntries.
+var SortFields = []string{"-time", "-_id"}
+
+// CollectAuditEntries reads audit entry documents from the supplied
+// iterator, which must yield the most recent documents first, and
+// returns those satisfying the filter in chronological order.
+func CollectAuditEntries(iter Iterator, filter audit.Filter) ([]audit.AuditEntry, error) {
+	var entries []audit.AuditEntry
+	var doc auditEntryDoc
+	for iter.Next(&doc) {
+		entry, err := auditEntryFromAuditEntryDoc(doc)
+		if err != nil {
+			iter.Close()
+			return nil, errors.Trace(err)
+		}
+		doc = auditEntryDoc{}
+		if !filter.Match(entry) {
+			continue
+		}
+		entries = append(entries, entry)
+		if filter.Limit > 0 && len(entries) >= filter.Limit {
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Reverse the entries so the oldest is first.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotate(err, "parsing audit entry timestamp")
+	}
+	return audit.AuditEntry{
+		ID:                doc.DocID.Hex(),
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
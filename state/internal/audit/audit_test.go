@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -54,10 +56,18 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 		requestedTimeBlob, err := requested.Timestamp.MarshalText()
 		c.Assert(err, jc.ErrorIsNil)
 
+		var raw bson.M
+		err = bson.Unmarshal(serializedAuditDoc, &raw)
+		c.Assert(err, jc.ErrorIsNil)
+		docID, ok := raw["_id"].(bson.ObjectId)
+		c.Assert(ok, jc.IsTrue)
+
 		c.Check(string(serializedAuditDoc), jc.BSONEquals, map[string]interface{}{
+			"_id":                 docID,
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
 			"timestamp":           string(requestedTimeBlob),
+			"time":                requested.Timestamp,
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -111,3 +121,111 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestQueryForFilter(c *gc.C) {
+	after := time.Date(2016, 10, 1, 12, 0, 0, 1234567, time.UTC)
+	before := after.Add(time.Hour)
+	query := stateaudit.QueryForFilter(audit.Filter{
+		ModelUUID:     "uuid",
+		OriginName:    "user-bob",
+		Operation:     "Client:v1 - FullStatus",
+		RemoteAddress: "8.8.8.8",
+		After:         after,
+		Before:        before,
+	})
+	c.Assert(query, jc.DeepEquals, bson.D{
+		{"model-uuid", "uuid"},
+		{"origin-name", "user-bob"},
+		{"operation", bson.RegEx{Pattern: `Client:v1 - FullStatus`}},
+		{"remote-address", "8.8.8.8"},
+		{"time", bson.D{
+			{"$gte", after.Truncate(time.Millisecond)},
+			{"$lt", before},
+		}},
+	})
+	c.Assert(stateaudit.QueryForFilter(audit.Filter{}), gc.HasLen, 0)
+}
+
+func (*AuditSuite) TestCollectAuditEntries(c *gc.C) {
+	t0 := coretesting.NonZeroTime().UTC()
+	modelUUID := utils.MustNewUUID().String()
+	var entries []audit.AuditEntry
+	var docs []interface{}
+	for i := 0; i < 4; i++ {
+		entry := audit.AuditEntry{
+			JujuServerVersion: version.MustParse("1.0.0"),
+			ModelUUID:         modelUUID,
+			Timestamp:         t0.Add(time.Duration(i) * time.Minute),
+			RemoteAddress:     "8.8.8.8",
+			OriginType:        "user",
+			OriginName:        "bob",
+			Operation:         "status",
+			Data:              map[string]interface{}{"$a.b": "c"},
+		}
+		entries = append(entries, entry)
+		putAuditEntry := stateaudit.PutAuditEntryFn("audit.log", func(_ string, d ...interface{}) error {
+			// Most recent first, as if sorted by descending _id.
+			docs = append(d, docs...)
+			return nil
+		})
+		c.Assert(putAuditEntry(entry), jc.ErrorIsNil)
+	}
+
+	result, err := stateaudit.CollectAuditEntries(&fakeIterator{docs: docs}, audit.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, len(entries))
+	ids := make(map[string]bool)
+	for i, entry := range result {
+		c.Check(entry.ID, gc.Not(gc.Equals), "")
+		ids[entry.ID] = true
+		entries[i].ID = entry.ID
+	}
+	c.Check(ids, gc.HasLen, len(entries))
+	c.Assert(result, jc.DeepEquals, entries)
+
+	result, err = stateaudit.CollectAuditEntries(&fakeIterator{docs: docs}, audit.Filter{
+		Before: t0.Add(3 * time.Minute),
+		Limit:  2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries[1:3])
+
+	// The lower bound is inclusive.
+	result, err = stateaudit.CollectAuditEntries(&fakeIterator{docs: docs}, audit.Filter{
+		After: t0.Add(2 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries[2:])
+}
+
+func (*AuditSuite) TestCollectAuditEntriesIteratorError(c *gc.C) {
+	iter := &fakeIterator{err: errors.New("boom")}
+	_, err := stateaudit.CollectAuditEntries(iter, audit.Filter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeIterator struct {
+	docs []interface{}
+	err  error
+}
+
+func (f *fakeIterator) Next(result interface{}) bool {
+	if len(f.docs) == 0 {
+		return false
+	}
+	data, err := bson.Marshal(f.docs[0])
+	if err != nil {
+		f.err = err
+		return false
+	}
+	f.docs = f.docs[1:]
+	if err := bson.Unmarshal(data, result); err != nil {
+		f.err = err
+		return false
+	}
+	return true
+}
+
+func (f *fakeIterator) Close() error {
+	return f.err
+}
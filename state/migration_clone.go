@@ -0,0 +1,207 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state/storage"
+)
+
+// CloneModelArgs holds the details of a model cloned from another.
+type CloneModelArgs struct {
+	// Model describes the identity of the new model.
+	Model description.CloneArgs
+
+	// AddUnits is true if each application in the new model is to be
+	// given as many units as it has in the source model, each on a
+	// new machine.
+	AddUnits bool
+}
+
+// CloneModel creates a new model in the controller, holding a copy of
+// this State's model's config, constraints, applications and their
+// charms, settings, constraints and endpoint bindings, and the
+// relations between them. The machines and units of the model are not
+// copied; the new model's applications are given fresh units only if
+// args.AddUnits is true. The model is exported and imported as for a
+// migration, so callers must first check, with migration.ExportPrecheck,
+// that it holds nothing an export cannot carry, such as secrets and
+// cross-model relations. The new model and a State for it are
+// returned; the caller is responsible for closing the State.
+func (st *State) CloneModel(args CloneModelArgs) (_ *Model, _ *State, err error) {
+	source, err := st.Export()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "exporting model")
+	}
+	if err := st.checkCloneCredential(source, args.Model.Owner); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	clone, err := description.Clone(source, args.Model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbModel, newSt, err := st.Import(clone)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "importing model")
+	}
+	var charmPaths []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		// The new model is still being imported, so can be removed
+		// outright, along with the charm archives stored for it.
+		newSt.removeCharmArchives(charmPaths)
+		if err := newSt.RemoveImportingModelDocs(); err != nil {
+			logger.Errorf("cannot remove partially cloned model %s: %v", dbModel.UUID(), err)
+		}
+		newSt.Close()
+	}()
+
+	charmPaths, err = newSt.copyCharms(st, clone.Applications())
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "copying charms")
+	}
+	if args.AddUnits {
+		if err := newSt.addClonedUnits(source.Applications()); err != nil {
+			return nil, nil, errors.Annotate(err, "adding units")
+		}
+	}
+	if err := dbModel.SetMigrationMode(MigrationModeNone); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return dbModel, newSt, nil
+}
+
+// checkCloneCredential returns an error if the model's cloud
+// credential, if it has one, cannot be used by the owner of its clone.
+// Only the owner of a credential may use it.
+func (st *State) checkCloneCredential(model description.Model, owner names.UserTag) error {
+	credential := model.CloudCredential()
+	if credential == "" {
+		return nil
+	}
+	credentials, err := st.CloudCredentials(owner, model.Cloud())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := credentials[credential]; !ok {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"model uses cloud credential %q, which %q cannot use", credential, owner.Id(),
+		))
+	}
+	return nil
+}
+
+// copyCharms stores a copy of the charm archive of each of the
+// applications, as found in the source model, in st's model. The
+// storage paths of the archives copied are returned, even on failure.
+func (st *State) copyCharms(source *State, applications []description.Application) ([]string, error) {
+	sourceStorage := storage.NewStorage(source.ModelUUID(), source.MongoSession())
+	targetStorage := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	var storagePaths []string
+	copied := set.NewStrings()
+	for _, application := range applications {
+		if copied.Contains(application.CharmURL()) {
+			continue
+		}
+		copied.Add(application.CharmURL())
+		curl, err := charm.ParseURL(application.CharmURL())
+		if err != nil {
+			return storagePaths, errors.Trace(err)
+		}
+		storagePath, err := st.copyCharm(source, curl, sourceStorage, targetStorage)
+		if err != nil {
+			return storagePaths, errors.Annotatef(err, "charm %q", curl)
+		}
+		storagePaths = append(storagePaths, storagePath)
+	}
+	return storagePaths, nil
+}
+
+func (st *State) copyCharm(source *State, curl *charm.URL, sourceStorage, targetStorage storage.Storage) (string, error) {
+	ch, err := source.Charm(curl)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	macaroon, err := ch.Macaroon()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	reader, size, err := sourceStorage.Get(ch.StoragePath())
+	if err != nil {
+		return "", errors.Annotate(err, "cannot get charm from model storage")
+	}
+	defer reader.Close()
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charms/%s-%s", curl, uuid)
+	if err := targetStorage.Put(storagePath, reader, size); err != nil {
+		return "", errors.Annotate(err, "cannot add charm to model storage")
+	}
+	if _, err := st.AddCharm(CharmInfo{
+		Charm:       ch,
+		ID:          curl,
+		StoragePath: storagePath,
+		SHA256:      ch.BundleSha256(),
+		Macaroon:    macaroon,
+	}); err != nil {
+		if err := targetStorage.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove unrecorded charm archive from storage: %v", err)
+		}
+		return "", errors.Trace(err)
+	}
+	return storagePath, nil
+}
+
+// removeCharmArchives removes the charm archives at the given storage
+// paths from st's model storage, logging any that cannot be removed.
+func (st *State) removeCharmArchives(storagePaths []string) {
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, storagePath := range storagePaths {
+		if err := stor.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove charm archive %q from storage: %v", storagePath, err)
+		}
+	}
+}
+
+// addClonedUnits adds as many units to each principal application in
+// st's model as it has in the source model, each on a new machine.
+// Subordinate units are added as they join relations, as usual.
+func (st *State) addClonedUnits(sourceApplications []description.Application) error {
+	for _, sourceApplication := range sourceApplications {
+		if sourceApplication.Subordinate() {
+			continue
+		}
+		count := len(sourceApplication.Units())
+		if count == 0 {
+			continue
+		}
+		application, err := st.Application(sourceApplication.Name())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i := 0; i < count; i++ {
+			unit, err := application.AddUnit()
+			if err != nil {
+				return errors.Annotatef(err, "cannot add unit %d/%d to application %q", i+1, count, application.Name())
+			}
+			if err := st.AssignUnit(unit, AssignNew); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type internalCloneSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&internalCloneSuite{})
+
+func (s *internalCloneSuite) addCharm(c *gc.C, name, storagePath string) {
+	_, err := s.state.AddCharm(CharmInfo{
+		Charm:       testcharms.Repo.CharmDir(name),
+		ID:          charm.MustParseURL("cs:quantal/" + name + "-1"),
+		StoragePath: storagePath,
+		SHA256:      name + "-sha256",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *internalCloneSuite) TestCopyCharmsFailure(c *gc.C) {
+	s.addCharm(c, "mysql", "mysql-path")
+	s.addCharm(c, "wordpress", "wordpress-path")
+	// Only the mysql charm's archive is stored.
+	sourceStorage := storage.NewStorage(s.state.ModelUUID(), s.state.MongoSession())
+	err := sourceStorage.Put("mysql-path", strings.NewReader("archive"), int64(len("archive")))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt, err := s.state.NewModel(ModelArgs{
+		CloudName:   "dummy",
+		CloudRegion: "dummy-region",
+		Config: testing.CustomModelConfig(c, testing.Attrs{
+			"name": "clone",
+			"uuid": utils.MustNewUUID().String(),
+		}),
+		Owner:                   s.owner,
+		StorageProviderRegistry: provider.CommonStorageProviders(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	model := description.NewModel(description.ModelArgs{Owner: s.owner})
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		CharmURL: "cs:quantal/mysql-1",
+	})
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		CharmURL: "cs:quantal/wordpress-1",
+	})
+	storagePaths, err := newSt.copyCharms(s.state, model.Applications())
+	c.Assert(err, gc.ErrorMatches, `charm "cs:quantal/wordpress-1": cannot get charm from model storage: .*`)
+	c.Assert(storagePaths, gc.HasLen, 1)
+
+	targetStorage := storage.NewStorage(newSt.ModelUUID(), newSt.MongoSession())
+	reader, _, err := targetStorage.Get(storagePaths[0])
+	c.Assert(err, jc.ErrorIsNil)
+	reader.Close()
+
+	newSt.removeCharmArchives(storagePaths)
+	_, _, err = targetStorage.Get(storagePaths[0])
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
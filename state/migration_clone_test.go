@@ -0,0 +1,228 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MigrationCloneSuite struct {
+	MigrationBaseSuite
+}
+
+var _ = gc.Suite(&MigrationCloneSuite{})
+
+const testCharmArchive = "charm archive"
+
+func (s *MigrationCloneSuite) SetUpTest(c *gc.C) {
+	s.MigrationBaseSuite.SetUpTest(c)
+	// The testing charms all claim to be stored at the same path.
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	err := stor.Put("dummy-path", strings.NewReader(testCharmArchive), int64(len(testCharmArchive)))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrationCloneSuite) addApplications(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "wordpress",
+		Charm:    state.AddTestingCharm(c, s.State, "wordpress"),
+		Settings: charm.Settings{"blog-title": "Staging"},
+		EndpointBindings: map[string]string{
+			"db": "db",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := state.AddTestingService(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i < 2; i++ {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ru.EnterScope(nil), jc.ErrorIsNil)
+	}
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql})
+}
+
+func (s *MigrationCloneSuite) cloneModel(c *gc.C, addUnits bool) (*state.Model, *state.State) {
+	owner := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	args := state.CloneModelArgs{
+		Model: description.CloneArgs{
+			UUID:  utils.MustNewUUID().String(),
+			Name:  "staging",
+			Owner: owner.UserTag(),
+		},
+		AddUnits: addUnits,
+	}
+	newModel, newSt, err := s.State.CloneModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(newSt.Close(), jc.ErrorIsNil)
+	})
+	return newModel, newSt
+}
+
+func (s *MigrationCloneSuite) TestCloneModel(c *gc.C) {
+	s.addApplications(c)
+
+	newModel, newSt := s.cloneModel(c, false)
+
+	c.Check(newModel.Name(), gc.Equals, "staging")
+	c.Check(newModel.Owner(), gc.Equals, names.NewUserTag("bob"))
+	c.Check(newModel.MigrationMode(), gc.Equals, state.MigrationModeNone)
+
+	machines, err := newSt.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 0)
+
+	applications, err := newSt.AllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, gc.HasLen, 2)
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(units, gc.HasLen, 0)
+	}
+
+	wordpress, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings["blog-title"], gc.Equals, "Staging")
+	bindings, err := wordpress.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bindings["db"], gc.Equals, "db")
+
+	relations, err := wordpress.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relations, gc.HasLen, 1)
+	c.Check(relations[0].String(), gc.Equals, "wordpress:db mysql:server")
+}
+
+func (s *MigrationCloneSuite) TestCloneModelCopiesCharms(c *gc.C) {
+	s.addApplications(c)
+
+	_, newSt := s.cloneModel(c, false)
+
+	mysql, err := newSt.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := mysql.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.IsUploaded(), jc.IsTrue)
+
+	stor := storage.NewStorage(newSt.ModelUUID(), newSt.MongoSession())
+	reader, _, err := stor.Get(ch.StoragePath())
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, testCharmArchive)
+}
+
+func (s *MigrationCloneSuite) TestCloneModelAddUnits(c *gc.C) {
+	s.addApplications(c)
+
+	_, newSt := s.cloneModel(c, true)
+
+	wordpress, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := wordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 2)
+
+	mysql, err := newSt.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = mysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 1)
+
+	machines, err := newSt.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 3)
+}
+
+func (s *MigrationCloneSuite) TestCloneModelNameInUse(c *gc.C) {
+	s.Factory.MakeModel(c, &factory.ModelParams{
+		Name:  "staging",
+		Owner: s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"}).UserTag(),
+	}).Close()
+
+	_, _, err := s.State.CloneModel(state.CloneModelArgs{
+		Model: description.CloneArgs{
+			UUID:  utils.MustNewUUID().String(),
+			Name:  "staging",
+			Owner: names.NewUserTag("bob"),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `importing model: model "staging" for bob already exists`)
+}
+
+// addCredentialModel makes the controller's cloud require a credential,
+// and returns a model that uses one owned by the controller's owner.
+func (s *MigrationCloneSuite) addCredentialModel(c *gc.C) (*state.State, names.CloudCredentialTag) {
+	state.SetCloudAuthTypes(c, s.State, "dummy", cloud.UserPassAuthType)
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/cred")
+	err := s.State.UpdateCloudCredential(tag, cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"username": "admin", "password": "secret"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{CloudCredential: tag})
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	return st, tag
+}
+
+func (s *MigrationCloneSuite) TestCloneModelCloudCredential(c *gc.C) {
+	st, tag := s.addCredentialModel(c)
+
+	newModel, newSt, err := st.CloneModel(state.CloneModelArgs{
+		Model: description.CloneArgs{
+			UUID:  utils.MustNewUUID().String(),
+			Name:  "staging",
+			Owner: s.Owner,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	credential, ok := newModel.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(credential, gc.Equals, tag)
+}
+
+func (s *MigrationCloneSuite) TestCloneModelCloudCredentialNotOwned(c *gc.C) {
+	st, _ := s.addCredentialModel(c)
+	uuid := utils.MustNewUUID().String()
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+
+	_, _, err := st.CloneModel(state.CloneModelArgs{
+		Model: description.CloneArgs{
+			UUID:  uuid,
+			Name:  "staging",
+			Owner: names.NewUserTag("bob"),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model uses cloud credential "dummy/test-admin/cred", which "bob" cannot use`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -67,6 +67,9 @@ func (st *State) Export() (description.Model, error) {
 		LatestToolsVersion: dbModel.LatestToolsVersion(),
 		Blocks:             blocks,
 	}
+	if credentialTag, ok := dbModel.CloudCredential(); ok {
+		args.CloudCredential = credentialTag.Id()
+	}
 	export.model = description.NewModel(args)
 	modelKey := dbModel.globalKey()
 	export.model.SetAnnotations(export.getAnnotations(modelKey))
@@ -117,6 +120,10 @@ func (st *State) Export() (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.operations(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.cloudimagemetadata(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -482,6 +489,11 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	bindings, err := e.readAllEndpointBindings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	for _, application := range applications {
 		applicationUnits := e.units[application.Name()]
 		leader := leaders[application.Name()]
@@ -491,6 +503,7 @@ func (e *exporter) applications() error {
 			meterStatus: meterStatus,
 			leader:      leader,
 			payloads:    payloads,
+			bindings:    bindings[application.globalKey()],
 		}); err != nil {
 			return errors.Trace(err)
 		}
@@ -547,6 +560,7 @@ type addApplicationContext struct {
 	meterStatus map[string]*meterStatusDoc
 	leader      string
 	payloads    map[string][]payload.FullPayloadInfo
+	bindings    map[string]string
 }
 
 func (e *exporter) addApplication(ctx addApplicationContext) error {
@@ -579,6 +593,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		Leader:               ctx.leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
+		EndpointBindings:     ctx.bindings,
 	}
 	if constraints, found := e.modelStorageConstraints[storageConstraintsKey]; found {
 		args.StorageConstraints = e.storageConstraints(constraints)
@@ -881,6 +896,13 @@ func (e *exporter) actions() error {
 	e.logger.Debugf("read %d actions", len(actions))
 	for _, action := range actions {
 		results, message := action.Results()
+		var messages []description.ActionMessageArgs
+		for _, m := range action.Messages() {
+			messages = append(messages, description.ActionMessageArgs{
+				Timestamp: m.Timestamp,
+				Message:   m.Message,
+			})
+		}
 		e.model.AddAction(description.ActionArgs{
 			Receiver:   action.Receiver(),
 			Name:       action.Name(),
@@ -891,12 +913,31 @@ func (e *exporter) actions() error {
 			Status:     string(action.Status()),
 			Results:    results,
 			Message:    message,
+			Messages:   messages,
+			Timeout:    action.Timeout(),
+			Operation:  action.Operation(),
 			Id:         action.Id(),
 		})
 	}
 	return nil
 }
 
+func (e *exporter) operations() error {
+	operations, err := e.st.AllOperations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d operations", len(operations))
+	for _, operation := range operations {
+		e.model.AddOperation(description.OperationArgs{
+			Id:       operation.Id(),
+			Summary:  operation.Summary(),
+			Enqueued: operation.Enqueued(),
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -933,6 +974,23 @@ func (e *exporter) readAllUnits() (map[string][]*Unit, error) {
 	return result, nil
 }
 
+func (e *exporter) readAllEndpointBindings() (map[string]map[string]string, error) {
+	bindings, closer := e.st.getCollection(endpointBindingsC)
+	defer closer()
+
+	docs := []endpointBindingsDoc{}
+	err := bindings.Find(nil).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all endpoint bindings")
+	}
+	e.logger.Debugf("found %d endpoint binding docs", len(docs))
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		result[e.st.localID(doc.DocID)] = doc.Bindings
+	}
+	return result, nil
+}
+
 func (e *exporter) readAllMeterStatus() (map[string]*meterStatusDoc, error) {
 	meterStatuses, closer := e.st.getCollection(meterStatusC)
 	defer closer()
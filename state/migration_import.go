@@ -4,6 +4,7 @@
 package state
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -55,12 +56,20 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	var credentialTag names.CloudCredentialTag
+	if credential := model.CloudCredential(); credential != "" {
+		if !names.IsValidCloudCredential(credential) {
+			return nil, nil, errors.NotValidf("cloud credential %q", credential)
+		}
+		credentialTag = names.NewCloudCredentialTag(credential)
+	}
 	dbModel, newSt, err := st.NewModel(ModelArgs{
-		CloudName:     model.Cloud(),
-		CloudRegion:   model.CloudRegion(),
-		Config:        cfg,
-		Owner:         model.Owner(),
-		MigrationMode: MigrationModeImporting,
+		CloudName:       model.Cloud(),
+		CloudRegion:     model.CloudRegion(),
+		CloudCredential: credentialTag,
+		Config:          cfg,
+		Owner:           model.Owner(),
+		MigrationMode:   MigrationModeImporting,
 
 		// NOTE(axw) we create the model without any storage
 		// pools. We'll need to import the storage pools from
@@ -102,6 +111,9 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.cloudimagemetadata(); err != nil {
 		return nil, nil, errors.Annotate(err, "cloudimagemetadata")
 	}
+	if err := restore.operations(); err != nil {
+		return nil, nil, errors.Annotate(err, "operations")
+	}
 	if err := restore.actions(); err != nil {
 		return nil, nil, errors.Annotate(err, "actions")
 	}
@@ -642,6 +654,19 @@ func (i *importer) application(s description.Application) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// The charm may not have been uploaded yet, so the bindings are
+	// recorded as they were exported rather than merged with the
+	// charm's defaults.
+	if bindings := s.EndpointBindings(); len(bindings) > 0 {
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     applicationGlobalKey(s.Name()),
+			Assert: txn.DocMissing,
+			Insert: endpointBindingsDoc{
+				Bindings: bindings,
+			},
+		})
+	}
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -1216,6 +1241,38 @@ func (i *importer) actions() error {
 	return nil
 }
 
+func (i *importer) operations() error {
+	i.logger.Debugf("importing operations")
+	modelUUID := i.st.ModelUUID()
+	var ops []txn.Op
+	for _, operation := range i.model.Operations() {
+		seq, err := strconv.Atoi(operation.Id())
+		if err != nil {
+			return errors.Annotatef(err, "operation %q", operation.Id())
+		}
+		ops = append(ops, txn.Op{
+			C:      operationsC,
+			Id:     i.st.docID(operation.Id()),
+			Assert: txn.DocMissing,
+			Insert: &operationDoc{
+				DocId:     i.st.docID(operation.Id()),
+				ModelUUID: modelUUID,
+				Sequence:  seq,
+				Summary:   operation.Summary(),
+				Enqueued:  operation.Enqueued(),
+			},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing operations succeeded")
+	return nil
+}
+
 func (i *importer) addAction(action description.Action) error {
 	modelUUID := i.st.ModelUUID()
 	newDoc := &actionDoc{
@@ -1230,6 +1287,14 @@ func (i *importer) addAction(action description.Action) error {
 		Started:    action.Started(),
 		Completed:  action.Completed(),
 		Status:     ActionStatus(action.Status()),
+		Timeout:    action.Timeout(),
+		Operation:  action.Operation(),
+	}
+	for _, message := range action.Messages() {
+		newDoc.Logs = append(newDoc.Logs, ActionMessage{
+			Timestamp: message.Timestamp(),
+			Message:   message.Message(),
+		})
 	}
 	prefix := ensureActionMarker(action.Receiver())
 	notificationDoc := &actionNotificationDoc{
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
@@ -145,6 +146,27 @@ func (s *MigrationImportSuite) TestNewModel(c *gc.C) {
 	c.Assert(blocks[0].Message(), gc.Equals, "locked down")
 }
 
+func (s *MigrationImportSuite) TestNewModelCloudCredential(c *gc.C) {
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/cred")
+	err := s.State.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{CloudCredential: tag})
+	defer st.Close()
+
+	out, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.CloudCredential(), gc.Equals, tag.Id())
+
+	in := newModel(out, utils.MustNewUUID().String(), "new")
+	newModel, newSt, err := s.State.Import(in)
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	credential, ok := newModel.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credential, gc.Equals, tag)
+}
+
 func (s *MigrationImportSuite) newModelUser(c *gc.C, name string, readOnly bool, lastConnection time.Time) permission.UserAccess {
 	access := permission.AdminAccess
 	if readOnly {
@@ -371,6 +393,30 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestApplicationEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "mysql",
+		Charm: state.AddTestingCharm(c, s.State, "mysql"),
+		EndpointBindings: map[string]string{
+			"server": "db",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	exportedBindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	imported, err := newSt.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	importedBindings, err := imported.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedBindings, jc.DeepEquals, exportedBindings)
+	c.Assert(importedBindings["server"], gc.Equals, "db")
+}
+
 func (s *MigrationImportSuite) TestApplicationLeaders(c *gc.C) {
 	s.makeApplicationWithLeader(c, "mysql", 2, 1)
 	s.makeApplicationWithLeader(c, "wordpress", 4, 2)
@@ -751,7 +797,7 @@ func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
 	})
-	_, err := s.State.EnqueueAction(machine.MachineTag(), "foo", nil)
+	_, err := s.State.EnqueueActionWithTimeout(machine.MachineTag(), "foo", nil, time.Hour)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, newSt := s.importModel(c)
@@ -765,6 +811,55 @@ func (s *MigrationImportSuite) TestAction(c *gc.C) {
 	c.Check(action.Receiver(), gc.Equals, machine.Id())
 	c.Check(action.Name(), gc.Equals, "foo")
 	c.Check(action.Status(), gc.Equals, state.ActionPending)
+	c.Check(action.Timeout(), gc.Equals, time.Hour)
+}
+
+func (s *MigrationImportSuite) TestOperation(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	operation, err := s.State.EnqueueOperation("foo run on 1 machine")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnqueueOperationAction(operation.Id(), machine.MachineTag(), "foo", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer func() {
+		c.Assert(newSt.Close(), jc.ErrorIsNil)
+	}()
+
+	imported, err := newSt.Operation(operation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Summary(), gc.Equals, "foo run on 1 machine")
+	c.Check(imported.Enqueued().Equal(operation.Enqueued()), jc.IsTrue)
+	actions, err := imported.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Operation(), gc.Equals, operation.Id())
+
+	// New operations carry on from the imported sequence.
+	next, err := newSt.EnqueueOperation("bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Id(), gc.Not(gc.Equals), operation.Id())
+}
+
+func (s *MigrationImportSuite) TestActionMessages(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	action, err := s.State.EnqueueAction(machine.MachineTag(), "foo", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("working")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer func() {
+		c.Assert(newSt.Close(), jc.ErrorIsNil)
+	}()
+
+	imported, err := newSt.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Messages(), jc.DeepEquals, action.Messages())
 }
 
 func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
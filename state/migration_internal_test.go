@@ -42,6 +42,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
 		payloadsC,
+		endpointBindingsC,
 
 		// relation
 		relationsC,
@@ -61,6 +62,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// actions
 		actionsC,
+		operationsC,
 
 		// storage
 		filesystemsC,
@@ -155,6 +157,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
+
+		// Hook history describes what units did on the source
+		// controller, and is rebuilt as they run hooks on the target.
+		hookHistoryC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -168,11 +174,21 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// service / unit
 		charmsC,
 		"resources",
-		endpointBindingsC,
 
 		// uncategorised
 		metricsManagerC, // should really be copied across
 		auditingC,
+
+		// The secrets' values are encrypted with a key belonging to
+		// the source controller, so models with secrets are refused
+		// by the migration prechecks.
+		secretsC,
+
+		// Cross-model relations refer to other models by UUID, so
+		// models with remote applications or offers are refused by
+		// the migration prechecks.
+		applicationOffersC,
+		remoteApplicationsC,
 	)
 
 	envCollections := set.NewStrings()
@@ -651,10 +667,27 @@ func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 		"Results",
 		"Message",
 		"Status",
+		"Logs",
+		"Timeout",
+		"Operation",
 	)
 	s.AssertExportedFields(c, actionDoc{}, migrated.Union(ignored))
 }
 
+func (s *MigrationSuite) TestOperationDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		// Sequence is the number in the id.
+		"Sequence",
+	)
+	migrated := set.NewStrings(
+		"DocId",
+		"Summary",
+		"Enqueued",
+	)
+	s.AssertExportedFields(c, operationDoc{}, migrated.Union(ignored))
+}
+
 func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
@@ -275,6 +275,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 	newSt.controllerModelTag = st.controllerModelTag
+	newSt.secretsKey = st.secretsKey
 
 	modelOps, err := newSt.modelSetupOps(st.controllerTag.Id(), args, nil)
 	if err != nil {
@@ -305,7 +306,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		} else if envCount > 0 {
 			err = errors.AlreadyExistsf("model %q for %s", name, owner.Id())
 		} else {
-			err = errors.New("model already exists")
+			err = errors.NewAlreadyExists(nil, "model already exists")
 		}
 	}
 	if err != nil {
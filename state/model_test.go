@@ -130,6 +130,27 @@ func (s *ModelSuite) TestNewModelSameUserSameNameFails(c *gc.C) {
 	c.Assert(st2, gc.NotNil)
 }
 
+func (s *ModelSuite) TestNewModelSameUUIDFails(c *gc.C) {
+	cfg, _ := s.createTestModelConfig(c)
+	owner := s.Factory.MakeUser(c, nil).UserTag()
+	args := state.ModelArgs{
+		CloudName:   "dummy",
+		CloudRegion: "dummy-region",
+		Config:      cfg,
+		Owner:       owner,
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+	}
+	_, st1, err := s.State.NewModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st1.Close()
+
+	// Another owner is used, so that only the UUID clashes.
+	args.Owner = s.Factory.MakeUser(c, nil).UserTag()
+	_, _, err = s.State.NewModel(args)
+	c.Assert(err, gc.ErrorMatches, "model already exists")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
 func (s *ModelSuite) TestNewModel(c *gc.C) {
 	cfg, uuid := s.createTestModelConfig(c)
 	owner := names.NewUserTag("test@remote")
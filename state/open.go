@@ -249,6 +249,10 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 		return nil, err
 	}
 
+	// The controller's secrets are kept apart from the controller
+	// config, which is readable by every agent.
+	controllerConfig, controllerSecrets := args.ControllerConfig.SplitSecrets()
+
 	dateCreated := st.NowToTheSecond()
 	ops := createInitialUserOps(
 		args.ControllerConfig.ControllerUUID(),
@@ -287,7 +291,8 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 			Assert: txn.DocMissing,
 			Insert: &hostedModelCountDoc{},
 		},
-		createSettingsOp(controllersC, controllerSettingsGlobalKey, args.ControllerConfig),
+		createSettingsOp(controllersC, controllerSettingsGlobalKey, controllerConfig),
+		createSettingsOp(controllersC, controllerSecretsGlobalKey, controllerSecrets),
 		createSettingsOp(globalSettingsC, controllerInheritedSettingsGlobalKey, args.ControllerInheritedConfig),
 	)
 	for k, v := range args.Cloud.RegionConfig {
@@ -0,0 +1,299 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// operationDoc records a group of actions enqueued together, such as
+// one action run across all the units of an application.
+type operationDoc struct {
+	// DocId is the key for this document; it is a sequence number.
+	DocId string `bson:"_id"`
+
+	// ModelUUID is the model identifier.
+	ModelUUID string `bson:"model-uuid"`
+
+	// Sequence is the sequence number in the id, by which operations
+	// are listed in the order they were enqueued.
+	Sequence int `bson:"sequence"`
+
+	// Summary describes the operation for people.
+	Summary string `bson:"summary"`
+
+	// Enqueued is the time the operation was added.
+	Enqueued time.Time `bson:"enqueued"`
+}
+
+// Operation groups the actions enqueued by a single request, so that
+// they may be followed together.
+type Operation struct {
+	st  *State
+	doc operationDoc
+}
+
+// Id returns the local id of the operation.
+func (op *Operation) Id() string {
+	return op.st.localID(op.doc.DocId)
+}
+
+// Summary returns the description of the operation.
+func (op *Operation) Summary() string {
+	return op.doc.Summary
+}
+
+// Enqueued returns the time the operation was added.
+func (op *Operation) Enqueued() time.Time {
+	return op.doc.Enqueued
+}
+
+// Actions returns the actions that make up the operation, in the order
+// they were enqueued.
+func (op *Operation) Actions() ([]Action, error) {
+	actions, closer := op.st.getCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	err := actions.Find(bson.D{{"operation", op.Id()}}).Sort("enqueued", "_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get actions for operation %q", op.Id())
+	}
+	results := make([]Action, len(docs))
+	for i, doc := range docs {
+		results[i] = newAction(op.st, doc)
+	}
+	return results, nil
+}
+
+// ActionCounts returns the number of the operation's actions with each
+// status.
+func (op *Operation) ActionCounts() (map[ActionStatus]int, error) {
+	counts, err := op.st.OperationActionCounts([]string{op.Id()})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return counts[op.Id()], nil
+}
+
+// OperationActionCounts returns the number of actions with each status
+// for each of the operations with the given ids, in a single query.
+// An operation without any actions has no counts.
+func (st *State) OperationActionCounts(ids []string) (map[string]map[ActionStatus]int, error) {
+	actions, closer := st.getCollection(actionsC)
+	defer closer()
+
+	var doc struct {
+		Operation string       `bson:"operation"`
+		Status    ActionStatus `bson:"status"`
+	}
+	counts := make(map[string]map[ActionStatus]int)
+	for _, id := range ids {
+		counts[id] = make(map[ActionStatus]int)
+	}
+	iter := actions.Find(bson.D{{"operation", bson.D{{"$in", ids}}}}).Select(bson.D{
+		{"operation", 1},
+		{"status", 1},
+	}).Iter()
+	for iter.Next(&doc) {
+		counts[doc.Operation][doc.Status]++
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot count actions for operations")
+	}
+	return counts, nil
+}
+
+// OperationStatus returns the status of an operation whose actions
+// have the given counts of each status. An operation is pending until
+// any of its actions start, and running until they have all finished;
+// it has then failed if any of them failed or were aborted, been
+// cancelled if they all were, and otherwise completed.
+func OperationStatus(counts map[ActionStatus]int) ActionStatus {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	switch {
+	case counts[ActionPending] == total:
+		return ActionPending
+	case counts[ActionPending]+counts[ActionRunning]+counts[ActionAborting] > 0:
+		return ActionRunning
+	case counts[ActionFailed]+counts[ActionAborted] > 0:
+		return ActionFailed
+	case counts[ActionCancelled] == total:
+		return ActionCancelled
+	}
+	return ActionCompleted
+}
+
+// Remove removes the operation. Its actions are left alone, so it
+// should only be used for an operation none of whose actions could be
+// enqueued.
+func (op *Operation) Remove() error {
+	err := op.st.runTransaction([]txn.Op{{
+		C:      operationsC,
+		Id:     op.doc.DocId,
+		Remove: true,
+	}})
+	return errors.Annotatef(err, "cannot remove operation %q", op.Id())
+}
+
+// EnqueueOperation adds an operation with the given summary, to which
+// actions may then be added with EnqueueOperationAction.
+func (st *State) EnqueueOperation(summary string) (*Operation, error) {
+	seq, err := st.sequence("operation")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := operationDoc{
+		DocId:     st.docID(strconv.Itoa(seq)),
+		ModelUUID: st.ModelUUID(),
+		Sequence:  seq,
+		Summary:   summary,
+		Enqueued:  st.NowToTheSecond(),
+	}
+	err = st.runTransaction([]txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add operation")
+	}
+	return &Operation{st: st, doc: doc}, nil
+}
+
+// Operation returns the operation with the given id.
+func (st *State) Operation(id string) (*Operation, error) {
+	operations, closer := st.getCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("operation %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get operation %q", id)
+	}
+	return &Operation{st: st, doc: doc}, nil
+}
+
+// AllOperations returns all the operations in the model, oldest first.
+func (st *State) AllOperations() ([]*Operation, error) {
+	operations, closer := st.getCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	if err := operations.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all operations")
+	}
+	results := make([]*Operation, len(docs))
+	for i, doc := range docs {
+		results[i] = &Operation{st: st, doc: doc}
+	}
+	// Operations are numbered in the order they are enqueued, which
+	// is finer than their enqueued times.
+	sort.Sort(operationsById(results))
+	return results, nil
+}
+
+// ListOperations returns up to limit operations in the model, oldest
+// first, skipping the first offset of them. It also reports whether
+// there are more operations after those returned.
+func (st *State) ListOperations(offset, limit int) ([]*Operation, bool, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, false, errors.NotValidf("offset %d and limit %d", offset, limit)
+	}
+	operations, closer := st.getCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	err := operations.Find(nil).Sort("sequence").Skip(offset).Limit(limit + 1).All(&docs)
+	if err != nil {
+		return nil, false, errors.Annotate(err, "cannot list operations")
+	}
+	more := len(docs) > limit
+	if more {
+		docs = docs[:limit]
+	}
+	results := make([]*Operation, len(docs))
+	for i, doc := range docs {
+		results[i] = &Operation{st: st, doc: doc}
+	}
+	return results, more, nil
+}
+
+type operationsById []*Operation
+
+func (ops operationsById) Len() int      { return len(ops) }
+func (ops operationsById) Swap(i, j int) { ops[i], ops[j] = ops[j], ops[i] }
+func (ops operationsById) Less(i, j int) bool {
+	// The ids are always numbers.
+	iId, _ := strconv.Atoi(ops[i].Id())
+	jId, _ := strconv.Atoi(ops[j].Id())
+	return iId < jId
+}
+
+// pruneOperations removes the operations enqueued before the given
+// time that no longer have any actions. Only those operations are
+// looked at, and both queries are served by indexes.
+func pruneOperations(st *State, before time.Time) error {
+	operations, closer := st.getCollection(operationsC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	err := operations.Find(bson.D{
+		{"enqueued", bson.D{{"$lt", before}}},
+	}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "finding operations to prune")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	candidates := make([]string, len(docs))
+	for i, doc := range docs {
+		candidates[i] = st.localID(doc.DocId)
+	}
+
+	actions, closer := st.getCollection(actionsC)
+	defer closer()
+	var inUse []string
+	err = actions.Find(bson.D{
+		{"operation", bson.D{{"$in", candidates}}},
+	}).Distinct("operation", &inUse)
+	if err != nil {
+		return errors.Annotate(err, "finding operations in use")
+	}
+	used := set.NewStrings(inUse...)
+	var unused []string
+	for _, id := range candidates {
+		if !used.Contains(id) {
+			unused = append(unused, st.docID(id))
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+	info, err := operations.Writeable().RemoveAll(bson.D{
+		{"_id", bson.D{{"$in", unused}}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "pruning operations")
+	}
+	actionLogger.Debugf("pruned %d operations without actions", info.Removed)
+	return nil
+}
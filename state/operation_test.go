@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+func (s *ActionSuite) TestEnqueueOperation(c *gc.C) {
+	first, err := s.State.EnqueueOperation("snapshot run on 2 units")
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.State.EnqueueOperation("fakeaction run on 1 unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first.Id(), gc.Not(gc.Equals), second.Id())
+
+	op, err := s.State.Operation(first.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(op.Id(), gc.Equals, first.Id())
+	c.Check(op.Summary(), gc.Equals, "snapshot run on 2 units")
+	c.Check(op.Enqueued().Equal(first.Enqueued()), jc.IsTrue)
+
+	all, err := s.State.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Check(all[0].Id(), gc.Equals, first.Id())
+	c.Check(all[1].Id(), gc.Equals, second.Id())
+}
+
+func (s *ActionSuite) TestOperationNotFound(c *gc.C) {
+	_, err := s.State.Operation("42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionSuite) TestOperationActions(c *gc.C) {
+	op, err := s.State.EnqueueOperation("snapshot run on 2 units")
+	c.Assert(err, jc.ErrorIsNil)
+	a1, err := s.unit.AddOperationAction(op.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	a2, err := s.unit2.AddOperationAction(op.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	alone, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a1.Operation(), gc.Equals, op.Id())
+	c.Check(alone.Operation(), gc.Equals, "")
+
+	actions, err := op.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+	ids := []string{actions[0].Id(), actions[1].Id()}
+	c.Check(ids, jc.SameContents, []string{a1.Id(), a2.Id()})
+
+	s.assertOperationCounts(c, op, map[state.ActionStatus]int{
+		state.ActionPending: 2,
+	}, state.ActionPending)
+
+	_, err = a1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOperationCounts(c, op, map[state.ActionStatus]int{
+		state.ActionPending: 1,
+		state.ActionRunning: 1,
+	}, state.ActionRunning)
+
+	_, err = a1.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a2.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOperationCounts(c, op, map[state.ActionStatus]int{
+		state.ActionCompleted: 1,
+		state.ActionFailed:    1,
+	}, state.ActionFailed)
+}
+
+func (s *ActionSuite) assertOperationCounts(c *gc.C, op *state.Operation, expect map[state.ActionStatus]int, status state.ActionStatus) {
+	counts, err := op.ActionCounts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(counts, jc.DeepEquals, expect)
+	c.Check(state.OperationStatus(counts), gc.Equals, status)
+}
+
+func (s *ActionSuite) TestAddOperationActionUnknownOperation(c *gc.C) {
+	_, err := s.unit.AddOperationAction("42", "snapshot", nil, 0)
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *ActionSuite) TestOperationRemove(c *gc.C) {
+	op, err := s.State.EnqueueOperation("snapshot run on 1 unit")
+	c.Assert(err, jc.ErrorIsNil)
+	err = op.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Operation(op.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionSuite) TestOperationStatus(c *gc.C) {
+	for i, test := range []struct {
+		counts map[state.ActionStatus]int
+		status state.ActionStatus
+	}{{
+		counts: nil,
+		status: state.ActionPending,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionPending: 3},
+		status: state.ActionPending,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionPending: 1, state.ActionCompleted: 1},
+		status: state.ActionRunning,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionAborting: 1, state.ActionCompleted: 1},
+		status: state.ActionRunning,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionAborted: 1, state.ActionCompleted: 2},
+		status: state.ActionFailed,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionCancelled: 2},
+		status: state.ActionCancelled,
+	}, {
+		counts: map[state.ActionStatus]int{state.ActionCancelled: 1, state.ActionCompleted: 2},
+		status: state.ActionCompleted,
+	}} {
+		c.Logf("test %d: %v", i, test.counts)
+		c.Check(state.OperationStatus(test.counts), gc.Equals, test.status)
+	}
+}
+
+func (s *ActionSuite) TestPruneActionsRemovesEmptyOperations(c *gc.C) {
+	clock := jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old, err := s.State.EnqueueOperation("snapshot run on 1 unit")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddOperationAction(old.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock.Advance(48 * time.Hour)
+	recent, err := s.State.EnqueueOperation("snapshot run on 1 unit")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.AddOperationAction(recent.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Operation(old.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Operation(recent.Id())
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestPruneActionsKeepsOperationsWithActions(c *gc.C) {
+	clock := jujutesting.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old, err := s.State.EnqueueOperation("snapshot run on 2 units")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddOperationAction(old.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit2.AddOperationAction(old.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock.Advance(48 * time.Hour)
+	err = state.PruneActions(s.State, 24*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Operation(old.Id())
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestListOperations(c *gc.C) {
+	var ids []string
+	for i := 0; i < 12; i++ {
+		op, err := s.State.EnqueueOperation("snapshot run on 1 unit")
+		c.Assert(err, jc.ErrorIsNil)
+		ids = append(ids, op.Id())
+	}
+	opIds := func(ops []*state.Operation) []string {
+		var result []string
+		for _, op := range ops {
+			result = append(result, op.Id())
+		}
+		return result
+	}
+
+	// Operations are listed in the order they were enqueued, which is
+	// not the order of their ids as strings.
+	ops, more, err := s.State.ListOperations(0, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(opIds(ops), jc.DeepEquals, ids[:10])
+	c.Check(more, jc.IsTrue)
+
+	ops, more, err = s.State.ListOperations(10, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(opIds(ops), jc.DeepEquals, ids[10:])
+	c.Check(more, jc.IsFalse)
+
+	_, _, err = s.State.ListOperations(0, 0)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionSuite) TestOperationActionCounts(c *gc.C) {
+	op1, err := s.State.EnqueueOperation("snapshot run on 2 units")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddOperationAction(op1.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit2.AddOperationAction(op1.Id(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	op2, err := s.State.EnqueueOperation("snapshot run on 1 unit")
+	c.Assert(err, jc.ErrorIsNil)
+
+	counts, err := s.State.OperationActionCounts([]string{op1.Id(), op2.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(counts, jc.DeepEquals, map[string]map[state.ActionStatus]int{
+		op1.Id(): {
+			state.ActionPending: 1,
+			state.ActionRunning: 1,
+		},
+		op2.Id(): {},
+	})
+}
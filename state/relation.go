@@ -144,7 +144,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -160,33 +160,55 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
-// services may be Dying and otherwise unreferenced, and may thus require
-// removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+// included; if departingUnitName is not empty, this implies that the
+// relation's services may be Dying and otherwise unreferenced, and may
+// thus require removal themselves.
+func (r *Relation) removeOps(ignoreService string, departingUnitName string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	var departingApplication string
+	if departingUnitName != "" {
+		var err error
+		if departingApplication, err = names.UnitApplication(departingUnitName); err != nil {
+			return nil, errors.Trace(err)
+		}
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
 	}
 	ops := []txn.Op{relOp}
+	secretOps, err := removeSecretGrantsOps(r.st, r.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
 	for _, ep := range r.doc.Endpoints {
 		if ep.ApplicationName == ignoreService {
 			continue
 		}
+		isRemote, err := isRemoteApplication(r.st, ep.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isRemote {
+			remoteOps, err := r.remoteApplicationRemoveOps(ep.ApplicationName, departingApplication)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, remoteOps...)
+			continue
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingUnitName == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ApplicationName == departingUnit.ApplicationName() {
+		} else if ep.ApplicationName == departingApplication {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
@@ -228,6 +250,24 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 	return append(ops, cleanupOp), nil
 }
 
+// remoteApplicationRemoveOps returns the operations necessary to update
+// the named remote application when the relation is removed. Remote
+// applications have no units of their own to keep them alive, so when
+// a unit departs, one that is Dying is removed along with its last
+// relation.
+func (r *Relation) remoteApplicationRemoveOps(applicationName, departingApplication string) ([]txn.Op, error) {
+	if departingApplication != "" {
+		return remoteApplicationRelationRemoveOps(r.st, applicationName)
+	}
+	hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     r.st.docID(applicationName),
+		Assert: append(hasRelation, isAliveDoc...),
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
+
 // Id returns the integer internal relation key. This is exposed
 // because the unit agent needs to expose a value derived from this
 // (as JUJU_RELATION_ID) to allow relation hooks to differentiate
@@ -291,11 +331,42 @@ func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 		st:       r.st,
 		relation: r,
 		unit:     u,
+		unitName: u.doc.Name,
 		endpoint: ep,
 		scope:    strings.Join(scope, "#"),
 	}, nil
 }
 
+// RemoteUnit returns a RelationUnit for the named unit of a remote
+// application taking part in the relation. Remote units have no
+// document of their own; they enter and leave scope, and write their
+// settings, on behalf of the unit in the other model.
+func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
+	applicationName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ep, err := r.Endpoint(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if isRemote, err := isRemoteApplication(r.st, applicationName); err != nil {
+		return nil, errors.Trace(err)
+	} else if !isRemote {
+		return nil, errors.NotValidf("unit %q of local application", unitName)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.NotSupportedf("remote unit %q in container scoped relation", unitName)
+	}
+	return &RelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+		scope:    fmt.Sprintf("r#%d", r.doc.Id),
+	}, nil
+}
+
 // relationSettingsCleanupChange removes the settings doc.
 type relationSettingsCleanupChange struct {
 	Prefix string
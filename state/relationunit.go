@@ -24,7 +24,10 @@ import (
 type RelationUnit struct {
 	st       *State
 	relation *Relation
+	// unit is nil for the units of remote applications, which are
+	// known only by unitName.
 	unit     *Unit
+	unitName string
 	endpoint Endpoint
 	scope    string
 }
@@ -42,6 +45,9 @@ func (ru *RelationUnit) Endpoint() Endpoint {
 
 // PrivateAddress returns the private address of the unit.
 func (ru *RelationUnit) PrivateAddress() (network.Address, error) {
+	if ru.unit == nil {
+		return network.Address{}, errors.NotSupportedf("private address of remote unit %q", ru.unitName)
+	}
 	return ru.unit.PrivateAddress()
 }
 
@@ -90,9 +96,15 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// * TODO(fwereade): check unit status == params.StatusActive (this
 	//   breaks a bunch of tests in a boring but noisy-to-fix way, and is
 	//   being saved for a followup).
-	unitDocID, relationDocID := ru.unit.doc.DocID, ru.relation.doc.DocID
+	// The units of remote applications have no documents, so their
+	// application's state is checked instead.
+	unitsColl, unitDocID := unitsC, ru.st.docID(ru.unitName)
+	if ru.unit == nil {
+		unitsColl, unitDocID = remoteApplicationsC, ru.st.docID(ru.endpoint.ApplicationName)
+	}
+	relationDocID := ru.relation.doc.DocID
 	ops := []txn.Op{{
-		C:      unitsC,
+		C:      unitsColl,
 		Id:     unitDocID,
 		Assert: isAliveDoc,
 	}, {
@@ -154,6 +166,8 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 
 	units, closer := db.GetCollection(unitsC)
 	defer closer()
+	unitsOrApplications, closer := db.GetCollection(unitsColl)
+	defer closer()
 	relations, closer := db.GetCollection(relationsC)
 	defer closer()
 
@@ -162,7 +176,7 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// unit: this could fail due to the subordinate service's not being Alive,
 	// but this case will always be caught by the check for the relation's
 	// life (because a relation cannot be Alive if its services are not).)
-	if alive, err := isAliveWithSession(units, unitDocID); err != nil {
+	if alive, err := isAliveWithSession(unitsOrApplications, unitDocID); err != nil {
 		return err
 	} else if !alive {
 		return ErrCannotEnterScope
@@ -187,7 +201,7 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 	// has changed under our feet, preventing us from clearing it properly; if
 	// that is the case, something is seriously wrong (nobody else should be
 	// touching that doc under our feet) and we should bail out.
-	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unit, ru.relation)
+	prefix := fmt.Sprintf("cannot enter scope for unit %q in relation %q: ", ru.unitName, ru.relation)
 	if changed, err := settingsChanged(); err != nil {
 		return err
 	} else if changed {
@@ -207,7 +221,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	units, closer := ru.st.getCollection(unitsC)
 	defer closer()
 
-	if !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
+	if ru.unit == nil || !ru.unit.IsPrincipal() || ru.endpoint.Scope != charm.ScopeContainer {
 		return nil, "", nil
 	}
 	related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName)
@@ -292,7 +306,7 @@ func (ru *RelationUnit) LeaveScope() error {
 	// to have a Dying relation with a smaller-than-real unit count, because
 	// Destroy changes the Life attribute in memory (units could join before
 	// the database is actually changed).
-	desc := fmt.Sprintf("unit %q in relation %q", ru.unit, ru.relation)
+	desc := fmt.Sprintf("unit %q in relation %q", ru.unitName, ru.relation)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := ru.relation.Refresh(); errors.IsNotFound(err) {
@@ -328,7 +342,7 @@ func (ru *RelationUnit) LeaveScope() error {
 				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
 			})
 		} else {
-			relOps, err := ru.relation.removeOps("", ru.unit)
+			relOps, err := ru.relation.removeOps("", ru.unitName)
 			if err != nil {
 				return nil, err
 			}
@@ -372,7 +386,7 @@ func (ru *RelationUnit) inScope(sel bson.D) (bool, error) {
 func (ru *RelationUnit) WatchScope() *RelationScopeWatcher {
 	role := counterpartRole(ru.endpoint.Role)
 	scope := ru.scope + "#" + string(role)
-	return newRelationScopeWatcher(ru.st, scope, ru.unit.Name())
+	return newRelationScopeWatcher(ru.st, scope, ru.unitName)
 }
 
 // Settings returns a Settings which allows access to the unit's settings
@@ -421,7 +435,7 @@ func (ru *RelationUnit) unitKey(uname string) (string, error) {
 // which is used as a key for that unit within this relation in the settings,
 // presence, and relationScopes collections.
 func (ru *RelationUnit) key() string {
-	return ru._key(string(ru.endpoint.Role), ru.unit.Name())
+	return ru._key(string(ru.endpoint.Role), ru.unitName)
 }
 
 func (ru *RelationUnit) _key(role, unitname string) string {
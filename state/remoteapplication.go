@@ -0,0 +1,479 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// remoteApplicationDoc represents an application in another model,
+// which applications in this model may relate to.
+type remoteApplicationDoc struct {
+	DocID     string `bson:"_id"`
+	Name      string `bson:"name"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// OfferURL is the URL of the offer the application was consumed
+	// from; it is empty for consumer proxies.
+	OfferURL string `bson:"offer-url,omitempty"`
+
+	// SourceModelUUID is the UUID of the model the application lives
+	// in.
+	SourceModelUUID string `bson:"source-model-uuid"`
+
+	// SourceApplicationName is the name of the application in its
+	// own model.
+	SourceApplicationName string `bson:"source-application"`
+
+	// OfferName is the name of the offer in the source model; it is
+	// empty for consumer proxies.
+	OfferName string `bson:"offer-name,omitempty"`
+
+	// IsConsumerProxy is true if the application consumes one of this
+	// model's offers, rather than being consumed by this model.
+	IsConsumerProxy bool `bson:"is-consumer-proxy"`
+
+	Endpoints     []charm.Relation `bson:"endpoints"`
+	Life          Life             `bson:"life"`
+	RelationCount int              `bson:"relationcount"`
+
+	// Terminated is set once the application consumed has gone from
+	// its model, or the model itself has gone.
+	Terminated bool `bson:"terminated,omitempty"`
+}
+
+// RemoteApplication represents the state of an application hosted in
+// another model. Units of the applications related to it see the
+// remote application's units as they would those of a local one.
+type RemoteApplication struct {
+	st  *State
+	doc remoteApplicationDoc
+}
+
+func newRemoteApplication(st *State, doc *remoteApplicationDoc) *RemoteApplication {
+	return &RemoteApplication{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// Name returns the name of the remote application, as it is known in
+// this model.
+func (s *RemoteApplication) Name() string {
+	return s.doc.Name
+}
+
+// Tag returns a name identifying the remote application.
+func (s *RemoteApplication) Tag() names.Tag {
+	return names.NewApplicationTag(s.doc.Name)
+}
+
+// String returns the remote application name.
+func (s *RemoteApplication) String() string {
+	return s.doc.Name
+}
+
+// OfferURL returns the URL of the offer the application was consumed
+// from.
+func (s *RemoteApplication) OfferURL() string {
+	return s.doc.OfferURL
+}
+
+// SourceApplicationName returns the name of the application in the
+// model it lives in.
+func (s *RemoteApplication) SourceApplicationName() string {
+	return s.doc.SourceApplicationName
+}
+
+// OfferName returns the name of the offer, in the source model, that
+// the application was consumed from.
+func (s *RemoteApplication) OfferName() string {
+	return s.doc.OfferName
+}
+
+// SourceModel returns the tag of the model the application lives in.
+func (s *RemoteApplication) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// IsConsumerProxy returns whether the remote application stands for an
+// application consuming one of this model's offers.
+func (s *RemoteApplication) IsConsumerProxy() bool {
+	return s.doc.IsConsumerProxy
+}
+
+// IsTerminated returns whether the application consumed has gone from
+// its model, so that no relations can be made to it.
+func (s *RemoteApplication) IsTerminated() bool {
+	return s.doc.Terminated
+}
+
+// setTerminated records that the application consumed has gone from
+// its model.
+func (s *RemoteApplication) setTerminated() error {
+	if s.doc.Terminated {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"terminated", true}}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("remote application %q", s.doc.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot terminate remote application %q", s.doc.Name)
+	}
+	s.doc.Terminated = true
+	return nil
+}
+
+// Life returns whether the remote application is Alive, Dying or Dead.
+func (s *RemoteApplication) Life() Life {
+	return s.doc.Life
+}
+
+// Endpoints returns the remote application's relation endpoints.
+func (s *RemoteApplication) Endpoints() ([]Endpoint, error) {
+	eps := make([]Endpoint, len(s.doc.Endpoints))
+	for i, rel := range s.doc.Endpoints {
+		eps[i] = Endpoint{
+			ApplicationName: s.doc.Name,
+			Relation:        rel,
+		}
+	}
+	sort.Sort(epSlice(eps))
+	return eps, nil
+}
+
+// Endpoint returns the relation endpoint with the supplied name, if it
+// exists.
+func (s *RemoteApplication) Endpoint(relationName string) (Endpoint, error) {
+	eps, err := s.Endpoints()
+	if err != nil {
+		return Endpoint{}, err
+	}
+	for _, ep := range eps {
+		if ep.Name == relationName {
+			return ep, nil
+		}
+	}
+	return Endpoint{}, errors.Errorf("remote application %q has no %q relation", s, relationName)
+}
+
+// Relations returns the relations of the remote application.
+func (s *RemoteApplication) Relations() ([]*Relation, error) {
+	return applicationRelations(s.st, s.doc.Name)
+}
+
+// Refresh refreshes the contents of the remote application from the
+// underlying state. It returns an error that satisfies errors.IsNotFound
+// if the remote application has been removed.
+func (s *RemoteApplication) Refresh() error {
+	applications, closer := s.st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	err := applications.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote application %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote application %q", s)
+	}
+	return nil
+}
+
+// Destroy ensures that the remote application and its relations will
+// be removed at some point; if it has no relations, it is removed
+// immediately.
+func (s *RemoteApplication) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote application %q", s)
+	defer func() {
+		if err == nil {
+			// This is a white lie; the document might actually be removed.
+			s.doc.Life = Dying
+		}
+	}()
+	app := &RemoteApplication{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		switch ops, err := app.destroyOps(); err {
+		case errRefresh:
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return ops, nil
+		default:
+			return nil, err
+		}
+		return nil, jujutxn.ErrTransientFailure
+	}
+	return app.st.run(buildTxn)
+}
+
+// destroyOps returns the operations required to destroy the remote
+// application. If it returns errRefresh, the application should be
+// refreshed and the destruction operations recalculated.
+func (s *RemoteApplication) destroyOps() ([]txn.Op, error) {
+	if s.doc.Life == Dying {
+		return nil, errAlreadyDying
+	}
+	rels, err := s.Relations()
+	if err != nil {
+		return nil, err
+	}
+	if len(rels) != s.doc.RelationCount {
+		// This is just an early bail out. The relations obtained may still
+		// be wrong, but that situation will be caught by a combination of
+		// asserts on relationcount and on each known relation, below.
+		return nil, errRefresh
+	}
+	var ops []txn.Op
+	removeCount := 0
+	for _, rel := range rels {
+		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
+		if err == errAlreadyDying {
+			relOps = []txn.Op{{
+				C:      relationsC,
+				Id:     rel.doc.DocID,
+				Assert: bson.D{{"life", Dying}},
+			}}
+		} else if err != nil {
+			return nil, err
+		}
+		if isRemove {
+			removeCount++
+		}
+		ops = append(ops, relOps...)
+	}
+	// If all of its known relations will be removed, the remote
+	// application can also be removed.
+	if s.doc.RelationCount == removeCount {
+		hasLastRefs := bson.D{{"life", Alive}, {"relationcount", removeCount}}
+		return append(ops, s.removeOps(hasLastRefs)...), nil
+	}
+	// Otherwise the remote application will be removed with its last
+	// relation.
+	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	if removeCount != 0 {
+		decref := bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}
+		update = append(update, decref...)
+	}
+	return append(ops, txn.Op{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"relationcount", s.doc.RelationCount}},
+		Update: update,
+	}), nil
+}
+
+// removeOps returns the operations required to remove the remote
+// application. Supplied asserts will be included in the operation on
+// the remote application document.
+func (s *RemoteApplication) removeOps(asserts bson.D) []txn.Op {
+	return []txn.Op{{
+		C:      remoteApplicationsC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	}}
+}
+
+// remoteApplicationRelationRemoveOps returns the operations required
+// to update the named remote application when one of its relations is
+// removed; the remote application itself is removed with its last
+// relation if it is dying.
+func remoteApplicationRelationRemoveOps(st *State, name string) ([]txn.Op, error) {
+	applications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	app := &RemoteApplication{st: st}
+	hasLastRef := bson.D{{"life", Dying}, {"relationcount", 1}}
+	removable := append(bson.D{{"_id", name}}, hasLastRef...)
+	if err := applications.Find(removable).One(&app.doc); err == nil {
+		return app.removeOps(hasLastRef), nil
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:  remoteApplicationsC,
+		Id: st.docID(name),
+		Assert: bson.D{{"$or", []bson.D{
+			{{"life", Alive}},
+			{{"relationcount", bson.D{{"$gt", 1}}}},
+		}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
+
+// AddRemoteApplicationArgs holds the arguments for adding a remote
+// application.
+type AddRemoteApplicationArgs struct {
+	// Name is the name the application is known by in this model.
+	Name string
+
+	// OfferURL is the URL of the offer the application is consumed
+	// from; it is empty for consumer proxies.
+	OfferURL string
+
+	// SourceModel is the model the application lives in.
+	SourceModel names.ModelTag
+
+	// SourceApplicationName is the name of the application in its own
+	// model.
+	SourceApplicationName string
+
+	// OfferName is the name of the offer in the source model; it is
+	// empty for consumer proxies.
+	OfferName string
+
+	// IsConsumerProxy is true if the application consumes one of this
+	// model's offers, rather than being consumed by this model.
+	IsConsumerProxy bool
+
+	// Endpoints holds the application's endpoints that may be related
+	// to.
+	Endpoints []charm.Relation
+}
+
+// AddRemoteApplication creates a new remote application record,
+// standing for an application hosted in another model.
+func (st *State) AddRemoteApplication(args AddRemoteApplicationArgs) (_ *RemoteApplication, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote application %q", args.Name)
+
+	if !names.IsValidApplication(args.Name) {
+		return nil, errors.Errorf("invalid name")
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.Errorf("no endpoints")
+	}
+	for _, ep := range args.Endpoints {
+		if ep.Role == charm.RolePeer {
+			return nil, errors.Errorf("peer relation %q cannot be remote", ep.Name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("container scoped relation %q cannot be remote", ep.Name)
+		}
+	}
+	if exists, err := isNotDead(st, applicationsC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("local application with same name already exists")
+	}
+	if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote application already exists")
+	}
+	doc := &remoteApplicationDoc{
+		DocID:                 st.docID(args.Name),
+		Name:                  args.Name,
+		ModelUUID:             st.ModelUUID(),
+		OfferURL:              args.OfferURL,
+		SourceModelUUID:       args.SourceModel.Id(),
+		SourceApplicationName: args.SourceApplicationName,
+		OfferName:             args.OfferName,
+		IsConsumerProxy:       args.IsConsumerProxy,
+		Endpoints:             args.Endpoints,
+		Life:                  Alive,
+	}
+	ops := []txn.Op{
+		assertModelActiveOp(st.ModelUUID()),
+		{
+			C:      applicationsC,
+			Id:     st.docID(args.Name),
+			Assert: txn.DocMissing,
+		}, {
+			C:      remoteApplicationsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("application already exists")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// RemoteApplication returns the remote application with the given name.
+func (st *State) RemoteApplication(name string) (_ *RemoteApplication, err error) {
+	if !names.IsValidApplication(name) {
+		return nil, errors.NotValidf("remote application name %q", name)
+	}
+	applications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	doc := &remoteApplicationDoc{}
+	err = applications.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote application %q", name)
+	}
+	return newRemoteApplication(st, doc), nil
+}
+
+// AllRemoteApplications returns all the remote applications in the
+// model.
+func (st *State) AllRemoteApplications() ([]*RemoteApplication, error) {
+	applications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	var docs []remoteApplicationDoc
+	if err := applications.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all remote applications")
+	}
+	results := make([]*RemoteApplication, len(docs))
+	for i := range docs {
+		results[i] = newRemoteApplication(st, &docs[i])
+	}
+	return results, nil
+}
+
+// RemoteUnitName returns the name by which the given unit of an
+// application in another model is known in this one, as a unit of the
+// named remote application.
+func RemoteUnitName(remoteApplication, unitName string) (string, error) {
+	number, err := names.UnitNumber(unitName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%s/%d", remoteApplication, number), nil
+}
+
+// isRemoteApplication returns whether the named application is a
+// remote application.
+func isRemoteApplication(st *State, name string) (bool, error) {
+	applications, closer := st.getCollection(remoteApplicationsC)
+	defer closer()
+
+	count, err := applications.FindId(name).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
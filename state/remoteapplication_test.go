@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoteApplicationSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.RemoteApplication
+}
+
+var _ = gc.Suite(&RemoteApplicationSuite{})
+
+var mysqlServerRelation = charm.Relation{
+	Interface: "mysql",
+	Name:      "server",
+	Role:      charm.RoleProvider,
+	Scope:     charm.ScopeGlobal,
+}
+
+func (s *RemoteApplicationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.mysql, err = s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  "mysql",
+		OfferURL:              "admin/prod.db",
+		SourceModel:           coretesting.ModelTag,
+		SourceApplicationName: "mysql",
+		OfferName:             "db",
+		Endpoints:             []charm.Relation{mysqlServerRelation},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteApplicationSuite) addRelation(c *gc.C) *state.Relation {
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplication(c *gc.C) {
+	app, err := s.State.RemoteApplication("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.Name(), gc.Equals, "mysql")
+	c.Check(app.Tag().String(), gc.Equals, "application-mysql")
+	c.Check(app.OfferURL(), gc.Equals, "admin/prod.db")
+	c.Check(app.OfferName(), gc.Equals, "db")
+	c.Check(app.SourceModel(), gc.Equals, coretesting.ModelTag)
+	c.Check(app.SourceApplicationName(), gc.Equals, "mysql")
+	c.Check(app.IsConsumerProxy(), jc.IsFalse)
+	c.Check(app.Life(), gc.Equals, state.Alive)
+	eps, err := app.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(eps, jc.DeepEquals, []state.Endpoint{{
+		ApplicationName: "mysql",
+		Relation:        mysqlServerRelation,
+	}})
+
+	all, err := s.State.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Check(all[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplicationNameClash(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "wordpress",
+		SourceModel: coretesting.ModelTag,
+		Endpoints:   []charm.Relation{mysqlServerRelation},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "wordpress": local application with same name already exists`)
+
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "mysql",
+		SourceModel: coretesting.ModelTag,
+		Endpoints:   []charm.Relation{mysqlServerRelation},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "mysql": remote application already exists`)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{Name: "mysql", Charm: s.AddTestingCharm(c, "mysql")})
+	c.Check(err, gc.ErrorMatches, `cannot add application "mysql": remote application with same name already exists`)
+}
+
+func (s *RemoteApplicationSuite) TestAddRemoteApplicationInvalidEndpoints(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:        "riak",
+		SourceModel: coretesting.ModelTag,
+		Endpoints: []charm.Relation{{
+			Interface: "riak",
+			Name:      "ring",
+			Role:      charm.RolePeer,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add remote application "riak": peer relation "ring" cannot be remote`)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteApplicationNotFound(c *gc.C) {
+	_, err := s.State.RemoteApplication("nope")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestAddRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	c.Check(rel.String(), gc.Equals, "wordpress:db mysql:server")
+
+	rels, err := s.mysql.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Check(rels[0].Id(), gc.Equals, rel.Id())
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitScope(c *gc.C) {
+	rel := s.addRelation(c)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	localRU, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	remoteRU, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = remoteRU.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	assertJoined(c, remoteRU)
+
+	settings, err := localRU.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+
+	err = remoteRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotInScope(c, remoteRU)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitOfLocalApplication(c *gc.C) {
+	rel := s.addRelation(c)
+	_, err := rel.RemoteUnit("wordpress/0")
+	c.Check(err, gc.ErrorMatches, `unit "wordpress/0" of local application not valid`)
+}
+
+func (s *RemoteApplicationSuite) TestDestroyWithoutRelations(c *gc.C) {
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestDestroyRemovesRelations(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = rel.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestDestroyRemovedWithLastUnitLeavingScope(c *gc.C) {
+	rel := s.addRelation(c)
+	remoteRU, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.mysql.Life(), gc.Equals, state.Dying)
+
+	err = remoteRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = rel.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestLocalApplicationDestroyRemovesRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.wordpress.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteApplicationSuite) TestRemoteUnitName(c *gc.C) {
+	name, err := state.RemoteUnitName("db", "mysql/3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "db/3")
+}
@@ -0,0 +1,478 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SyncRemoteRelations brings the relations between this model's
+// applications and the applications it has consumed from other models
+// into line with their counterparts in those models, which must be
+// hosted by the same controller.
+//
+// For each relation to a consumed application, the offering model gets
+// a relation to a consumer proxy: a remote application standing for the
+// consuming application. The units in scope on each side, and their
+// settings, are then mirrored to the other as remote units, and the
+// destruction of either relation destroys the other. Relations to
+// consumer proxies in this model whose consumer relation has gone are
+// destroyed.
+//
+// A remote application that cannot be synced is logged, and does not
+// stop the others being synced.
+func (st *State) SyncRemoteRelations() error {
+	apps, err := st.AllRemoteApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range apps {
+		if app.IsConsumerProxy() {
+			err = st.syncConsumerProxy(app)
+		} else {
+			err = st.syncConsumedApplication(app)
+		}
+		if err != nil {
+			logger.Errorf("cannot sync remote application %q in model %s: %v", app.Name(), st.ModelUUID(), err)
+		}
+	}
+	return nil
+}
+
+// syncConsumedApplication syncs the relations of an application
+// consumed from another model. If the application, or its model, has
+// gone, the remote application is marked terminated and its relations
+// destroyed.
+func (st *State) syncConsumedApplication(app *RemoteApplication) error {
+	if app.IsTerminated() {
+		return errors.Trace(st.destroyMirroredRelations(app))
+	}
+	if _, err := st.GetModel(app.SourceModel()); errors.IsNotFound(err) {
+		return errors.Trace(st.terminateConsumedApplication(app))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	offerSt, err := st.ForModel(app.SourceModel())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer offerSt.Close()
+
+	offered, err := offerSt.Application(app.SourceApplicationName())
+	if errors.IsNotFound(err) {
+		return errors.Trace(st.terminateConsumedApplication(app))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if offered.Life() != Alive {
+		return errors.Trace(st.destroyMirroredRelations(app))
+	}
+	// Removing the offer stops new relations being made to the
+	// application, but leaves those already made alone.
+	offerExists, err := hasApplicationOffer(offerSt, app.OfferName(), offered.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rels, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range rels {
+		if err := st.syncConsumedRelation(offerSt, app, offered, offerExists, rel); err != nil {
+			return errors.Annotatef(err, "relation %q", rel)
+		}
+	}
+	return nil
+}
+
+// terminateConsumedApplication marks the remote application terminated,
+// as the application it stands for has gone, and destroys its
+// relations.
+func (st *State) terminateConsumedApplication(app *RemoteApplication) error {
+	if err := app.setTerminated(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.destroyMirroredRelations(app))
+}
+
+// destroyMirroredRelations destroys all the relations of the remote
+// application.
+func (st *State) destroyMirroredRelations(app *RemoteApplication) error {
+	rels, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range rels {
+		if err := destroyMirroredRelation(rel, app.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// hasApplicationOffer returns whether the model offerSt still has the
+// named offer of the given application.
+func hasApplicationOffer(offerSt *State, offerName, applicationName string) (bool, error) {
+	offer, err := offerSt.ApplicationOffer(offerName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return offer.ApplicationName() == applicationName, nil
+}
+
+// syncConsumedRelation syncs a relation to an application consumed
+// from the model offerSt, with the relation to the consumer proxy in
+// that model. The relation in the offering model is only added while
+// the application is still offered.
+func (st *State) syncConsumedRelation(offerSt *State, app *RemoteApplication, offered *Application, offerExists bool, rel *Relation) error {
+	remoteEp, err := rel.Endpoint(app.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	related, err := rel.RelatedEndpoints(app.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localEp := related[0]
+	proxy, err := st.findConsumerProxy(offerSt, localEp.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var offerRel *Relation
+	if proxy != nil {
+		offerRel, err = offerSt.EndpointsRelation(Endpoint{
+			ApplicationName: offered.Name(),
+			Relation:        remoteEp.Relation,
+		}, Endpoint{
+			ApplicationName: proxy.Name(),
+			Relation:        localEp.Relation,
+		})
+	} else {
+		err = errors.NotFoundf("consumer proxy for %q", localEp.ApplicationName)
+	}
+	if errors.IsNotFound(err) {
+		if rel.Life() != Alive || !offerExists {
+			return errors.Trace(destroyMirroredRelation(rel, app.Name()))
+		}
+		if proxy == nil {
+			if proxy, err = st.addConsumerProxy(offerSt, localEp.ApplicationName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		offerRel, err = offerSt.AddRelation(Endpoint{
+			ApplicationName: offered.Name(),
+			Relation:        remoteEp.Relation,
+		}, Endpoint{
+			ApplicationName: proxy.Name(),
+			Relation:        localEp.Relation,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Destroying either relation destroys the other.
+	if rel.Life() != Alive && offerRel.Life() == Alive {
+		if err := offerRel.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	} else if offerRel.Life() != Alive && rel.Life() == Alive {
+		if err := rel.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if removed, err := refreshRelation(rel); err != nil {
+		return errors.Trace(err)
+	} else if removed {
+		return nil
+	}
+	if removed, err := refreshRelation(offerRel); err != nil {
+		return errors.Trace(err)
+	} else if removed {
+		return errors.Trace(destroyMirroredRelation(rel, app.Name()))
+	}
+
+	if err := mirrorRelationUnits(rel, localEp, offerRel, proxy.Name()); err != nil {
+		return errors.Trace(err)
+	}
+	offeredEp, err := offerRel.Endpoint(offered.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(mirrorRelationUnits(offerRel, offeredEp, rel, app.Name()))
+}
+
+// consumerProxyName returns the name of the consumer proxy, in an
+// offering model, for the named application of the consuming model
+// with the given UUID. The UUID keeps the proxies for applications of
+// the same name in different models apart, and from the offering
+// model's own applications.
+func consumerProxyName(consumerModelUUID, applicationName string) string {
+	return fmt.Sprintf("%s-c%s", applicationName, strings.Replace(consumerModelUUID, "-", "", -1))
+}
+
+// findConsumerProxy returns the consumer proxy in the model offerSt for
+// the named application in this model, or nil if there is none.
+func (st *State) findConsumerProxy(offerSt *State, applicationName string) (*RemoteApplication, error) {
+	apps, err := offerSt.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range apps {
+		if !app.IsConsumerProxy() || app.SourceModel() != st.ModelTag() {
+			continue
+		}
+		if app.SourceApplicationName() == applicationName {
+			return app, nil
+		}
+	}
+	return nil, nil
+}
+
+// addConsumerProxy adds to the model offerSt a consumer proxy for the
+// named application in this model, offering all of the application's
+// endpoints that may be related to remotely.
+func (st *State) addConsumerProxy(offerSt *State, applicationName string) (*RemoteApplication, error) {
+	app, err := st.Application(applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := AddRemoteApplicationArgs{
+		Name:                  consumerProxyName(st.ModelUUID(), applicationName),
+		SourceModel:           st.ModelTag(),
+		SourceApplicationName: applicationName,
+		IsConsumerProxy:       true,
+	}
+	for _, ep := range eps {
+		if isPeer(ep) || ep.IsImplicit() || ep.Scope != charm.ScopeGlobal {
+			continue
+		}
+		args.Endpoints = append(args.Endpoints, ep.Relation)
+	}
+	proxy, err := offerSt.AddRemoteApplication(args)
+	return proxy, errors.Trace(err)
+}
+
+// syncConsumerProxy destroys the relations of a consumer proxy whose
+// relation in the consuming model has gone, and the proxy itself once
+// it has no relations.
+func (st *State) syncConsumerProxy(proxy *RemoteApplication) error {
+	consumerSt, err := st.ForModel(proxy.SourceModel())
+	if errors.IsNotFound(err) {
+		return errors.Trace(proxy.Destroy())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer consumerSt.Close()
+
+	rels, err := proxy.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rels) == 0 {
+		return errors.Trace(proxy.Destroy())
+	}
+	for _, rel := range rels {
+		exists, err := st.hasConsumerRelation(consumerSt, proxy, rel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !exists {
+			if err := destroyMirroredRelation(rel, proxy.Name()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// hasConsumerRelation returns whether the consuming model consumerSt
+// still has the relation that the given relation to a consumer proxy
+// mirrors.
+func (st *State) hasConsumerRelation(consumerSt *State, proxy *RemoteApplication, rel *Relation) (bool, error) {
+	proxyEp, err := rel.Endpoint(proxy.Name())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	related, err := rel.RelatedEndpoints(proxy.Name())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	offeredEp := related[0]
+	consumingEp := Endpoint{
+		ApplicationName: proxy.SourceApplicationName(),
+		Relation:        proxyEp.Relation,
+	}
+	apps, err := consumerSt.AllRemoteApplications()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, app := range apps {
+		if app.IsConsumerProxy() || app.SourceModel() != st.ModelTag() {
+			continue
+		}
+		if app.SourceApplicationName() != offeredEp.ApplicationName {
+			continue
+		}
+		_, err := consumerSt.EndpointsRelation(Endpoint{
+			ApplicationName: app.Name(),
+			Relation:        offeredEp.Relation,
+		}, consumingEp)
+		if err == nil {
+			return true, nil
+		} else if !errors.IsNotFound(err) {
+			return false, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
+
+// destroyMirroredRelation destroys a relation whose counterpart in
+// another model has gone, and takes the units of the named remote
+// application out of its scope so that it can be removed.
+func destroyMirroredRelation(rel *Relation, remoteApplicationName string) error {
+	if rel.Life() == Alive {
+		if err := rel.Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if removed, err := refreshRelation(rel); err != nil || removed {
+		return errors.Trace(err)
+	}
+	ep, err := rel.Endpoint(remoteApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	members, err := relationScopeMembers(rel, ep)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for unitName := range members {
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// mirrorRelationUnits makes the units of the named remote application
+// in scope in dst, and their settings, match the units of the
+// application with endpoint srcEp in scope in src.
+func mirrorRelationUnits(src *Relation, srcEp Endpoint, dst *Relation, dstApplicationName string) error {
+	members, err := relationScopeMembers(src, srcEp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dstEp, err := dst.Endpoint(dstApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing, err := relationScopeMembers(dst, dstEp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mirrored := make(map[string]bool)
+	for unitName, settings := range members {
+		dstUnitName, err := RemoteUnitName(dstApplicationName, unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		mirrored[dstUnitName] = true
+		ru, err := dst.RemoteUnit(dstUnitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := existing[dstUnitName]; ok {
+			err = replaceRelationSettings(ru, settings)
+		} else if dst.Life() == Alive {
+			err = ru.EnterScope(settings)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "mirroring unit %q", unitName)
+		}
+	}
+	for dstUnitName := range existing {
+		if mirrored[dstUnitName] {
+			continue
+		}
+		ru, err := dst.RemoteUnit(dstUnitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// relationScopeMembers returns the settings of the units with the given
+// endpoint that are in scope in the relation, and not departing, keyed
+// by unit name. Container scoped relations are not supported.
+func relationScopeMembers(rel *Relation, ep Endpoint) (map[string]map[string]interface{}, error) {
+	relationScopes, closer := rel.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := fmt.Sprintf("r#%d#%s#", rel.Id(), ep.Role)
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + prefix}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	members := make(map[string]map[string]interface{})
+	for _, doc := range docs {
+		settings, err := readSettings(rel.st, settingsC, doc.Key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		members[doc.unitName()] = settings.Map()
+	}
+	return members, nil
+}
+
+// replaceRelationSettings replaces the settings of the relation unit
+// with the given values.
+func replaceRelationSettings(ru *RelationUnit, values map[string]interface{}) error {
+	settings, err := ru.Settings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, key := range settings.Keys() {
+		if _, ok := values[key]; !ok {
+			settings.Delete(key)
+		}
+	}
+	settings.Update(values)
+	_, err = settings.Write()
+	return errors.Trace(err)
+}
+
+// refreshRelation refreshes the relation, and returns whether it has
+// been removed.
+func refreshRelation(rel *Relation) (bool, error) {
+	if err := rel.Refresh(); errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return false, nil
+}
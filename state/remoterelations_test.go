@@ -0,0 +1,298 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type RemoteRelationsSuite struct {
+	ConnSuite
+	offerSt  *state.State
+	mysql    *state.Application
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&RemoteRelationsSuite{})
+
+func (s *RemoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.offerSt = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.offerSt.Close() })
+	s.mysql = state.AddTestingService(c, s.offerSt, "mysql", state.AddTestingCharm(c, s.offerSt, "mysql"))
+	_, err := s.offerSt.AddApplicationOffer(state.AddApplicationOfferArgs{
+		OfferName:       "db",
+		ApplicationName: "mysql",
+		Endpoints:       []string{"server"},
+		Owner:           s.Owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  "db",
+		OfferURL:              "admin/offers.db",
+		SourceModel:           s.offerSt.ModelTag(),
+		SourceApplicationName: "mysql",
+		OfferName:             "db",
+		Endpoints:             []charm.Relation{mysqlServerRelation},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteRelationsSuite) enterScope(c *gc.C, st *state.State, rel *state.Relation, appName string, settings map[string]interface{}) *state.RelationUnit {
+	app, err := st.Application(appName)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(settings)
+	c.Assert(err, jc.ErrorIsNil)
+	return ru
+}
+
+// proxyName returns the name of the consumer proxy, in the offering
+// model, for the named application of the model st.
+func proxyName(st *state.State, applicationName string) string {
+	return applicationName + "-c" + strings.Replace(st.ModelUUID(), "-", "", -1)
+}
+
+func (s *RemoteRelationsSuite) offerRelation(c *gc.C) *state.Relation {
+	eps, err := s.offerSt.InferEndpoints(proxyName(s.State, "wordpress"), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.offerSt.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *RemoteRelationsSuite) TestSyncCreatesConsumerProxy(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	proxy, err := s.offerSt.RemoteApplication(proxyName(s.State, "wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(proxy.IsConsumerProxy(), jc.IsTrue)
+	c.Check(proxy.SourceModel(), gc.Equals, s.State.ModelTag())
+	c.Check(proxy.SourceApplicationName(), gc.Equals, "wordpress")
+
+	rel := s.offerRelation(c)
+	c.Check(rel.String(), gc.Equals, proxy.Name()+":db mysql:server")
+
+	// The proxy is found again by the application it stands for.
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	proxies, err := s.offerSt.AllRemoteApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(proxies, gc.HasLen, 1)
+}
+
+func (s *RemoteRelationsSuite) TestSyncMirrorsUnits(c *gc.C) {
+	wordpressRU := s.enterScope(c, s.State, s.relation, "wordpress", map[string]interface{}{"user": "wp"})
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	offerRel := s.offerRelation(c)
+	mysqlRU := s.enterScope(c, s.offerSt, offerRel, "mysql", map[string]interface{}{"host": "db.example.com"})
+	settings, err := mysqlRU.ReadSettings(proxyName(s.State, "wordpress") + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, map[string]interface{}{"user": "wp"})
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = wordpressRU.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+
+	// Settings changes, and departures, are mirrored too.
+	node, err := mysqlRU.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("host", "db2.example.com")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpressRU.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err = wordpressRU.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings, jc.DeepEquals, map[string]interface{}{"host": "db2.example.com"})
+	proxyRU, err := offerRel.RemoteUnit(proxyName(s.State, "wordpress") + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotInScope(c, proxyRU)
+}
+
+func (s *RemoteRelationsSuite) TestSyncDestroysOfferRelation(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	offerRel := s.offerRelation(c)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.offerSt.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = offerRel.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = s.offerSt.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.offerSt.RemoteApplication(proxyName(s.State, "wordpress"))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteRelationsSuite) TestSyncDestroysConsumerRelation(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteRelationsSuite) TestSyncProxyBesideLocalApplication(c *gc.C) {
+	state.AddTestingService(c, s.offerSt, "wordpress", state.AddTestingCharm(c, s.offerSt, "wordpress"))
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	s.offerRelation(c)
+}
+
+func (s *RemoteRelationsSuite) TestSyncProxiesForApplicationsOfSameName(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	state.AddTestingService(c, otherSt, "wordpress", state.AddTestingCharm(c, otherSt, "wordpress"))
+	_, err := otherSt.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  "db",
+		OfferURL:              "admin/offers.db",
+		SourceModel:           s.offerSt.ModelTag(),
+		SourceApplicationName: "mysql",
+		OfferName:             "db",
+		Endpoints:             []charm.Relation{mysqlServerRelation},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := otherSt.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = otherSt.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherSt.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.offerRelation(c)
+	eps, err = s.offerSt.InferEndpoints(proxyName(otherSt, "wordpress"), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.offerSt.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteRelationsSuite) TestSyncContinuesPastFailure(c *gc.C) {
+	// Another application has taken the proxy's name, so the relation
+	// to db cannot be synced; the relation to db2 still is.
+	state.AddTestingService(c, s.offerSt, proxyName(s.State, "wordpress"), state.AddTestingCharm(c, s.offerSt, "wordpress"))
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationArgs{
+		Name:                  "db2",
+		OfferURL:              "admin/offers.db",
+		SourceModel:           s.offerSt.ModelTag(),
+		SourceApplicationName: "mysql",
+		OfferName:             "db",
+		Endpoints:             []charm.Relation{mysqlServerRelation},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := wordpress.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	state.AddTestingService(c, s.State, "blog", ch)
+	eps, err := s.State.InferEndpoints("blog", "db2")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err = s.offerSt.InferEndpoints(proxyName(s.State, "blog"), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.offerSt.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemoteRelationsSuite) assertTerminated(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.RemoteApplication("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.IsTerminated(), jc.IsTrue)
+	err = s.relation.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	eps, err := s.State.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Check(err, gc.ErrorMatches, `cannot add relation "wordpress:db db:server": remote application "db" has been terminated`)
+}
+
+func (s *RemoteRelationsSuite) TestSyncTerminatesWhenApplicationRemoved(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.offerRelation(c).Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.assertTerminated(c)
+}
+
+func (s *RemoteRelationsSuite) TestSyncTerminatesWhenModelRemoved(c *gc.C) {
+	err := state.SetModelLifeDead(s.offerSt, s.offerSt.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.offerSt.RemoveAllModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertTerminated(c)
+}
+
+func (s *RemoteRelationsSuite) TestSyncRemovedOfferKeepsExistingRelations(c *gc.C) {
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.offerSt.RemoveApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Refresh()
+	c.Check(err, jc.ErrorIsNil)
+	s.offerRelation(c)
+}
+
+func (s *RemoteRelationsSuite) TestSyncRemovedOfferRefusesNewRelations(c *gc.C) {
+	err := s.offerSt.RemoveApplicationOffer("db")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Refresh()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.offerSt.RemoteApplication(proxyName(s.State, "wordpress"))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
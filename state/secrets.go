@@ -0,0 +1,436 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// secretDoc records a secret stored by a charm.
+type secretDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Owner is the tag of the application whose units may read the
+	// secret, and grant it to others.
+	Owner string `bson:"owner"`
+
+	// Description describes the secret for people.
+	Description string `bson:"description"`
+
+	// Data holds the secret's value, encrypted.
+	Data []byte `bson:"data"`
+
+	// Grants holds the tags of the units, applications and relations
+	// the secret has been granted to.
+	Grants []string `bson:"grants"`
+
+	Created time.Time `bson:"created"`
+}
+
+// Secret is a value stored by a charm, such as a password, that only
+// the units it is granted to may read.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// Id returns the id of the secret.
+func (s *Secret) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Owner returns the tag of the application owning the secret.
+func (s *Secret) Owner() names.ApplicationTag {
+	tag, err := names.ParseApplicationTag(s.doc.Owner)
+	if err != nil {
+		// The owner is checked when the secret is added.
+		panic(err)
+	}
+	return tag
+}
+
+// Description returns the description of the secret.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Grants returns the tags of the units, applications and relations
+// the secret has been granted to.
+func (s *Secret) Grants() []string {
+	return s.doc.Grants
+}
+
+// Created returns the time the secret was added.
+func (s *Secret) Created() time.Time {
+	return s.doc.Created
+}
+
+// Value returns the decrypted value of the secret.
+func (s *Secret) Value() (map[string]string, error) {
+	key, err := s.st.getSecretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := decryptSecret(key, s.Id(), s.doc.Data)
+	return value, errors.Annotatef(err, "cannot read secret %q", s.Id())
+}
+
+// CanRead reports whether the unit may read the secret: it may if its
+// application owns the secret, or if the secret has been granted to
+// the unit, to its application, or to a relation its application takes
+// part in.
+func (s *Secret) CanRead(unit names.UnitTag) (bool, error) {
+	appName, err := names.UnitApplication(unit.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	appTag := names.NewApplicationTag(appName)
+	if s.doc.Owner == appTag.String() {
+		return true, nil
+	}
+	for _, grant := range s.doc.Grants {
+		if grant == unit.String() || grant == appTag.String() {
+			return true, nil
+		}
+		relationTag, err := names.ParseRelationTag(grant)
+		if err != nil {
+			continue
+		}
+		relation, err := s.st.KeyRelation(relationTag.Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if _, err := relation.Endpoint(appName); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Grant allows the given unit, application or relation to read the
+// secret. Granting a relation allows the units of all the applications
+// taking part in it to read the secret.
+func (s *Secret) Grant(subject names.Tag) error {
+	var subjectOp txn.Op
+	switch tag := subject.(type) {
+	case names.UnitTag:
+		subjectOp = txn.Op{C: unitsC, Id: s.st.docID(tag.Id()), Assert: isAliveDoc}
+	case names.ApplicationTag:
+		subjectOp = txn.Op{C: applicationsC, Id: s.st.docID(tag.Id()), Assert: isAliveDoc}
+	case names.RelationTag:
+		relation, err := s.st.KeyRelation(tag.Id())
+		if err != nil {
+			return errors.Annotatef(err, "cannot grant secret %q", s.Id())
+		}
+		subjectOp = txn.Op{C: relationsC, Id: relation.doc.DocID, Assert: isAliveDoc}
+	default:
+		return errors.NotValidf("granting secret to %q", subject)
+	}
+	ops := []txn.Op{subjectOp, {
+		C:      secretsC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"grants", subject.String()}}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot grant secret %q: secret or %s not found or not alive", s.Id(), names.ReadableString(subject))
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q", s.Id())
+	}
+	for _, grant := range s.doc.Grants {
+		if grant == subject.String() {
+			return nil
+		}
+	}
+	s.doc.Grants = append(s.doc.Grants, subject.String())
+	return nil
+}
+
+// AddSecretArgs holds the arguments for adding a secret.
+type AddSecretArgs struct {
+	// Owner is the application whose units may read the secret,
+	// and grant it to others.
+	Owner names.ApplicationTag
+
+	// Description describes the secret for people.
+	Description string
+
+	// Value holds the secret's value; it is encrypted before it is
+	// stored.
+	Value map[string]string
+}
+
+// AddSecret stores a new secret, and returns it.
+func (st *State) AddSecret(args AddSecretArgs) (*Secret, error) {
+	if len(args.Value) == 0 {
+		return nil, errors.NotValidf("empty secret value")
+	}
+	uuid, err := NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate secret id")
+	}
+	id := uuid.String()
+	key, err := st.getSecretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := encryptSecret(key, id, args.Value)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot encrypt secret")
+	}
+	doc := secretDoc{
+		DocId:       st.docID(id),
+		ModelUUID:   st.ModelUUID(),
+		Owner:       args.Owner.String(),
+		Description: args.Description,
+		Data:        data,
+		Created:     st.NowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(args.Owner.Id()),
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("cannot add secret: application %q not found or not alive", args.Owner.Id())
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot add secret")
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model, oldest first.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all secrets")
+	}
+	sort.Sort(secretDocsByCreated(docs))
+	results := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		results[i] = &Secret{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// HasSecrets reports whether any secrets have been stored in the
+// model.
+func (st *State) HasSecrets() (bool, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	count, err := secrets.Find(nil).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count secrets")
+	}
+	return count > 0, nil
+}
+
+type secretDocsByCreated []secretDoc
+
+func (docs secretDocsByCreated) Len() int      { return len(docs) }
+func (docs secretDocsByCreated) Swap(i, j int) { docs[i], docs[j] = docs[j], docs[i] }
+func (docs secretDocsByCreated) Less(i, j int) bool {
+	if !docs[i].Created.Equal(docs[j].Created) {
+		return docs[i].Created.Before(docs[j].Created)
+	}
+	return docs[i].DocId < docs[j].DocId
+}
+
+// secretsKeySize is the size in bytes of the key with which secrets
+// are encrypted, which selects AES-256.
+const secretsKeySize = 32
+
+// NewSecretsKey returns a new random key with which to encrypt
+// secrets.
+func NewSecretsKey() ([]byte, error) {
+	key := make([]byte, secretsKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "cannot generate secrets key")
+	}
+	return key, nil
+}
+
+// SetSecretsKey sets the key with which secrets are encrypted. The key
+// is kept in the controller agents' configuration rather than in the
+// database, so that reading the database does not reveal the values of
+// secrets; states returned by ForModel share it.
+func (st *State) SetSecretsKey(key []byte) error {
+	if len(key) != secretsKeySize {
+		return errors.NotValidf("secrets key of %d bytes", len(key))
+	}
+	st.secretsKey = func() []byte { return key }
+	return nil
+}
+
+// SetSecretsKeyFunc sets a function returning the key with which
+// secrets are encrypted, or nil if there is none yet. It is called
+// each time the key is needed, so that a key provisioned after the
+// State was opened, as when a controller is upgraded from a version
+// without secrets, is used at once.
+func (st *State) SetSecretsKeyFunc(f func() []byte) {
+	st.secretsKey = f
+}
+
+// SecretsKey returns the key with which secrets are encrypted, or nil
+// if it has not been set.
+func (st *State) SecretsKey() []byte {
+	if st.secretsKey == nil {
+		return nil
+	}
+	return st.secretsKey()
+}
+
+// getSecretsKey returns the key with which secrets are encrypted.
+func (st *State) getSecretsKey() ([]byte, error) {
+	key := st.SecretsKey()
+	if key == nil {
+		return nil, errors.NotProvisionedf("secrets key")
+	}
+	if len(key) != secretsKeySize {
+		return nil, errors.NotValidf("secrets key of %d bytes", len(key))
+	}
+	return key, nil
+}
+
+// removeSecretGrantsOps returns the operations to revoke every grant
+// of a secret to the given unit, application or relation.
+func removeSecretGrantsOps(st *State, subject names.Tag) ([]txn.Op, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	sel := bson.D{{"grants", subject.String()}}
+	if err := secrets.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     doc.DocId,
+			Update: bson.D{{"$pull", bson.D{{"grants", subject.String()}}}},
+		}
+	}
+	return ops, nil
+}
+
+// removeApplicationSecretsOps returns the operations to remove the
+// secrets owned by the application, and to revoke those granted to it,
+// so that none outlive it and pass to a new application of the same
+// name.
+func removeApplicationSecretsOps(st *State, applicationName string) ([]txn.Op, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+
+	tag := names.NewApplicationTag(applicationName)
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	sel := bson.D{{"owner", tag.String()}}
+	if err := secrets.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     doc.DocId,
+			Remove: true,
+		})
+	}
+	grantOps, err := removeSecretGrantsOps(st, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, grantOps...), nil
+}
+
+// encryptSecret encrypts the secret's value with AES-GCM. The id of the
+// secret is authenticated along with it, so that the data cannot be
+// passed off as the value of another secret.
+func encryptSecret(key []byte, id string, value map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, []byte(id)), nil
+}
+
+// decryptSecret decrypts a secret's value encrypted by encryptSecret.
+func decryptSecret(key []byte, id string, data []byte) (map[string]string, error) {
+	gcm, err := newSecretsCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("encrypted value too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var value map[string]string
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return value, nil
+}
+
+func newSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
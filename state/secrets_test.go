@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	mysql     *state.Application
+	wordpress *state.Application
+	relation  *state.Relation
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.relation = s.Factory.MakeRelation(c, nil)
+	var err error
+	s.mysql, err = s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.wordpress, err = s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C) *state.Secret {
+	secret, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner:       s.mysql.ApplicationTag(),
+		Description: "root password",
+		Value:       map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	secret := s.addSecret(c)
+	c.Check(secret.Owner(), gc.Equals, s.mysql.ApplicationTag())
+	c.Check(secret.Description(), gc.Equals, "root password")
+	c.Check(secret.Grants(), gc.HasLen, 0)
+
+	secret, err := s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	all, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Check(all[0].Id(), gc.Equals, secret.Id())
+}
+
+func (s *SecretsSuite) TestAddSecretEncryptsValue(c *gc.C) {
+	secret := s.addSecret(c)
+
+	var doc bson.M
+	secrets := s.State.MongoSession().DB("juju").C("secrets")
+	err := secrets.FindId(s.State.ModelUUID() + ":" + secret.Id()).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := doc["data"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(string(data), gc.Not(jc.Contains), "sekrit")
+	c.Check(string(data), gc.Not(jc.Contains), "password")
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.AddSecretArgs{Owner: s.mysql.ApplicationTag()})
+	c.Check(err, gc.ErrorMatches, "empty secret value not valid")
+
+	_, err = s.State.AddSecret(state.AddSecretArgs{
+		Owner: names.NewApplicationTag("nope"),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add secret: application "nope" not found or not alive`)
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret("42")
+	c.Check(err, gc.ErrorMatches, `secret "42" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestCanRead(c *gc.C) {
+	secret := s.addSecret(c)
+	mysql0 := names.NewUnitTag("mysql/0")
+	wordpress0 := names.NewUnitTag("wordpress/0")
+	wordpress1 := names.NewUnitTag("wordpress/1")
+	s.assertCanRead(c, secret, mysql0, true)
+	s.assertCanRead(c, secret, wordpress0, false)
+
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.wordpress})
+	err := secret.Grant(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []string{unit.Tag().String()})
+	secret, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanRead(c, secret, unit.UnitTag(), true)
+	s.assertCanRead(c, secret, wordpress1, false)
+}
+
+func (s *SecretsSuite) TestCanReadGrantedApplication(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant(s.wordpress.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanRead(c, secret, names.NewUnitTag("wordpress/3"), true)
+}
+
+func (s *SecretsSuite) TestCanReadGrantedRelation(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant(s.relation.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCanRead(c, secret, names.NewUnitTag("wordpress/0"), true)
+	s.assertCanRead(c, secret, names.NewUnitTag("other/0"), false)
+}
+
+func (s *SecretsSuite) TestGrantUnknownSubject(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant(names.NewUnitTag("wordpress/42"))
+	c.Check(err, gc.ErrorMatches, `cannot grant secret ".*": secret or unit wordpress/42 not found or not alive`)
+	err = secret.Grant(names.NewRelationTag("foo:bar baz:qux"))
+	c.Check(err, gc.ErrorMatches, `cannot grant secret ".*": relation "foo:bar baz:qux" not found`)
+	err = secret.Grant(names.NewMachineTag("0"))
+	c.Check(err, gc.ErrorMatches, `granting secret to "machine-0" not valid`)
+}
+
+func (s *SecretsSuite) assertCanRead(c *gc.C, secret *state.Secret, unit names.UnitTag, expect bool) {
+	canRead, err := secret.CanRead(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canRead, gc.Equals, expect, gc.Commentf("unit %s", unit.Id()))
+}
+
+func (s *SecretsSuite) TestSetSecretsKey(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	// States for other models share the controller's key.
+	c.Check(st.SecretsKey(), jc.DeepEquals, s.State.SecretsKey())
+
+	err := s.State.SetSecretsKey([]byte("too short"))
+	c.Check(err, gc.ErrorMatches, "secrets key of 9 bytes not valid")
+}
+
+func (s *SecretsSuite) TestSetSecretsKeyFunc(c *gc.C) {
+	var key []byte
+	s.State.SetSecretsKeyFunc(func() []byte { return key })
+	_, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.mysql.ApplicationTag(),
+		Value: map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*secrets key not provisioned")
+
+	// A key provisioned later is used at once.
+	key, err = state.NewSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.State.SecretsKey(), jc.DeepEquals, key)
+	s.addSecret(c)
+}
+
+func (s *SecretsSuite) TestHasSecrets(c *gc.C) {
+	hasSecrets, err := s.State.HasSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hasSecrets, jc.IsFalse)
+
+	s.addSecret(c)
+	hasSecrets, err = s.State.HasSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(hasSecrets, jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestRemoveApplicationRemovesSecrets(c *gc.C) {
+	owned := s.addSecret(c)
+	granted, err := s.State.AddSecret(state.AddSecretArgs{
+		Owner: s.wordpress.ApplicationTag(),
+		Value: map[string]string{"token": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = granted.Grant(s.mysql.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(owned.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	granted, err = s.State.Secret(granted.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(granted.Grants(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemoveUnitRevokesGrants(c *gc.C) {
+	secret := s.addSecret(c)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.wordpress})
+	err := secret.Grant(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemoveRelationRevokesGrants(c *gc.C) {
+	secret := s.addSecret(c)
+	err := secret.Grant(s.relation.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+}
@@ -86,6 +86,10 @@ type State struct {
 	policy             Policy
 	newPolicy          NewPolicyFunc
 
+	// secretsKey returns the key with which secrets are encrypted;
+	// it is supplied by the controller agent.
+	secretsKey func() []byte
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -308,6 +312,7 @@ func (st *State) ForModel(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.secretsKey = st.secretsKey
 	if err := newSt.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -995,6 +1000,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	} else if exists {
 		return nil, errors.Errorf("application already exists")
 	}
+	if exists, err := isNotDead(st, remoteApplicationsC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote application with same name already exists")
+	}
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1136,6 +1146,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	ops := []txn.Op{
 		assertModelActiveOp(st.ModelUUID()),
 		endpointBindingsOp,
+		{
+			C:      remoteApplicationsC,
+			Id:     st.docID(args.Name),
+			Assert: txn.DocMissing,
+		},
 	}
 	addOps, err := addApplicationOps(st, addApplicationOpsArgs{
 		applicationDoc: svcDoc,
@@ -1536,7 +1551,15 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
+	var svc interface {
+		Endpoint(string) (Endpoint, error)
+		Endpoints() ([]Endpoint, error)
+	}
 	svc, err := st.Application(svcName)
+	if errors.IsNotFound(err) {
+		// The endpoint may belong to an application in another model.
+		svc, err = st.RemoteApplication(svcName)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1601,12 +1624,18 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		// Collect per-service operations, checking sanity as we go.
 		var ops []txn.Op
-		var subordinateCount int
+		var subordinateCount, remoteCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
 			svc, err := st.Application(ep.ApplicationName)
 			if errors.IsNotFound(err) {
-				return nil, errors.Errorf("application %q does not exist", ep.ApplicationName)
+				remoteOp, err := st.addRemoteRelationOp(ep)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				remoteCount++
+				ops = append(ops, remoteOp)
+				continue
 			} else if err != nil {
 				return nil, errors.Trace(err)
 			} else if svc.doc.Life != Alive {
@@ -1630,6 +1659,9 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 				Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
 			})
 		}
+		if remoteCount > 1 {
+			return nil, errors.Errorf("cannot relate two remote applications")
+		}
 		if matchSeries && len(series) != 1 {
 			return nil, errors.Errorf("principal and subordinate applications' series must match")
 		}
@@ -1668,6 +1700,36 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	return nil, errors.Trace(err)
 }
 
+// addRemoteRelationOp returns the operation needed to add a relation
+// to the remote application owning the given endpoint.
+func (st *State) addRemoteRelationOp(ep Endpoint) (txn.Op, error) {
+	app, err := st.RemoteApplication(ep.ApplicationName)
+	if errors.IsNotFound(err) {
+		return txn.Op{}, errors.Errorf("application %q does not exist", ep.ApplicationName)
+	} else if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	} else if app.doc.Life != Alive {
+		return txn.Op{}, errors.Errorf("remote application %q is not alive", ep.ApplicationName)
+	} else if app.doc.Terminated {
+		return txn.Op{}, errors.Errorf("remote application %q has been terminated", ep.ApplicationName)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return txn.Op{}, errors.Errorf("remote application %q cannot take part in a container scoped relation", ep.ApplicationName)
+	}
+	if _, err := app.Endpoint(ep.Name); err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	return txn.Op{
+		C:  remoteApplicationsC,
+		Id: st.docID(ep.ApplicationName),
+		Assert: bson.D{
+			{"life", Alive},
+			{"terminated", bson.D{{"$ne", true}}},
+		},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+	}, nil
+}
+
 // EndpointsRelation returns the existing relation with the given endpoints.
 func (st *State) EndpointsRelation(endpoints ...Endpoint) (*Relation, error) {
 	return st.KeyRelation(relationKey(endpoints))
@@ -2056,6 +2118,18 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the recorded audit entries satisfying the
+// supplied filter, oldest first.
+func (st *State) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	collection, closeCollection := st.getCollection(auditingC)
+	defer closeCollection()
+
+	query := collection.Find(stateaudit.QueryForFilter(filter))
+	iter := query.Sort(stateaudit.SortFields...).Iter()
+	entries, err := stateaudit.CollectAuditEntries(iter, filter)
+	return entries, errors.Trace(err)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
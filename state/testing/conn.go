@@ -99,6 +99,10 @@ func InitializeWithArgs(c *gc.C, args InitializeArgs) *state.State {
 		NewPolicy:     args.NewPolicy,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	secretsKey, err := state.NewSecretsKey()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetSecretsKey(secretsKey)
+	c.Assert(err, jc.ErrorIsNil)
 	return st
 }
 
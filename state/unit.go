@@ -2208,6 +2208,20 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action as AddAction does, which the
+// unit will stop and mark failed if it runs for longer than timeout.
+// If timeout is zero, the unit applies the timeout declared for the
+// action in the charm's actions.yaml, if any.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
+	return u.AddOperationAction("", name, payload, timeout)
+}
+
+// AddOperationAction adds a new Action as AddActionWithTimeout does,
+// as part of the operation with the given id.
+func (u *Unit) AddOperationAction(operationId, name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2233,7 +2247,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.EnqueueOperationAction(operationId, u.Tag(), name, payloadWithDefaults, timeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2274,6 +2288,12 @@ func (u *Unit) WatchActionNotifications() StringsWatcher {
 	return u.st.watchEnqueuedActionsFilteredBy(u)
 }
 
+// WatchAbortingActions starts and returns a StringsWatcher that
+// notifies when running actions for this Unit are asked to stop.
+func (u *Unit) WatchAbortingActions() StringsWatcher {
+	return newActionStatusWatcher(u.st, []ActionReceiver{u}, ActionAborting)
+}
+
 // Actions returns a list of actions pending or completed for this unit.
 func (u *Unit) Actions() ([]Action, error) {
 	return u.st.matchingActions(u)
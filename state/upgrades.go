@@ -5,6 +5,7 @@ package state
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -220,3 +221,30 @@ func stripLocalFromFields(st *State, collName string, fields ...string) ([]txn.O
 	}
 	return ops, nil
 }
+
+// AddAuditEntryTimes records the time of each audit entry as a date,
+// alongside the text timestamp, so that entries can be selected by
+// time in queries.
+func AddAuditEntryTimes(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+	upgradesLogger.Infof("adding times to audit entries")
+
+	iter := coll.Find(bson.D{{"time", bson.D{{"$exists", false}}}}).Select(bson.D{{"timestamp", 1}}).Iter()
+	defer iter.Close()
+	var doc struct {
+		DocID     bson.ObjectId `bson:"_id"`
+		Timestamp string        `bson:"timestamp"`
+	}
+	for iter.Next(&doc) {
+		var timestamp time.Time
+		if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			return errors.Annotatef(err, "parsing timestamp of audit entry %q", doc.DocID.Hex())
+		}
+		update := bson.D{{"$set", bson.D{{"time", timestamp.UTC()}}}}
+		if err := coll.UpdateId(doc.DocID, update); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(iter.Close())
+}
@@ -361,3 +361,32 @@ func (s *upgradesSuite) TestRenameAddModelPermission(c *gc.C) {
 	}}
 	s.assertUpgradedData(c, RenameAddModelPermission, coll, expected)
 }
+
+func (s *upgradesSuite) TestAddAuditEntryTimes(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	id0, id1 := bson.NewObjectId(), bson.NewObjectId()
+	err := coll.Insert(
+		bson.M{"_id": id0, "timestamp": "2016-10-01T12:00:00Z"},
+		bson.M{"_id": id1, "timestamp": "2016-10-01T12:01:00Z", "time": t1},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = AddAuditEntryTimes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []struct {
+		DocID bson.ObjectId `bson:"_id"`
+		Time  time.Time     `bson:"time"`
+	}
+	err = coll.Find(nil).Sort("_id").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0].DocID, gc.Equals, id0)
+	c.Check(docs[0].Time.Equal(t0), jc.IsTrue)
+	c.Check(docs[1].DocID, gc.Equals, id1)
+	c.Check(docs[1].Time.Equal(t1), jc.IsTrue)
+}
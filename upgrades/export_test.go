@@ -8,6 +8,7 @@ import "github.com/juju/juju/environs"
 var (
 	UpgradeOperations      = &upgradeOperations
 	StateUpgradeOperations = &stateUpgradeOperations
+	ControllerServingInfo  = &controllerServingInfo
 )
 
 type ModelConfigUpdater environConfigUpdater
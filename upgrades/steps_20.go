@@ -4,9 +4,15 @@
 package upgrades
 
 import (
+	"encoding/base64"
 	"os"
 	"path/filepath"
 
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
@@ -27,6 +33,13 @@ func stateStepsFor20() []Step {
 				return state.RenameAddModelPermission(context.State())
 			},
 		},
+		&upgradeStep{
+			description: "add times to audit entries",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.AddAuditEntryTimes(context.State())
+			},
+		},
 	}
 }
 
@@ -38,6 +51,16 @@ func stepsFor20() []Step {
 			targets:     []Target{Controller},
 			run:         removeCharmGetCache,
 		},
+		&upgradeStep{
+			description: "generate secrets key",
+			targets:     []Target{DatabaseMaster},
+			run:         generateSecretsKey,
+		},
+		&upgradeStep{
+			description: "get secrets key from master controller",
+			targets:     []Target{Controller},
+			run:         fetchSecretsKey,
+		},
 	}
 }
 
@@ -48,3 +71,72 @@ func removeCharmGetCache(context Context) error {
 	cacheDir := filepath.Join(dataDir, "charm-get-cache")
 	return os.RemoveAll(cacheDir)
 }
+
+// generateSecretsKey generates the key with which charm secrets are
+// encrypted, for a controller upgraded from a version without secrets,
+// and keeps it in the master controller's agent config. The other
+// controllers get it from the master with fetchSecretsKey.
+func generateSecretsKey(context Context) error {
+	config := context.AgentConfig()
+	info, ok := config.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info available")
+	}
+	if info.SecretsKey != "" {
+		return nil
+	}
+	key, err := state.NewSecretsKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info.SecretsKey = base64.StdEncoding.EncodeToString(key)
+	config.SetStateServingInfo(info)
+	return nil
+}
+
+// fetchSecretsKey gets the key with which charm secrets are encrypted
+// from the agent API of whichever controller has it, for a controller
+// upgraded from a version without secrets, and keeps it in the agent
+// config. Each controller is asked in turn, as the one the agent is
+// connected to may be this one; if none has the key yet, the step
+// fails and is retried.
+func fetchSecretsKey(context Context) error {
+	config := context.AgentConfig()
+	info, ok := config.StateServingInfo()
+	if !ok {
+		return errors.New("no state serving info available")
+	}
+	if info.SecretsKey != "" {
+		return nil
+	}
+	apiInfo, ok := config.APIInfo()
+	if !ok {
+		return errors.New("no API info available")
+	}
+	for _, addr := range apiInfo.Addrs {
+		addrInfo := *apiInfo
+		addrInfo.Addrs = []string{addr}
+		servingInfo, err := controllerServingInfo(&addrInfo)
+		if err != nil {
+			logger.Debugf("cannot get secrets key from %s: %v", addr, err)
+			continue
+		}
+		if servingInfo.SecretsKey != "" {
+			info.SecretsKey = servingInfo.SecretsKey
+			config.SetStateServingInfo(info)
+			return nil
+		}
+	}
+	return errors.NotProvisionedf("secrets key")
+}
+
+// controllerServingInfo returns the state serving info reported by the
+// agent API at the given address.
+var controllerServingInfo = func(info *api.Info) (params.StateServingInfo, error) {
+	conn, err := api.Open(info, api.DefaultDialOpts())
+	if err != nil {
+		return params.StateServingInfo{}, errors.Trace(err)
+	}
+	defer conn.Close()
+	return apiagent.NewState(conn).StateServingInfo()
+}
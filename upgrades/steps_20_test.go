@@ -4,17 +4,22 @@
 package upgrades_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/upgrades"
+	jujuversion "github.com/juju/juju/version"
 )
 
 var v200 = version.MustParse("2.0.0")
@@ -37,6 +42,12 @@ func (s *steps20Suite) TestRenameAddModelPermission(c *gc.C) {
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
 
+func (s *steps20Suite) TestAddAuditEntryTimes(c *gc.C) {
+	step := findStateStep(c, v200, "add times to audit entries")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
 func (s *steps20Suite) TestCharmGetCacheDir(c *gc.C) {
 	// Create a cache directory with some stuff in it.
 	dataDir := c.MkDir()
@@ -63,6 +74,106 @@ func (s *steps20Suite) TestCharmGetCacheDir(c *gc.C) {
 	check() // Check OK when directory not present
 }
 
+func (s *steps20Suite) TestGenerateSecretsKey(c *gc.C) {
+	step := findStep(c, v200, "generate secrets key")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{
+			servingInfo: params.StateServingInfo{APIPort: 17070},
+		},
+	}
+	err := step.Run(context)
+	c.Assert(err, jc.ErrorIsNil)
+	info := context.agentConfig.servingInfo
+	c.Check(info.APIPort, gc.Equals, 17070)
+	key, err := base64.StdEncoding.DecodeString(info.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key, gc.HasLen, 32)
+
+	// The key is only generated once.
+	err = step.Run(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(context.agentConfig.servingInfo.SecretsKey, gc.Equals, info.SecretsKey)
+}
+
+func (s *steps20Suite) TestFetchSecretsKey(c *gc.C) {
+	step := findStep(c, v200, "get secrets key from master controller")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.Controller})
+
+	var addrs []string
+	s.PatchValue(upgrades.ControllerServingInfo, func(info *api.Info) (params.StateServingInfo, error) {
+		c.Assert(info.Addrs, gc.HasLen, 1)
+		addrs = append(addrs, info.Addrs[0])
+		switch info.Addrs[0] {
+		case "10.0.0.1:17070":
+			return params.StateServingInfo{}, errors.New("upgrade in progress")
+		case "10.0.0.2:17070":
+			return params.StateServingInfo{SecretsKey: "master key"}, nil
+		}
+		return params.StateServingInfo{}, nil
+	})
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{
+			apiAddresses: []string{"localhost:17070", "10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"},
+		},
+	}
+	err := step.Run(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(context.agentConfig.servingInfo.SecretsKey, gc.Equals, "master key")
+	c.Check(addrs, jc.DeepEquals, []string{"localhost:17070", "10.0.0.1:17070", "10.0.0.2:17070"})
+}
+
+func (s *steps20Suite) TestFetchSecretsKeyNotYetGenerated(c *gc.C) {
+	step := findStep(c, v200, "get secrets key from master controller")
+	s.PatchValue(upgrades.ControllerServingInfo, func(info *api.Info) (params.StateServingInfo, error) {
+		return params.StateServingInfo{}, nil
+	})
+	context := &mockContext{
+		agentConfig: &mockAgentConfig{
+			apiAddresses: []string{"localhost:17070"},
+		},
+	}
+	err := step.Run(context)
+	c.Assert(err, gc.ErrorMatches, "secrets key not provisioned")
+	c.Check(context.agentConfig.servingInfo.SecretsKey, gc.Equals, "")
+}
+
+func (s *steps20Suite) TestUpgradeControllersWithoutSecretsKey(c *gc.C) {
+	// The state steps need a real database; only the API steps are run.
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation { return nil })
+	s.PatchValue(&jujuversion.Current, v200)
+	from := version.MustParse("2.0-rc3")
+
+	master := &mockContext{
+		agentConfig: &mockAgentConfig{
+			dataDir:      c.MkDir(),
+			apiAddresses: []string{"10.0.0.1:17070"},
+		},
+	}
+	err := upgrades.PerformUpgrade(from, []upgrades.Target{upgrades.Controller, upgrades.DatabaseMaster}, master)
+	c.Assert(err, jc.ErrorIsNil)
+	key := master.agentConfig.servingInfo.SecretsKey
+	c.Assert(key, gc.Not(gc.Equals), "")
+
+	// The other controllers get the master's key.
+	s.PatchValue(upgrades.ControllerServingInfo, func(info *api.Info) (params.StateServingInfo, error) {
+		if info.Addrs[0] == "10.0.0.1:17070" {
+			return master.agentConfig.servingInfo, nil
+		}
+		return params.StateServingInfo{}, nil
+	})
+	other := &mockContext{
+		agentConfig: &mockAgentConfig{
+			dataDir:      c.MkDir(),
+			apiAddresses: []string{"localhost:17070", "10.0.0.1:17070"},
+		},
+	}
+	err = upgrades.PerformUpgrade(from, []upgrades.Target{upgrades.Controller}, other)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(other.agentConfig.servingInfo.SecretsKey, gc.Equals, key)
+}
+
 func pathExists(p string) bool {
 	_, err := os.Stat(p)
 	if err == nil {
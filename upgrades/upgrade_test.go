@@ -181,6 +181,13 @@ func (mock *mockAgentConfig) Value(name string) string {
 	return mock.values[name]
 }
 
+func (mock *mockAgentConfig) APIInfo() (*api.Info, bool) {
+	return &api.Info{
+		Addrs: mock.apiAddresses,
+		Tag:   mock.tag,
+	}, true
+}
+
 func (mock *mockAgentConfig) MongoInfo() (*mongo.MongoInfo, bool) {
 	return mock.mongoInfo, true
 }
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker"
+)
+
+// Facade represents an API that implements action pruning.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	Prune(maxHistoryTime time.Duration, maxHistoryCount int) error
+}
+
+// Config holds all necessary attributes to start a pruner worker.
+type Config struct {
+	Facade        Facade
+	PruneInterval time.Duration
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	NewTimer worker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that periodically prunes the completed
+// actions in the model, keeping those allowed by the model's
+// max-action-results-age and max-action-results-count settings.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doPruning := func(stop <-chan struct{}) error {
+		// The model config is read afresh each time, so that
+		// changes to it take effect without restarting the worker.
+		modelConfig, err := conf.Facade.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		maxAge := modelConfig.MaxActionResultsAge()
+		maxCount := modelConfig.MaxActionResultsCount()
+		if maxAge == 0 && maxCount == 0 {
+			return nil
+		}
+		if err := conf.Facade.Prune(maxAge, maxCount); err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	return worker.NewPeriodicWorker(doPruning, conf.PruneInterval, conf.NewTimer), nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/actionpruner"
+)
+
+type actionPrunerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionPrunerSuite{})
+
+func (s *actionPrunerSuite) startPruner(c *gc.C, facade actionpruner.Facade) *mockTimer {
+	fakeTimer := newMockTimer()
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		// construction of timer should be with 0 because we intend it to
+		// run once before waiting.
+		c.Assert(d, gc.Equals, 0*time.Nanosecond)
+		return fakeTimer
+	}
+	conf := actionpruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
+	}
+
+	pruner, err := actionpruner.New(conf)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+	return fakeTimer
+}
+
+func (s *actionPrunerSuite) TestValidate(c *gc.C) {
+	_, err := actionpruner.New(actionpruner.Config{})
+	c.Assert(err, gc.ErrorMatches, "missing Facade")
+
+	_, err = actionpruner.New(actionpruner.Config{Facade: newFakeFacade(nil)})
+	c.Assert(err, gc.ErrorMatches, "missing Timer")
+}
+
+func (s *actionPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
+	facade := newFakeFacade(coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"max-action-results-age":   "1h",
+		"max-action-results-count": 10,
+	}))
+	fakeTimer := s.startPruner(c, facade)
+
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+
+	var args pruneArgs
+	select {
+	case args = <-facade.passedArgs:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for prune")
+	}
+	c.Assert(args, jc.DeepEquals, pruneArgs{time.Hour, 10})
+
+	// Reset will have been called with the actual PruneInterval
+	var period time.Duration
+	select {
+	case period = <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset by pruner")
+	}
+	c.Assert(period, gc.Equals, coretesting.ShortWait)
+}
+
+func (s *actionPrunerSuite) TestWorkerUsesDefaultAge(c *gc.C) {
+	facade := newFakeFacade(coretesting.ModelConfig(c))
+	fakeTimer := s.startPruner(c, facade)
+
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+
+	select {
+	case args := <-facade.passedArgs:
+		c.Assert(args, jc.DeepEquals, pruneArgs{config.DefaultActionResultsAge, 0})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for prune")
+	}
+}
+
+func (s *actionPrunerSuite) TestWorkerWontPruneWhenDisabled(c *gc.C) {
+	facade := newFakeFacade(coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"max-action-results-age": "0s",
+	}))
+	fakeTimer := s.startPruner(c, facade)
+
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+
+	select {
+	case <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset by pruner")
+	}
+	select {
+	case <-facade.passedArgs:
+		c.Fatal("pruned with pruning disabled")
+	default:
+	}
+}
+
+func (s *actionPrunerSuite) TestWorkerWontCallPruneBeforeFiringTimer(c *gc.C) {
+	facade := newFakeFacade(coretesting.ModelConfig(c))
+	s.startPruner(c, facade)
+
+	select {
+	case <-facade.passedArgs:
+		c.Fatal("called before firing timer.")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	case <-time.After(coretesting.LongWait):
+		panic("timed out waiting for timer to reset")
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for pruner to run")
+	}
+	return nil
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{
+		period: make(chan time.Duration, 1),
+		c:      make(chan time.Time),
+	}
+}
+
+type pruneArgs struct {
+	maxHistoryTime  time.Duration
+	maxHistoryCount int
+}
+
+type fakeFacade struct {
+	modelConfig *config.Config
+	passedArgs  chan pruneArgs
+}
+
+func newFakeFacade(modelConfig *config.Config) *fakeFacade {
+	return &fakeFacade{
+		modelConfig: modelConfig,
+		passedArgs:  make(chan pruneArgs, 1),
+	}
+}
+
+// ModelConfig implements Facade.
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	return f.modelConfig, nil
+}
+
+// Prune implements Facade.
+func (f *fakeFacade) Prune(maxHistoryTime time.Duration, maxHistoryCount int) error {
+	select {
+	case f.passedArgs <- pruneArgs{maxHistoryTime, maxHistoryCount}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for facade call Prune to run")
+	}
+	return nil
+}
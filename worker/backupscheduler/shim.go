@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackups returns a Backups which backs up the controller
+// from the machine with the given ID, as the Backups facade does.
+func NewStateBackups(st *state.State, machineID string, paths backups.Paths) Backups {
+	return &stateBackups{
+		st:        st,
+		machineID: machineID,
+		paths:     paths,
+	}
+}
+
+type stateBackups struct {
+	st        *state.State
+	machineID string
+	paths     backups.Paths
+}
+
+func (b *stateBackups) open() (backups.Backups, func()) {
+	stor := backups.NewStorage(b.st)
+	return backups.NewControllerBackups(stor, b.st), func() { stor.Close() }
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes, storage string) (*backups.Metadata, error) {
+	api, closer := b.open()
+	defer closer()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if dbInfo.Oplog, err = backups.ReadOplogRange(session); err != nil {
+		logger.Warningf("cannot record oplog position of backup: %v", err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Storage = storage
+	paths := b.paths
+	if err := api.Create(meta, &paths, dbInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	api, closer := b.open()
+	defer closer()
+	return api.List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	api, closer := b.open()
+	defer closer()
+	return api.Remove(id)
+}
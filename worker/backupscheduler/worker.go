@@ -0,0 +1,245 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// DefaultPollInterval is how often the worker rereads the controller
+// config while waiting for the next scheduled backup.
+const DefaultPollInterval = 5 * time.Minute
+
+// Backend exposes the controller state needed by the worker.
+type Backend interface {
+	// ControllerConfig returns the controller config, which holds
+	// the backup schedule and retention rules.
+	ControllerConfig() (controller.Config, error)
+
+	// SetBackupScheduleStatus records the outcome of a scheduled
+	// backup in the status history.
+	SetBackupScheduleStatus(status.StatusInfo) error
+}
+
+// Backups creates, lists and removes backups.
+type Backups interface {
+	// Create creates a backup with the given notes, kept in the
+	// named storage, and returns its metadata.
+	Create(notes, storage string) (*backups.Metadata, error)
+
+	// List returns the metadata of all backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove removes the identified backup.
+	Remove(id string) error
+}
+
+// Config defines the operation of a backup scheduler worker.
+type Config struct {
+
+	// Backend is the worker's view of the controller.
+	Backend Backend
+
+	// Backups is used to create and prune backups.
+	Backups Backups
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// PollInterval is the longest time the worker waits before
+	// rereading the controller config.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that creates backups on the schedule set
+// in the controller config, and prunes scheduled backups according to
+// the configured retention rules after each one is created. The
+// outcome of each scheduled backup is recorded in the status history.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type schedulerWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *schedulerWorker) loop() error {
+	// since is the time after which the next backup is due. It only
+	// advances when a backup runs (or while there is no schedule), so
+	// waking periodically to reread the config never causes a backup
+	// to be skipped. Backups missed while a slow one was running are
+	// not made up.
+	since := w.config.Clock.Now()
+	for {
+		cfg, err := w.config.Backend.ControllerConfig()
+		if err != nil {
+			return errors.Annotate(err, "reading controller config")
+		}
+		delay := w.config.PollInterval
+		var due time.Time
+		if spec := cfg.BackupSchedule(); spec != "" {
+			schedule, err := cron.Parse(spec)
+			if err != nil {
+				return errors.Annotate(err, "reading backup schedule")
+			}
+			next := schedule.Next(since)
+			if !next.IsZero() {
+				if untilNext := next.Sub(w.config.Clock.Now()); untilNext <= delay {
+					due, delay = next, untilNext
+				}
+			}
+		} else {
+			// Don't run a backup as soon as a schedule is set,
+			// just because it would have run in the past.
+			since = w.config.Clock.Now()
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+		}
+		if !due.IsZero() {
+			w.runScheduled(cfg)
+			since = w.config.Clock.Now()
+		}
+	}
+}
+
+// runScheduled creates a backup and prunes old scheduled backups.
+// Failures are recorded in the status history rather than stopping
+// the worker, so that one failed backup does not prevent the next.
+func (w *schedulerWorker) runScheduled(cfg controller.Config) {
+	storage := cfg.BackupScheduleStorage()
+	meta, err := w.config.Backups.Create(backups.ScheduledNotes, storage)
+	if err != nil {
+		w.setStatus(status.Error, fmt.Sprintf("creating backup: %v", err), storage)
+		return
+	}
+	w.setStatus(status.Active, fmt.Sprintf("created backup %s", meta.ID()), storage)
+
+	retention := cfg.BackupRetention()
+	policy := backups.RetentionPolicy{
+		KeepLast:   retention.KeepLast,
+		KeepDaily:  retention.KeepDaily,
+		KeepWeekly: retention.KeepWeekly,
+	}
+	if err := w.prune(policy); err != nil {
+		w.setStatus(status.Error, fmt.Sprintf("pruning backups: %v", err), storage)
+	}
+}
+
+// prune removes the scheduled backups that the policy does not keep.
+func (w *schedulerWorker) prune(policy backups.RetentionPolicy) error {
+	if policy.IsZero() {
+		return nil
+	}
+	metaList, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var scheduled []*backups.Metadata
+	children := make(map[string]int)
+	for _, meta := range metaList {
+		if meta.Notes == backups.ScheduledNotes {
+			scheduled = append(scheduled, meta)
+		}
+		if meta.IsIncremental() {
+			children[meta.Parent]++
+		}
+	}
+	// Expired backups are removed newest first, so that an expired
+	// incremental backup is gone before its parent is considered. A
+	// backup on which any remaining backup builds is kept, since
+	// that backup could not be restored without it.
+	expired := policy.Expired(scheduled)
+	for i := len(expired) - 1; i >= 0; i-- {
+		meta := expired[i]
+		if children[meta.ID()] > 0 {
+			logger.Infof("keeping expired backup %s, on which other backups build", meta.ID())
+			continue
+		}
+		if err := w.config.Backups.Remove(meta.ID()); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing backup %s", meta.ID())
+		}
+		logger.Infof("removed expired backup %s", meta.ID())
+		if meta.IsIncremental() {
+			children[meta.Parent]--
+		}
+	}
+	return nil
+}
+
+func (w *schedulerWorker) setStatus(s status.Status, message, storage string) {
+	if s == status.Error {
+		logger.Errorf("scheduled backup: %s", message)
+	} else {
+		logger.Infof("scheduled backup: %s", message)
+	}
+	if storage == "" {
+		storage = backups.ControllerStorage
+	}
+	now := w.config.Clock.Now()
+	err := w.config.Backend.SetBackupScheduleStatus(status.StatusInfo{
+		Status:  s,
+		Message: message,
+		Data:    map[string]interface{}{"storage": storage},
+		Since:   &now,
+	})
+	if err != nil {
+		logger.Errorf("cannot record scheduled backup status: %v", err)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *schedulerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *schedulerWorker) Wait() error {
+	return w.tomb.Wait()
+}
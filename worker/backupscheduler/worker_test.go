@@ -0,0 +1,254 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testing.Clock
+	backend *fakeBackend
+	backups *fakeBackups
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2016, 10, 18, 2, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		config:   controller.Config{},
+		statuses: make(chan status.StatusInfo, 10),
+	}
+	s.backups = &fakeBackups{}
+	s.config = backupscheduler.Config{
+		Backend:      s.backend,
+		Backups:      s.backups,
+		Clock:        s.clock,
+		PollInterval: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { worker.Stop(w) })
+	return w
+}
+
+func (s *WorkerSuite) waitStatus(c *gc.C) status.StatusInfo {
+	select {
+	case sInfo := <-s.backend.statuses:
+		return sInfo
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Backend = nil
+	_, err := backupscheduler.NewWorker(s.config)
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+
+	s.config.Backend = s.backend
+	s.config.PollInterval = 0
+	_, err = backupscheduler.NewWorker(s.config)
+	c.Check(err, gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestCreatesOnSchedule(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "30 2 * * *"
+	s.backend.config[controller.BackupScheduleStorage] = "dir"
+	s.backend.config[controller.BackupKeepLast] = 2
+	s.backups.list = []*backups.Metadata{
+		newMeta("manual", "", "2016-10-15 00:00"),
+		newMeta("oldest", backups.ScheduledNotes, "2016-10-16 02:30"),
+		newMeta("older", backups.ScheduledNotes, "2016-10-17 02:30"),
+		newMeta("new", backups.ScheduledNotes, "2016-10-18 02:30"),
+	}
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	sInfo := s.waitStatus(c)
+	c.Check(sInfo.Status, gc.Equals, status.Active)
+	c.Check(sInfo.Message, gc.Equals, "created backup new")
+	c.Check(sInfo.Data, jc.DeepEquals, map[string]interface{}{"storage": "dir"})
+	c.Check(*sInfo.Since, gc.Equals, s.clock.Now())
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.backups.stub.CheckCalls(c, []testing.StubCall{
+		{FuncName: "Create", Args: []interface{}{backups.ScheduledNotes, "dir"}},
+		{FuncName: "List"},
+		{FuncName: "Remove", Args: []interface{}{"oldest"}},
+	})
+}
+
+func (s *WorkerSuite) TestPruneKeepsParents(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "30 2 * * *"
+	s.backend.config[controller.BackupKeepLast] = 1
+	child := newMeta("child", "", "2016-10-16 12:00")
+	child.Parent = "parent"
+	expiredChild := newMeta("expired-child", backups.ScheduledNotes, "2016-10-17 02:30")
+	expiredChild.Parent = "expired-parent"
+	s.backups.list = []*backups.Metadata{
+		newMeta("parent", backups.ScheduledNotes, "2016-10-16 02:30"),
+		child,
+		newMeta("expired-parent", backups.ScheduledNotes, "2016-10-17 00:30"),
+		expiredChild,
+		newMeta("new", backups.ScheduledNotes, "2016-10-18 02:30"),
+	}
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitStatus(c).Status, gc.Equals, status.Active)
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.backups.stub.CheckCalls(c, []testing.StubCall{
+		{FuncName: "Create", Args: []interface{}{backups.ScheduledNotes, ""}},
+		{FuncName: "List"},
+		{FuncName: "Remove", Args: []interface{}{"expired-child"}},
+		{FuncName: "Remove", Args: []interface{}{"expired-parent"}},
+	})
+}
+
+func (s *WorkerSuite) TestNotBeforeSchedule(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "30 2 * * *"
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(29*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.backups.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	w := s.startWorker(c)
+
+	// Wait for the config to be reread twice.
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.backend.stub.CheckCallNames(c, "ControllerConfig", "ControllerConfig", "ControllerConfig")
+	s.backups.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestCreateErrorRecorded(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "@hourly"
+	s.backups.stub.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	sInfo := s.waitStatus(c)
+	c.Check(sInfo.Status, gc.Equals, status.Error)
+	c.Check(sInfo.Message, gc.Equals, "creating backup: boom")
+	c.Check(sInfo.Data, jc.DeepEquals, map[string]interface{}{"storage": "controller"})
+
+	// The next scheduled backup is still attempted.
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	sInfo = s.waitStatus(c)
+	c.Check(sInfo.Status, gc.Equals, status.Active)
+
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	s.backups.stub.CheckCallNames(c, "Create", "Create")
+}
+
+func (s *WorkerSuite) TestPruneErrorRecorded(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "@hourly"
+	s.backend.config[controller.BackupKeepLast] = 1
+	s.backups.stub.SetErrors(nil, errors.New("boom"))
+	w := s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.waitStatus(c).Status, gc.Equals, status.Active)
+	sInfo := s.waitStatus(c)
+	c.Check(sInfo.Status, gc.Equals, status.Error)
+	c.Check(sInfo.Message, gc.Equals, "pruning backups: boom")
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.backend.stub.SetErrors(errors.New("boom"))
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.Wait()
+	c.Assert(err, gc.ErrorMatches, "reading controller config: boom")
+}
+
+func newMeta(id, notes, started string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started, _ = time.Parse("2006-01-02 15:04", started)
+	return meta
+}
+
+type fakeBackend struct {
+	stub     testing.Stub
+	config   controller.Config
+	statuses chan status.StatusInfo
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.stub.AddCall("ControllerConfig")
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.config, nil
+}
+
+func (b *fakeBackend) SetBackupScheduleStatus(sInfo status.StatusInfo) error {
+	b.statuses <- sInfo
+	return nil
+}
+
+type fakeBackups struct {
+	stub testing.Stub
+	list []*backups.Metadata
+}
+
+func (b *fakeBackups) Create(notes, storage string) (*backups.Metadata, error) {
+	b.stub.AddCall("Create", notes, storage)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	meta := backups.NewMetadata()
+	meta.SetID("new")
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.stub.AddCall("List")
+	return b.list, b.stub.NextErr()
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.stub.AddCall("Remove", id)
+	return b.stub.NextErr()
+}
@@ -6,9 +6,11 @@ package logforwarder
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/api/base"
@@ -22,7 +24,10 @@ var logger = loggo.GetLogger("juju.worker.logforwarder")
 // LogStream streams log entries from a log source (e.g. the Juju controller).
 type LogStream interface {
 	// Next returns the next batch of log records from the stream.
-	Next() ([]logfwd.Record, error)
+	// The source applies the log forwarding filter of each record's
+	// model; if records were dropped since the previous batch,
+	// skipped identifies the last of them.
+	Next() (records []logfwd.Record, skipped *logfwd.Record, err error)
 }
 
 // LogStreamFn is a function that opens a log stream.
@@ -40,8 +45,33 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
+// RetryStrategy defines how a LogForwarder retries sending log
+// records that its sink failed to accept. The delay between attempts
+// starts at Delay and doubles after each failure, up to MaxDelay.
+type RetryStrategy struct {
+	// Attempts is the number of times a send is retried before the
+	// worker gives up. If zero, failed sends are not retried.
+	Attempts int
+
+	// Delay is the time to wait before the first retry.
+	Delay time.Duration
+
+	// MaxDelay is the maximum time to wait between retries. If
+	// zero, the delay is not capped.
+	MaxDelay time.Duration
+}
+
+// BatchStrategy defines how a LogForwarder groups log records into
+// batches, so that its sink is sent several records at a time.
+type BatchStrategy struct {
+	// Size is the most records sent to the sink at once. If zero,
+	// records are not batched but sent as soon as they are received.
+	Size int
+
+	// Wait is the longest time a record is held waiting for the
+	// batch to fill before the batch is sent anyway.
+	Wait time.Duration
+}
 
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
@@ -51,6 +81,10 @@ type LogForwarder struct {
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+
+	// tracker records the position of records dropped by the log
+	// stream's filters, which are never sent.
+	tracker *lastSentTracker
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -70,6 +104,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkConfig is the function that extracts the log sink's
+	// configuration from the model config.
+	SinkConfig LogSinkConfigFn
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -77,9 +115,24 @@ type OpenLogForwarderArgs struct {
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
+
+	// RetryStrategy controls how sends rejected by the log sink are
+	// retried before the worker fails. Once the worker is restarted,
+	// forwarding resumes from the last record that was sent.
+	RetryStrategy RetryStrategy
+
+	// BatchStrategy controls how records are grouped into batches
+	// before they are sent. The last-sent record is recorded once per
+	// batch.
+	BatchStrategy BatchStrategy
+
+	// Clock is used to wait between retries and for batches to fill.
+	// It must be set if RetryStrategy.Attempts or BatchStrategy.Size
+	// is non-zero.
+	Clock clock.Clock
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -94,12 +147,13 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	modelCfg, err := lf.args.LogForwardConfig.ModelConfig()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
+	cfg, ok := lf.args.SinkConfig(modelCfg)
+	if !ok {
 		logger.Infof("config change - log forwarding not enabled")
 		return nil, closeExisting()
 	}
@@ -108,7 +162,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	// config change to come through.
 	// We'll continue sending using the current sink.
 	if err := cfg.Validate(); err != nil {
-		logger.Errorf("invalid log forward config change: %v", err)
+		logger.Errorf("invalid log forward config change for %s: %v", lf.args.Name, err)
 		return currentSender, nil
 	}
 
@@ -149,7 +203,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -158,9 +212,16 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 // NewLogForwarder returns a worker that forwards logs received from
 // the stream to the sender.
 func NewLogForwarder(args OpenLogForwarderArgs) (*LogForwarder, error) {
+	if args.SinkConfig == nil {
+		return nil, errors.NotValidf("nil SinkConfig")
+	}
+	if (args.RetryStrategy.Attempts > 0 || args.BatchStrategy.Size > 0) && args.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
 	lf := &LogForwarder{
 		args:      args,
 		enabledCh: make(chan bool, 1),
+		tracker:   newLastSentTracker(args.Name, args.Caller),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &lf.catacomb,
@@ -183,7 +244,11 @@ func (lf *LogForwarder) loop() error {
 		return errors.Trace(err)
 	}
 
-	records := make(chan []logfwd.Record)
+	type streamed struct {
+		records []logfwd.Record
+		skipped *logfwd.Record
+	}
+	records := make(chan streamed)
 	var stream LogStream
 	go func() {
 		for {
@@ -209,7 +274,7 @@ func (lf *LogForwarder) loop() error {
 				}
 
 			}
-			rec, err := stream.Next()
+			rec, skipped, err := stream.Next()
 			if err != nil {
 				lf.catacomb.Kill(errors.Annotate(err, "getting next log record"))
 				break
@@ -217,7 +282,7 @@ func (lf *LogForwarder) loop() error {
 			select {
 			case <-lf.catacomb.Dying():
 				return
-			case records <- rec: // Wait until the last one is sent.
+			case records <- streamed{rec, skipped}: // Wait until the last one is sent.
 			}
 		}
 	}()
@@ -229,28 +294,114 @@ func (lf *LogForwarder) loop() error {
 		}
 	}()
 
+	// batch holds the records received but not yet sent, and
+	// batchTimeout fires when they have waited long enough.
+	var batch []logfwd.Record
+	var batchTimeout <-chan time.Time
+	flush := func() error {
+		records := batch
+		batch, batchTimeout = nil, nil
+		if sender == nil {
+			return nil
+		}
+		return lf.sendBatches(sender, records)
+	}
+
 	for {
 		select {
 		case <-lf.catacomb.Dying():
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
+			}
+			// Send what has been received with the old config
+			// before switching to the new one.
+			if err := flush(); err != nil {
+				return errors.Trace(err)
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
 			}
-		case rec := <-records:
+		case <-batchTimeout:
+			if err := flush(); err != nil {
+				return errors.Trace(err)
+			}
+		case next := <-records:
 			if sender == nil {
 				continue
 			}
-			if err := sender.Send(rec); err != nil {
+			if next.skipped != nil {
+				// The skipped record follows any in the batch, so
+				// those must be sent before it is recorded.
+				if err := flush(); err != nil {
+					return errors.Trace(err)
+				}
+				skipped := []logfwd.Record{*next.skipped}
+				if err := lf.tracker.setLastSent(lf.args.AllModels, skipped); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if len(next.records) == 0 {
+				continue
+			}
+			batch = append(batch, next.records...)
+			if len(batch) < lf.args.BatchStrategy.Size {
+				if batchTimeout == nil {
+					batchTimeout = lf.args.Clock.After(lf.args.BatchStrategy.Wait)
+				}
+				continue
+			}
+			if err := flush(); err != nil {
 				return errors.Trace(err)
 			}
 		}
 	}
 }
 
+// sendBatches sends the records to the sender, at most
+// BatchStrategy.Size at a time.
+func (lf *LogForwarder) sendBatches(sender SendCloser, records []logfwd.Record) error {
+	size := lf.args.BatchStrategy.Size
+	for len(records) > 0 {
+		n := len(records)
+		if size > 0 && n > size {
+			n = size
+		}
+		if err := lf.send(sender, records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+// send sends the records to the sender, retrying according to the
+// configured RetryStrategy.
+func (lf *LogForwarder) send(sender SendCloser, records []logfwd.Record) error {
+	strategy := lf.args.RetryStrategy
+	delay := strategy.Delay
+	for attempt := 0; ; attempt++ {
+		err := sender.Send(records)
+		if err == nil {
+			return nil
+		}
+		if attempt >= strategy.Attempts {
+			return errors.Trace(err)
+		}
+		logger.Warningf("sending log records to %s failed, retrying in %v: %v", lf.args.Name, delay, err)
+		select {
+		case <-lf.catacomb.Dying():
+			return lf.catacomb.ErrDying()
+		case <-lf.args.Clock.After(delay):
+		}
+		delay *= 2
+		if strategy.MaxDelay > 0 && delay > strategy.MaxDelay {
+			delay = strategy.MaxDelay
+		}
+	}
+}
+
 // Kill implements Worker.Kill()
 func (lf *LogForwarder) Kill() {
 	lf.catacomb.Kill(nil)
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -113,9 +114,15 @@ func (*mockWatcher) Wait() error {
 
 type mockCaller struct {
 	base.APICaller
+
+	// calls, if set, receives the name of each call made.
+	calls chan string
 }
 
-func (*mockCaller) APICall(objType string, version int, id, request string, params, response interface{}) error {
+func (m *mockCaller) APICall(objType string, version int, id, request string, params, response interface{}) error {
+	if m.calls != nil {
+		m.calls <- objType + "." + request
+	}
 	return nil
 }
 
@@ -131,14 +138,22 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+func (c *mockLogForwardConfig) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"logforward-enabled": c.enabled,
+		"syslog-host":        c.host,
+		"syslog-ca-cert":     coretesting.CACert,
+		"syslog-client-cert": coretesting.ServerCert,
+		"syslog-client-key":  coretesting.ServerKey,
+	}))
+}
+
+func syslogConfig(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdSyslog()
+	if !ok || !cfg.Enabled {
+		return nil, false
+	}
+	return cfg, true
 }
 
 func (s *LogForwarderSuite) newLogForwarderArgs(c *gc.C, stream logforwarder.LogStream, sender *stubSender) logforwarder.OpenLogForwarderArgs {
@@ -155,8 +170,9 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(c *gc.C, configAPI logfor
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		SinkConfig:       syslogConfig,
+		OpenSink: func(cfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -212,6 +228,41 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	s.stub.ResetCalls()
 }
 
+func (s *LogForwarderSuite) TestSkipped(c *gc.C) {
+	skippedRec := s.rec
+	rec2 := s.rec
+	rec2.ID = 11
+	s.stream.skipped = map[int64]bool{skippedRec.ID: true}
+	s.stream.setRecords(c, []logfwd.Record{
+		skippedRec,
+		rec2,
+	})
+
+	caller := &mockCaller{calls: make(chan string, 10)}
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.Caller = caller
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.checkClose(c, lf, nil)
+
+	// The record dropped by the stream's filters is not sent, but
+	// is recorded as the last one dealt with.
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	select {
+	case call := <-caller.calls:
+		c.Check(call, gc.Equals, "LogForwarding.SetLastSent")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for SetLastSent")
+	}
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.sender.waitAfterSend(c)
+	s.stub.CheckCallNames(c, "Next", "Next", "Send")
+	s.stub.CheckCall(c, 2, "Send", []logfwd.Record{rec2})
+	s.stub.ResetCalls()
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
@@ -252,9 +303,103 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	s.checkClose(c, lf, failure)
 }
 
+func (s *LogForwarderSuite) TestSenderErrorRetried(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, failure)
+	s.stream.setRecords(c, []logfwd.Record{
+		s.rec,
+	})
+	clock := testing.NewClock(coretesting.ZeroTime())
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.RetryStrategy = logforwarder.RetryStrategy{
+		Attempts: 1,
+		Delay:    time.Second,
+	}
+	args.Clock = clock
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.checkClose(c, lf, nil)
+
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.sender.waitAfterSend(c)
+	c.Assert(clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.sender.waitAfterSend(c)
+	s.stub.CheckCallNames(c, "Next", "Send", "Send")
+	s.stub.CheckCall(c, 2, "Send", []logfwd.Record{s.rec})
+	s.stub.ResetCalls()
+}
+
+func (s *LogForwarderSuite) TestBatched(c *gc.C) {
+	rec2 := s.rec
+	rec2.ID = 11
+	s.stream.setRecords(c, []logfwd.Record{
+		s.rec,
+		rec2,
+	})
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.BatchStrategy = logforwarder.BatchStrategy{
+		Size: 2,
+		Wait: time.Minute,
+	}
+	args.Clock = testing.NewClock(coretesting.ZeroTime())
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.checkClose(c, lf, nil)
+
+	// Both records are sent together once the batch is full.
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.sender.waitAfterSend(c)
+	s.stub.CheckCallNames(c, "Next", "Next", "Send")
+	s.stub.CheckCall(c, 2, "Send", []logfwd.Record{s.rec, rec2})
+	s.stub.ResetCalls()
+}
+
+func (s *LogForwarderSuite) TestBatchSentAfterWait(c *gc.C) {
+	s.stream.setRecords(c, []logfwd.Record{
+		s.rec,
+	})
+	clock := testing.NewClock(coretesting.ZeroTime())
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.BatchStrategy = logforwarder.BatchStrategy{
+		Size: 10,
+		Wait: time.Second,
+	}
+	args.Clock = clock
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.checkClose(c, lf, nil)
+
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	c.Assert(clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.sender.waitAfterSend(c)
+	s.stub.CheckCall(c, 1, "Send", []logfwd.Record{s.rec})
+	s.stub.ResetCalls()
+}
+
+func (s *LogForwarderSuite) TestMissingClock(c *gc.C) {
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.RetryStrategy = logforwarder.RetryStrategy{Attempts: 1}
+	_, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	args.RetryStrategy = logforwarder.RetryStrategy{}
+	args.BatchStrategy = logforwarder.BatchStrategy{Size: 10}
+	_, err = logforwarder.NewLogForwarder(args)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
 type stubStream struct {
 	stub *testing.Stub
 
+	// skipped holds the IDs of the records returned as dropped by
+	// the stream's filters.
+	skipped map[int64]bool
+
 	waitCh     chan struct{}
 	ReturnNext <-chan logfwd.Record
 }
@@ -297,16 +442,19 @@ func (s *stubStream) waitAfterNext(c *gc.C) {
 	}
 }
 
-func (s *stubStream) Next() ([]logfwd.Record, error) {
+func (s *stubStream) Next() ([]logfwd.Record, *logfwd.Record, error) {
 	s.waitCh <- struct{}{}
 	s.stub.AddCall("Next")
 	s.waitCh <- struct{}{}
 	if err := s.stub.NextErr(); err != nil {
-		return []logfwd.Record{}, errors.Trace(err)
+		return []logfwd.Record{}, nil, errors.Trace(err)
 	}
 
 	rec := <-s.ReturnNext
-	return []logfwd.Record{rec}, nil
+	if s.skipped[rec.ID] {
+		return nil, &rec, nil
+	}
+	return []logfwd.Record{rec}, nil, nil
 }
 
 type stubSender struct {
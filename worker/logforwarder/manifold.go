@@ -4,7 +4,10 @@
 package logforwarder
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
@@ -14,6 +17,21 @@ import (
 	"github.com/juju/juju/worker/dependency"
 )
 
+// defaultRetryStrategy is used when a ManifoldConfig does not
+// specify how failed sends should be retried.
+var defaultRetryStrategy = RetryStrategy{
+	Attempts: 5,
+	Delay:    time.Second,
+	MaxDelay: 30 * time.Second,
+}
+
+// defaultBatchStrategy is used when a ManifoldConfig does not
+// specify how records should be batched.
+var defaultBatchStrategy = BatchStrategy{
+	Size: 100,
+	Wait: time.Second,
+}
+
 // ManifoldConfig defines the names of the manifolds on which a
 // Manifold will depend.
 type ManifoldConfig struct {
@@ -31,6 +49,18 @@ type ManifoldConfig struct {
 
 	// OpenLogForwarder opens each log forwarder that will be used.
 	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
+
+	// RetryStrategy controls how failed sends are retried. If
+	// nil, a default strategy is used.
+	RetryStrategy *RetryStrategy
+
+	// BatchStrategy controls how records are batched before they
+	// are sent. If nil, a default strategy is used.
+	BatchStrategy *BatchStrategy
+
+	// Clock is used to wait between retries and for batches to
+	// fill. If nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Manifold returns a dependency manifold that runs a log forwarding
@@ -48,6 +78,21 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		openForwarder = NewLogForwarder
 	}
 
+	retryStrategy := defaultRetryStrategy
+	if config.RetryStrategy != nil {
+		retryStrategy = *config.RetryStrategy
+	}
+
+	batchStrategy := defaultBatchStrategy
+	if config.BatchStrategy != nil {
+		batchStrategy = *config.BatchStrategy
+	}
+
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+
 	return dependency.Manifold{
 		Inputs: []string{
 			config.StateName, // ...just to force it to run only on the controller.
@@ -72,6 +117,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Sinks:            config.Sinks,
 				OpenLogStream:    openLogStream,
 				OpenLogForwarder: openForwarder,
+				RetryStrategy:    retryStrategy,
+				BatchStrategy:    batchStrategy,
+				Clock:            clk,
 			})
 			return orchestrator, errors.Annotate(err, "creating log forwarding orchestrator")
 		},
@@ -4,7 +4,7 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
 )
 
@@ -14,20 +14,36 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	// ModelConfig returns the current model configuration, from
+	// which the configuration of each log sink is extracted.
+	ModelConfig() (*config.Config, error)
 }
 
+// LogSinkConfig is the configuration of a single log sink.
+type LogSinkConfig interface {
+	// Validate ensures that the config is currently valid.
+	Validate() error
+}
+
+// LogSinkConfigFn is a function that extracts a log sink's
+// configuration from the model config. It returns false if the
+// log sink is not configured or not enabled.
+type LogSinkConfigFn func(*config.Config) (LogSinkConfig, bool)
+
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// Config is a function that extracts the log sink's
+	// configuration from the model config.
+	Config LogSinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg LogSinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// HTTPConfig returns the HTTP log forwarding config from the model
+// config, and whether HTTP log forwarding is enabled.
+func HTTPConfig(modelCfg *config.Config) (logforwarder.LogSinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdHTTP()
+	if !ok || !cfg.Enabled {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// OpenHTTP returns a sink which forwards log records, encoded as JSON,
+// to an HTTP endpoint or Elasticsearch cluster.
+func OpenHTTP(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*httpjson.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected HTTP log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
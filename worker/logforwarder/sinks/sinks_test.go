@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestSyslogConfig(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "10.0.0.1:1234",
+		"syslog-ca-cert":     coretesting.CACert,
+		"syslog-client-cert": coretesting.ServerCert,
+		"syslog-client-key":  coretesting.ServerKey,
+	})
+	cfg, ok := sinks.SyslogConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(cfg.(*syslog.RawConfig).Host, gc.Equals, "10.0.0.1:1234")
+}
+
+func (s *SinksSuite) TestSyslogConfigNotEnabled(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"syslog-host":        "10.0.0.1:1234",
+		"syslog-ca-cert":     coretesting.CACert,
+		"syslog-client-cert": coretesting.ServerCert,
+		"syslog-client-key":  coretesting.ServerKey,
+	})
+	_, ok := sinks.SyslogConfig(modelCfg)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *SinksSuite) TestHTTPConfig(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled":     true,
+		"logforward-http-url":    "http://10.0.0.1:9200",
+		"logforward-http-format": "elasticsearch",
+	})
+	cfg, ok := sinks.HTTPConfig(modelCfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(cfg, jc.DeepEquals, &httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1:9200",
+		Format:  "elasticsearch",
+	})
+}
+
+func (s *SinksSuite) TestHTTPConfigNotConfigured(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+	})
+	_, ok := sinks.HTTPConfig(modelCfg)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *SinksSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1:9200",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.NotNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *SinksSuite) TestOpenHTTPWrongConfig(c *gc.C) {
+	_, err := sinks.OpenHTTP(&syslog.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `expected HTTP log forwarding config, got \*syslog.RawConfig`)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
@@ -19,7 +18,7 @@ type TrackingSinkArgs struct {
 	AllModels bool
 
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config LogSinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -44,6 +43,7 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 		&trackingSender{
 			SendCloser: sink,
 			tracker:    newLastSentTracker(args.Name, args.Caller),
+			allModels:  args.AllModels,
 		},
 	}, nil
 }
@@ -54,7 +54,8 @@ type trackingSender struct {
 	allModels bool
 }
 
-// Send implements Sender.
+// Send implements Sender. The last-sent record is recorded once for
+// each call, so records should be sent in batches.
 func (s *trackingSender) Send(records []logfwd.Record) error {
 	if err := s.SendCloser.Send(records); err != nil {
 		return errors.Trace(err)
@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides a worker that keeps a model's
+// relations to applications in other models, on the same controller,
+// in step with their counterparts there: units entering and leaving
+// scope on one side, and changes to their settings, are mirrored to
+// the other.
+package remoterelations
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker"
+)
+
+// Facade represents an API that syncs remote relations.
+type Facade interface {
+	Sync() error
+}
+
+// Config holds all necessary attributes to start a remoterelations
+// worker.
+type Config struct {
+	Facade       Facade
+	SyncInterval time.Duration
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	NewTimer worker.NewTimerFunc
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.NewTimer == nil {
+		return errors.New("missing Timer")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that periodically syncs the model's
+// remote relations.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doSync := func(stop <-chan struct{}) error {
+		return errors.Trace(conf.Facade.Sync())
+	}
+	return worker.NewPeriodicWorker(doSync, conf.SyncInterval, conf.NewTimer), nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/remoterelations"
+)
+
+type remoteRelationsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) startWorker(c *gc.C, facade remoterelations.Facade) (worker.Worker, *mockTimer) {
+	fakeTimer := newMockTimer()
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		// construction of timer should be with 0 because we intend it to
+		// run once before waiting.
+		c.Assert(d, gc.Equals, 0*time.Nanosecond)
+		return fakeTimer
+	}
+	w, err := remoterelations.New(remoterelations.Config{
+		Facade:       facade,
+		SyncInterval: coretesting.ShortWait,
+		NewTimer:     fakeTimerFunc,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w, fakeTimer
+}
+
+func (s *remoteRelationsSuite) TestValidate(c *gc.C) {
+	_, err := remoterelations.New(remoterelations.Config{})
+	c.Assert(err, gc.ErrorMatches, "missing Facade")
+
+	_, err = remoterelations.New(remoterelations.Config{Facade: newFakeFacade(nil)})
+	c.Assert(err, gc.ErrorMatches, "missing Timer")
+}
+
+func (s *remoteRelationsSuite) TestWorkerCallsSync(c *gc.C) {
+	facade := newFakeFacade(nil)
+	w, fakeTimer := s.startWorker(c, facade)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+	select {
+	case <-facade.synced:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for sync")
+	}
+
+	// Reset will have been called with the actual SyncInterval.
+	select {
+	case period := <-fakeTimer.period:
+		c.Assert(period, gc.Equals, coretesting.ShortWait)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset")
+	}
+}
+
+func (s *remoteRelationsSuite) TestWorkerStopsOnSyncError(c *gc.C) {
+	facade := newFakeFacade(errors.New("boom"))
+	w, fakeTimer := s.startWorker(c, facade)
+
+	err := fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+	err = w.Wait()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockTimer struct {
+	period chan time.Duration
+	c      chan time.Time
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	select {
+	case t.period <- d:
+	case <-time.After(coretesting.LongWait):
+		panic("timed out waiting for timer to reset")
+	}
+	return true
+}
+
+func (t *mockTimer) CountDown() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) fire() error {
+	select {
+	case t.c <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		return errors.New("timed out waiting for worker to run")
+	}
+	return nil
+}
+
+func newMockTimer() *mockTimer {
+	return &mockTimer{
+		period: make(chan time.Duration, 1),
+		c:      make(chan time.Time),
+	}
+}
+
+type fakeFacade struct {
+	err    error
+	synced chan struct{}
+}
+
+func newFakeFacade(err error) *fakeFacade {
+	return &fakeFacade{
+		err:    err,
+		synced: make(chan struct{}, 1),
+	}
+}
+
+// Sync implements Facade.
+func (f *fakeFacade) Sync() error {
+	select {
+	case f.synced <- struct{}{}:
+	default:
+	}
+	return f.err
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+)
+
+// actionAborts records which running actions have been asked to
+// abort. The remote state watcher reports aborts as it sees them, while
+// the action they concern is being run by the operation executor, so
+// each action is handed a channel that is closed when it is aborted.
+// An action's entries are released once it has finished.
+type actionAborts struct {
+	mu      sync.Mutex
+	aborted map[string]bool
+	waiting map[string]chan struct{}
+}
+
+// abort records that the action with the given id has been asked to
+// abort, closing the channel returned for it.
+func (a *actionAborts) abort(actionId string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.aborted[actionId] {
+		return
+	}
+	if a.aborted == nil {
+		a.aborted = make(map[string]bool)
+	}
+	a.aborted[actionId] = true
+	if ch, ok := a.waiting[actionId]; ok {
+		close(ch)
+		delete(a.waiting, actionId)
+	}
+}
+
+// abortedChannel returns a channel that is closed when the action with
+// the given id is asked to abort. The channel is already closed if the
+// abort has been seen.
+func (a *actionAborts) abortedChannel(actionId string) <-chan struct{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ch := make(chan struct{})
+	if a.aborted[actionId] {
+		close(ch)
+		return ch
+	}
+	if existing, ok := a.waiting[actionId]; ok {
+		return existing
+	}
+	if a.waiting == nil {
+		a.waiting = make(map[string]chan struct{})
+	}
+	a.waiting[actionId] = ch
+	return ch
+}
+
+// release forgets the action with the given id, which has finished
+// running.
+func (a *actionAborts) release(actionId string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.aborted, actionId)
+	delete(a.waiting, actionId)
+}
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
@@ -91,6 +92,27 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHook is part of the operation.Callbacks interface. The hook
+// history is only informational, so failing to record it does not fail
+// the hook.
+func (opc *operationCallbacks) RecordHook(hookName string, ctx runner.Context, started time.Time, duration time.Duration, hookErr error) {
+	record := params.HookRecord{
+		Hook:     hookName,
+		Started:  started,
+		Duration: duration,
+	}
+	if r, err := ctx.HookRelation(); err == nil {
+		record.Relation = r.FakeId()
+		record.RemoteUnit, _ = ctx.RemoteUnitName()
+	}
+	if hookErr != nil {
+		record.Error = hookErr.Error()
+	}
+	if err := opc.u.unit.AddHookRecord(record); err != nil {
+		logger.Warningf("cannot record %q hook in hook history: %v", hookName, err)
+	}
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
@@ -104,6 +126,16 @@ func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	return err
 }
 
+// ActionAborted is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) ActionAborted(actionId string) <-chan struct{} {
+	return opc.u.aborts.abortedChannel(actionId)
+}
+
+// ActionFinished is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) ActionFinished(actionId string) {
+	opc.u.aborts.release(actionId)
+}
+
 // GetArchiveInfo is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
 	ch, err := opc.u.st.Charm(charmURL)
@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
@@ -141,6 +143,10 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHook adds an execution of a hook, and how long it took, to
+	// the unit's hook history. It's only used by RunHook operations.
+	RecordHook(hookName string, ctx runner.Context, started time.Time, duration time.Duration, hookErr error)
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -148,6 +154,15 @@ type Callbacks interface {
 	// RunActions operations.
 	FailAction(actionId, message string) error
 
+	// ActionAborted returns a channel that is closed when the supplied
+	// action is asked to abort. It's only used by RunAction operations.
+	ActionAborted(actionId string) <-chan struct{}
+
+	// ActionFinished releases what is held to watch for the supplied
+	// action being asked to abort, once it has run. It's only used by
+	// RunAction operations.
+	ActionFinished(actionId string)
+
 	// GetArchiveInfo is used to find out how to download a charm archive. It's
 	// only used by Deploy operations.
 	GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error)
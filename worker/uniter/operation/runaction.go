@@ -49,6 +49,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		// this should *really* never happen, but let's not panic
 		return nil, errors.Trace(err)
 	}
+	actionData.Cancel = ra.callbacks.ActionAborted(ra.actionId)
 	err = rnr.Context().Prepare()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -73,6 +74,7 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}
 
 	err := ra.runner.RunAction(ra.name)
+	ra.callbacks.ActionFinished(ra.actionId)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
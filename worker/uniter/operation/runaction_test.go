@@ -74,6 +74,7 @@ func (s *RunActionSuite) TestPrepareErrorActionNotAvailable(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -90,6 +91,7 @@ func (s *RunActionSuite) TestPrepareErrorOther(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -111,6 +113,7 @@ func (s *RunActionSuite) TestPrepareCtxCalled(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -121,6 +124,29 @@ func (s *RunActionSuite) TestPrepareCtxCalled(c *gc.C) {
 	ctx.CheckCall(c, 0, "Prepare")
 }
 
+func (s *RunActionSuite) TestPrepareWatchesForAbort(c *gc.C) {
+	ctx := &MockContext{actionData: &context.ActionData{Name: "some-action-name"}}
+	runnerFactory := &MockRunnerFactory{
+		MockNewActionRunner: &MockNewActionRunner{
+			runner: &MockRunner{
+				context: ctx,
+			},
+		},
+	}
+	callbacks := &RunActionCallbacks{abort: make(chan struct{})}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.abortedActionId, gc.Equals, someActionId)
+	c.Assert(ctx.actionData.Cancel, gc.Equals, (<-chan struct{})(callbacks.abort))
+}
+
 func (s *RunActionSuite) TestPrepareCtxError(c *gc.C) {
 	ctx := &MockContext{actionData: &context.ActionData{Name: "some-action-name"}}
 	ctx.SetErrors(errors.New("ctx prepare error"))
@@ -133,6 +159,7 @@ func (s *RunActionSuite) TestPrepareCtxError(c *gc.C) {
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -147,6 +174,7 @@ func (s *RunActionSuite) TestPrepareSuccessCleanState(c *gc.C) {
 	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -165,6 +193,7 @@ func (s *RunActionSuite) TestPrepareSuccessDirtyState(c *gc.C) {
 	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
 	})
 	op, err := factory.NewAction(someActionId)
 	c.Assert(err, jc.ErrorIsNil)
@@ -223,6 +252,7 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(newState, jc.DeepEquals, &test.after)
 		c.Assert(callbacks.executingMessage, gc.Equals, "running action some-action-name")
+		c.Assert(callbacks.finishedActionId, gc.Equals, someActionId)
 		c.Assert(*runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.Equals, "some-action-name")
 	}
 }
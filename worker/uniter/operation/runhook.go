@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -91,7 +92,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
+	duration := time.Since(started)
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
@@ -102,15 +105,21 @@ func (rh *runHook) Execute(state State) (*State, error) {
 		fallthrough
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
+	case cause == context.ErrAborted:
+		// The hook did not run, and will be run again once the
+		// uniter restarts.
+		return nil, err
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.callbacks.RecordHook(rh.name, rh.runner.Context(), started, duration, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		rh.callbacks.RecordHook(rh.name, rh.runner.Context(), started, duration, nil)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
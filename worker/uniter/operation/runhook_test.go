@@ -184,6 +184,7 @@ func (s *RunHookSuite) getExecuteRunnerTest(c *gc.C, newHook newHook, kind hooks
 		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
 		MockNotifyHookCompleted: &MockNotify{},
 		MockNotifyHookFailed:    &MockNotify{},
+		MockRecordHook:          &MockRecordHook{},
 	}
 	factory := operation.NewFactory(operation.FactoryParams{
 		RunnerFactory: runnerFactory,
@@ -212,6 +213,7 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.MockRecordHook.gotName, gc.IsNil)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -236,6 +238,8 @@ func (s *RunHookSuite) TestExecuteRequeueRebootError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+	c.Assert(*callbacks.MockRecordHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockRecordHook.gotErr, jc.ErrorIsNil)
 }
 
 func (s *RunHookSuite) TestExecuteRebootError(c *gc.C) {
@@ -255,6 +259,23 @@ func (s *RunHookSuite) TestExecuteRebootError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+	c.Assert(*callbacks.MockRecordHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockRecordHook.gotErr, jc.ErrorIsNil)
+}
+
+func (s *RunHookSuite) TestExecuteAborted(c *gc.C) {
+	runErr := context.ErrAborted
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, context.ErrAborted)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(callbacks.MockRecordHook.gotName, gc.IsNil)
 }
 
 func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
@@ -270,6 +291,8 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(*callbacks.MockRecordHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockRecordHook.gotErr, gc.Equals, runErr)
 }
 
 func (s *RunHookSuite) testExecuteSuccess(
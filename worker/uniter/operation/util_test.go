@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -116,12 +118,24 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	abortedActionId  string
+	finishedActionId string
+	abort            chan struct{}
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
 	return cb.MockFailAction.Call(actionId, message)
 }
 
+func (cb *RunActionCallbacks) ActionAborted(actionId string) <-chan struct{} {
+	cb.abortedActionId = actionId
+	return cb.abort
+}
+
+func (cb *RunActionCallbacks) ActionFinished(actionId string) {
+	cb.finishedActionId = actionId
+}
+
 func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	cb.executingMessage = message
 	return nil
@@ -173,10 +187,21 @@ func (mock *MockNotify) Call(hookName string, ctx runner.Context) {
 	mock.gotContext = &ctx
 }
 
+type MockRecordHook struct {
+	gotName *string
+	gotErr  error
+}
+
+func (mock *MockRecordHook) Call(hookName string, hookErr error) {
+	mock.gotName = &hookName
+	mock.gotErr = hookErr
+}
+
 type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	MockRecordHook          *MockRecordHook
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -187,6 +212,10 @@ func (cb *ExecuteHookCallbacks) NotifyHookFailed(hookName string, ctx runner.Con
 	cb.MockNotifyHookFailed.Call(hookName, ctx)
 }
 
+func (cb *ExecuteHookCallbacks) RecordHook(hookName string, ctx runner.Context, started time.Time, duration time.Duration, hookErr error) {
+	cb.MockRecordHook.Call(hookName, hookErr)
+}
+
 type MockCommitHook struct {
 	gotHook *hook.Info
 	err     error
@@ -268,6 +297,10 @@ func (f *MockRunnerFactory) NewActionRunner(actionId string) (runner.Runner, err
 	return f.MockNewActionRunner.Call(actionId)
 }
 
+func (f *MockRunnerFactory) IsParallelAction(actionId string) (bool, error) {
+	return false, nil
+}
+
 func (f *MockRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
 	return f.MockNewHookRunner.Call(hookInfo)
 }
@@ -0,0 +1,233 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+// parallelActions runs the actions that a charm declares safe to run in
+// parallel with its hooks and other actions. They are started as soon
+// as the remote state watcher sees them, rather than being queued for
+// the operation executor, and do not take the machine lock; so they
+// need wait neither for the unit's hooks nor for those of other units
+// on the machine.
+//
+// The watcher offers each pending action without waiting, and the
+// actions' own goroutine decides whether each may run in parallel,
+// sending back those that may not for the operation executor to run.
+//
+// As they are not recorded in the operation state, an action still
+// running when the uniter stops is aborted.
+type parallelActions struct {
+	runnerFactory runner.Factory
+	aborts        *actionAborts
+	abort         <-chan struct{}
+
+	// charmDir is held for reading by each running action, and for
+	// writing while a charm is deployed, so that the charm does not
+	// change beneath the actions.
+	charmDir sync.RWMutex
+
+	// queued receives the ids of the offered actions that were not
+	// started, to be added to the remote state.
+	queued chan []string
+	wake   chan struct{}
+	done   chan struct{}
+
+	mu      sync.Mutex
+	offered []string
+	stopped bool
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+func newParallelActions(runnerFactory runner.Factory, aborts *actionAborts, abort <-chan struct{}) *parallelActions {
+	p := &parallelActions{
+		runnerFactory: runnerFactory,
+		aborts:        aborts,
+		abort:         abort,
+		queued:        make(chan []string),
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		running:       make(map[string]bool),
+	}
+	p.wg.Add(1)
+	go p.loop()
+	return p
+}
+
+// offer hands over the pending actions with the given ids, to be
+// started if they may run in parallel or else sent on the queued
+// channel. It does not block.
+func (p *parallelActions) offer(actionIds []string) {
+	p.mu.Lock()
+	p.offered = append(p.offered, actionIds...)
+	p.mu.Unlock()
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *parallelActions) loop() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-p.abort:
+			return
+		case <-p.wake:
+		}
+		p.mu.Lock()
+		offered := p.offered
+		p.offered = nil
+		p.mu.Unlock()
+
+		var queued []string
+		for _, actionId := range offered {
+			if !p.start(actionId) {
+				queued = append(queued, actionId)
+			}
+		}
+		if len(queued) == 0 {
+			continue
+		}
+		select {
+		case <-p.done:
+			return
+		case <-p.abort:
+			return
+		case p.queued <- queued:
+		}
+	}
+}
+
+// start starts the action with the given id if it may run in parallel,
+// and reports whether it did. Actions it does not start are left to the
+// operation executor, which reports any problems with them.
+func (p *parallelActions) start(actionId string) bool {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return false
+	}
+	if p.running[actionId] {
+		p.mu.Unlock()
+		return true
+	}
+	p.mu.Unlock()
+
+	p.charmDir.RLock()
+	rnr, err := p.prepare(actionId)
+	if err != nil {
+		p.charmDir.RUnlock()
+		logger.Debugf("not running action %q in parallel: %v", actionId, err)
+		return false
+	} else if rnr == nil {
+		p.charmDir.RUnlock()
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		// The action has been marked as running, so it must be
+		// finished; it will be, as aborted.
+		p.aborts.abort(actionId)
+	}
+	p.running[actionId] = true
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.finished(actionId)
+		defer p.charmDir.RUnlock()
+		p.run(actionId, rnr)
+	}()
+	return true
+}
+
+// prepare returns a runner for the action with the given id, having
+// marked the action as running, or nil if the action may not run in
+// parallel.
+func (p *parallelActions) prepare(actionId string) (runner.Runner, error) {
+	parallel, err := p.runnerFactory.IsParallelAction(actionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if !parallel {
+		return nil, nil
+	}
+	rnr, err := p.runnerFactory.NewActionRunner(actionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	actionData, err := rnr.Context().ActionData()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	actionData.Cancel = p.aborts.abortedChannel(actionId)
+	if err := rnr.Context().Prepare(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rnr, nil
+}
+
+func (p *parallelActions) run(actionId string, rnr runner.Runner) {
+	actionData, err := rnr.Context().ActionData()
+	if err != nil {
+		logger.Errorf("running action %q: %v", actionId, err)
+		return
+	}
+	logger.Infof("running action %q in parallel", actionData.Name)
+	if err := rnr.RunAction(actionData.Name); err != nil {
+		// The action could not be run, rather than failing, so
+		// there is no-one to tell but the log.
+		logger.Errorf("running action %q: %v", actionData.Name, err)
+	}
+}
+
+func (p *parallelActions) finished(actionId string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.running, actionId)
+	p.aborts.release(actionId)
+}
+
+// stop aborts the running actions, and waits for them to finish. No
+// more actions are started once it is called.
+func (p *parallelActions) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	close(p.done)
+	for actionId := range p.running {
+		p.aborts.abort(actionId)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// deployer returns a charm.Deployer that waits for the running actions
+// to finish before deploying a charm, and keeps any more from starting
+// until it is done.
+func (p *parallelActions) deployer(deployer charm.Deployer) charm.Deployer {
+	return &parallelActionsDeployer{deployer, p}
+}
+
+type parallelActionsDeployer struct {
+	charm.Deployer
+	actions *parallelActions
+}
+
+// Deploy is part of the charm.Deployer interface.
+func (d *parallelActionsDeployer) Deploy() error {
+	d.actions.charmDir.Lock()
+	defer d.actions.charmDir.Unlock()
+	return d.Deployer.Deploy()
+}
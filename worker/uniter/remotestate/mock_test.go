@@ -6,6 +6,7 @@ package remotestate_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -191,6 +192,7 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	abortingWatcher       *mockStringsWatcher
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -233,6 +235,13 @@ func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
 
+func (u *mockUnit) WatchAbortingActions() (watcher.StringsWatcher, error) {
+	if u.abortingWatcher == nil {
+		return nil, errors.NotImplementedf("WatchAbortingActions() (need V5+)")
+	}
+	return u.abortingWatcher, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
@@ -35,6 +35,9 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	abortAction               func(actionId string)
+	offerActions              func(actionIds []string)
+	queuedActions             <-chan []string
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +55,21 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// AbortAction, if not nil, is called with the id of each running
+	// action that is asked to abort. It is called directly from the
+	// watcher, as the action being aborted will usually be holding up
+	// the observer of the snapshots.
+	AbortAction func(actionId string)
+
+	// OfferActions, if not nil, is called with the ids of pending
+	// actions in place of adding them to the snapshot, so that any
+	// which may run in parallel can be started alongside whatever the
+	// observer of the snapshots is doing. It must not block. The ids of the actions
+	// it does not run itself are added to the snapshot when they are
+	// received on QueuedActions.
+	OfferActions  func(actionIds []string)
+	QueuedActions <-chan []string
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +85,9 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		abortAction:               config.AbortAction,
+		offerActions:              config.OfferActions,
+		queuedActions:             config.QueuedActions,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -256,6 +277,23 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenAbortingActionsChange bool
+	var abortingChanges watcher.StringsChannel
+	abortingw, err := w.unit.WatchAbortingActions()
+	if errors.IsNotImplemented(err) {
+		// The controller is too old to abort running actions, so
+		// there will never be any to watch for.
+		logger.Debugf("not watching aborting actions: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(abortingw); err != nil {
+			return errors.Trace(err)
+		}
+		abortingChanges = abortingw.Changes()
+		requiredEvents++
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -364,6 +402,18 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case actions, ok := <-abortingChanges:
+			logger.Debugf("got aborting actions change: %v ok=%t", actions, ok)
+			if !ok {
+				return errors.New("aborting actions watcher closed")
+			}
+			w.abortingActionsChanged(actions)
+			observedEvent(&seenAbortingActionsChange)
+
+		case actions := <-w.queuedActions:
+			logger.Debugf("got queued actions: %v", actions)
+			w.actionsQueued(actions)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -632,10 +682,30 @@ func (w *RemoteStateWatcher) storageAttachmentChanged(change storageAttachmentCh
 }
 
 func (w *RemoteStateWatcher) actionsChanged(actions []string) error {
+	if w.offerActions != nil {
+		w.offerActions(actions)
+		return nil
+	}
+	w.actionsQueued(actions)
+	return nil
+}
+
+// actionsQueued adds the ids of pending actions to the snapshot.
+func (w *RemoteStateWatcher) actionsQueued(actions []string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.current.Actions = append(w.current.Actions, actions...)
-	return nil
+}
+
+// abortingActionsChanged passes on the ids of running actions that
+// have been asked to abort.
+func (w *RemoteStateWatcher) abortingActionsChanged(actions []string) {
+	if w.abortAction == nil {
+		return
+	}
+	for _, id := range actions {
+		w.abortAction(id)
+	}
 }
 
 // storageChanged responds to unit storage changes.
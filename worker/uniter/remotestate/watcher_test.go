@@ -25,6 +25,14 @@ type WatcherSuite struct {
 	leadership *mockLeadershipTracker
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testing.Clock
+	aborted    chan string
+	offered    chan []string
+	queued     chan []string
+
+	// abortsNotSupported is true if the mock state cannot watch
+	// for aborting actions, as with controllers too old to abort
+	// running actions.
+	abortsNotSupported bool
 }
 
 // Duration is arbitrary, we'll trigger the ticker
@@ -35,6 +43,14 @@ var _ = gc.Suite(&WatcherSuite{})
 
 func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
+	s.abortsNotSupported = false
+	s.startWatcher(c, false)
+}
+
+// startWatcher starts a watcher of a new mock state. If offerActions
+// is true, pending actions are sent on s.offered rather than added to
+// the snapshot, and those sent on s.queued are added.
+func (s *WatcherSuite) startWatcher(c *gc.C, offerActions bool) {
 	s.st = &mockState{
 		unit: mockUnit{
 			tag:  names.NewUnitTag("mysql/0"),
@@ -53,12 +69,16 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			configSettingsWatcher: newMockNotifyWatcher(),
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
+			abortingWatcher:       newMockStringsWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
 		relationUnitsWatchers:     make(map[names.RelationTag]*mockRelationUnitsWatcher),
 		storageAttachmentWatchers: make(map[names.StorageTag]*mockNotifyWatcher),
 	}
+	if s.abortsNotSupported {
+		s.st.unit.abortingWatcher = nil
+	}
 
 	s.leadership = &mockLeadershipTracker{
 		claimTicket:  mockTicket{make(chan struct{}, 1), true},
@@ -71,12 +91,25 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		return s.clock.After(statusTickDuration)
 	}
 
-	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+	s.aborted = make(chan string, 10)
+	config := remotestate.WatcherConfig{
 		State:               s.st,
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
-	})
+		AbortAction: func(actionId string) {
+			s.aborted <- actionId
+		},
+	}
+	if offerActions {
+		s.offered = make(chan []string, 10)
+		s.queued = make(chan []string)
+		config.OfferActions = func(actionIds []string) {
+			s.offered <- actionIds
+		}
+		config.QueuedActions = s.queued
+	}
+	w, err := remotestate.NewWatcher(config)
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
 }
@@ -103,6 +136,26 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.unitWatcher.changes <- struct{}{}
 	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
 
+	s.st.unit.addressesWatcher.changes <- struct{}{}
+	s.st.unit.configSettingsWatcher.changes <- struct{}{}
+	s.st.unit.storageWatcher.changes <- []string{}
+	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.abortingWatcher.changes <- []string{}
+	s.st.unit.service.serviceWatcher.changes <- struct{}{}
+	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
+	s.st.unit.service.relationsWatcher.changes <- []string{}
+	s.leadership.claimTicket.ch <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+}
+
+func (s *WatcherSuite) TestInitialSignalAbortsNotSupported(c *gc.C) {
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.abortsNotSupported = true
+	s.startWatcher(c, false)
+
+	// There is no aborting actions watcher to wait for.
+	s.st.unit.unitWatcher.changes <- struct{}{}
 	s.st.unit.addressesWatcher.changes <- struct{}{}
 	s.st.unit.configSettingsWatcher.changes <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
@@ -120,6 +173,7 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.configSettingsWatcher.changes <- struct{}{}
 	st.unit.storageWatcher.changes <- []string{}
 	st.unit.actionWatcher.changes <- []string{}
+	st.unit.abortingWatcher.changes <- []string{}
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.service.relationsWatcher.changes <- []string{}
@@ -196,6 +250,50 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestOfferedActions(c *gc.C) {
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.startWatcher(c, true)
+	signalAll(s.st, s.leadership)
+	c.Assert(<-s.offered, gc.HasLen, 0)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.actionWatcher.changes <- []string{"an-action", "a-parallel-action"}
+	select {
+	case ids := <-s.offered:
+		c.Assert(ids, gc.DeepEquals, []string{"an-action", "a-parallel-action"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("actions not offered")
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Actions, gc.HasLen, 0)
+
+	// Only the actions sent back are added to the snapshot.
+	select {
+	case s.queued <- []string{"an-action"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("queued actions not received")
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
+}
+
+func (s *WatcherSuite) TestAbortingActions(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.abortingWatcher.changes <- []string{"an-action", "another-action"}
+	for _, expect := range []string{"an-action", "another-action"} {
+		select {
+		case id := <-s.aborted:
+			c.Check(id, gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %q to be aborted", expect)
+		}
+	}
+	c.Assert(s.watcher.Snapshot().Actions, gc.HasLen, 0)
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 )
 
@@ -15,6 +17,19 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Cancel is closed when the Action is asked to abort.
+	Cancel <-chan struct{}
+	// Aborted records that the Action was stopped after being asked
+	// to abort.
+	Aborted bool
+
+	// Timeout is how long the Action may run for, or zero if it may
+	// run for as long as it needs.
+	Timeout time.Duration
+	// TimedOut records that the Action was stopped after running for
+	// longer than its timeout.
+	TimedOut bool
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -500,6 +500,16 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the running action,
+// which is sent to the controller straight away so that it can be
+// followed while the action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.ActionLog(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -746,6 +756,14 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		}
 		status = params.ActionFailed
 	}
+	if ctx.actionData.Aborted {
+		status = params.ActionAborted
+		message = "action aborted"
+	}
+	if ctx.actionData.TimedOut {
+		status = params.ActionFailed
+		message = fmt.Sprintf("action timed out after %v", ctx.actionData.Timeout)
+	}
 
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
@@ -828,3 +846,35 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	}
 	return result.OneError()
 }
+
+// AddSecret stores a secret owned by the unit's application, and
+// returns its id.
+func (ctx *HookContext) AddSecret(value map[string]string, description string) (string, error) {
+	return ctx.state.AddSecret(description, value)
+}
+
+// GetSecret returns the value of the secret with the given id.
+func (ctx *HookContext) GetSecret(id string) (map[string]string, error) {
+	return ctx.state.GetSecret(id)
+}
+
+// GrantSecret allows the given unit, application or relation to read
+// the secret with the given id.
+func (ctx *HookContext) GrantSecret(id string, grantee jujuc.SecretGrantee) error {
+	var subject names.Tag
+	switch {
+	case grantee.Unit != "":
+		subject = names.NewUnitTag(grantee.Unit)
+	case grantee.Application != "":
+		subject = names.NewApplicationTag(grantee.Application)
+	case grantee.RelationId != -1:
+		r, found := ctx.relations[grantee.RelationId]
+		if !found {
+			return errors.NotFoundf("relation %d", grantee.RelationId)
+		}
+		subject = r.ru.Relation().Tag()
+	default:
+		return errors.New("no unit, application or relation specified")
+	}
+	return ctx.state.GrantSecret(id, subject)
+}
@@ -150,6 +150,29 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestSecrets(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	id, err := ctx.AddSecret(map[string]string{"password": "sekrit"}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err := ctx.GetSecret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	err = ctx.GrantSecret(id, jujuc.SecretGrantee{RelationId: 0})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.GrantSecret(id, jujuc.SecretGrantee{Application: "db0", RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.GrantSecret(id, jujuc.SecretGrantee{RelationId: 123})
+	c.Assert(err, gc.ErrorMatches, "relation 123 not found")
+
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Grants(), jc.DeepEquals, []string{
+		s.relunits[0].Relation().Tag().String(),
+		"application-db0",
+	})
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -231,6 +254,8 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.SetActionMessage("foo")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.LogActionMessage("foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
 }
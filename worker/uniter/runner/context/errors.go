@@ -11,6 +11,10 @@ var ErrRequeueAndReboot = errors.New("reboot now")
 var ErrReboot = errors.New("reboot after hook")
 var ErrNoProcess = errors.New("no process to kill")
 
+// ErrAborted is returned when a hook is not run because the uniter is
+// stopping; the hook should be run again when the uniter restarts.
+var ErrAborted = errors.New("hook aborted")
+
 type missingHookError struct {
 	hookName string
 }
@@ -12,33 +12,57 @@ import (
 
 type hookArgs struct {
 	Hooks []string `yaml:"hooks,omitempty"`
+
+	// DebugAt is set by debug-code clients, which pause matching
+	// hooks rather than running them in tmux. It is exported to
+	// the hook as JUJU_DEBUG_AT.
+	DebugAt string `yaml:"debug-at,omitempty"`
 }
 
 // ClientScript returns a bash script suitable for executing
 // on the unit system to intercept hooks via tmux shell.
 func ClientScript(c *HooksContext, hooks []string) string {
-	// If any hook is "*", then the client is interested in all.
-	for _, hook := range hooks {
-		if hook == "*" {
-			hooks = nil
-			break
-		}
-	}
-
 	s := strings.Replace(debugHooksClientScript, "{unit_name}", c.Unit, -1)
 	s = strings.Replace(s, "{tmux_conf}", tmuxConf, 1)
 	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
 	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
 
-	yamlArgs := encodeArgs(hooks)
+	yamlArgs := encodeArgs(hookArgs{Hooks: allHooks(hooks)})
+	base64Args := base64.StdEncoding.EncodeToString(yamlArgs)
+	s = strings.Replace(s, "{hook_args}", base64Args, 1)
+	return s
+}
+
+// CodeClientScript returns a bash script suitable for executing on the
+// unit system to pause hooks and actions as they start, without tmux.
+// While a hook is paused, the operator may resume it, skip it, or run
+// it by hand in a shell with the hook environment already set. debugAt
+// is exported to the hook as JUJU_DEBUG_AT.
+func CodeClientScript(c *HooksContext, hooks []string, debugAt string) string {
+	s := strings.Replace(debugCodeClientScript, "{unit_name}", c.Unit, -1)
+	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
+	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
+	s = strings.Replace(s, "{pause_dir}", c.ClientPauseDir(), -1)
+
+	yamlArgs := encodeArgs(hookArgs{Hooks: allHooks(hooks), DebugAt: debugAt})
 	base64Args := base64.StdEncoding.EncodeToString(yamlArgs)
 	s = strings.Replace(s, "{hook_args}", base64Args, 1)
 	return s
 }
 
-func encodeArgs(hooks []string) []byte {
+// allHooks returns nil, meaning all hooks, if any hook is "*".
+func allHooks(hooks []string) []string {
+	for _, hook := range hooks {
+		if hook == "*" {
+			return nil
+		}
+	}
+	return hooks
+}
+
+func encodeArgs(args hookArgs) []byte {
 	// Marshal to YAML, then encode in base64 to avoid shell escapes.
-	yamlArgs, err := goyaml.Marshal(hookArgs{Hooks: hooks})
+	yamlArgs, err := goyaml.Marshal(args)
 	if err != nil {
 		// This should not happen: we're in full control.
 		panic(err)
@@ -108,6 +132,61 @@ fi
 exit $?
 `
 
+const debugCodeClientScript = `#!/bin/bash
+(
+# Lock the juju-<unit>-debug lockfile.
+flock -n 8 || {
+	echo "Found an existing debug session for {unit_name}" >&2
+	exit 1
+}
+(
+# Close the inherited lock FD.
+exec 8>&-
+
+# Write out the debug-code args.
+echo "{hook_args}" | base64 -d > {entry_flock}
+
+# Lock the juju-<unit>-debug-exit lockfile. The uniter pauses hooks
+# only while it is held.
+flock -n 9 || exit 1
+
+echo "Waiting for {unit_name} to run a matching hook or action; press CTRL-C to stop."
+while true; do
+	while [ ! -f {pause_dir}/current ]; do
+		sleep 1
+	done
+	dir=$(cat {pause_dir}/current)
+	hook=$(cat "$dir/hook")
+	echo "{unit_name} is paused at the start of $hook."
+	decision=
+	while [ -z "$decision" ]; do
+		read -r -p "[r]esume, [s]kip, or run it in a [d]ebug shell? " choice || choice=r
+		case "$choice" in
+		r|resume)
+			decision=resume
+			;;
+		s|skip)
+			decision=skip
+			;;
+		d|debug)
+			/bin/bash --noprofile --init-file "$dir/init.sh"
+			decision="shell $?"
+			;;
+		esac
+	done
+	echo "$decision" > "$dir/decision.tmp"
+	mv "$dir/decision.tmp" "$dir/decision"
+
+	# Wait for the uniter to act on the decision.
+	while [ -d "$dir" ]; do
+		sleep 1
+	done
+done
+) 9>{exit_flock}
+) 8>{entry_flock}
+exit $?
+`
+
 const tmuxConf = `
 # Status bar
 set-option -g status-bg black
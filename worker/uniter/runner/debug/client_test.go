@@ -48,3 +48,22 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 	)
 	c.Assert(debug.ClientScript(ctx, []string{"something somethingelse"}), gc.Matches, expected)
 }
+
+func (*DebugHooksClientSuite) TestCodeClientScript(c *gc.C) {
+	ctx := debug.NewHooksContext("foo/8")
+
+	result := debug.CodeClientScript(ctx, []string{"install"}, "all")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{[a-z_]+}(.|\n)*")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*tmux(.|\n)*")
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*while \\[ ! -f %s/hook \\](.|\n)*", regexp.QuoteMeta(ctx.ClientPauseDir())))
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*\\) 9>%s(.|\n)*", regexp.QuoteMeta(ctx.ClientExitFileLock())))
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*\\) 8>%s(.|\n)*", regexp.QuoteMeta(ctx.ClientFileLock())))
+
+	// The args include the value for JUJU_DEBUG_AT.
+	expected := fmt.Sprintf(
+		`(.|\n)*echo "aG9va3M6Ci0gaW5zdGFsbApkZWJ1Zy1hdDogYWxsCg==" | base64 -d > %s(.|\n)*`,
+		regexp.QuoteMeta(ctx.ClientFileLock()),
+	)
+	c.Assert(result, gc.Matches, expected)
+	c.Assert(debug.CodeClientScript(ctx, []string{"*", "install"}, "all"), gc.Equals, debug.CodeClientScript(ctx, nil, "all"))
+}
@@ -0,0 +1,191 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// Decision is what the operator of a debug-code session chose to do
+// with a paused hook.
+type Decision string
+
+const (
+	// DecisionResume means the hook should be run as usual.
+	DecisionResume Decision = "resume"
+
+	// DecisionSkip means the hook should not be run, and treated
+	// as having succeeded.
+	DecisionSkip Decision = "skip"
+
+	// DecisionShell means the operator has run the hook by hand in
+	// a shell; the result of the shell is the result of the hook.
+	DecisionShell Decision = "shell"
+)
+
+// pausePollInterval is how often a paused hook checks for the
+// operator's decision.
+var pausePollInterval = time.Second
+
+// clientConnected reports whether a client holds the exit lock.
+// This is a var so it can be replaced for testing.
+var clientConnected = func(c *HooksContext) bool {
+	// flock -n fails if the lock is held by someone else.
+	return exec.Command("flock", "-n", c.ClientExitFileLock(), "-c", "true").Run() != nil
+}
+
+// ErrAborted is returned by Pause when the uniter stops while a hook
+// is paused.
+var ErrAborted = errors.New("debug-code pause aborted")
+
+// Pause pauses the hook with the specified name until the debug-code
+// client decides what to do with it, or abort is closed. It writes out
+// the environment the hook would run with, so that the operator may
+// run it by hand in a shell. If the operator chose to do that, the
+// error returned is the result of the shell. If the client goes away,
+// the hook is resumed.
+func (s *ServerSession) Pause(hookName, charmDir string, env []string, abort <-chan struct{}) (Decision, error) {
+	baseDir := s.ClientPauseDir()
+	if err := ensurePrivateDir(baseDir); err != nil {
+		return "", errors.Annotate(err, "cannot create debug-code directory")
+	}
+	// Each paused hook gets a directory of its own, so that nothing
+	// left from one hook can be taken for a decision about another.
+	dir, err := ioutil.TempDir(baseDir, "hook-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	env = append(env, "JUJU_HOOK_NAME="+hookName, "JUJU_DEBUG_AT="+s.debugAt)
+	if err := writePauseFiles(dir, charmDir, env); err != nil {
+		return "", errors.Annotate(err, "cannot write debug-code files")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "hook"), []byte(hookName+"\n"), 0600); err != nil {
+		return "", errors.Trace(err)
+	}
+	// Point the client at the hook's directory last, and atomically,
+	// as it tells the client the hook is paused.
+	currentFile := filepath.Join(baseDir, "current")
+	tmpFile := currentFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, []byte(dir+"\n"), 0600); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := os.Rename(tmpFile, currentFile); err != nil {
+		return "", errors.Trace(err)
+	}
+	defer os.Remove(currentFile)
+
+	decisionFile := filepath.Join(dir, "decision")
+	for {
+		data, err := ioutil.ReadFile(decisionFile)
+		if err == nil {
+			return parseDecision(string(data))
+		} else if !os.IsNotExist(err) {
+			return "", errors.Trace(err)
+		}
+		if !clientConnected(s.HooksContext) {
+			return DecisionResume, nil
+		}
+		select {
+		case <-abort:
+			return "", ErrAborted
+		case <-time.After(pausePollInterval):
+		}
+	}
+}
+
+// ensurePrivateDir creates dir, accessible only by its owner. If dir
+// already exists, it must be a directory owned by this user and not
+// accessible by anyone else, so that nobody else can read or plant
+// the files passed through it.
+func ensurePrivateDir(dir string) error {
+	err := os.Mkdir(dir, 0700)
+	if err == nil {
+		return nil
+	} else if !os.IsExist(err) {
+		return errors.Trace(err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("%s is not a directory", dir)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return errors.Errorf("%s is accessible by other users", dir)
+	}
+	if !ownedByCurrentUser(info) {
+		return errors.Errorf("%s is owned by another user", dir)
+	}
+	return nil
+}
+
+// parseDecision parses the decision written by the client: "resume",
+// "skip" or "shell" followed by the exit status of the shell.
+func parseDecision(data string) (Decision, error) {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return "", errors.New("empty debug-code decision")
+	}
+	switch decision := Decision(fields[0]); decision {
+	case DecisionResume, DecisionSkip:
+		return decision, nil
+	case DecisionShell:
+		if len(fields) != 2 {
+			return "", errors.Errorf("invalid debug-code decision %q", data)
+		}
+		status, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return "", errors.Errorf("invalid debug-code decision %q", data)
+		}
+		if status != 0 {
+			return decision, errors.Errorf("exit status %d", status)
+		}
+		return decision, nil
+	}
+	return "", errors.Errorf("invalid debug-code decision %q", data)
+}
+
+// writePauseFiles writes env.sh, holding the hook environment, and
+// init.sh, which sets up a debug shell, into dir.
+func writePauseFiles(dir, charmDir string, env []string) error {
+	var exports []string
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		exports = append(exports, fmt.Sprintf("export %s=%s\n", parts[0], utils.ShQuote(parts[1])))
+	}
+	sort.Strings(exports)
+	envsh := filepath.Join(dir, "env.sh")
+	if err := ioutil.WriteFile(envsh, []byte(strings.Join(exports, "")), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	initsh := fmt.Sprintf(debugCodeInitScript, utils.ShQuote(envsh), utils.ShQuote(charmDir))
+	return ioutil.WriteFile(filepath.Join(dir, "init.sh"), []byte(initsh), 0600)
+}
+
+const debugCodeInitScript = `. %s
+cd %s
+export PS1="$JUJU_UNIT_NAME:$JUJU_HOOK_NAME %% "
+cat <<END
+This is a Juju debug-code shell, with the environment of $JUJU_HOOK_NAME.
+Run the hook or action by hand if you want it to run, then 'exit' to let
+Juju continue; the exit status of this shell becomes the result of the hook.
+END
+`
@@ -0,0 +1,239 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type DebugCodeServerSuite struct {
+	testing.BaseSuite
+	ctx *HooksContext
+
+	// clientGone is closed to simulate the client going away.
+	clientGone chan struct{}
+
+	// abort is passed to Pause.
+	abort chan struct{}
+}
+
+var _ = gc.Suite(&DebugCodeServerSuite{})
+
+func (s *DebugCodeServerSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.ctx = NewHooksContext("foo/8")
+	s.ctx.FlockDir = c.MkDir()
+	s.clientGone = make(chan struct{})
+	s.abort = make(chan struct{})
+	s.PatchValue(&clientConnected, func(*HooksContext) bool {
+		select {
+		case <-s.clientGone:
+			return false
+		default:
+			return true
+		}
+	})
+	s.PatchValue(&pausePollInterval, time.Millisecond)
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte("hooks: [install]\ndebug-at: all\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DebugCodeServerSuite) TestFindSession(c *gc.C) {
+	// No tmux is needed for a debug-code session.
+	s.PatchEnvironment("PATH", "")
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session.DebugAt(), gc.Equals, "all")
+	c.Check(session.MatchHook("install"), jc.IsTrue)
+	c.Check(session.MatchHook("start"), jc.IsFalse)
+
+	close(s.clientGone)
+	session, err = s.ctx.FindSession()
+	c.Check(session, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "debug-code client not connected")
+}
+
+// waitPaused waits for a hook to be paused, and returns the directory
+// the client is pointed at.
+func (s *DebugCodeServerSuite) waitPaused(c *gc.C) string {
+	currentFile := filepath.Join(s.ctx.ClientPauseDir(), "current")
+	timeout := time.After(testing.LongWait)
+	for {
+		if data, err := ioutil.ReadFile(currentFile); err == nil {
+			dir := strings.TrimSpace(string(data))
+			c.Assert(filepath.Dir(dir), gc.Equals, s.ctx.ClientPauseDir())
+			return dir
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("hook not paused")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// pause pauses the install hook, and writes the decision once the
+// hook is paused.
+func (s *DebugCodeServerSuite) pause(c *gc.C, decision string) (Decision, error) {
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	charmDir := c.MkDir()
+
+	type result struct {
+		decision Decision
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		decision, err := session.Pause("install", charmDir, []string{"JUJU_UNIT_NAME=foo/8", "PATH=/usr/bin"}, s.abort)
+		done <- result{decision, err}
+	}()
+
+	dir := s.waitPaused(c)
+	hook, err := ioutil.ReadFile(filepath.Join(dir, "hook"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(hook), gc.Equals, "install\n")
+
+	envsh, err := ioutil.ReadFile(filepath.Join(dir, "env.sh"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(envsh), gc.Equals, `
+export JUJU_DEBUG_AT='all'
+export JUJU_HOOK_NAME='install'
+export JUJU_UNIT_NAME='foo/8'
+export PATH='/usr/bin'
+`[1:])
+	initsh, err := ioutil.ReadFile(filepath.Join(dir, "init.sh"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(initsh), jc.Contains, "cd '"+charmDir+"'\n")
+
+	if decision != "" {
+		err = ioutil.WriteFile(filepath.Join(dir, "decision"), []byte(decision), 0600)
+		c.Assert(err, jc.ErrorIsNil)
+	} else {
+		close(s.clientGone)
+	}
+	select {
+	case r := <-done:
+		_, err := os.Stat(dir)
+		c.Check(err, jc.Satisfies, os.IsNotExist)
+		_, err = os.Stat(filepath.Join(s.ctx.ClientPauseDir(), "current"))
+		c.Check(err, jc.Satisfies, os.IsNotExist)
+		return r.decision, r.err
+	case <-time.After(testing.LongWait):
+		c.Fatalf("hook not resumed")
+	}
+	panic("unreachable")
+}
+
+func (s *DebugCodeServerSuite) TestPauseResume(c *gc.C) {
+	decision, err := s.pause(c, "resume\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, gc.Equals, DecisionResume)
+}
+
+func (s *DebugCodeServerSuite) TestPauseSkip(c *gc.C) {
+	decision, err := s.pause(c, "skip\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, gc.Equals, DecisionSkip)
+}
+
+func (s *DebugCodeServerSuite) TestPauseShell(c *gc.C) {
+	decision, err := s.pause(c, "shell 0\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, gc.Equals, DecisionShell)
+
+	decision, err = s.pause(c, "shell 3\n")
+	c.Assert(err, gc.ErrorMatches, "exit status 3")
+	c.Assert(decision, gc.Equals, DecisionShell)
+}
+
+func (s *DebugCodeServerSuite) TestPauseInvalidDecision(c *gc.C) {
+	_, err := s.pause(c, "frobnicate\n")
+	c.Assert(err, gc.ErrorMatches, `invalid debug-code decision "frobnicate\\n"`)
+}
+
+func (s *DebugCodeServerSuite) TestPauseClientGone(c *gc.C) {
+	decision, err := s.pause(c, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, gc.Equals, DecisionResume)
+}
+
+func (s *DebugCodeServerSuite) TestPauseAborted(c *gc.C) {
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error, 1)
+	go func() {
+		_, err := session.Pause("install", c.MkDir(), nil, s.abort)
+		done <- err
+	}()
+	dir := s.waitPaused(c)
+
+	close(s.abort)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.Equals, ErrAborted)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("pause not aborted")
+	}
+	_, err = os.Stat(dir)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *DebugCodeServerSuite) TestPauseUsesNewDirForEachHook(c *gc.C) {
+	var dirs []string
+	for i := 0; i < 2; i++ {
+		session, err := s.ctx.FindSession()
+		c.Assert(err, jc.ErrorIsNil)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			session.Pause("install", c.MkDir(), nil, s.abort)
+		}()
+		dir := s.waitPaused(c)
+		dirs = append(dirs, dir)
+		err = ioutil.WriteFile(filepath.Join(dir, "decision"), []byte("skip\n"), 0600)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-done:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("hook not resumed")
+		}
+	}
+	c.Assert(dirs[0], gc.Not(gc.Equals), dirs[1])
+}
+
+func (s *DebugCodeServerSuite) TestPauseRefusesSharedDir(c *gc.C) {
+	err := os.Mkdir(s.ctx.ClientPauseDir(), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Chmod(s.ctx.ClientPauseDir(), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = session.Pause("install", c.MkDir(), nil, s.abort)
+	c.Assert(err, gc.ErrorMatches, "cannot create debug-code directory: .* is accessible by other users")
+}
+
+func (s *DebugCodeServerSuite) TestPauseRefusesSymlink(c *gc.C) {
+	err := os.Symlink(c.MkDir(), s.ctx.ClientPauseDir())
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = session.Pause("install", c.MkDir(), nil, s.abort)
+	c.Assert(err, gc.ErrorMatches, "cannot create debug-code directory: .* is not a directory")
+}
@@ -30,6 +30,12 @@ func (c *HooksContext) ClientExitFileLock() string {
 	return c.ClientFileLock() + "-exit"
 }
 
+// ClientPauseDir returns the directory through which a debug-code
+// client and the uniter communicate while a hook is paused.
+func (c *HooksContext) ClientPauseDir() string {
+	return c.ClientFileLock() + "-pause"
+}
+
 func (c *HooksContext) tmuxSessionName() string {
 	return c.Unit
 }
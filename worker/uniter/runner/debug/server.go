@@ -18,7 +18,8 @@ import (
 // ServerSession represents a "juju debug-hooks" session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks   set.Strings
+	debugAt string
 
 	output io.Writer
 }
@@ -29,6 +30,14 @@ func (s *ServerSession) MatchHook(hookName string) bool {
 	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
 }
 
+// DebugAt returns the value requested by a debug-code client for
+// JUJU_DEBUG_AT, or "" for a debug-hooks session. Hooks matched by a
+// debug-code session are paused with Pause rather than run with
+// RunHook.
+func (s *ServerSession) DebugAt() string {
+	return s.debugAt
+}
+
 // waitClientExit executes flock, waiting for the SSH client to exit.
 // This is a var so it can be replaced for testing.
 var waitClientExit = func(s *ServerSession) {
@@ -63,6 +72,14 @@ func (s *ServerSession) RunHook(hookName, charmDir string, env []string) error {
 // FindSession attempts to find a debug hooks session for the unit specified
 // in the context, and returns a new ServerSession structure for it.
 func (c *HooksContext) FindSession() (*ServerSession, error) {
+	// A debug-code session does not use tmux; it is active for as
+	// long as the client holds the exit lock.
+	if args, err := c.readArgs(); err == nil && args.DebugAt != "" {
+		if !clientConnected(c) {
+			return nil, errors.New("debug-code client not connected")
+		}
+		return c.newSession(args), nil
+	}
 	cmd := exec.Command("tmux", "has-session", "-t", c.tmuxSessionName())
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -73,18 +90,33 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 		}
 	}
 	// Parse the debug-hooks file for an optional hook name.
-	data, err := ioutil.ReadFile(c.ClientFileLock())
+	args, err := c.readArgs()
 	if err != nil {
 		return nil, err
 	}
+	return c.newSession(args), nil
+}
+
+// readArgs reads the arguments written by the client.
+func (c *HooksContext) readArgs() (hookArgs, error) {
+	data, err := ioutil.ReadFile(c.ClientFileLock())
+	if err != nil {
+		return hookArgs{}, err
+	}
 	var args hookArgs
 	err = goyaml.Unmarshal(data, &args)
 	if err != nil {
-		return nil, err
+		return hookArgs{}, err
+	}
+	return args, nil
+}
+
+func (c *HooksContext) newSession(args hookArgs) *ServerSession {
+	return &ServerSession{
+		HooksContext: c,
+		hooks:        set.NewStrings(args.Hooks...),
+		debugAt:      args.DebugAt,
 	}
-	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{HooksContext: c, hooks: hooks}
-	return session, nil
 }
 
 const debugHooksServerScript = `set -e
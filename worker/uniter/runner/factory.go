@@ -30,14 +30,21 @@ type Factory interface {
 	// NewActionRunner returns an execution context suitable for running the
 	// action identified by the supplied id.
 	NewActionRunner(actionId string) (Runner, error)
+
+	// IsParallelAction reports whether the action identified by the
+	// supplied id is declared by the charm to be safe to run in
+	// parallel with hooks and other actions.
+	IsParallelAction(actionId string) (bool, error)
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. The runners stop waiting for a
+// debug-code client when abort is closed.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	abort <-chan struct{},
 ) (
 	Factory, error,
 ) {
@@ -45,6 +52,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		abort:          abort,
 	}
 
 	return f, nil
@@ -58,6 +66,13 @@ type factory struct {
 
 	// Fields that shouldn't change in a factory's lifetime.
 	paths context.Paths
+	abort <-chan struct{}
+}
+
+// newRunner returns a runner for the context that stops waiting for
+// a debug-code client when the factory's abort channel is closed.
+func (f *factory) newRunner(ctx Context) Runner {
+	return &runner{context: ctx, paths: f.paths, abort: f.abort}
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -66,7 +81,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := f.newRunner(ctx)
 	return runner, nil
 }
 
@@ -80,51 +95,82 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := f.newRunner(ctx)
 	return runner, nil
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
 func (f *factory) NewActionRunner(actionId string) (Runner, error) {
+	action, spec, err := f.getAction(actionId)
+	if err != nil {
+		return nil, err
+	}
+
+	name := action.Name()
+	params := action.Params()
+	if err := spec.ValidateParams(params); err != nil {
+		return nil, &badActionError{name, err.Error()}
+	}
+
+	tag := names.NewActionTag(actionId)
+	actionData := context.NewActionData(name, &tag, params)
+	actionData.Timeout = action.Timeout()
+	if actionData.Timeout == 0 {
+		settings, err := actions.ReadCharmSettings(f.paths.GetCharmDir())
+		if err != nil {
+			return nil, &badActionError{name, err.Error()}
+		}
+		actionData.Timeout = settings[name].Timeout
+	}
+	ctx, err := f.contextFactory.ActionContext(actionData)
+	runner := f.newRunner(ctx)
+	return runner, nil
+}
+
+// IsParallelAction exists to satisfy the Factory interface.
+func (f *factory) IsParallelAction(actionId string) (bool, error) {
+	action, _, err := f.getAction(actionId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	settings, err := actions.ReadCharmSettings(f.paths.GetCharmDir())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return settings[action.Name()].Parallel, nil
+}
+
+// getAction returns the action identified by the supplied id, and the
+// spec that defines it, either in the charm or as a predefined action.
+func (f *factory) getAction(actionId string) (*uniter.Action, charm.ActionSpec, error) {
 	ch, err := getCharm(f.paths.GetCharmDir())
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, charm.ActionSpec{}, errors.Trace(err)
 	}
 
 	ok := names.IsValidAction(actionId)
 	if !ok {
-		return nil, &badActionError{actionId, "not valid actionId"}
+		return nil, charm.ActionSpec{}, &badActionError{actionId, "not valid actionId"}
 	}
-	tag := names.NewActionTag(actionId)
-	action, err := f.state.Action(tag)
+	action, err := f.state.Action(names.NewActionTag(actionId))
 	if params.IsCodeNotFoundOrCodeUnauthorized(err) {
-		return nil, ErrActionNotAvailable
+		return nil, charm.ActionSpec{}, ErrActionNotAvailable
 	} else if params.IsCodeActionNotAvailable(err) {
-		return nil, ErrActionNotAvailable
+		return nil, charm.ActionSpec{}, ErrActionNotAvailable
 	} else if err != nil {
-		return nil, errors.Trace(err)
+		return nil, charm.ActionSpec{}, errors.Trace(err)
 	}
 
 	name := action.Name()
-
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		var ok bool
 		spec, ok = ch.Actions().ActionSpecs[name]
 		if !ok {
-			return nil, &badActionError{name, "not defined"}
+			return nil, charm.ActionSpec{}, &badActionError{name, "not defined"}
 		}
 	}
-
-	params := action.Params()
-	if err := spec.ValidateParams(params); err != nil {
-		return nil, &badActionError{name, err.Error()}
-	}
-
-	actionData := context.NewActionData(name, &tag, params)
-	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	return action, spec, nil
 }
 
 func getCharm(charmPath string) (charm.Charm, error) {
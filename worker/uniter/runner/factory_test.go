@@ -4,7 +4,9 @@
 package runner_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -174,6 +176,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -252,6 +255,37 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 	}
 }
 
+func (s *FactorySuite) TestNewActionRunnerDefaultTimeout(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	actionsYaml := `
+snapshot:
+  description: Take a snapshot of the database.
+  timeout: 30m
+  params:
+    outfile:
+      description: The file to write out to.
+      type: string
+`
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "actions.yaml"), []byte(actionsYaml), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		{timeout: 0, expected: 30 * time.Minute},
+		{timeout: 5 * time.Minute, expected: 5 * time.Minute},
+	} {
+		c.Logf("test %d: %v", i, test.timeout)
+		action, err := s.unit.AddActionWithTimeout("snapshot", nil, test.timeout)
+		c.Assert(err, jc.ErrorIsNil)
+		rnr, err := s.factory.NewActionRunner(action.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := rnr.Context().ActionData()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(data.Timeout, gc.Equals, test.expected)
+	}
+}
+
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
 	rnr, err := s.factory.NewActionRunner("irrelevant")
 	c.Assert(rnr, gc.IsNil)
@@ -304,3 +338,39 @@ func (s *FactorySuite) TestNewActionRunnerUnauthAction(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "action no longer available")
 	c.Check(err, gc.Equals, runner.ErrActionNotAvailable)
 }
+
+func (s *FactorySuite) TestIsParallelAction(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	actionsYaml := `
+snapshot:
+  description: Take a snapshot of the database.
+report:
+  description: Report on the database.
+  parallel: true
+`
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "actions.yaml"), []byte(actionsYaml), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		actionName string
+		parallel   bool
+	}{
+		{actionName: "snapshot"},
+		{actionName: "report", parallel: true},
+		{actionName: "juju-run"},
+	} {
+		c.Logf("test %d: %s", i, test.actionName)
+		action, err := s.State.EnqueueAction(s.unit.Tag(), test.actionName, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		parallel, err := s.factory.IsParallelAction(action.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(parallel, gc.Equals, test.parallel)
+	}
+}
+
+func (s *FactorySuite) TestIsParallelActionBadName(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	action, err := s.State.EnqueueAction(s.unit.Tag(), "no-such-action", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.factory.IsParallelAction(action.Id())
+	c.Check(err, gc.ErrorMatches, "cannot run \"no-such-action\" action: not defined")
+}
@@ -53,6 +53,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -95,6 +96,9 @@ type actionHookContext interface {
 	// SetActionMessage sets a message for the Action.
 	SetActionMessage(string) error
 
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
+
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 }
@@ -300,6 +304,37 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextSecrets is the part of a hook context related to secrets:
+// values such as passwords that only the units they are granted to may
+// read.
+type ContextSecrets interface {
+	// AddSecret stores a secret owned by the unit's application, and
+	// returns its id.
+	AddSecret(value map[string]string, description string) (string, error)
+
+	// GetSecret returns the value of the secret with the given id, or
+	// an error if the unit may not read it.
+	GetSecret(id string) (map[string]string, error)
+
+	// GrantSecret allows the given unit, application or relation to
+	// read the secret with the given id.
+	GrantSecret(id string, grantee SecretGrantee) error
+}
+
+// SecretGrantee identifies who a secret is granted to. Exactly one of
+// its fields is set; RelationId is -1 when unset.
+type SecretGrantee struct {
+	// Unit is the name of a unit.
+	Unit string
+
+	// Application is the name of an application.
+	Application string
+
+	// RelationId is the id of a relation the executing unit takes
+	// part in.
+	RelationId int
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
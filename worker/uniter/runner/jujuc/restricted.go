@@ -130,6 +130,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // SetActionMessage implements jujuc.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
@@ -147,3 +150,18 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// AddSecret implements jujuc.Context.
+func (*RestrictedContext) AddSecret(map[string]string, string) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetSecret implements jujuc.Context.
+func (*RestrictedContext) GetSecret(string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(string, SecretGrantee) error {
+	return ErrRestrictedContext
+}
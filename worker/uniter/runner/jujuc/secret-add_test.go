@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestInitError(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret value specified",
+	}, {
+		args: []string{"password"},
+		err:  `expected "key=value", got "password"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, info := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--description", "admin", "user=admin", "password=sekrit"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "secret-0\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	value := map[string]string{"user": "admin", "password": "sekrit"}
+	c.Check(info.Secrets.Secrets, jc.DeepEquals, map[string]map[string]string{"secret-0": value})
+	s.Stub.CheckCall(c, 0, "AddSecret", value, "admin")
+}
+
+func (s *SecretAddSuite) TestAddSecretError(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"password=sekrit"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot add secret: boom\n")
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) TestInitError(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, nil)
+	c.Check(err, gc.ErrorMatches, "no secret id specified")
+
+	com, err = jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, []string{"secret-0", "password", "extra"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *SecretGetSuite) TestGetSecret(c *gc.C) {
+	for i, t := range []struct {
+		summary string
+		args    []string
+		out     string
+	}{{
+		summary: "all keys",
+		args:    []string{"secret-0"},
+		out:     "password: sekrit\nuser: admin\n",
+	}, {
+		summary: "one key",
+		args:    []string{"secret-0", "password"},
+		out:     "sekrit\n",
+	}, {
+		summary: "missing key",
+		args:    []string{"secret-0", "nope"},
+		out:     "",
+	}, {
+		summary: "json",
+		args:    []string{"--format", "json", "secret-0"},
+		out:     `{"password":"sekrit","user":"admin"}` + "\n",
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.NewHookContext()
+		info.Secrets.Secrets = map[string]map[string]string{
+			"secret-0": {"user": "admin", "password": "sekrit"},
+		}
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+}
+
+func (s *SecretGetSuite) TestGetSecretNotFound(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-9"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: cannot read secret "secret-9": secret "secret-9" not found`+"\n")
+}
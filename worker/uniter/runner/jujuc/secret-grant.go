@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+)
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	id              string
+	grantee         SecretGrantee
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secretGrantCommand with the given context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx}
+	rV, err := newRelationIdValue(ctx, &c.grantee.RelationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant allows a unit, every unit of an application, or every unit taking
+part in a relation to read the secret with the given id. Only the units of the
+application that added a secret may grant it. If neither a unit nor an
+application is given, the secret is granted to the relation given with -r, or
+by default to the relation of the executing hook.
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<id> [<unit>|<application>]",
+		Purpose: "allow others to read a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id, args = args[0], args[1:]
+	if len(args) == 0 {
+		if c.grantee.RelationId == -1 {
+			return errors.New("no unit, application or relation specified")
+		}
+		return nil
+	}
+	switch subject := args[0]; {
+	case names.IsValidUnit(subject):
+		c.grantee.Unit = subject
+	case names.IsValidApplication(subject):
+		c.grantee.Application = subject
+	default:
+		return errors.Errorf("invalid unit or application name %q", subject)
+	}
+	// A unit or application given explicitly takes the place of the
+	// hook's relation.
+	c.grantee.RelationId = -1
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.GrantSecret(c.id, c.grantee)
+	return errors.Annotatef(err, "cannot grant secret %q", c.id)
+}
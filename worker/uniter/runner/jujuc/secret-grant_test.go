@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) TestInitError(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret id specified",
+	}, {
+		args: []string{"secret-0"},
+		err:  "no unit, application or relation specified",
+	}, {
+		args: []string{"secret-0", "-r", "7"},
+		err:  `invalid value "7" for flag -r: relation not found`,
+	}, {
+		args: []string{"secret-0", "Foo"},
+		err:  `invalid unit or application name "Foo"`,
+	}, {
+		args: []string{"secret-0", "mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantSecret(c *gc.C) {
+	for i, t := range []struct {
+		summary string
+		relid   int
+		args    []string
+		grantee jujuc.SecretGrantee
+	}{{
+		summary: "unit",
+		relid:   -1,
+		args:    []string{"secret-0", "mysql/0"},
+		grantee: jujuc.SecretGrantee{Unit: "mysql/0", RelationId: -1},
+	}, {
+		summary: "application",
+		relid:   -1,
+		args:    []string{"secret-0", "mysql"},
+		grantee: jujuc.SecretGrantee{Application: "mysql", RelationId: -1},
+	}, {
+		summary: "explicit relation",
+		relid:   -1,
+		args:    []string{"secret-0", "-r", "peer1:1"},
+		grantee: jujuc.SecretGrantee{RelationId: 1},
+	}, {
+		summary: "hook relation",
+		relid:   0,
+		args:    []string{"secret-0"},
+		grantee: jujuc.SecretGrantee{RelationId: 0},
+	}, {
+		summary: "unit in relation hook",
+		relid:   0,
+		args:    []string{"secret-0", "mysql/0"},
+		grantee: jujuc.SecretGrantee{Unit: "mysql/0", RelationId: -1},
+	}} {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.newHookContext(t.relid, "")
+		info.Secrets.Secrets = map[string]map[string]string{
+			"secret-0": {"password": "sekrit"},
+		}
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(info.Secrets.Grants, jc.DeepEquals, map[string][]jujuc.SecretGrantee{
+			"secret-0": {t.grantee},
+		})
+	}
+}
+
+func (s *SecretGrantSuite) TestGrantSecretNotFound(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"secret-9", "mysql"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: cannot grant secret "secret-9": secret "secret-9" not found`+"\n")
+}
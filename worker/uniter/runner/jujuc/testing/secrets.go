@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	Secrets map[string]map[string]string
+	Grants  map[string][]jujuc.SecretGrantee
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// AddSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) AddSecret(value map[string]string, description string) (string, error) {
+	c.stub.AddCall("AddSecret", value, description)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]map[string]string)
+	}
+	id := fmt.Sprintf("secret-%d", len(c.info.Secrets))
+	c.info.Secrets[id] = value
+	return id, nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, ok := c.info.Secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return value, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, grantee jujuc.SecretGrantee) error {
+	c.stub.AddCall("GrantSecret", id, grantee)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := c.info.Secrets[id]; !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	if c.info.Grants == nil {
+		c.info.Grants = make(map[string][]jujuc.SecretGrantee)
+	}
+	c.info.Grants[id] = append(c.info.Grants[id], grantee)
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, where processes are not
+// grouped.
+func setProcessGroup(ps *exec.Cmd) {}
+
+// terminateProcessGroup kills p, as windows processes cannot be asked
+// to stop.
+func terminateProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
+
+// killProcessGroup kills p.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
@@ -27,6 +27,10 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter.runner")
 
+// abortGracePeriod is how long an aborted action's processes are given
+// to exit after being asked to, before they are killed.
+var abortGracePeriod = 10 * time.Second
+
 // Runner is responsible for invoking commands in a context.
 type Runner interface {
 
@@ -59,13 +63,17 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+
+	// abort is closed when the uniter stops, so that a hook paused
+	// for debug-code does not keep it waiting.
+	abort <-chan struct{}
 }
 
 func (runner *runner) Context() Context {
@@ -74,13 +82,14 @@ func (runner *runner) Context() Context {
 
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
+	result, err := runner.runCommandsWithTimeout(commands, 0, nil, clock.WallClock)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
-// juju-run as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+// juju-run as an action. The commands are cancelled when the timeout
+// expires or the abort channel is closed, whichever is first.
+func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, abort <-chan struct{}, clock clock.Clock) (*utilexec.ExecResponse, error) {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return nil, err
@@ -105,10 +114,17 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	runner.context.SetProcess(hookProcess{command.Process()})
 
 	var cancel chan struct{}
-	if timeout != 0 {
+	if timeout != 0 || abort != nil {
+		var expired <-chan time.Time
+		if timeout != 0 {
+			expired = clock.After(timeout)
+		}
 		cancel = make(chan struct{})
 		go func() {
-			<-clock.After(timeout)
+			select {
+			case <-expired:
+			case <-abort:
+			}
 			close(cancel)
 		}()
 	}
@@ -135,7 +151,25 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	actionData, err := runner.context.ActionData()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The action's own timeout applies too, where it is the sooner.
+	runTimeout := time.Duration(timeout)
+	actionTimedOut := actionData.Timeout > 0 && (runTimeout == 0 || actionData.Timeout <= runTimeout)
+	if actionTimedOut {
+		runTimeout = actionData.Timeout
+	}
+	results, err := runner.runCommandsWithTimeout(command, runTimeout, actionData.Cancel, clock.WallClock)
+	if err == utilexec.ErrCancelled {
+		if isClosed(actionData.Cancel) {
+			actionData.Aborted = true
+		} else if actionTimedOut {
+			logger.Infof("action %q timed out after %v", actionData.Name, actionData.Timeout)
+			actionData.TimedOut = true
+		}
+	}
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -223,14 +257,45 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
-		logger.Infof("executing %s via debug-hooks", hookName)
-		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		if session.DebugAt() != "" {
+			err = runner.pauseCharmHook(session, hookName, env, charmLocation)
+			if err == context.ErrAborted {
+				// Nothing ran, so there is nothing to flush; the
+				// hook or action is run again when the uniter
+				// restarts.
+				return err
+			}
+		} else {
+			logger.Infof("executing %s via debug-hooks", hookName)
+			err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		}
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
+// pauseCharmHook pauses the hook for a debug-code session, and then runs
+// it, skips it, or takes the result of the operator running it by hand,
+// as the operator decides.
+func (runner *runner) pauseCharmHook(session *debug.ServerSession, hookName string, env []string, charmLocation string) error {
+	logger.Infof("pausing %s for debug-code", hookName)
+	decision, err := session.Pause(hookName, runner.paths.GetCharmDir(), env, runner.abort)
+	if err == debug.ErrAborted {
+		return context.ErrAborted
+	}
+	switch decision {
+	case debug.DecisionResume:
+		logger.Infof("resuming %s", hookName)
+		env = append(env, "JUJU_DEBUG_AT="+session.DebugAt())
+		return runner.runCharmHook(hookName, env, charmLocation)
+	case debug.DecisionSkip:
+		logger.Infof("skipping %s", hookName)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
@@ -241,6 +306,12 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	// An action runs in a process group of its own, so that it can be
+	// stopped along with anything it starts if it is aborted.
+	actionData, _ := runner.context.ActionData()
+	if actionData != nil {
+		setProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -259,12 +330,64 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		if actionData != nil {
+			err = waitAction(ps, actionData)
+		} else {
+			err = ps.Wait()
+		}
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// waitAction waits for the process running an action to finish. If the
+// action is aborted or times out first, the process and those it
+// started are asked to stop, and are killed if they have not done so
+// within the grace period.
+func waitAction(ps *exec.Cmd, actionData *context.ActionData) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var expired <-chan time.Time
+	if actionData.Timeout > 0 {
+		expired = time.After(actionData.Timeout)
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-actionData.Cancel:
+		logger.Infof("aborting action %q", actionData.Name)
+		actionData.Aborted = true
+	case <-expired:
+		logger.Infof("action %q timed out after %v", actionData.Name, actionData.Timeout)
+		actionData.TimedOut = true
+	}
+	if err := terminateProcessGroup(ps.Process); err != nil {
+		logger.Warningf("cannot stop action %q: %v", actionData.Name, err)
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(abortGracePeriod):
+	}
+	logger.Infof("killing action %q", actionData.Name)
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Warningf("cannot kill action %q: %v", actionData.Name, err)
+	}
+	return <-done
+}
+
+// isClosed reports whether the channel has been closed.
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
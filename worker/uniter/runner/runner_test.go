@@ -336,6 +336,90 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionAborted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are not run in process groups on windows")
+	}
+	s.PatchValue(runner.AbortGracePeriod, 100*time.Millisecond)
+	abort := make(chan struct{})
+	close(abort)
+	ctx := &MockContext{
+		actionData: &context.ActionData{Cancel: abort},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 60,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Now().Sub(t0) < 30*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "signal: terminated")
+	c.Assert(ctx.actionData.Aborted, jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunActionTimedOut(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("actions are not run in process groups on windows")
+	}
+	s.PatchValue(runner.AbortGracePeriod, 100*time.Millisecond)
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: 100 * time.Millisecond},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 60,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Now().Sub(t0) < 30*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "signal: terminated")
+	c.Assert(ctx.actionData.TimedOut, jc.IsTrue)
+	c.Assert(ctx.actionData.Aborted, jc.IsFalse)
+}
+
+func (s *RunMockContextSuite) TestRunJujuRunActionAborted(c *gc.C) {
+	abort := make(chan struct{})
+	close(abort)
+	ctx := &MockContext{
+		actionData: &context.ActionData{Cancel: abort},
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+			"timeout": float64(0),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+	c.Assert(ctx.actionData.Aborted, jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunJujuRunActionTimedOut(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{Timeout: 100 * time.Millisecond},
+		actionParams: map[string]interface{}{
+			"command": "sleep 10",
+			"timeout": float64(0),
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.Equals, exec.ErrCancelled)
+	c.Assert(ctx.actionData.TimedOut, jc.IsTrue)
+	c.Assert(ctx.actionData.Aborted, jc.IsFalse)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
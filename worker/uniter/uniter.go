@@ -100,6 +100,14 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// aborts records the running actions that have been asked to
+	// abort.
+	aborts actionAborts
+
+	// parallelActions runs the actions that need not wait for the
+	// operation executor.
+	parallelActions *parallelActions
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -156,6 +164,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 		return errors.Annotatef(err, "failed to initialize uniter for %q", unitTag)
 	}
+	defer u.parallelActions.stop()
 	logger.Infof("unit %q started", u.unit)
 
 	// Install is a special case, as it must run before there
@@ -237,6 +246,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				AbortAction:         u.aborts.abort,
+				OfferActions:        u.parallelActions.offer,
+				QueuedActions:       u.parallelActions.queued,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -454,13 +466,14 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.catacomb.Dying(),
 	)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	u.parallelActions = newParallelActions(runnerFactory, &u.aborts, u.catacomb.Dying())
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
-		Deployer:       deployer,
+		Deployer:       u.parallelActions.deployer(deployer),
 		RunnerFactory:  runnerFactory,
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
@@ -1396,6 +1396,32 @@ func (s *UniterSuite) TestActionEvents(c *gc.C) {
 				statusGetter: unitStatusGetter,
 				status:       status.Unknown,
 			},
+		), ut(
+			"actions declared parallel are run outside the operation executor",
+			createCharm{
+				customize: func(c *gc.C, ctx *context, path string) {
+					ctx.writeAction(c, path, "action-parallel")
+					ctx.writeActionsYaml(c, path, "action-parallel")
+				},
+			},
+			serveCharm{},
+			ensureStateWorker{},
+			createServiceAndUnit{},
+			startUniter{},
+			waitAddresses{},
+			waitUnitAgent{status: status.Idle},
+			waitHooks{"install", "leader-elected", "config-changed", "start"},
+			verifyCharm{},
+			addAction{"action-parallel", nil},
+			waitActionResults{[]actionResult{{
+				name: "action-parallel",
+				results: map[string]interface{}{
+					"ran": "in parallel",
+				},
+				status: params.ActionCompleted,
+			}}},
+			waitUnitAgent{status: status.Idle},
+			waitHooks{},
 		), ut(
 			"pending actions get consumed",
 			createCharm{
@@ -222,6 +222,10 @@ action-log-fail:
 `[1:],
 		"action-log-fail-error": `
 action-log-fail-error:
+`[1:],
+		"action-parallel": `
+action-parallel:
+   parallel: true
 `[1:],
 		"action-reboot": `
 action-reboot:
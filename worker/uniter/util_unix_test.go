@@ -87,6 +87,10 @@ action-set foo="still works"
 action-fail too many arguments
 action-set foo="still works"
 action-fail "A real message"
+`[1:],
+		"action-parallel": `
+#!/bin/bash --norc
+action-set ran="in parallel"
 `[1:],
 		"action-reboot": `
 #!/bin/bash --norc
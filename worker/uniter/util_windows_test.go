@@ -81,6 +81,9 @@ action-set.exe foo="still works"
 action-fail.exe too many arguments
 action-set.exe foo="still works"
 action-fail.exe "A real message"
+`[1:],
+		"action-parallel": `
+action-set.exe ran="in parallel"
 `[1:],
 		"action-reboot": `
 juju-reboot.exe || action-set.exe reboot-delayed="good"